@@ -3,17 +3,18 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
 	"syscall"
-	"time"
 
 	otelruntime "go.opentelemetry.io/contrib/instrumentation/runtime"
 	"go.opentelemetry.io/otel"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
 	"github.com/SigNoz/signoz-mcp-server/internal/config"
 	"github.com/SigNoz/signoz-mcp-server/internal/handler/tools"
 	mcpserver "github.com/SigNoz/signoz-mcp-server/internal/mcp-server"
@@ -30,7 +31,10 @@ func main() {
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
-	cfg, err := config.LoadConfig()
+	configPath := flag.String("config", "", "path to a YAML or JSON config file keyed by environment variable name; set environment variables override it")
+	flag.Parse()
+
+	cfg, err := config.LoadConfigFile(*configPath)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 		os.Exit(1)
@@ -40,12 +44,26 @@ func main() {
 		fmt.Fprintf(os.Stderr, "Configuration validation failed: %v\n", err)
 		os.Exit(1)
 	}
+	if err := tools.ValidateToolSelection(cfg.EnabledToolGroups, cfg.DisabledTools); err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration validation failed: %v\n", err)
+		os.Exit(1)
+	}
 
-	logger := logpkg.New(cfg.LogLevel)
+	logger := logpkg.NewWithFormat(cfg.LogLevel, cfg.LogFormat)
 	logger.InfoContext(ctx, "Starting SigNoz MCP Server",
 		slog.String("log_level", cfg.LogLevel),
+		slog.String("log_format", cfg.LogFormat),
 		slog.String("transport_mode", cfg.TransportMode))
 
+	if cfg.MockMode {
+		logger.WarnContext(ctx, "MOCK_MODE is on; tools return canned fixture data and never contact SigNoz")
+	}
+
+	if proxy := cfg.Proxy(); proxy != nil {
+		client.SetProxy(proxy)
+		logger.InfoContext(ctx, "Routing SigNoz API calls through proxy", slog.String("proxy", proxy.Redacted()))
+	}
+
 	// resource.New returns a best-effort Resource even when individual
 	// detectors fail (e.g. malformed OTEL_RESOURCE_ATTRIBUTES), so log the
 	// error and continue with whatever attributes were detected rather than
@@ -104,6 +122,13 @@ func main() {
 		analyticsInstance = noopanalytics.New()
 	}
 
+	if cfg.StartupHealthCheck && !cfg.MockMode {
+		if err := checkStartupConnectivity(ctx, logger, cfg); err != nil {
+			fmt.Fprintf(os.Stderr, "Startup health check failed: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
 	handler := tools.NewHandler(logger, cfg)
 
 	dashboard.InitClickhouseSchema()
@@ -130,10 +155,11 @@ func main() {
 		// The channel is now drained; do not receive from it again.
 		serverDone = true
 	case <-ctx.Done():
-		logger.InfoContext(ctx, "Received shutdown signal")
+		logger.InfoContext(ctx, "Received shutdown signal; waiting for in-flight requests",
+			slog.Duration("timeout", cfg.ShutdownTimeout))
 	}
 
-	shutCtx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	shutCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
 	defer cancel()
 
 	var shutdownErr error
@@ -194,4 +220,37 @@ func main() {
 		logger.ErrorContext(ctx, "Server exited with errors", logpkg.ErrAttr(errors.Join(runErr, shutdownErr)))
 		os.Exit(1)
 	}
+	logger.InfoContext(ctx, "Server stopped")
+}
+
+// checkStartupConnectivity pings the SigNoz instance configured through the
+// environment so a bad URL or API key fails at startup instead of on the
+// first tool call. Without both a URL and a key (HTTP mode with per-request
+// credentials) there is nothing to check.
+func checkStartupConnectivity(ctx context.Context, logger *slog.Logger, cfg *config.Config) error {
+	if cfg.URL == "" || cfg.APIKey == "" {
+		logger.InfoContext(ctx, "Skipping startup health check: SIGNOZ_URL or SIGNOZ_API_KEY is not set")
+		return nil
+	}
+	credential, authHeader := cfg.EnvCredential()
+	signozClient := client.NewClient(logger, cfg.URL, credential, authHeader, cfg.CustomHeaders)
+	if tlsConfig := cfg.TLSConfig(); tlsConfig != nil {
+		signozClient.SetTransport(client.NewTLSTransport(tlsConfig))
+	}
+	result, err := signozClient.Ping(ctx)
+	switch {
+	case err == nil:
+		logger.InfoContext(ctx, "Startup health check passed",
+			slog.String("signoz_version", result.Version),
+			slog.Duration("latency", result.Latency))
+		return nil
+	case errors.Is(err, client.ErrUnauthorized):
+		return fmt.Errorf("SIGNOZ_API_KEY was rejected by %s: %w", cfg.URL, err)
+	case errors.Is(err, client.ErrInstanceNotFound):
+		return fmt.Errorf("no SigNoz API answers at SIGNOZ_URL %s: %w", cfg.URL, err)
+	case errors.Is(err, client.ErrUnreachable):
+		return fmt.Errorf("could not reach SIGNOZ_URL %s: %w", cfg.URL, err)
+	default:
+		return fmt.Errorf("SigNoz at %s failed the connectivity check: %w", cfg.URL, err)
+	}
 }
@@ -28,6 +28,7 @@ var GrandfatheredWideSchemaProperties = map[string][]string{
 		"end",
 		"error",
 		"filter",
+		"format",
 		"groupBy",
 		"limit",
 		"maxDuration",
@@ -105,6 +106,43 @@ var GrandfatheredWideSchemaProperties = map[string][]string{
 		"timeAggregation",
 		"timeRange",
 	},
+	"signoz_search_logs": {
+		"bodyArrayField",
+		"bodyHasArrayValue",
+		"bodyHasToken",
+		"conditions",
+		"cursor",
+		"end",
+		"filter",
+		"format",
+		"limit",
+		"offset",
+		"searchContext",
+		"searchText",
+		"selectFields",
+		"service",
+		"severity",
+		"start",
+		"timeRange",
+	},
+	"signoz_search_traces": {
+		"conditions",
+		"end",
+		"error",
+		"filter",
+		"format",
+		"limit",
+		"maxDuration",
+		"minDuration",
+		"offset",
+		"operation",
+		"orderBy",
+		"searchContext",
+		"selectFields",
+		"service",
+		"start",
+		"timeRange",
+	},
 	"signoz_update_alert": {
 		"alert",
 		"alertType",
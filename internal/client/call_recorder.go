@@ -0,0 +1,156 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+// maxRecordedRequests bounds how many upstream requests one recorded tool
+// call keeps, so a fan-out tool cannot grow the record without limit.
+const maxRecordedRequests = 20
+
+// maxRecordedCalls bounds how many tool calls a recorder keeps; the oldest
+// call is dropped first.
+const maxRecordedCalls = 16
+
+// CallRecorder keeps the upstream requests of the most recent tool calls
+// made through one client, keyed by request ID so concurrent calls do not
+// overwrite each other. Clients are per tenant credentials, so a recorder
+// never mixes tenants. It is safe for concurrent use; a nil recorder records
+// nothing.
+type CallRecorder struct {
+	mu    sync.Mutex
+	calls map[string]*CallRecord
+	// order holds the keys of calls, oldest first.
+	order []string
+}
+
+// CallRecord describes one tool call's upstream requests. Response bodies
+// are never kept.
+type CallRecord struct {
+	RequestID string            `json:"requestId,omitempty"`
+	Tool      string            `json:"tool,omitempty"`
+	Requests  []RecordedRequest `json:"requests"`
+	// Truncated reports that requests past maxRecordedRequests were dropped.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+// RecordedRequest is one upstream request as sent. Start, End, and Filters
+// are read from a query payload body, when it has them.
+type RecordedRequest struct {
+	Method     string    `json:"method"`
+	URL        string    `json:"url"`
+	Status     int       `json:"status"`
+	DurationMs int64     `json:"durationMs"`
+	At         time.Time `json:"at"`
+	Start      int64     `json:"start,omitempty"`
+	End        int64     `json:"end,omitempty"`
+	Filters    []string  `json:"filters,omitempty"`
+}
+
+func NewCallRecorder() *CallRecorder {
+	return &CallRecorder{calls: make(map[string]*CallRecord)}
+}
+
+// Record adds one logical request (including any retries) to the record of
+// the tool call in ctx, starting a new record for a request ID it has not
+// seen. status is the final HTTP status, or 0 when no response was received.
+func (r *CallRecorder) Record(ctx context.Context, method, reqURL string, body []byte, status int, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	requestID, _ := util.GetRequestID(ctx)
+	tool, _ := util.GetToolName(ctx)
+	req := RecordedRequest{
+		Method:     method,
+		URL:        redactURL(reqURL),
+		Status:     status,
+		DurationMs: elapsed.Milliseconds(),
+		At:         time.Now().UTC(),
+	}
+	req.Start, req.End, req.Filters = queryPayloadSummary(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := requestID + "\x00" + tool
+	call, ok := r.calls[key]
+	if !ok {
+		if len(r.order) >= maxRecordedCalls {
+			delete(r.calls, r.order[0])
+			r.order = r.order[1:]
+		}
+		call = &CallRecord{RequestID: requestID, Tool: tool}
+		r.calls[key] = call
+		r.order = append(r.order, key)
+	}
+	if len(call.Requests) >= maxRecordedRequests {
+		call.Truncated = true
+		return
+	}
+	call.Requests = append(call.Requests, req)
+}
+
+// Last returns a copy of the record of the tool call with requestID, or of
+// the most recently started call when requestID is empty. ok is false when
+// no such call has been recorded.
+func (r *CallRecorder) Last(requestID string) (CallRecord, bool) {
+	if r == nil {
+		return CallRecord{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := len(r.order) - 1; i >= 0; i-- {
+		call := r.calls[r.order[i]]
+		if requestID != "" && call.RequestID != requestID {
+			continue
+		}
+		out := *call
+		out.Requests = append([]RecordedRequest(nil), call.Requests...)
+		return out, true
+	}
+	return CallRecord{}, false
+}
+
+// redactURL hides any password in reqURL's userinfo.
+func redactURL(reqURL string) string {
+	u, err := url.Parse(reqURL)
+	if err != nil {
+		return reqURL
+	}
+	return u.Redacted()
+}
+
+// queryPayloadSummary reads the time range and filter expressions from a
+// Query Builder v5 request body. Other bodies yield zero values.
+func queryPayloadSummary(body []byte) (start, end int64, filters []string) {
+	if len(body) == 0 {
+		return 0, 0, nil
+	}
+	var payload struct {
+		Start          int64 `json:"start"`
+		End            int64 `json:"end"`
+		CompositeQuery struct {
+			Queries []struct {
+				Spec struct {
+					Filter *struct {
+						Expression string `json:"expression"`
+					} `json:"filter"`
+				} `json:"spec"`
+			} `json:"queries"`
+		} `json:"compositeQuery"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return 0, 0, nil
+	}
+	for _, q := range payload.CompositeQuery.Queries {
+		if q.Spec.Filter != nil && q.Spec.Filter.Expression != "" {
+			filters = append(filters, q.Spec.Filter.Expression)
+		}
+	}
+	return payload.Start, payload.End, filters
+}
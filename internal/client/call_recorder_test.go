@@ -0,0 +1,129 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+func callCtx(requestID string) context.Context {
+	ctx := util.SetToolName(context.Background(), "signoz_search_logs")
+	return util.SetRequestID(ctx, requestID)
+}
+
+func TestCallRecorder_KeepsLatestCall(t *testing.T) {
+	rec := NewCallRecorder()
+	_, ok := rec.Last("")
+	assert.False(t, ok)
+
+	body := []byte(`{"start":1767225600000,"end":1767229200000,"compositeQuery":{"queries":[
+		{"type":"builder_query","spec":{"name":"A","filter":{"expression":"service.name = 'checkout'"}}},
+		{"type":"builder_query","spec":{"name":"B"}}]}}`)
+	rec.Record(callCtx("req-1"), http.MethodGet, "http://x/api/v1/services", nil, 200, 5*time.Millisecond)
+	rec.Record(callCtx("req-2"), http.MethodPost, "https://user:secret@x/api/v5/query_range", body, 200, 40*time.Millisecond)
+	rec.Record(callCtx("req-2"), http.MethodPost, "http://x/api/v5/query_range", []byte(`not json`), 0, time.Millisecond)
+
+	last, ok := rec.Last("")
+	require.True(t, ok)
+	assert.Equal(t, "req-2", last.RequestID)
+	assert.Equal(t, "signoz_search_logs", last.Tool)
+	require.Len(t, last.Requests, 2)
+
+	query := last.Requests[0]
+	assert.Equal(t, "https://user:xxxxx@x/api/v5/query_range", query.URL)
+	assert.EqualValues(t, 1767225600000, query.Start)
+	assert.EqualValues(t, 1767229200000, query.End)
+	assert.Equal(t, []string{"service.name = 'checkout'"}, query.Filters)
+	assert.EqualValues(t, 40, query.DurationMs)
+
+	assert.Zero(t, last.Requests[1].Start)
+	assert.Empty(t, last.Requests[1].Filters)
+}
+
+func TestCallRecorder_ConcurrentCallsKeepTheirOwnRecords(t *testing.T) {
+	rec := NewCallRecorder()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := callCtx(fmt.Sprintf("req-%d", i))
+			for range 3 {
+				rec.Record(ctx, http.MethodGet, "http://x/api/v1/services", nil, 200, time.Millisecond)
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := range 8 {
+		call, ok := rec.Last(fmt.Sprintf("req-%d", i))
+		require.True(t, ok)
+		assert.Len(t, call.Requests, 3, "req-%d", i)
+	}
+	_, ok := rec.Last("req-missing")
+	assert.False(t, ok)
+}
+
+func TestCallRecorder_DropsOldestCall(t *testing.T) {
+	rec := NewCallRecorder()
+	for i := range maxRecordedCalls + 1 {
+		rec.Record(callCtx(fmt.Sprintf("req-%d", i)), http.MethodGet, "http://x/api/v1/services", nil, 200, time.Millisecond)
+	}
+
+	_, ok := rec.Last("req-0")
+	assert.False(t, ok, "the oldest call should be evicted")
+	last, ok := rec.Last("")
+	require.True(t, ok)
+	assert.Equal(t, fmt.Sprintf("req-%d", maxRecordedCalls), last.RequestID)
+}
+
+func TestCallRecorder_CapsRequestsPerCall(t *testing.T) {
+	rec := NewCallRecorder()
+	for range maxRecordedRequests + 5 {
+		rec.Record(callCtx("req-1"), http.MethodGet, "http://x/api/v2/rules/1", nil, 200, time.Millisecond)
+	}
+
+	last, ok := rec.Last("")
+	require.True(t, ok)
+	assert.Len(t, last.Requests, maxRecordedRequests)
+	assert.True(t, last.Truncated)
+}
+
+func TestCallRecorder_NilIsNoop(t *testing.T) {
+	var rec *CallRecorder
+	assert.NotPanics(t, func() {
+		rec.Record(callCtx("req-1"), http.MethodGet, "http://x/api/v1/alerts", nil, 200, time.Millisecond)
+	})
+	_, ok := rec.Last("")
+	assert.False(t, ok)
+}
+
+func TestDoRequest_RecordsLastCall(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":"error","error":"rule not found"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(logpkg.New("error"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+	client.SetCallRecorder(NewCallRecorder())
+
+	_, err := client.GetAlertByRuleID(callCtx("req-1"), "17")
+	require.Error(t, err)
+
+	last, ok := client.LastCall("")
+	require.True(t, ok)
+	require.Len(t, last.Requests, 1)
+	assert.Equal(t, server.URL+"/api/v2/rules/17", last.Requests[0].URL)
+	assert.Equal(t, http.StatusNotFound, last.Requests[0].Status)
+}
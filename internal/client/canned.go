@@ -0,0 +1,456 @@
+package client
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+//go:embed fixtures/*.json
+var cannedFixtures embed.FS
+
+// cannedSeriesPoints is how many points each generated time series has.
+const cannedSeriesPoints = 30
+
+// cannedGroupValues are the label values generated series and scalar rows
+// use for common groupBy keys, so grouped results line up with the services
+// and routes in the fixtures. Other keys get "<key>-1", "<key>-2".
+var cannedGroupValues = map[string][]string{
+	"service.name":       {"checkout", "frontend", "payment"},
+	"http.route":         {"/api/checkout", "/api/cart", "/api/products"},
+	"severity_text":      {"ERROR", "WARN", "INFO"},
+	"k8s.namespace.name": {"shop", "kube-system"},
+	"host.name":          {"node-a", "node-b"},
+}
+
+// CannedClient implements Client without a SigNoz backend, for MOCK_MODE
+// demos and local development. Reads return the JSON fixtures embedded from
+// fixtures/, and any ID returns the same object. Query Builder requests get
+// the raw logs or traces fixture, or generated scalar and time series data
+// shaped after the request's queries and groupBy keys. Writes succeed
+// without storing anything.
+type CannedClient struct{}
+
+// Compile-time check that CannedClient satisfies Client.
+var _ Client = (*CannedClient)(nil)
+
+func NewCannedClient() *CannedClient {
+	return &CannedClient{}
+}
+
+func cannedFixture(name string) (json.RawMessage, error) {
+	body, err := cannedFixtures.ReadFile(path.Join("fixtures", name+".json"))
+	if err != nil {
+		return nil, fmt.Errorf("mock mode: no fixture %q: %w", name, err)
+	}
+	return body, nil
+}
+
+// cannedCreated answers a create call with the submitted object under a new
+// id, the way SigNoz echoes what it stored.
+func cannedCreated(body []byte) (json.RawMessage, error) {
+	obj := map[string]any{}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, &obj); err != nil {
+			return nil, fmt.Errorf("mock mode: invalid request body: %w", err)
+		}
+	}
+	obj["id"] = uuid.NewString()
+	return json.Marshal(map[string]any{"status": "success", "data": obj})
+}
+
+func (c *CannedClient) GetAnalyticsIdentity(ctx context.Context) (*AnalyticsIdentity, error) {
+	return &AnalyticsIdentity{OrgID: "mock-org", UserID: "mock-user", Name: "Mock User", Email: "mock@example.com", Principal: "user"}, nil
+}
+
+func (c *CannedClient) Ping(ctx context.Context) (*PingResult, error) {
+	version, err := c.GetVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &PingResult{Reachable: true, Authenticated: true, Version: version.Version}, nil
+}
+
+func (c *CannedClient) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	body, err := cannedFixture("version")
+	if err != nil {
+		return nil, err
+	}
+	return parseVersionInfo(body)
+}
+
+func (c *CannedClient) ListMetrics(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
+	return cannedFixture("metrics")
+}
+
+func (c *CannedClient) GetTopMetrics(ctx context.Context, start, end int64, limit int) (json.RawMessage, error) {
+	return cannedFixture("top_metrics")
+}
+
+func (c *CannedClient) ListAlerts(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
+	return cannedFixture("alerts")
+}
+
+func (c *CannedClient) ListAlertRules(ctx context.Context) (json.RawMessage, error) {
+	return cannedFixture("alert_rules")
+}
+
+func (c *CannedClient) GetAlertByRuleID(ctx context.Context, ruleID string) (json.RawMessage, error) {
+	return cannedFixture("alert_rule")
+}
+
+func (c *CannedClient) GetAlertHistory(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+	return cannedFixture("alert_history")
+}
+
+func (c *CannedClient) ListDashboards(ctx context.Context) (json.RawMessage, error) {
+	body, err := cannedFixture("dashboards")
+	if err != nil {
+		return nil, err
+	}
+	simplified, _, err := simplifyDashboardList(body)
+	return simplified, err
+}
+
+func (c *CannedClient) GetDashboard(ctx context.Context, uuid string) (json.RawMessage, error) {
+	return cannedFixture("dashboard")
+}
+
+func (c *CannedClient) CreateDashboard(ctx context.Context, dashboard types.Dashboard) (json.RawMessage, error) {
+	body, err := json.Marshal(map[string]any{"data": dashboard})
+	if err != nil {
+		return nil, err
+	}
+	return cannedCreated(body)
+}
+
+func (c *CannedClient) UpdateDashboard(ctx context.Context, id string, dashboard types.Dashboard) error {
+	return nil
+}
+
+func (c *CannedClient) CreateDashboardRaw(ctx context.Context, dashboardJSON []byte) (json.RawMessage, error) {
+	body, err := json.Marshal(map[string]json.RawMessage{"data": dashboardJSON})
+	if err != nil {
+		return nil, fmt.Errorf("mock mode: invalid dashboard: %w", err)
+	}
+	return cannedCreated(body)
+}
+
+func (c *CannedClient) UpdateDashboardRaw(ctx context.Context, id string, dashboardJSON []byte) error {
+	return nil
+}
+
+func (c *CannedClient) DeleteDashboard(ctx context.Context, id string) error {
+	return nil
+}
+
+func (c *CannedClient) QueryDashboardVariable(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
+	return json.Marshal(map[string]any{"status": "success", "data": map[string]any{"variableValues": cannedGroupValues["service.name"]}})
+}
+
+func (c *CannedClient) ListServices(ctx context.Context, start, end string) (json.RawMessage, error) {
+	return cannedFixture("services")
+}
+
+func (c *CannedClient) GetServiceTopOperations(ctx context.Context, start, end, service string, tags json.RawMessage) (json.RawMessage, error) {
+	return cannedFixture("top_operations")
+}
+
+func (c *CannedClient) QueryBuilderV5(ctx context.Context, body []byte) (json.RawMessage, error) {
+	var payload types.QueryPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("mock mode: invalid query payload: %w", err)
+	}
+	switch payload.RequestType {
+	case "raw", "raw_stream", "trace":
+		return cannedRawRows(payload)
+	case "scalar":
+		return json.Marshal(cannedResponse("scalar", cannedScalarResults(payload)))
+	default:
+		return json.Marshal(cannedResponse("time_series", cannedTimeSeriesResults(payload)))
+	}
+}
+
+func (c *CannedClient) ListViews(ctx context.Context, sourcePage, name, category string) (json.RawMessage, error) {
+	return cannedFixture("views")
+}
+
+func (c *CannedClient) GetView(ctx context.Context, viewID string) (json.RawMessage, error) {
+	return cannedFixture("view")
+}
+
+func (c *CannedClient) CreateView(ctx context.Context, body []byte) (json.RawMessage, error) {
+	return cannedCreated(body)
+}
+
+func (c *CannedClient) UpdateView(ctx context.Context, viewID string, body []byte) (json.RawMessage, error) {
+	return json.RawMessage(`{"status":"success","data":null}`), nil
+}
+
+func (c *CannedClient) DeleteView(ctx context.Context, viewID string) (json.RawMessage, error) {
+	return json.RawMessage(`{"status":"success","data":null}`), nil
+}
+
+func (c *CannedClient) GetFieldKeys(ctx context.Context, signal, metricName, searchText, fieldContext, fieldDataType, source string) (json.RawMessage, error) {
+	switch signal {
+	case "logs", "traces", "metrics":
+		return cannedFixture("field_keys_" + signal)
+	default:
+		return cannedFixture("field_keys_traces")
+	}
+}
+
+func (c *CannedClient) GetFieldValues(ctx context.Context, signal, name, metricName, searchText, fieldContext, source string) (json.RawMessage, error) {
+	if values, ok := cannedGroupValues[name]; ok {
+		return json.Marshal(map[string]any{"status": "success", "data": map[string]any{
+			"values":   map[string]any{"stringValues": values, "numberValues": []any{}},
+			"complete": true,
+		}})
+	}
+	return cannedFixture("field_values")
+}
+
+func (c *CannedClient) GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
+	if startTime == 0 || endTime == 0 {
+		return nil, fmt.Errorf("start and end time parameters are required")
+	}
+	if limit <= 0 {
+		limit = types.DefaultTraceDetailsLimit
+	}
+	filterExpression := fmt.Sprintf("trace_id = '%s'", traceID)
+	return cannedRawRows(*types.BuildTracesQueryPayload(startTime, endTime, filterExpression, limit, 0, "", ""))
+}
+
+func (c *CannedClient) CreateAlertRule(ctx context.Context, alertJSON []byte) (json.RawMessage, error) {
+	return cannedCreated(alertJSON)
+}
+
+func (c *CannedClient) UpdateAlertRule(ctx context.Context, ruleID string, alertJSON []byte) error {
+	return nil
+}
+
+func (c *CannedClient) DeleteAlertRule(ctx context.Context, ruleID string) error {
+	return nil
+}
+
+func (c *CannedClient) CheckMetricUsage(ctx context.Context, names []string) (map[string]MetricUsage, error) {
+	out := make(map[string]MetricUsage, len(names))
+	for _, name := range names {
+		out[name] = MetricUsage{Dashboards: []string{}, Alerts: []string{}}
+	}
+	return out, nil
+}
+
+func (c *CannedClient) ListNotificationChannels(ctx context.Context) (json.RawMessage, error) {
+	return cannedFixture("notification_channels")
+}
+
+func (c *CannedClient) GetNotificationChannel(ctx context.Context, id string) (json.RawMessage, error) {
+	return cannedFixture("notification_channel")
+}
+
+func (c *CannedClient) CreateNotificationChannel(ctx context.Context, receiverJSON []byte) (json.RawMessage, error) {
+	return cannedCreated(receiverJSON)
+}
+
+func (c *CannedClient) UpdateNotificationChannel(ctx context.Context, id string, receiverJSON []byte) error {
+	return nil
+}
+
+func (c *CannedClient) DeleteNotificationChannel(ctx context.Context, id string) error {
+	return nil
+}
+
+func (c *CannedClient) TestNotificationChannel(ctx context.Context, receiverJSON []byte) error {
+	return nil
+}
+
+func (c *CannedClient) GetMetricCardinality(ctx context.Context, name string, start, end int64) (json.RawMessage, error) {
+	return cannedFixture("metric_cardinality")
+}
+
+// LastCall reports nothing: canned responses never reach SigNoz.
+func (c *CannedClient) LastCall(requestID string) (CallRecord, bool) {
+	return CallRecord{}, false
+}
+
+// cannedQuery is the part of one composite query a generated response needs.
+type cannedQuery struct {
+	name         string
+	signal       string
+	groupBy      []string
+	aggregations int
+	limit        int
+}
+
+func cannedQueries(payload types.QueryPayload) []cannedQuery {
+	var out []cannedQuery
+	for _, q := range payload.CompositeQuery.Queries {
+		switch spec := q.Spec.(type) {
+		case types.QuerySpec:
+			if spec.Disabled {
+				continue
+			}
+			cq := cannedQuery{name: spec.Name, signal: spec.Signal, aggregations: max(len(spec.Aggregations), 1), limit: spec.Limit}
+			for _, g := range spec.GroupBy {
+				cq.groupBy = append(cq.groupBy, g.Name)
+			}
+			out = append(out, cq)
+		case types.FormulaSpec:
+			if !spec.Disabled {
+				out = append(out, cannedQuery{name: spec.Name, aggregations: 1, limit: spec.Limit})
+			}
+		case types.PromQLSpec:
+			if !spec.Disabled {
+				out = append(out, cannedQuery{name: spec.Name, aggregations: 1})
+			}
+		case types.ClickHouseSQLSpec:
+			if !spec.Disabled {
+				out = append(out, cannedQuery{name: spec.Name, aggregations: 1})
+			}
+		}
+	}
+	return out
+}
+
+// groups returns the label values of each group the query reports: one
+// unlabelled group without a groupBy, else up to three, capped by limit.
+func (q cannedQuery) groups() [][]string {
+	if len(q.groupBy) == 0 {
+		return [][]string{nil}
+	}
+	n := 3
+	if q.limit > 0 {
+		n = min(n, q.limit)
+	}
+	groups := make([][]string, n)
+	for i := range groups {
+		for _, key := range q.groupBy {
+			values := cannedGroupValues[key]
+			if i < len(values) {
+				groups[i] = append(groups[i], values[i])
+			} else {
+				groups[i] = append(groups[i], fmt.Sprintf("%s-%d", key, i+1))
+			}
+		}
+	}
+	return groups
+}
+
+// cannedValue gives each (group, aggregation) series its own level and a
+// gentle wave over time, so charts and comparisons have something to show.
+func cannedValue(group, aggregation, point int) float64 {
+	base := 120.0 / float64(group+1) * float64(aggregation+1)
+	return math.Round(base*(1+0.2*math.Sin(float64(point)/3))*100) / 100
+}
+
+func cannedResponse(requestType string, results []any) map[string]any {
+	return map[string]any{"status": "success", "data": map[string]any{
+		"type": requestType,
+		"data": map[string]any{"results": results},
+	}}
+}
+
+func cannedTimeSeriesResults(payload types.QueryPayload) []any {
+	start, end := payload.Start, payload.End
+	if end <= start {
+		end = time.Now().UnixMilli()
+		start = end - time.Hour.Milliseconds()
+	}
+	step := max((end-start)/cannedSeriesPoints, time.Minute.Milliseconds())
+
+	results := []any{}
+	for _, q := range cannedQueries(payload) {
+		aggregations := make([]any, 0, q.aggregations)
+		for a := range q.aggregations {
+			series := []any{}
+			for g, group := range q.groups() {
+				labels := make([]any, 0, len(group))
+				for i, value := range group {
+					labels = append(labels, map[string]any{"key": map[string]any{"name": q.groupBy[i]}, "value": value})
+				}
+				values := []any{}
+				for p, ts := 0, start-start%step; ts < end; p, ts = p+1, ts+step {
+					values = append(values, map[string]any{"timestamp": ts, "value": cannedValue(g, a, p)})
+				}
+				series = append(series, map[string]any{"labels": labels, "values": values})
+			}
+			aggregations = append(aggregations, map[string]any{"index": a, "alias": "", "series": series})
+		}
+		results = append(results, map[string]any{"queryName": q.name, "aggregations": aggregations})
+	}
+	return results
+}
+
+func cannedScalarResults(payload types.QueryPayload) []any {
+	results := []any{}
+	for _, q := range cannedQueries(payload) {
+		columns := []any{}
+		for _, key := range q.groupBy {
+			columns = append(columns, map[string]any{"name": key, "queryName": q.name, "columnType": "group"})
+		}
+		for a := range q.aggregations {
+			columns = append(columns, map[string]any{"name": fmt.Sprintf("__result_%d", a), "queryName": q.name, "aggregationIndex": a, "columnType": "aggregation"})
+		}
+		rows := []any{}
+		for g, group := range q.groups() {
+			row := make([]any, 0, len(columns))
+			for _, value := range group {
+				row = append(row, value)
+			}
+			for a := range q.aggregations {
+				row = append(row, cannedValue(g, a, 0))
+			}
+			rows = append(rows, row)
+		}
+		results = append(results, map[string]any{"queryName": q.name, "columns": columns, "data": rows})
+	}
+	return results
+}
+
+// cannedRawRows answers a raw request with the logs or traces fixture,
+// renamed to the request's first query and cut to its limit.
+func cannedRawRows(payload types.QueryPayload) (json.RawMessage, error) {
+	queries := cannedQueries(payload)
+	if len(queries) == 0 {
+		return json.Marshal(cannedResponse("raw", []any{}))
+	}
+	q := queries[0]
+	fixture := "query_raw_traces"
+	if strings.EqualFold(q.signal, "logs") {
+		fixture = "query_raw_logs"
+	}
+	body, err := cannedFixture(fixture)
+	if err != nil {
+		return nil, err
+	}
+	var resp struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Rows []json.RawMessage `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("mock mode: invalid fixture %q: %w", fixture, err)
+	}
+	var rows []json.RawMessage
+	for _, res := range resp.Data.Data.Results {
+		rows = append(rows, res.Rows...)
+	}
+	if q.limit > 0 && len(rows) > q.limit {
+		rows = rows[:q.limit]
+	}
+	return json.Marshal(cannedResponse("raw", []any{map[string]any{"queryName": q.name, "nextCursor": "", "rows": rows}}))
+}
@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io/fs"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestCannedFixtures_AreValidJSON(t *testing.T) {
+	names, err := fs.Glob(cannedFixtures, "fixtures/*.json")
+	require.NoError(t, err)
+	require.NotEmpty(t, names)
+	for _, name := range names {
+		body, err := cannedFixtures.ReadFile(name)
+		require.NoError(t, err)
+		assert.True(t, json.Valid(body), name)
+	}
+}
+
+func TestCannedClient_TimeSeriesFollowsGroupBy(t *testing.T) {
+	c := NewCannedClient()
+	payload := types.BuildAggregateQueryPayload("traces", 1_700_000_000_000, 1_700_003_600_000, "p99(duration_nano)", "", []types.SelectField{{Name: "service.name"}}, "", "", 0, "time_series", nil)
+	body, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	data, err := c.QueryBuilderV5(context.Background(), body)
+	require.NoError(t, err)
+
+	var resp struct {
+		Data struct {
+			Type string `json:"type"`
+			Data struct {
+				Results []struct {
+					QueryName    string `json:"queryName"`
+					Aggregations []struct {
+						Series []struct {
+							Labels []struct {
+								Key struct {
+									Name string `json:"name"`
+								} `json:"key"`
+								Value string `json:"value"`
+							} `json:"labels"`
+							Values []struct {
+								Timestamp int64   `json:"timestamp"`
+								Value     float64 `json:"value"`
+							} `json:"values"`
+						} `json:"series"`
+					} `json:"aggregations"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.Len(t, resp.Data.Data.Results, 1)
+	series := resp.Data.Data.Results[0].Aggregations[0].Series
+	require.Len(t, series, 3)
+	assert.Equal(t, "service.name", series[0].Labels[0].Key.Name)
+	assert.Equal(t, "checkout", series[0].Labels[0].Value)
+	require.NotEmpty(t, series[0].Values)
+	step := series[0].Values[1].Timestamp - series[0].Values[0].Timestamp
+	assert.Greater(t, series[0].Values[0].Timestamp, int64(1_700_000_000_000)-step)
+	assert.Less(t, series[0].Values[len(series[0].Values)-1].Timestamp, int64(1_700_003_600_000))
+}
+
+func TestCannedClient_RawRowsHonourLimitAndSignal(t *testing.T) {
+	c := NewCannedClient()
+	body, err := json.Marshal(types.BuildLogsQueryPayload(1, 2, "", 2, 0))
+	require.NoError(t, err)
+
+	data, err := c.QueryBuilderV5(context.Background(), body)
+	require.NoError(t, err)
+
+	var resp struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					QueryName string `json:"queryName"`
+					Rows      []struct {
+						Data map[string]any `json:"data"`
+					} `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.Len(t, resp.Data.Data.Results, 1)
+	rows := resp.Data.Data.Results[0].Rows
+	require.Len(t, rows, 2)
+	assert.Contains(t, rows[0].Data, "body")
+
+	data, err = c.GetTraceDetails(context.Background(), "4bf92f3577b34da6a3ce929d0e0e4736", true, 1, 2, 0)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &resp))
+	assert.Contains(t, resp.Data.Data.Results[0].Rows[0].Data, "span_id")
+}
+
+func TestCannedClient_ListDashboardsIsSimplified(t *testing.T) {
+	data, err := NewCannedClient().ListDashboards(context.Background())
+	require.NoError(t, err)
+
+	var resp struct {
+		Data []map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+	require.NotEmpty(t, resp.Data)
+	assert.Equal(t, "Checkout service", resp.Data[0]["name"])
+	assert.NotContains(t, resp.Data[0], "data")
+}
+
+func TestCannedClient_CreateEchoesBodyWithID(t *testing.T) {
+	data, err := NewCannedClient().CreateView(context.Background(), []byte(`{"name":"Errors","sourcePage":"logs"}`))
+	require.NoError(t, err)
+
+	var resp struct {
+		Data map[string]any `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(data, &resp))
+	assert.Equal(t, "Errors", resp.Data["name"])
+	assert.NotEmpty(t, resp.Data["id"])
+}
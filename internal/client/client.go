@@ -3,17 +3,21 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
 	"log/slog"
+	"mime"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	expirable "github.com/hashicorp/golang-lru/v2/expirable"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"go.opentelemetry.io/otel/metric"
 	"go.opentelemetry.io/otel/trace"
@@ -30,14 +34,18 @@ const (
 	ContentType  = "Content-Type"
 	UserAgent    = "User-Agent"
 
-	// DefaultQueryTimeout is used for read-only API calls.
-	DefaultQueryTimeout = 600 * time.Second
+	// DefaultQueryTimeout is used for read-only API calls unless the client
+	// was given another one with SetRequestTimeout.
+	DefaultQueryTimeout = 60 * time.Second
 	// DashboardWriteTimeout is used for dashboard create/update operations.
 	DashboardWriteTimeout = 30 * time.Second
 
 	// analyticsIdentityCacheTTL keeps /me out of the hot analytics path;
 	// identity rarely changes, so 10 min is long enough to absorb bursts.
 	analyticsIdentityCacheTTL = 10 * time.Minute
+
+	// fieldCacheSize bounds the field keys/values responses kept per client.
+	fieldCacheSize = 256
 )
 
 var (
@@ -47,7 +55,14 @@ var (
 	// an HTML 404 page. A live SigNoz API replies to the validation endpoints
 	// with JSON, even on 404.
 	ErrInstanceNotFound = errors.New("no signoz instance found at URL")
-	defaultUserAgent    = version.UserAgent()
+	// ErrUnreachable means no HTTP response came back at all (DNS, TLS,
+	// connection refused, timeout).
+	ErrUnreachable = errors.New("failed to reach SigNoz API")
+	// ErrNonJSONResponse means a request succeeded but the body is a markup
+	// page, typically the SigNoz UI or a login redirect served because the
+	// URL points at the frontend or the credentials were not accepted.
+	ErrNonJSONResponse = errors.New("received non-JSON response; check SIGNOZ_URL points to the SigNoz API and the API key is valid")
+	defaultUserAgent   = version.UserAgent()
 )
 
 // HTTPStatusError preserves status and response details from a non-2xx SigNoz API response.
@@ -88,6 +103,14 @@ type SigNoz struct {
 	cachedIdentity   *AnalyticsIdentity
 	identityCachedAt time.Time
 	meters           *otelpkg.Meters
+	requestStats     *RequestStats
+	callRecorder     *CallRecorder
+	upstreamLimiter  *UpstreamLimiter
+	requestTimeout   time.Duration
+	// fieldCache holds field keys/values responses by request URL; nil
+	// disables caching. Clients are per tenant credentials, so entries are
+	// never shared across API keys.
+	fieldCache *expirable.LRU[string, json.RawMessage]
 }
 
 // sharedTransport is a single process-wide *http.Transport — and therefore a
@@ -112,10 +135,40 @@ var sharedTransport = func() *http.Transport {
 	return t
 }()
 
+// SetProxy routes every client through proxyURL instead of the
+// HTTP_PROXY/HTTPS_PROXY/NO_PROXY environment that sharedTransport honours
+// by default. Call it once at startup, before any request or
+// NewTLSTransport; a nil URL keeps the environment settings.
+func SetProxy(proxyURL *url.URL) {
+	if proxyURL == nil {
+		return
+	}
+	sharedTransport.Proxy = http.ProxyURL(proxyURL)
+}
+
+// CloseIdleConnections closes the idle keep-alive connections pooled by
+// sharedTransport, so shutdown does not leave them to the server's idle
+// timeout. Connections in use are left alone.
+func CloseIdleConnections() {
+	sharedTransport.CloseIdleConnections()
+}
+
+// NewTLSTransport returns a transport with sharedTransport's pooling
+// settings and the given TLS configuration. Build it once and share it
+// between clients through SetTransport so they keep pooling connections.
+func NewTLSTransport(tlsConfig *tls.Config) *http.Transport {
+	t := sharedTransport.Clone()
+	t.TLSClientConfig = tlsConfig
+	return t
+}
+
+// NewClient returns a client for the SigNoz API at baseURL. baseURL may carry
+// a path prefix (https://host/signoz) when SigNoz is served behind a reverse
+// proxy; every endpoint is appended to it.
 func NewClient(log *slog.Logger, baseURL, apiKey, authHeaderName string, customHeaders map[string]string) *SigNoz {
 	return &SigNoz{
 		logger:         log,
-		baseURL:        baseURL,
+		baseURL:        strings.TrimRight(baseURL, "/"),
 		apiKey:         apiKey,
 		authHeaderName: authHeaderName,
 		customHeaders:  customHeaders,
@@ -139,6 +192,82 @@ func (s *SigNoz) SetMeters(meters *otelpkg.Meters) {
 	s.meters = meters
 }
 
+// SetRequestStats attaches a shared per-endpoint stats collector. A nil
+// collector (the default) disables recording.
+func (s *SigNoz) SetRequestStats(stats *RequestStats) {
+	s.requestStats = stats
+}
+
+// SetUpstreamLimiter attaches a shared cap on concurrent SigNoz requests. A
+// nil limiter (the default) leaves requests unlimited.
+func (s *SigNoz) SetUpstreamLimiter(limiter *UpstreamLimiter) {
+	s.upstreamLimiter = limiter
+}
+
+// SetCallRecorder attaches a recorder for the latest tool calls' upstream
+// requests. A nil recorder (the default) disables recording.
+func (s *SigNoz) SetCallRecorder(recorder *CallRecorder) {
+	s.callRecorder = recorder
+}
+
+// LastCall returns the upstream requests of the tool call with requestID,
+// or of the latest tool call made through this client when requestID is
+// empty; ok is false when recording is off or nothing matches.
+func (s *SigNoz) LastCall(requestID string) (CallRecord, bool) {
+	return s.callRecorder.Last(requestID)
+}
+
+// SetTransport replaces sharedTransport, e.g. with one from NewTLSTransport
+// for a SigNoz instance that needs a private CA. A nil transport keeps the
+// current one.
+func (s *SigNoz) SetTransport(t *http.Transport) {
+	if t == nil {
+		return
+	}
+	s.httpClient.Transport = otelhttp.NewTransport(t)
+}
+
+// SetRequestTimeout replaces DefaultQueryTimeout for read-only calls. A
+// non-positive value restores the default.
+func (s *SigNoz) SetRequestTimeout(timeout time.Duration) {
+	s.requestTimeout = timeout
+}
+
+// SetFieldCacheTTL caches successful GetFieldKeys and GetFieldValues
+// responses for ttl. A non-positive ttl disables the cache.
+func (s *SigNoz) SetFieldCacheTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		s.fieldCache = nil
+		return
+	}
+	s.fieldCache = expirable.NewLRU[string, json.RawMessage](fieldCacheSize, nil, ttl)
+}
+
+// cachedGet serves a GET from fieldCache when possible and caches successful
+// responses. Errors are never cached.
+func (s *SigNoz) cachedGet(ctx context.Context, reqURL string) (json.RawMessage, error) {
+	if s.fieldCache == nil {
+		return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
+	}
+	if cached, ok := s.fieldCache.Get(reqURL); ok {
+		return bytes.Clone(cached), nil
+	}
+	body, err := s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
+	if err != nil {
+		return nil, err
+	}
+	s.fieldCache.Add(reqURL, bytes.Clone(body))
+	return body, nil
+}
+
+// queryTimeout is the deadline applied to read-only calls.
+func (s *SigNoz) queryTimeout() time.Duration {
+	if s.requestTimeout > 0 {
+		return s.requestTimeout
+	}
+	return DefaultQueryTimeout
+}
+
 func (s *SigNoz) ensureTenantContext(ctx context.Context) context.Context {
 	if _, ok := util.GetSigNozURL(ctx); !ok && s.baseURL != "" {
 		return util.SetSigNozURL(ctx, s.baseURL)
@@ -187,7 +316,7 @@ func (s *SigNoz) ValidateCredentials(ctx context.Context) error {
 	if err != nil {
 		s.logger.ErrorContext(ctx, "SigNoz credential validation request failed",
 			slog.String("url", userURL), logpkg.ErrAttr(err))
-		return fmt.Errorf("failed to reach SigNoz API: %w", err)
+		return fmt.Errorf("%w: %w", ErrUnreachable, err)
 	}
 
 	// A JSON 404 means the key is a service-account key; validate via the
@@ -202,13 +331,84 @@ func (s *SigNoz) ValidateCredentials(ctx context.Context) error {
 		if err != nil {
 			s.logger.ErrorContext(ctx, "SigNoz credential validation request failed",
 				slog.String("url", saURL), logpkg.ErrAttr(err))
-			return fmt.Errorf("failed to reach SigNoz API: %w", err)
+			return fmt.Errorf("%w: %w", ErrUnreachable, err)
 		}
 	}
 
 	return s.evaluateValidationResponse(ctx, status, body)
 }
 
+// PingResult reports the outcome of a connectivity check.
+type PingResult struct {
+	// Reachable is true when a SigNoz API answered at all, even if it
+	// rejected the credentials.
+	Reachable bool
+	// Authenticated is true when the credentials were accepted.
+	Authenticated bool
+	// Version is the backend version, when /api/v1/version reported one.
+	Version string
+	// Latency is the round trip of the credential check.
+	Latency time.Duration
+}
+
+// Ping checks that the instance is reachable and accepts the configured
+// credentials, then makes a best-effort lookup of the backend version. The
+// result is always non-nil; the error is non-nil unless Authenticated.
+func (s *SigNoz) Ping(ctx context.Context) (*PingResult, error) {
+	ctx = s.ensureTenantContext(ctx)
+	start := time.Now()
+	err := s.ValidateCredentials(ctx)
+	result := &PingResult{
+		Reachable:     err == nil || !(errors.Is(err, ErrUnreachable) || errors.Is(err, ErrInstanceNotFound)),
+		Authenticated: err == nil,
+		Latency:       time.Since(start),
+	}
+	if result.Reachable {
+		if v, verr := s.GetVersion(ctx); verr == nil {
+			result.Version = v.Version
+		} else {
+			s.logger.DebugContext(ctx, "SigNoz version lookup failed", logpkg.ErrAttr(verr))
+		}
+	}
+	return result, err
+}
+
+// VersionInfo is the SigNoz backend build reported by /api/v1/version.
+type VersionInfo struct {
+	Version string `json:"version"`
+	// Edition is "enterprise" for EE builds and "community" otherwise.
+	Edition string `json:"edition"`
+}
+
+// GetVersion returns the backend version and edition.
+func (s *SigNoz) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	reqURL := fmt.Sprintf("%s/api/v1/version", s.baseURL)
+	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching SigNoz version")
+	body, err := s.doRequest(ctx, http.MethodGet, reqURL, nil, 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return parseVersionInfo(body)
+}
+
+func parseVersionInfo(body []byte) (*VersionInfo, error) {
+	var resp struct {
+		Version string `json:"version"`
+		EE      string `json:"ee"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("unmarshal version response: %w", err)
+	}
+	if resp.Version == "" {
+		return nil, fmt.Errorf("version response has no version")
+	}
+	edition := "community"
+	if strings.EqualFold(resp.EE, "Y") {
+		edition = "enterprise"
+	}
+	return &VersionInfo{Version: resp.Version, Edition: edition}, nil
+}
+
 // GetAnalyticsIdentity returns the org + user identity for the current
 // credentials, cached per-client and mutex-serialized so a burst of events
 // produces a single /me roundtrip.
@@ -259,7 +459,7 @@ func (s *SigNoz) fetchAnalyticsIdentity(ctx context.Context) (*AnalyticsIdentity
 	if err != nil {
 		s.logger.ErrorContext(ctx, "SigNoz analytics identity request failed",
 			slog.String("url", reqURL), logpkg.ErrAttr(err))
-		return nil, fmt.Errorf("failed to reach SigNoz API: %w", err)
+		return nil, fmt.Errorf("%w: %w", ErrUnreachable, err)
 	}
 	if status != http.StatusOK {
 		return nil, s.evaluateValidationResponse(ctx, status, body)
@@ -371,12 +571,42 @@ func isHTMLBody(body []byte) bool {
 	return len(trimmed) > 0 && trimmed[0] == '<'
 }
 
+// isHTMLContentType reports whether a Content-Type header names an HTML page.
+func isHTMLContentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "text/html" || mediaType == "application/xhtml+xml")
+}
+
 const (
 	maxRetries    = 3
 	retryBaseWait = 100 * time.Millisecond
 	retryMultiply = 4
+	// maxRetryAfterWait caps how long a Retry-After header can hold one
+	// retry, so a large value cannot stall a tool call for minutes.
+	maxRetryAfterWait = 10 * time.Second
 )
 
+// retryAfterWait parses a Retry-After header, given either as delay seconds
+// or as an HTTP date, and caps it at maxRetryAfterWait. ok is false when the
+// header is absent or unparseable, so the caller keeps its own backoff.
+func retryAfterWait(header string, now time.Time) (wait time.Duration, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(header); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		wait = time.Duration(secs) * time.Second
+	} else if at, err := http.ParseTime(header); err == nil {
+		wait = max(at.Sub(now), 0)
+	} else {
+		return 0, false
+	}
+	return min(wait, maxRetryAfterWait), true
+}
+
 // maxResponseBytes caps how many bytes doRequest buffers from one backend
 // response, so an unbounded response (e.g. a builder query for millions of
 // rows) can't OOM the shared pod. We error rather than truncate, so callers
@@ -398,9 +628,39 @@ func (s *SigNoz) doReplaySafePost(ctx context.Context, reqURL string, body []byt
 
 func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL string, body []byte, timeout time.Duration, replaySafe bool) (json.RawMessage, error) {
 	ctx = s.ensureTenantContext(ctx)
+	// Queue for an upstream slot before the request timeout starts, so time
+	// spent waiting is bounded by the limiter's own wait, not the timeout.
+	// The slot is held for one attempt at a time: it is released before a
+	// retry's backoff and taken again for the next attempt.
+	release, err := s.acquireUpstreamSlot(ctx, method, reqURL)
+	if err != nil {
+		return nil, err
+	}
+	releaseSlot := func() {
+		if release != nil {
+			release()
+			release = nil
+		}
+	}
+	defer releaseSlot()
+	// A caller-supplied override (e.g. a tool's timeoutSeconds) replaces the
+	// per-endpoint default; the handler has already bounded it.
+	if override, ok := util.GetRequestTimeout(ctx); ok {
+		timeout = override
+	}
+	// WithTimeout keeps an earlier deadline already on ctx, so a caller's
+	// own deadline is never extended.
 	ctx, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
 
+	// status tracks the last HTTP status seen so the stats record the
+	// outcome of the whole request, retries included.
+	started, status := time.Now(), 0
+	defer func() {
+		s.requestStats.Record(method, reqURL, status, time.Since(started))
+		s.callRecorder.Record(ctx, method, reqURL, body, status, time.Since(started))
+	}()
+
 	var lastErr error
 	wait := retryBaseWait
 	maxAttempts := 1
@@ -409,6 +669,11 @@ func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL s
 	}
 
 	for attempt := range maxAttempts {
+		if release == nil {
+			if release, err = s.acquireUpstreamSlot(ctx, method, reqURL); err != nil {
+				return nil, fmt.Errorf("retry aborted: %w: %w", err, lastErr)
+			}
+		}
 		var reqBody io.Reader
 		if body != nil {
 			reqBody = bytes.NewReader(body)
@@ -423,6 +688,8 @@ func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL s
 
 		resp, err := s.httpClient.Do(req)
 		if err != nil {
+			releaseSlot()
+			status = 0
 			// Don't retry on context cancellation.
 			if ctx.Err() != nil {
 				return nil, fmt.Errorf("request cancelled: %w", err)
@@ -435,7 +702,7 @@ func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL s
 					logpkg.ErrAttr(err))
 				select {
 				case <-ctx.Done():
-					return nil, fmt.Errorf("retry aborted: %w", lastErr)
+					return nil, fmt.Errorf("retry aborted: %w: %w", ctx.Err(), lastErr)
 				case <-time.After(wait):
 				}
 				wait *= retryMultiply
@@ -455,10 +722,13 @@ func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL s
 			break
 		}
 
+		status = resp.StatusCode
+
 		// Read one byte past the cap to detect (and reject, not truncate) an
 		// over-limit response. Oversize is terminal, not retried.
 		respBody, readErr := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes+1))
 		_ = resp.Body.Close()
+		releaseSlot()
 
 		if readErr != nil {
 			return nil, fmt.Errorf("failed to read response body: %w", readErr)
@@ -468,22 +738,39 @@ func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL s
 		}
 
 		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			// An HTML page here would only fail later as a confusing JSON
+			// parse error in the caller. Empty bodies (e.g. 204) pass.
+			contentType := resp.Header.Get(ContentType)
+			if isHTMLBody(respBody) || (isHTMLContentType(contentType) && len(bytes.TrimSpace(respBody)) > 0) {
+				s.logger.WarnContext(ctx, "SigNoz request returned a non-JSON response",
+					slog.String("url", reqURL),
+					slog.Int("status", resp.StatusCode),
+					slog.String("content_type", contentType),
+					slog.String("response", logpkg.TruncBody(respBody)))
+				return nil, fmt.Errorf("%w (status %d, content type %q)", ErrNonJSONResponse, resp.StatusCode, contentType)
+			}
 			return respBody, nil
 		}
 
-		// Retry on transient server errors.
+		// Retry on transient server errors. A Retry-After header (sent with
+		// 429 and some 503s) replaces the backoff for this attempt.
 		if isRetryableStatus(resp.StatusCode) && attempt < maxAttempts-1 {
 			statusErr := newHTTPStatusError(resp.StatusCode, respBody)
 			lastErr = statusErr
+			delay := wait
+			if retryAfter, ok := retryAfterWait(resp.Header.Get("Retry-After"), time.Now()); ok {
+				delay = retryAfter
+			}
 			s.logger.DebugContext(ctx, "Retryable status, will retry",
 				slog.String("url", reqURL),
 				slog.Int("status", resp.StatusCode),
 				slog.Int("attempt", attempt+1),
+				slog.Duration("retry_in", delay),
 				slog.String("response", statusErr.truncatedBody()))
 			select {
 			case <-ctx.Done():
-				return nil, fmt.Errorf("retry aborted: %w", lastErr)
-			case <-time.After(wait):
+				return nil, fmt.Errorf("retry aborted: %w: %w", ctx.Err(), lastErr)
+			case <-time.After(delay):
 			}
 			wait *= retryMultiply
 			continue
@@ -508,6 +795,20 @@ func (s *SigNoz) doRequestWithReplayPolicy(ctx context.Context, method, reqURL s
 	return nil, lastErr
 }
 
+// acquireUpstreamSlot takes a slot from the shared upstream limiter for one
+// request attempt, logging when the limit rejects it.
+func (s *SigNoz) acquireUpstreamSlot(ctx context.Context, method, reqURL string) (func(), error) {
+	release, err := s.upstreamLimiter.acquire(ctx)
+	if err != nil {
+		if errors.Is(err, ErrServerBusy) {
+			s.logger.WarnContext(ctx, "SigNoz request rejected: concurrent request limit reached",
+				slog.String("method", method), slog.String("url", reqURL))
+		}
+		return nil, err
+	}
+	return release, nil
+}
+
 func newHTTPStatusError(statusCode int, respBody []byte) *HTTPStatusError {
 	return &HTTPStatusError{
 		StatusCode: statusCode,
@@ -548,7 +849,7 @@ func (s *SigNoz) ListMetrics(ctx context.Context, start, end int64, limit int, s
 
 	reqURL := fmt.Sprintf("%s/api/v2/metrics?%s", s.baseURL, params.Encode())
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Listing metrics", slog.String("searchText", searchText))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) ListMetricKeys(ctx context.Context) (json.RawMessage, error) {
@@ -556,7 +857,7 @@ func (s *SigNoz) ListMetricKeys(ctx context.Context) (json.RawMessage, error) {
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Making request to SigNoz API",
 		slog.String("method", "GET"),
 		slog.String("endpoint", "/api/v1/metrics/filters/keys"))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) ListAlerts(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
@@ -565,19 +866,19 @@ func (s *SigNoz) ListAlerts(ctx context.Context, params types.ListAlertsParams)
 		reqURL += "?" + qp.Encode()
 	}
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching alerts from SigNoz", slog.String("url", reqURL))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) ListAlertRules(ctx context.Context) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v2/rules", s.baseURL)
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching alert rules from SigNoz", slog.String("url", reqURL))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) GetAlertByRuleID(ctx context.Context, ruleID string) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v2/rules/%s", s.baseURL, url.PathEscape(ruleID))
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching alert rule details", slog.String("ruleID", ruleID))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 // ListDashboards filters data as it returns too much data even the ui tags
@@ -588,19 +889,32 @@ func (s *SigNoz) ListDashboards(ctx context.Context) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v1/dashboards", s.baseURL)
 	s.logger.DebugContext(ctx, "Fetching dashboards from SigNoz")
 
-	body, err := s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	body, err := s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
+	if err != nil {
+		return nil, err
+	}
+
+	simplifiedJSON, count, err := simplifyDashboardList(body)
 	if err != nil {
 		return nil, err
 	}
 
+	s.logger.DebugContext(ctx, "Successfully retrieved and simplified dashboards", slog.Int("count", count))
+	return simplifiedJSON, nil
+}
+
+// simplifyDashboardList reduces a /api/v1/dashboards response to each
+// dashboard's id, title, description, tags, and audit fields, dropping the
+// widget definitions. A body without a data array is returned unchanged.
+func simplifyDashboardList(body []byte) (json.RawMessage, int, error) {
 	var rawResponse map[string]interface{}
 	if err := json.Unmarshal(body, &rawResponse); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, 0, fmt.Errorf("failed to parse response: %w", err)
 	}
 
 	data, ok := rawResponse["data"].([]interface{})
 	if !ok {
-		return body, nil
+		return body, 0, nil
 	}
 
 	simplifiedDashboards := make([]map[string]interface{}, 0, len(data))
@@ -639,17 +953,15 @@ func (s *SigNoz) ListDashboards(ctx context.Context) (json.RawMessage, error) {
 
 	simplifiedJSON, err := json.Marshal(simplifiedResponse)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal simplified response: %w", err)
+		return nil, 0, fmt.Errorf("failed to marshal simplified response: %w", err)
 	}
-
-	s.logger.DebugContext(ctx, "Successfully retrieved and simplified dashboards", slog.Int("count", len(simplifiedDashboards)))
-	return simplifiedJSON, nil
+	return simplifiedJSON, len(simplifiedDashboards), nil
 }
 
 func (s *SigNoz) GetDashboard(ctx context.Context, uuid string) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v1/dashboards/%s", s.baseURL, url.PathEscape(uuid))
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching dashboard details", slog.String("uuid", uuid))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) ListServices(ctx context.Context, start, end string) (json.RawMessage, error) {
@@ -659,7 +971,7 @@ func (s *SigNoz) ListServices(ctx context.Context, start, end string) (json.RawM
 
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching services from SigNoz",
 		slog.String("start", start), slog.String("end", end))
-	return s.doReplaySafePost(ctx, reqURL, bodyBytes, DefaultQueryTimeout)
+	return s.doReplaySafePost(ctx, reqURL, bodyBytes, s.queryTimeout())
 }
 
 func (s *SigNoz) GetServiceTopOperations(ctx context.Context, start, end, service string, tags json.RawMessage) (json.RawMessage, error) {
@@ -668,7 +980,7 @@ func (s *SigNoz) GetServiceTopOperations(ctx context.Context, start, end, servic
 	bodyBytes, _ := json.Marshal(payload)
 
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching service top operations", slog.String("service", service))
-	return s.doReplaySafePost(ctx, reqURL, bodyBytes, DefaultQueryTimeout)
+	return s.doReplaySafePost(ctx, reqURL, bodyBytes, s.queryTimeout())
 }
 
 func (s *SigNoz) QueryBuilderV5(ctx context.Context, body []byte) (json.RawMessage, error) {
@@ -681,13 +993,13 @@ func (s *SigNoz) QueryBuilderV5(ctx context.Context, body []byte) (json.RawMessa
 	if span := trace.SpanFromContext(ctx); span.IsRecording() {
 		span.SetAttributes(otelpkg.MCPQueryPayloadKey.String(string(body)))
 	}
-	return s.doReplaySafePost(ctx, reqURL, body, DefaultQueryTimeout)
+	return s.doReplaySafePost(ctx, reqURL, body, s.queryTimeout())
 }
 
 func (s *SigNoz) GetAlertHistory(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v2/rules/%s/history/timeline?%s", s.baseURL, url.PathEscape(ruleID), req.QueryParams().Encode())
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching alert history", slog.String("ruleID", ruleID))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) CreateAlertRule(ctx context.Context, alertJSON []byte) (json.RawMessage, error) {
@@ -721,13 +1033,13 @@ func (s *SigNoz) ListViews(ctx context.Context, sourcePage, name, category strin
 	}
 	reqURL := fmt.Sprintf("%s/api/v1/explorer/views?%s", s.baseURL, params.Encode())
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Listing saved views", slog.String("sourcePage", sourcePage))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) GetView(ctx context.Context, viewID string) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v1/explorer/views/%s", s.baseURL, url.PathEscape(viewID))
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching saved view", slog.String("viewID", viewID))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) CreateView(ctx context.Context, body []byte) (json.RawMessage, error) {
@@ -771,7 +1083,7 @@ func (s *SigNoz) GetFieldKeys(ctx context.Context, signal, metricName, searchTex
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching field keys",
 		slog.String("signal", signal),
 		slog.String("searchText", searchText))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.cachedGet(ctx, reqURL)
 }
 
 func (s *SigNoz) GetFieldValues(ctx context.Context, signal, name, metricName, searchText, fieldContext, source string) (json.RawMessage, error) {
@@ -795,18 +1107,22 @@ func (s *SigNoz) GetFieldValues(ctx context.Context, signal, name, metricName, s
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching field values",
 		slog.String("signal", signal),
 		slog.String("name", name))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.cachedGet(ctx, reqURL)
 }
 
-func (s *SigNoz) GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+// GetTraceDetails fetches up to limit spans of one trace. A limit that is not
+// positive means types.DefaultTraceDetailsLimit.
+func (s *SigNoz) GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 	if startTime == 0 || endTime == 0 {
 		return nil, fmt.Errorf("start and end time parameters are required")
 	}
 
 	filterExpression := fmt.Sprintf("trace_id = '%s'", traceID)
-	limit := 1000
+	if limit <= 0 {
+		limit = types.DefaultTraceDetailsLimit
+	}
 
-	queryPayload := types.BuildTracesQueryPayload(startTime, endTime, filterExpression, limit, 0)
+	queryPayload := types.BuildTracesQueryPayload(startTime, endTime, filterExpression, limit, 0, "", "")
 	queryJSON, err := json.Marshal(queryPayload)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal query payload: %w", err)
@@ -855,6 +1171,19 @@ func (s *SigNoz) UpdateDashboardRaw(ctx context.Context, id string, dashboardJSO
 	return err
 }
 
+// QueryDashboardVariable runs a QUERY dashboard variable's ClickHouse query
+// and returns the {"variableValues": [...]} response. variables supplies the
+// values substituted for {{.name}} references in the query.
+func (s *SigNoz) QueryDashboardVariable(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
+	reqURL := fmt.Sprintf("%s/api/v2/variables/query", s.baseURL)
+	body, err := json.Marshal(map[string]any{"query": query, "variables": variables})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variable query: %w", err)
+	}
+	s.logger.DebugContext(s.ensureTenantContext(ctx), "Querying dashboard variable values")
+	return s.doReplaySafePost(ctx, reqURL, body, s.queryTimeout())
+}
+
 func (s *SigNoz) DeleteDashboard(ctx context.Context, id string) error {
 	reqURL := fmt.Sprintf("%s/api/v1/dashboards/%s", s.baseURL, url.PathEscape(id))
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Deleting dashboard", slog.String("id", id))
@@ -868,13 +1197,13 @@ const ChannelWriteTimeout = 30 * time.Second
 func (s *SigNoz) ListNotificationChannels(ctx context.Context) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v1/channels", s.baseURL)
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching notification channels from SigNoz")
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) GetNotificationChannel(ctx context.Context, id string) (json.RawMessage, error) {
 	reqURL := fmt.Sprintf("%s/api/v1/channels/%s", s.baseURL, url.PathEscape(id))
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching notification channel", slog.String("id", id))
-	return s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	return s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 }
 
 func (s *SigNoz) CreateNotificationChannel(ctx context.Context, receiverJSON []byte) (json.RawMessage, error) {
@@ -912,7 +1241,7 @@ func (s *SigNoz) GetTopMetrics(ctx context.Context, start, end int64, limit int)
 	reqURL := fmt.Sprintf("%s/api/v2/metrics/treemap", s.baseURL)
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching metrics treemap",
 		slog.Int("limit", limit))
-	return s.doReplaySafePost(ctx, reqURL, body, DefaultQueryTimeout)
+	return s.doReplaySafePost(ctx, reqURL, body, s.queryTimeout())
 }
 
 func (s *SigNoz) TestNotificationChannel(ctx context.Context, receiverJSON []byte) error {
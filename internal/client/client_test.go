@@ -3,6 +3,8 @@ package client
 import (
 	"bytes"
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -10,6 +12,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"strings"
 	"sync"
 	"sync/atomic"
@@ -21,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
 	"github.com/SigNoz/signoz-mcp-server/pkg/version"
 )
 
@@ -158,6 +162,75 @@ func TestListAlertRules(t *testing.T) {
 	assert.Contains(t, string(result), `"id":"rule-1"`)
 }
 
+func TestGetVersion(t *testing.T) {
+	for _, tt := range []struct {
+		body, edition string
+	}{
+		{body: `{"version":"v0.80.0","ee":"Y","setupCompleted":true}`, edition: "enterprise"},
+		{body: `{"version":"v0.80.0","ee":"N"}`, edition: "community"},
+		{body: `{"version":"v0.80.0"}`, edition: "community"},
+	} {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/api/v1/version", r.URL.Path)
+			_, _ = w.Write([]byte(tt.body))
+		}))
+		info, err := NewClient(logpkg.New("debug"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil).GetVersion(context.Background())
+		server.Close()
+		require.NoError(t, err, tt.body)
+		assert.Equal(t, &VersionInfo{Version: "v0.80.0", Edition: tt.edition}, info, tt.body)
+	}
+}
+
+func TestPing(t *testing.T) {
+	t.Run("healthy reports version", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v1/user/me":
+				_, _ = w.Write([]byte(`{"data":{"id":"u1"}}`))
+			case "/api/v1/version":
+				_, _ = w.Write([]byte(`{"version":"v0.80.0","ee":"Y"}`))
+			default:
+				t.Errorf("unexpected path %s", r.URL.Path)
+			}
+		}))
+		defer server.Close()
+
+		result, err := NewClient(logpkg.New("debug"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil).Ping(context.Background())
+		require.NoError(t, err)
+		assert.True(t, result.Reachable)
+		assert.True(t, result.Authenticated)
+		assert.Equal(t, "v0.80.0", result.Version)
+	})
+
+	t.Run("rejected key is reachable but unauthenticated", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == "/api/v1/version" {
+				_, _ = w.Write([]byte(`{"version":"v0.80.0"}`))
+				return
+			}
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		result, err := NewClient(logpkg.New("debug"), server.URL, "bad-key", "SIGNOZ-API-KEY", nil).Ping(context.Background())
+		assert.ErrorIs(t, err, ErrUnauthorized)
+		assert.True(t, result.Reachable)
+		assert.False(t, result.Authenticated)
+		assert.Equal(t, "v0.80.0", result.Version)
+	})
+
+	t.Run("closed port is unreachable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+		url := server.URL
+		server.Close()
+
+		result, err := NewClient(logpkg.New("debug"), url, "test-api-key", "SIGNOZ-API-KEY", nil).Ping(context.Background())
+		assert.ErrorIs(t, err, ErrUnreachable)
+		assert.False(t, result.Reachable)
+		assert.False(t, result.Authenticated)
+	})
+}
+
 // expiredWorkspaceHTML mirrors the 404 page the SigNoz Cloud ingress serves
 // for expired/deactivated workspaces (captured from production logs).
 const expiredWorkspaceHTML = `<!DOCTYPE html>
@@ -1353,7 +1426,7 @@ func TestGetTraceDetails_UsesCanonicalTraceIDFilter(t *testing.T) {
 	logger := logpkg.New("debug")
 	client := NewClient(logger, server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
 
-	_, err := client.GetTraceDetails(context.Background(), "abc123", true, 1711123200000, 1711130400000)
+	_, err := client.GetTraceDetails(context.Background(), "abc123", true, 1711123200000, 1711130400000, 0)
 	require.NoError(t, err)
 
 	payload := string(captured)
@@ -1363,6 +1436,49 @@ func TestGetTraceDetails_UsesCanonicalTraceIDFilter(t *testing.T) {
 	require.NotContains(t, payload, `"expression":"traceID = 'abc123'"`)
 }
 
+func TestGetTraceDetails_PassesLimit(t *testing.T) {
+	var captured []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		captured = body
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"result":[]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(logpkg.New("debug"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+
+	_, err := client.GetTraceDetails(context.Background(), "abc123", true, 1711123200000, 1711130400000, 5000)
+	require.NoError(t, err)
+	require.Contains(t, string(captured), `"limit":5000`)
+}
+
+func TestQueryDashboardVariable(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v2/variables/query", r.URL.Path)
+
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "SELECT DISTINCT host FROM t WHERE env = {{.env}}", body.Query)
+		assert.Equal(t, "prod", body.Variables["env"])
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"success","data":{"variableValues":["a","b"]}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(logpkg.New("debug"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+
+	got, err := client.QueryDashboardVariable(context.Background(), "SELECT DISTINCT host FROM t WHERE env = {{.env}}", map[string]any{"env": "prod"})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"status":"success","data":{"variableValues":["a","b"]}}`, string(got))
+}
+
 func TestCreateDashboard(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		assert.Equal(t, http.MethodPost, r.Method)
@@ -1758,6 +1874,54 @@ func TestDoRequest_RetryOn429(t *testing.T) {
 	assert.Contains(t, string(result), "success")
 }
 
+func TestDoRequest_RetryOn429HonorsRetryAfter(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 2 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":"rate limited"}`))
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+
+	started := time.Now()
+	result, err := c.doRequest(context.Background(), http.MethodGet, srv.URL+"/test", nil, DefaultQueryTimeout)
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Contains(t, string(result), "success")
+	assert.GreaterOrEqual(t, time.Since(started), time.Second, "expected the client to wait for Retry-After")
+}
+
+func TestRetryAfterWait(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		header string
+		want   time.Duration
+		ok     bool
+	}{
+		{"", 0, false},
+		{"soon", 0, false},
+		{"-1", 0, false},
+		{"0", 0, true},
+		{" 2 ", 2 * time.Second, true},
+		{"3600", maxRetryAfterWait, true},
+		{now.Add(3 * time.Second).Format(http.TimeFormat), 3 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+	}
+	for _, tt := range tests {
+		got, ok := retryAfterWait(tt.header, now)
+		assert.Equal(t, tt.ok, ok, tt.header)
+		assert.Equal(t, tt.want, got, tt.header)
+	}
+}
+
 func TestGuardrail_MutatingPOSTNotRetriedAfterRetryableStatus(t *testing.T) {
 	var attempts atomic.Int32
 	var requestBody []byte
@@ -2104,6 +2268,52 @@ func TestSharedTransportPoolTuning(t *testing.T) {
 	require.NotNil(t, sharedTransport.DialContext, "cloned DefaultTransport: DialContext preserved")
 }
 
+func TestSetProxy_RoutesRequestsThroughProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// A forward proxy receives the absolute target URL.
+		proxiedHost = r.URL.Host
+		_, _ = w.Write([]byte(`{"version":"v0.80.0"}`))
+	}))
+	defer proxy.Close()
+
+	original := sharedTransport.Proxy
+	t.Cleanup(func() { sharedTransport.Proxy = original })
+	SetProxy(nil)
+	require.NotNil(t, sharedTransport.Proxy, "nil keeps the environment proxy settings")
+
+	proxyURL, err := url.Parse(proxy.URL)
+	require.NoError(t, err)
+	SetProxy(proxyURL)
+
+	info, err := NewClient(logpkg.New("debug"), "http://signoz.internal.example", "test-api-key", "SIGNOZ-API-KEY", nil).GetVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v0.80.0", info.Version)
+	assert.Equal(t, "signoz.internal.example", proxiedHost)
+}
+
+func TestNewTLSTransport_TrustsPrivateCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"version":"v0.80.0"}`))
+	}))
+	defer server.Close()
+
+	c := NewClient(logpkg.New("debug"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+	_, err := c.GetVersion(context.Background())
+	require.Error(t, err, "the default transport must not trust the test CA")
+
+	pool := x509.NewCertPool()
+	pool.AddCert(server.Certificate())
+	transport := NewTLSTransport(&tls.Config{RootCAs: pool})
+	assert.Equal(t, sharedTransport.MaxIdleConnsPerHost, transport.MaxIdleConnsPerHost)
+
+	c = NewClient(logpkg.New("debug"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+	c.SetTransport(transport)
+	info, err := c.GetVersion(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "v0.80.0", info.Version)
+}
+
 // TestDoRequest_RejectsOversizeResponse verifies the response-size guard: a
 // backend response larger than maxResponseBytes is rejected with a clear error
 // (never silently truncated into invalid JSON), bounding single-request memory
@@ -2150,3 +2360,391 @@ func TestDoRequest_AllowsLargeUnderCapResponse(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, len(body), len(got))
 }
+
+func TestDoRequest_RequestTimeoutOverride(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(200 * time.Millisecond)
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+
+	// A shorter override fails a request the endpoint default would allow.
+	short := util.SetRequestTimeout(context.Background(), 20*time.Millisecond)
+	_, err := c.doRequest(short, http.MethodGet, srv.URL+"/test", nil, DefaultQueryTimeout)
+	require.Error(t, err)
+
+	// A longer override rescues a request the endpoint default would fail.
+	long := util.SetRequestTimeout(context.Background(), 5*time.Second)
+	result, err := c.doRequest(long, http.MethodGet, srv.URL+"/test", nil, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Contains(t, string(result), "success")
+}
+
+func TestRequestTimeout_FiresOnSlowBackend(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+	c.SetRequestTimeout(50 * time.Millisecond)
+
+	start := time.Now()
+	_, err := c.ListMetricKeys(context.Background())
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestRequestTimeout_KeepsEarlierCallerDeadline(t *testing.T) {
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer srv.Close()
+	defer close(release)
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+	c.SetRequestTimeout(time.Minute)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := c.ListMetricKeys(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Less(t, time.Since(start), 2*time.Second)
+}
+
+func TestQueryTimeout_DefaultsWhenUnset(t *testing.T) {
+	c := NewClient(logpkg.New("error"), "http://localhost", "test-key", "SIGNOZ-API-KEY", nil)
+	assert.Equal(t, DefaultQueryTimeout, c.queryTimeout())
+	c.SetRequestTimeout(90 * time.Second)
+	assert.Equal(t, 90*time.Second, c.queryTimeout())
+}
+
+// TestClient_BaseURLPathPrefix covers SigNoz served under a subpath by a
+// reverse proxy: every endpoint keeps the prefix, with or without a trailing
+// slash on the base URL.
+func TestClient_BaseURLPathPrefix(t *testing.T) {
+	calls := []struct {
+		name     string
+		wantPath string
+		call     func(c *SigNoz) error
+	}{
+		{"ListMetrics", "/signoz/api/v2/metrics", func(c *SigNoz) error {
+			_, err := c.ListMetrics(context.Background(), 0, 0, 10, "", "")
+			return err
+		}},
+		{"GetDashboard", "/signoz/api/v1/dashboards/abc", func(c *SigNoz) error {
+			_, err := c.GetDashboard(context.Background(), "abc")
+			return err
+		}},
+		{"QueryBuilderV5", "/signoz/api/v5/query_range", func(c *SigNoz) error {
+			_, err := c.QueryBuilderV5(context.Background(), []byte(`{}`))
+			return err
+		}},
+		{"GetFieldValues", "/signoz/api/v1/fields/values", func(c *SigNoz) error {
+			_, err := c.GetFieldValues(context.Background(), "logs", "service.name", "", "", "", "")
+			return err
+		}},
+	}
+	for _, suffix := range []string{"/signoz", "/signoz/"} {
+		for _, tt := range calls {
+			t.Run(tt.name+" "+suffix, func(t *testing.T) {
+				var gotPath string
+				srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					gotPath = r.URL.Path
+					_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+				}))
+				defer srv.Close()
+
+				c := NewClient(logpkg.New("error"), srv.URL+suffix, "test-key", "SIGNOZ-API-KEY", nil)
+				require.NoError(t, tt.call(c))
+				assert.Equal(t, tt.wantPath, gotPath)
+			})
+		}
+	}
+}
+
+// TestClientQueryParams_EscapeSpecialCharacters checks that free-text query
+// parameters survive the round trip: "&" must not split the parameter, "+"
+// must not decode as a space, and "#" must not start a fragment.
+func TestClientQueryParams_EscapeSpecialCharacters(t *testing.T) {
+	const text = "a&b c+d#e"
+	tests := []struct {
+		name  string
+		param string
+		call  func(c *SigNoz) error
+	}{
+		{"ListMetrics", "searchText", func(c *SigNoz) error {
+			_, err := c.ListMetrics(context.Background(), 0, 0, 10, text, "")
+			return err
+		}},
+		{"GetFieldKeys", "searchText", func(c *SigNoz) error {
+			_, err := c.GetFieldKeys(context.Background(), "traces", "", text, "", "", "")
+			return err
+		}},
+		{"GetFieldValues name", "name", func(c *SigNoz) error {
+			_, err := c.GetFieldValues(context.Background(), "traces", text, "", "", "", "")
+			return err
+		}},
+		{"GetFieldValues searchText", "searchText", func(c *SigNoz) error {
+			_, err := c.GetFieldValues(context.Background(), "traces", "http.url", "", text, "", "")
+			return err
+		}},
+		{"ListViews", "name", func(c *SigNoz) error {
+			_, err := c.ListViews(context.Background(), "traces", text, "")
+			return err
+		}},
+		{"GetMetricCardinality", "metricName", func(c *SigNoz) error {
+			_, err := c.GetMetricCardinality(context.Background(), text, 1, 2)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got url.Values
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.Query()
+				_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+			}))
+			defer srv.Close()
+
+			c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+			require.NoError(t, tt.call(c))
+			assert.Equal(t, []string{text}, got[tt.param])
+			assert.NotContains(t, got, "b c+d#e", "& must not split the parameter")
+		})
+	}
+}
+
+func TestFieldCache_HitMissAndExpiry(t *testing.T) {
+	var calls atomic.Int32
+	var fail atomic.Bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if fail.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":{"keys":{}}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+	c.SetFieldCacheTTL(50 * time.Millisecond)
+	ctx := context.Background()
+
+	_, err := c.GetFieldKeys(ctx, "logs", "", "http", "", "", "")
+	require.NoError(t, err)
+	_, err = c.GetFieldKeys(ctx, "logs", "", "http", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), calls.Load(), "same params hit the cache")
+
+	_, err = c.GetFieldKeys(ctx, "traces", "", "http", "", "", "")
+	require.NoError(t, err)
+	_, err = c.GetFieldValues(ctx, "logs", "service.name", "", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load(), "different params miss")
+
+	time.Sleep(100 * time.Millisecond)
+	_, err = c.GetFieldKeys(ctx, "logs", "", "http", "", "", "")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), calls.Load(), "expired entries are refetched")
+
+	fail.Store(true)
+	_, err = c.GetFieldValues(ctx, "logs", "host.name", "", "", "", "")
+	require.Error(t, err)
+	_, err = c.GetFieldValues(ctx, "logs", "host.name", "", "", "", "")
+	require.Error(t, err)
+	assert.Equal(t, int32(6), calls.Load(), "errors are not cached")
+}
+
+func TestFieldCache_DisabledByDefault(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"status":"success","data":{}}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+	for range 2 {
+		_, err := c.GetFieldKeys(context.Background(), "logs", "", "", "", "", "")
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+// hangingServer blocks every request until the client goes away and counts
+// the requests it saw aborted.
+func hangingServer(t *testing.T) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var aborted atomic.Int32
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The server only notices a disconnect once the body is consumed.
+		_, _ = io.Copy(io.Discard, r.Body)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			aborted.Add(1)
+		}
+	}))
+	t.Cleanup(func() {
+		close(release)
+		srv.Close()
+	})
+	return srv, &aborted
+}
+
+func TestClientMethods_ParentCancelAbortsInFlightRequest(t *testing.T) {
+	calls := map[string]func(ctx context.Context, c *SigNoz) error{
+		"GetVersion": func(ctx context.Context, c *SigNoz) error { _, err := c.GetVersion(ctx); return err },
+		"ListMetrics": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.ListMetrics(ctx, 0, 1, 10, "", "")
+			return err
+		},
+		"GetTopMetrics": func(ctx context.Context, c *SigNoz) error { _, err := c.GetTopMetrics(ctx, 0, 1, 10); return err },
+		"ListAlerts": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.ListAlerts(ctx, types.ListAlertsParams{})
+			return err
+		},
+		"ListAlertRules":   func(ctx context.Context, c *SigNoz) error { _, err := c.ListAlertRules(ctx); return err },
+		"GetAlertByRuleID": func(ctx context.Context, c *SigNoz) error { _, err := c.GetAlertByRuleID(ctx, "r1"); return err },
+		"GetAlertHistory": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.GetAlertHistory(ctx, "r1", types.AlertHistoryRequest{})
+			return err
+		},
+		"ListDashboards": func(ctx context.Context, c *SigNoz) error { _, err := c.ListDashboards(ctx); return err },
+		"GetDashboard":   func(ctx context.Context, c *SigNoz) error { _, err := c.GetDashboard(ctx, "d1"); return err },
+		"CreateDashboardRaw": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.CreateDashboardRaw(ctx, []byte(`{}`))
+			return err
+		},
+		"DeleteDashboard": func(ctx context.Context, c *SigNoz) error { return c.DeleteDashboard(ctx, "d1") },
+		"ListServices":    func(ctx context.Context, c *SigNoz) error { _, err := c.ListServices(ctx, "0", "1"); return err },
+		"QueryBuilderV5": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.QueryBuilderV5(ctx, []byte(`{}`))
+			return err
+		},
+		"ListViews": func(ctx context.Context, c *SigNoz) error { _, err := c.ListViews(ctx, "logs", "", ""); return err },
+		"GetFieldKeys": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.GetFieldKeys(ctx, "logs", "", "", "", "", "")
+			return err
+		},
+		"GetFieldValues": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.GetFieldValues(ctx, "logs", "service.name", "", "", "", "")
+			return err
+		},
+		"GetTraceDetails": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.GetTraceDetails(ctx, "abc", true, 1, 2, 0)
+			return err
+		},
+		"CheckMetricUsage": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.CheckMetricUsage(ctx, []string{"m"})
+			return err
+		},
+		"ListNotificationChannels": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.ListNotificationChannels(ctx)
+			return err
+		},
+		"GetMetricCardinality": func(ctx context.Context, c *SigNoz) error {
+			_, err := c.GetMetricCardinality(ctx, "m", 0, 1)
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			srv, aborted := hangingServer(t)
+			c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			time.AfterFunc(50*time.Millisecond, cancel)
+
+			start := time.Now()
+			err := call(ctx, c)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, context.Canceled)
+			assert.Less(t, time.Since(start), 2*time.Second, "request outlived its canceled parent")
+			assert.Eventually(t, func() bool { return aborted.Load() > 0 }, 2*time.Second, 10*time.Millisecond,
+				"server never saw the request aborted")
+		})
+	}
+}
+
+func TestDoRequest_ParentCancelDuringRetryBackoff(t *testing.T) {
+	var attempts atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	c := NewClient(logpkg.New("error"), srv.URL, "test-key", "SIGNOZ-API-KEY", nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	time.AfterFunc(20*time.Millisecond, cancel)
+
+	_, err := c.ListDashboards(ctx)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, int32(1), attempts.Load(), "no retry should follow a canceled parent")
+}
+
+func TestDoRequest_HTMLResponseReturnsNonJSONError(t *testing.T) {
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+	}{
+		{name: "html content type", contentType: "text/html; charset=utf-8", body: `<!doctype html><html><head><title>SigNoz</title></head></html>`},
+		{name: "markup body without content type", contentType: "", body: "\n  <html><body>Login</body></html>"},
+		{name: "html content type on json-looking body", contentType: "text/html", body: `window.location="/login"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header()[ContentType] = []string{tt.contentType}
+				w.WriteHeader(http.StatusOK)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(logpkg.New("error"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+
+			_, err := client.doRequest(context.Background(), http.MethodGet, server.URL, nil, time.Second)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, ErrNonJSONResponse)
+			assert.Contains(t, err.Error(), "check SIGNOZ_URL points to the SigNoz API")
+			var statusErr *HTTPStatusError
+			assert.False(t, errors.As(err, &statusErr))
+		})
+	}
+}
+
+func TestDoRequest_EmptyHTMLBodyPasses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(ContentType, "text/html")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(logpkg.New("error"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+
+	body, err := client.doRequest(context.Background(), http.MethodDelete, server.URL, nil, time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, body)
+}
@@ -11,6 +11,8 @@ import (
 // Handler code depends on this interface, enabling mock-based unit testing.
 type Client interface {
 	GetAnalyticsIdentity(ctx context.Context) (*AnalyticsIdentity, error)
+	Ping(ctx context.Context) (*PingResult, error)
+	GetVersion(ctx context.Context) (*VersionInfo, error)
 	ListMetrics(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error)
 	GetTopMetrics(ctx context.Context, start, end int64, limit int) (json.RawMessage, error)
 	ListAlerts(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error)
@@ -24,6 +26,7 @@ type Client interface {
 	CreateDashboardRaw(ctx context.Context, dashboardJSON []byte) (json.RawMessage, error)
 	UpdateDashboardRaw(ctx context.Context, id string, dashboardJSON []byte) error
 	DeleteDashboard(ctx context.Context, id string) error
+	QueryDashboardVariable(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error)
 	ListServices(ctx context.Context, start, end string) (json.RawMessage, error)
 	GetServiceTopOperations(ctx context.Context, start, end, service string, tags json.RawMessage) (json.RawMessage, error)
 	QueryBuilderV5(ctx context.Context, body []byte) (json.RawMessage, error)
@@ -34,7 +37,7 @@ type Client interface {
 	DeleteView(ctx context.Context, viewID string) (json.RawMessage, error)
 	GetFieldKeys(ctx context.Context, signal, metricName, searchText, fieldContext, fieldDataType, source string) (json.RawMessage, error)
 	GetFieldValues(ctx context.Context, signal, name, metricName, searchText, fieldContext, source string) (json.RawMessage, error)
-	GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error)
+	GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error)
 	CreateAlertRule(ctx context.Context, alertJSON []byte) (json.RawMessage, error)
 	UpdateAlertRule(ctx context.Context, ruleID string, alertJSON []byte) error
 	DeleteAlertRule(ctx context.Context, ruleID string) error
@@ -46,4 +49,5 @@ type Client interface {
 	DeleteNotificationChannel(ctx context.Context, id string) error
 	TestNotificationChannel(ctx context.Context, receiverJSON []byte) error
 	GetMetricCardinality(ctx context.Context, name string, start, end int64) (json.RawMessage, error)
+	LastCall(requestID string) (CallRecord, bool)
 }
@@ -0,0 +1,60 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrServerBusy means every upstream request slot stayed taken for the whole
+// queue wait, so the request was never sent.
+var ErrServerBusy = errors.New("server busy: too many concurrent SigNoz requests; retry shortly")
+
+// UpstreamLimiter caps how many SigNoz requests are in flight at once across
+// every client it is attached to. Requests past the cap queue for up to
+// maxWait and then fail with ErrServerBusy. A nil limiter admits everything.
+type UpstreamLimiter struct {
+	slots   chan struct{}
+	maxWait time.Duration
+}
+
+// NewUpstreamLimiter returns a limiter admitting limit concurrent requests,
+// or nil (no limit) when limit is not positive. A zero maxWait rejects excess
+// requests instead of queueing them.
+func NewUpstreamLimiter(limit int, maxWait time.Duration) *UpstreamLimiter {
+	if limit <= 0 {
+		return nil
+	}
+	return &UpstreamLimiter{slots: make(chan struct{}, limit), maxWait: maxWait}
+}
+
+// acquire takes a slot, waiting up to maxWait or until ctx is done. The
+// returned release must be called once the request finishes.
+func (l *UpstreamLimiter) acquire(ctx context.Context) (release func(), err error) {
+	if l == nil {
+		return func() {}, nil
+	}
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	default:
+	}
+	if l.maxWait <= 0 {
+		return nil, ErrServerBusy
+	}
+
+	timer := time.NewTimer(l.maxWait)
+	defer timer.Stop()
+	select {
+	case l.slots <- struct{}{}:
+		return l.release, nil
+	case <-timer.C:
+		return nil, ErrServerBusy
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (l *UpstreamLimiter) release() {
+	<-l.slots
+}
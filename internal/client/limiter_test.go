@@ -0,0 +1,131 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+func TestUpstreamLimiter_QueuesUntilSlotFrees(t *testing.T) {
+	limiter := NewUpstreamLimiter(1, time.Second)
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+
+	acquired := make(chan error, 1)
+	go func() {
+		second, err := limiter.acquire(context.Background())
+		if err == nil {
+			second()
+		}
+		acquired <- err
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second acquire must queue while the only slot is taken")
+	case <-time.After(20 * time.Millisecond):
+	}
+	release()
+	require.NoError(t, <-acquired)
+}
+
+func TestUpstreamLimiter_RejectsWhenSaturated(t *testing.T) {
+	queued := NewUpstreamLimiter(1, 20*time.Millisecond)
+	_, err := queued.acquire(context.Background())
+	require.NoError(t, err)
+	_, err = queued.acquire(context.Background())
+	assert.ErrorIs(t, err, ErrServerBusy, "queue wait elapsed")
+
+	immediate := NewUpstreamLimiter(1, 0)
+	_, err = immediate.acquire(context.Background())
+	require.NoError(t, err)
+	_, err = immediate.acquire(context.Background())
+	assert.ErrorIs(t, err, ErrServerBusy, "zero wait rejects at once")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = queued.acquire(ctx)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestUpstreamLimiter_NilIsUnlimited(t *testing.T) {
+	assert.Nil(t, NewUpstreamLimiter(0, time.Second))
+	var limiter *UpstreamLimiter
+	release, err := limiter.acquire(context.Background())
+	require.NoError(t, err)
+	assert.NotPanics(t, release)
+}
+
+func TestDoRequest_ConcurrencyLimitRejectsExcessRequest(t *testing.T) {
+	entered := make(chan struct{}, 1)
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case entered <- struct{}{}:
+		default:
+		}
+		<-unblock
+		_, _ = w.Write([]byte(`{"status":"success","data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(logpkg.New("error"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+	client.SetUpstreamLimiter(NewUpstreamLimiter(1, 0))
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.ListAlertRules(context.Background())
+		first <- err
+	}()
+	<-entered
+
+	_, err := client.ListAlertRules(context.Background())
+	assert.ErrorIs(t, err, ErrServerBusy)
+
+	close(unblock)
+	require.NoError(t, <-first)
+
+	_, err = client.ListAlertRules(context.Background())
+	assert.NoError(t, err, "the slot is free again once the first request finishes")
+}
+
+func TestDoRequest_ReleasesSlotDuringRetryBackoff(t *testing.T) {
+	var calls atomic.Int32
+	backingOff := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v2/rules" && calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			close(backingOff)
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"success","data":[]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(logpkg.New("error"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+	client.SetUpstreamLimiter(NewUpstreamLimiter(1, 0))
+
+	first := make(chan error, 1)
+	go func() {
+		_, err := client.ListAlertRules(context.Background())
+		first <- err
+	}()
+	<-backingOff
+	// Let the first request finish its attempt and start sleeping.
+	time.Sleep(50 * time.Millisecond)
+
+	_, err := client.GetAlertByRuleID(context.Background(), "17")
+	assert.NoError(t, err, "a request waiting out Retry-After must not hold the only slot")
+
+	require.NoError(t, <-first)
+	assert.EqualValues(t, 2, calls.Load())
+}
@@ -28,7 +28,7 @@ func (s *SigNoz) GetMetricCardinality(ctx context.Context, name string, start, e
 	reqURL := fmt.Sprintf("%s/api/v2/metrics/attributes?%s", s.baseURL, params.Encode())
 	s.logger.DebugContext(s.ensureTenantContext(ctx), "Fetching metric cardinality", slog.String("metric", name))
 
-	body, err := s.doRequest(ctx, http.MethodGet, reqURL, nil, DefaultQueryTimeout)
+	body, err := s.doRequest(ctx, http.MethodGet, reqURL, nil, s.queryTimeout())
 	if err != nil {
 		return nil, fmt.Errorf("cardinality lookup for %q: %w", name, err)
 	}
@@ -112,6 +112,11 @@ func (s *SigNoz) CheckMetricUsage(ctx context.Context, names []string) (map[stri
 	if err := g.Wait(); err != nil {
 		return nil, err
 	}
+	// A deadline keeps the partial results, but a caller that gave up gets
+	// an error rather than a map of per-metric "context canceled" entries.
+	if err := ctx.Err(); errors.Is(err, context.Canceled) {
+		return nil, err
+	}
 
 	out := make(map[string]MetricUsage, len(names))
 	for _, r := range results {
@@ -134,7 +139,7 @@ func (s *SigNoz) fetchMetricUsage(ctx context.Context, name string) (MetricUsage
 	dashURL := fmt.Sprintf("%s/api/v2/metrics/dashboards?%s", s.baseURL, query)
 	s.logger.DebugContext(ctx, "Fetching metric dashboard refs", slog.String("metric", name))
 
-	dashBody, err := s.doRequest(ctx, http.MethodGet, dashURL, nil, DefaultQueryTimeout)
+	dashBody, err := s.doRequest(ctx, http.MethodGet, dashURL, nil, s.queryTimeout())
 	if err != nil {
 		if isMetricUsageAuthzError(err) {
 			return usage, err
@@ -164,7 +169,7 @@ func (s *SigNoz) fetchMetricUsage(ctx context.Context, name string) (MetricUsage
 	alertURL := fmt.Sprintf("%s/api/v2/metrics/alerts?%s", s.baseURL, query)
 	s.logger.DebugContext(ctx, "Fetching metric alert refs", slog.String("metric", name))
 
-	alertBody, err := s.doRequest(ctx, http.MethodGet, alertURL, nil, DefaultQueryTimeout)
+	alertBody, err := s.doRequest(ctx, http.MethodGet, alertURL, nil, s.queryTimeout())
 	if err != nil {
 		if isMetricUsageAuthzError(err) {
 			return usage, err
@@ -12,6 +12,8 @@ import (
 // otherwise returns a default empty JSON object and nil error.
 type MockClient struct {
 	GetAnalyticsIdentityFn      func(ctx context.Context) (*AnalyticsIdentity, error)
+	PingFn                      func(ctx context.Context) (*PingResult, error)
+	GetVersionFn                func(ctx context.Context) (*VersionInfo, error)
 	ListMetricsFn               func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error)
 	GetTopMetricsFn             func(ctx context.Context, start, end int64, limit int) (json.RawMessage, error)
 	ListAlertsFn                func(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error)
@@ -25,6 +27,7 @@ type MockClient struct {
 	CreateDashboardRawFn        func(ctx context.Context, dashboardJSON []byte) (json.RawMessage, error)
 	UpdateDashboardRawFn        func(ctx context.Context, id string, dashboardJSON []byte) error
 	DeleteDashboardFn           func(ctx context.Context, id string) error
+	QueryDashboardVariableFn    func(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error)
 	ListServicesFn              func(ctx context.Context, start, end string) (json.RawMessage, error)
 	GetServiceTopOperationsFn   func(ctx context.Context, start, end, service string, tags json.RawMessage) (json.RawMessage, error)
 	QueryBuilderV5Fn            func(ctx context.Context, body []byte) (json.RawMessage, error)
@@ -35,7 +38,7 @@ type MockClient struct {
 	DeleteViewFn                func(ctx context.Context, viewID string) (json.RawMessage, error)
 	GetFieldKeysFn              func(ctx context.Context, signal, metricName, searchText, fieldContext, fieldDataType, source string) (json.RawMessage, error)
 	GetFieldValuesFn            func(ctx context.Context, signal, name, metricName, searchText, fieldContext, source string) (json.RawMessage, error)
-	GetTraceDetailsFn           func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error)
+	GetTraceDetailsFn           func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error)
 	CreateAlertRuleFn           func(ctx context.Context, alertJSON []byte) (json.RawMessage, error)
 	UpdateAlertRuleFn           func(ctx context.Context, ruleID string, alertJSON []byte) error
 	DeleteAlertRuleFn           func(ctx context.Context, ruleID string) error
@@ -47,6 +50,7 @@ type MockClient struct {
 	DeleteNotificationChannelFn func(ctx context.Context, id string) error
 	TestNotificationChannelFn   func(ctx context.Context, receiverJSON []byte) error
 	GetMetricCardinalityFn      func(ctx context.Context, name string, start, end int64) (json.RawMessage, error)
+	LastCallFn                  func(requestID string) (CallRecord, bool)
 }
 
 // Compile-time check that MockClient satisfies Client.
@@ -59,6 +63,20 @@ func (m *MockClient) GetAnalyticsIdentity(ctx context.Context) (*AnalyticsIdenti
 	return &AnalyticsIdentity{}, nil
 }
 
+func (m *MockClient) Ping(ctx context.Context) (*PingResult, error) {
+	if m.PingFn != nil {
+		return m.PingFn(ctx)
+	}
+	return &PingResult{Reachable: true, Authenticated: true}, nil
+}
+
+func (m *MockClient) GetVersion(ctx context.Context) (*VersionInfo, error) {
+	if m.GetVersionFn != nil {
+		return m.GetVersionFn(ctx)
+	}
+	return &VersionInfo{}, nil
+}
+
 func (m *MockClient) ListMetrics(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
 	if m.ListMetricsFn != nil {
 		return m.ListMetricsFn(ctx, start, end, limit, searchText, source)
@@ -150,6 +168,13 @@ func (m *MockClient) DeleteDashboard(ctx context.Context, id string) error {
 	return nil
 }
 
+func (m *MockClient) QueryDashboardVariable(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
+	if m.QueryDashboardVariableFn != nil {
+		return m.QueryDashboardVariableFn(ctx, query, variables)
+	}
+	return json.RawMessage(`{}`), nil
+}
+
 func (m *MockClient) ListServices(ctx context.Context, start, end string) (json.RawMessage, error) {
 	if m.ListServicesFn != nil {
 		return m.ListServicesFn(ctx, start, end)
@@ -220,9 +245,9 @@ func (m *MockClient) GetFieldValues(ctx context.Context, signal, name, metricNam
 	return json.RawMessage(`{}`), nil
 }
 
-func (m *MockClient) GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+func (m *MockClient) GetTraceDetails(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 	if m.GetTraceDetailsFn != nil {
-		return m.GetTraceDetailsFn(ctx, traceID, includeSpans, startTime, endTime)
+		return m.GetTraceDetailsFn(ctx, traceID, includeSpans, startTime, endTime, limit)
 	}
 	return json.RawMessage(`{}`), nil
 }
@@ -303,3 +328,10 @@ func (m *MockClient) GetMetricCardinality(ctx context.Context, name string, star
 	}
 	return json.RawMessage(`{}`), nil
 }
+
+func (m *MockClient) LastCall(requestID string) (CallRecord, bool) {
+	if m.LastCallFn != nil {
+		return m.LastCallFn(requestID)
+	}
+	return CallRecord{}, false
+}
@@ -0,0 +1,146 @@
+package client
+
+import (
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// RequestStats aggregates per-endpoint request counts, latency, and status
+// codes across every client it is attached to. It is safe for concurrent use.
+type RequestStats struct {
+	mu        sync.Mutex
+	since     time.Time
+	endpoints map[string]*endpointStats
+}
+
+type endpointStats struct {
+	count    int64
+	errors   int64
+	total    time.Duration
+	max      time.Duration
+	statuses map[string]int64
+}
+
+// EndpointStats is a point-in-time view of one endpoint's requests.
+// StatusCodes keys are HTTP status codes, or "error" for requests that
+// failed before a response was received.
+type EndpointStats struct {
+	Endpoint     string           `json:"endpoint"`
+	Count        int64            `json:"count"`
+	Errors       int64            `json:"errors"`
+	AvgLatencyMs float64          `json:"avgLatencyMs"`
+	MaxLatencyMs float64          `json:"maxLatencyMs"`
+	StatusCodes  map[string]int64 `json:"statusCodes"`
+}
+
+// RequestStatsSnapshot is the full view returned by RequestStats.Snapshot.
+type RequestStatsSnapshot struct {
+	Since     time.Time       `json:"since"`
+	Endpoints []EndpointStats `json:"endpoints"`
+}
+
+func NewRequestStats() *RequestStats {
+	return &RequestStats{
+		since:     time.Now(),
+		endpoints: make(map[string]*endpointStats),
+	}
+}
+
+// Record adds one logical request (including any retries) to the stats.
+// status is the final HTTP status, or 0 when no response was received.
+func (r *RequestStats) Record(method, reqURL string, status int, elapsed time.Duration) {
+	if r == nil {
+		return
+	}
+	key := endpointKey(method, reqURL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	e, ok := r.endpoints[key]
+	if !ok {
+		e = &endpointStats{statuses: make(map[string]int64)}
+		r.endpoints[key] = e
+	}
+	e.count++
+	e.total += elapsed
+	if elapsed > e.max {
+		e.max = elapsed
+	}
+	statusKey := "error"
+	if status > 0 {
+		statusKey = strconv.Itoa(status)
+	}
+	e.statuses[statusKey]++
+	if status == 0 || status >= 400 {
+		e.errors++
+	}
+}
+
+// Snapshot returns a copy of the current stats sorted by endpoint.
+func (r *RequestStats) Snapshot() RequestStatsSnapshot {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	out := RequestStatsSnapshot{Since: r.since, Endpoints: make([]EndpointStats, 0, len(r.endpoints))}
+	for key, e := range r.endpoints {
+		statuses := make(map[string]int64, len(e.statuses))
+		for k, v := range e.statuses {
+			statuses[k] = v
+		}
+		out.Endpoints = append(out.Endpoints, EndpointStats{
+			Endpoint:     key,
+			Count:        e.count,
+			Errors:       e.errors,
+			AvgLatencyMs: durationMs(e.total) / float64(e.count),
+			MaxLatencyMs: durationMs(e.max),
+			StatusCodes:  statuses,
+		})
+	}
+	sort.Slice(out.Endpoints, func(i, j int) bool {
+		return out.Endpoints[i].Endpoint < out.Endpoints[j].Endpoint
+	})
+	return out
+}
+
+func durationMs(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// endpointKey returns "METHOD /path" with ID-like path segments replaced by
+// {id}, so per-resource URLs (/dashboards/{uuid}, /rules/{id}) share one
+// bucket and the key space stays bounded. Query strings are dropped.
+func endpointKey(method, reqURL string) string {
+	path := reqURL
+	if u, err := url.Parse(reqURL); err == nil {
+		path = u.Path
+	}
+	segments := strings.Split(path, "/")
+	for i, seg := range segments {
+		if isIDSegment(seg) {
+			segments[i] = "{id}"
+		}
+	}
+	return method + " " + strings.Join(segments, "/")
+}
+
+// isIDSegment reports whether a path segment looks like a resource ID:
+// all digits, or at least 8 characters containing a digit (UUIDs, hex IDs).
+// Version segments such as "v1" or "v5" are too short to match.
+func isIDSegment(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	allDigits, hasDigit := true, false
+	for _, c := range seg {
+		if unicode.IsDigit(c) {
+			hasDigit = true
+		} else {
+			allDigits = false
+		}
+	}
+	return allDigits || (hasDigit && len(seg) >= 8)
+}
@@ -0,0 +1,82 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+func TestEndpointKey_TemplatesIDSegments(t *testing.T) {
+	tests := []struct {
+		method string
+		url    string
+		want   string
+	}{
+		{http.MethodPost, "https://signoz.example.com/api/v5/query_range", "POST /api/v5/query_range"},
+		{http.MethodGet, "https://signoz.example.com/api/v1/dashboards/0196a1b2-c3d4-7e5f-8a9b-0c1d2e3f4a5b", "GET /api/v1/dashboards/{id}"},
+		{http.MethodGet, "https://signoz.example.com/api/v2/rules/42", "GET /api/v2/rules/{id}"},
+		{http.MethodPost, "https://signoz.example.com/api/v2/rules/42/history/timeline", "POST /api/v2/rules/{id}/history/timeline"},
+		{http.MethodGet, "https://signoz.example.com/api/v1/alerts?active=true&silenced=false", "GET /api/v1/alerts"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, endpointKey(tt.method, tt.url), tt.url)
+	}
+}
+
+func TestRequestStats_RecordAndSnapshot(t *testing.T) {
+	stats := NewRequestStats()
+	stats.Record(http.MethodGet, "http://x/api/v2/rules/1", 200, 10*time.Millisecond)
+	stats.Record(http.MethodGet, "http://x/api/v2/rules/2", 404, 30*time.Millisecond)
+	stats.Record(http.MethodPost, "http://x/api/v5/query_range", 0, 5*time.Millisecond)
+
+	snap := stats.Snapshot()
+	require.Len(t, snap.Endpoints, 2)
+
+	rules := snap.Endpoints[0]
+	assert.Equal(t, "GET /api/v2/rules/{id}", rules.Endpoint)
+	assert.EqualValues(t, 2, rules.Count)
+	assert.EqualValues(t, 1, rules.Errors)
+	assert.InDelta(t, 20.0, rules.AvgLatencyMs, 0.001)
+	assert.InDelta(t, 30.0, rules.MaxLatencyMs, 0.001)
+	assert.Equal(t, map[string]int64{"200": 1, "404": 1}, rules.StatusCodes)
+
+	query := snap.Endpoints[1]
+	assert.Equal(t, "POST /api/v5/query_range", query.Endpoint)
+	assert.EqualValues(t, 1, query.Errors)
+	assert.Equal(t, map[string]int64{"error": 1}, query.StatusCodes)
+}
+
+func TestRequestStats_NilIsNoop(t *testing.T) {
+	var stats *RequestStats
+	assert.NotPanics(t, func() {
+		stats.Record(http.MethodGet, "http://x/api/v1/alerts", 200, time.Millisecond)
+	})
+}
+
+func TestDoRequest_RecordsRequestStats(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"status":"error","error":"rule not found"}`))
+	}))
+	defer server.Close()
+
+	stats := NewRequestStats()
+	client := NewClient(logpkg.New("error"), server.URL, "test-api-key", "SIGNOZ-API-KEY", nil)
+	client.SetRequestStats(stats)
+
+	_, err := client.GetAlertByRuleID(context.Background(), "17")
+	require.Error(t, err)
+
+	snap := stats.Snapshot()
+	require.Len(t, snap.Endpoints, 1)
+	assert.Equal(t, "GET /api/v2/rules/{id}", snap.Endpoints[0].Endpoint)
+	assert.EqualValues(t, 1, snap.Endpoints[0].Count)
+	assert.Equal(t, map[string]int64{"404": 1}, snap.Endpoints[0].StatusCodes)
+}
@@ -1,24 +1,47 @@
 package config
 
 import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
 	"log"
+	"net/url"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"golang.org/x/net/http/httpguts"
+	"gopkg.in/yaml.v3"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
 	"github.com/SigNoz/signoz-mcp-server/pkg/util"
 )
 
 type Config struct {
-	URL           string
-	APIKey        string
+	URL    string
+	APIKey string
+	// AuthMode selects how APIKey is sent upstream: AuthModeAPIKey (the
+	// SIGNOZ-API-KEY header) or AuthModeBearer (Authorization: Bearer).
+	AuthMode      string
 	LogLevel      string
+	LogFormat     string // logpkg.FormatJSON (default) or logpkg.FormatConsole
 	TransportMode string
 	Host          string
 	Port          string
 
+	// RequireCredentials makes HTTP mode insist on URL and APIKey like stdio
+	// mode does, for single-tenant deployments that never take credentials
+	// from request headers or OAuth.
+	RequireCredentials bool
+
+	// MockMode serves canned fixture responses instead of calling SigNoz,
+	// for demos and local development without an instance.
+	MockMode bool
+
 	OAuthEnabled     bool
 	OAuthTokenSecret string
 	OAuthIssuerURL   string
@@ -45,16 +68,99 @@ type Config struct {
 
 	// MaxRequestBytes caps the size of an inbound MCP HTTP request body.
 	MaxRequestBytes int
+
+	// MaxListLimit caps the per-page limit of the summary list tools.
+	MaxListLimit int
+
+	// MaxAlertHistoryLimit caps the per-page limit of signoz_get_alert_history.
+	MaxAlertHistoryLimit int
+
+	// MaxResponseBytes caps the size of a raw query tool response; larger
+	// responses are truncated to fit.
+	MaxResponseBytes int
+
+	// RequestTimeout is the deadline for read-only SigNoz API calls that
+	// don't carry a per-call timeoutSeconds override.
+	RequestTimeout time.Duration
+
+	// FieldCacheTTL is how long field keys/values responses are reused
+	// per tenant; zero disables the cache.
+	FieldCacheTTL time.Duration
+
+	// MaxQueryTimeout bounds the per-call timeoutSeconds override that
+	// heavy query tools accept.
+	MaxQueryTimeout time.Duration
+
+	// MaxConcurrentRequests caps in-flight SigNoz requests across all
+	// tenants; zero leaves them unlimited. Requests past the cap queue for
+	// up to RequestQueueTimeout, and zero there rejects them at once.
+	MaxConcurrentRequests int
+	RequestQueueTimeout   time.Duration
+
+	// ShutdownTimeout bounds how long shutdown waits for in-flight tool calls
+	// and telemetry flushes after SIGINT/SIGTERM.
+	ShutdownTimeout time.Duration
+
+	// PrettyJSON re-indents JSON tool output for clients that render raw text.
+	PrettyJSON bool
+
+	// RequestStats enables in-process per-endpoint stats for outbound SigNoz
+	// requests, reported by the signoz_server_stats tool.
+	RequestStats bool
+
+	// DebugRequests records each tenant's latest tool call upstream
+	// requests for signoz_debug_last_request. It defaults to on when
+	// LOG_LEVEL is debug.
+	DebugRequests bool
+
+	// EnabledToolGroups limits registration to these tool groups; empty
+	// registers every group. DisabledTools drops individual tools by name
+	// on top of that. Names are checked by tools.ValidateToolSelection.
+	EnabledToolGroups []string
+	DisabledTools     []string
+
+	// StartupHealthCheck pings the configured SigNoz instance before serving
+	// and exits if it is unreachable or rejects the API key.
+	StartupHealthCheck bool
+
+	// TLSCAFile is a PEM bundle trusted in addition to the system roots
+	// when connecting to SIGNOZ_URL.
+	TLSCAFile string
+	// TLSInsecureSkipVerify disables certificate verification for
+	// SIGNOZ_URL. Only meant for development setups.
+	TLSInsecureSkipVerify bool
+
+	// ProxyURL routes SigNoz API calls through an http, https, or socks5
+	// proxy, overriding HTTP_PROXY/HTTPS_PROXY/NO_PROXY.
+	ProxyURL string
+
+	// proxy is ProxyURL parsed by ValidateConfig; nil means the environment
+	// proxy settings apply.
+	proxy *url.URL
+
+	// tlsConfig is built from TLSCAFile and TLSInsecureSkipVerify by
+	// ValidateConfig; nil means the default TLS settings.
+	tlsConfig *tls.Config
 }
 
 const (
 	SignozURL     = "SIGNOZ_URL"
 	SignozApiKey  = "SIGNOZ_API_KEY"
+	AuthModeEnv   = "AUTH_MODE"
 	LogLevel      = "LOG_LEVEL"
+	LogFormatEnv  = "LOG_FORMAT"
 	TransportMode = "TRANSPORT_MODE"
 	MCPHost       = "MCP_SERVER_HOST"
 	MCPPort       = "MCP_SERVER_PORT"
 
+	RequireCredentialsEnv = "SIGNOZ_REQUIRE_CREDENTIALS"
+	MockModeEnv           = "MOCK_MODE"
+
+	// MockURL and MockAPIKey stand in for unset credentials in mock mode so
+	// both transports can build a request context without a real instance.
+	MockURL    = "http://mock.signoz.invalid"
+	MockAPIKey = "mock"
+
 	SignozCustomHeaders     = "SIGNOZ_CUSTOM_HEADERS"
 	InstanceURLAllowlistEnv = "SIGNOZ_INSTANCE_URL_ALLOWLIST"
 	ClientCacheSize         = "CLIENT_CACHE_SIZE"
@@ -73,7 +179,31 @@ const (
 	DocsRefreshIntervalEnv     = "SIGNOZ_DOCS_REFRESH_INTERVAL"
 	DocsFullRefreshIntervalEnv = "SIGNOZ_DOCS_FULL_REFRESH_INTERVAL"
 
-	MaxRequestBytesEnv = "MCP_MAX_REQUEST_BYTES"
+	MaxRequestBytesEnv      = "MCP_MAX_REQUEST_BYTES"
+	MaxResponseBytesEnv     = "MCP_MAX_RESPONSE_BYTES"
+	MaxListLimitEnv         = "MCP_MAX_LIST_LIMIT"
+	MaxAlertHistoryLimitEnv = "MCP_MAX_ALERT_HISTORY_LIMIT"
+	RequestTimeoutEnv       = "SIGNOZ_REQUEST_TIMEOUT"
+	FieldCacheTTLEnv        = "SIGNOZ_FIELD_CACHE_TTL"
+	MaxQueryTimeoutEnv      = "SIGNOZ_MAX_QUERY_TIMEOUT"
+	PrettyJSONEnv           = "SIGNOZ_PRETTY_JSON"
+	ShutdownTimeoutEnv      = "SHUTDOWN_TIMEOUT"
+	RequestStatsEnv         = "SIGNOZ_REQUEST_STATS"
+	DebugRequestsEnv        = "SIGNOZ_DEBUG_REQUESTS"
+	StartupHealthEnv        = "SIGNOZ_STARTUP_HEALTH_CHECK"
+
+	MaxConcurrentRequestsEnv = "SIGNOZ_MAX_CONCURRENT_REQUESTS"
+	RequestQueueTimeoutEnv   = "SIGNOZ_REQUEST_QUEUE_TIMEOUT"
+
+	EnabledToolGroupsEnv = "ENABLED_TOOL_GROUPS"
+	DisabledToolsEnv     = "DISABLED_TOOLS"
+
+	ProxyURLEnv              = "PROXY_URL"
+	TLSCAFileEnv             = "TLS_CA_FILE"
+	TLSInsecureSkipVerifyEnv = "TLS_INSECURE_SKIP_VERIFY"
+
+	AuthModeAPIKey = "apikey"
+	AuthModeBearer = "bearer"
 
 	defaultClientCacheSize       = 256
 	defaultClientCacheTTLMinutes = 30
@@ -85,19 +215,75 @@ const (
 	// defaultMaxRequestBytes bounds inbound MCP request bodies; 4 MiB is far
 	// above any legitimate tool-call payload (incl. dashboard imports).
 	defaultMaxRequestBytes = 4 << 20 // 4 MiB
+	// defaultMaxResponseBytes keeps a single query result well inside an LLM
+	// context window; the client separately rejects bodies above 64 MiB.
+	defaultMaxResponseBytes = 1 << 20 // 1 MiB
+	// defaultMaxAlertHistoryLimit keeps one page of rule history small
+	// enough to read; the cursor reaches the rest.
+	defaultMaxAlertHistoryLimit = 200
+	// defaultRequestTimeout matches the client's DefaultQueryTimeout; it is
+	// short enough that a hung backend surfaces as an error, not a stall.
+	defaultRequestTimeout = 60 * time.Second
+	// defaultFieldCacheTTL is short because field metadata changes slowly
+	// but new attributes should still show up within a minute.
+	defaultFieldCacheTTL = 60 * time.Second
+	// defaultMaxQueryTimeout leaves room for heavy queries to opt into a
+	// longer deadline via timeoutSeconds.
+	defaultMaxQueryTimeout = 600 * time.Second
+	// defaultShutdownTimeout fits inside Kubernetes' default 30 s
+	// termination grace period with room for telemetry flushes.
+	defaultShutdownTimeout = 15 * time.Second
+	// defaultRequestQueueTimeout lets a short burst drain through the
+	// concurrency cap without turning a sustained overload into long stalls.
+	defaultRequestQueueTimeout = 10 * time.Second
 )
 
+// LoadConfig reads the configuration from environment variables.
 func LoadConfig() (*Config, error) {
+	return (&settings{}).load()
+}
+
+// LoadConfigFile reads the configuration from the YAML or JSON file at path,
+// keyed by environment variable name, with any non-empty environment
+// variable taking precedence over the file. An empty path is LoadConfig.
+// A key that names no setting is an error, so a typo is not silently ignored.
+func LoadConfigFile(path string) (*Config, error) {
+	if path == "" {
+		return LoadConfig()
+	}
+	file, err := readConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	s := &settings{file: file, read: make(map[string]bool)}
+	cfg, err := s.load()
+	if err != nil {
+		return nil, err
+	}
+	var unknown []string
+	for key := range file {
+		if !s.read[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("config file %s: unknown setting(s) %s; keys are environment variable names such as %s", path, strings.Join(unknown, ", "), SignozURL)
+	}
+	return cfg, nil
+}
+
+func (s *settings) load() (*Config, error) {
 	// Trim trailing slash from URL to prevent double-slash issues in API paths
-	url := strings.TrimSuffix(getEnv(SignozURL, ""), "/")
-
-	cacheSize := getEnvInt(ClientCacheSize, defaultClientCacheSize)
-	cacheTTLMinutes := getEnvInt(ClientCacheTTL, defaultClientCacheTTLMinutes)
-	accessTTLMinutes := getEnvInt(OAuthAccessTTLMinutes, defaultAccessTTLMinutes)
-	refreshTTLMinutes := getEnvInt(OAuthRefreshTTLMinutes, defaultRefreshTTLMinutes)
-	authCodeTTLSeconds := getEnvInt(OAuthAuthCodeTTLSeconds, defaultAuthCodeTTLSeconds)
-	docsRefreshInterval := getEnvDuration(DocsRefreshIntervalEnv, defaultDocsRefreshInterval)
-	docsFullRefreshInterval := getEnvDuration(DocsFullRefreshIntervalEnv, defaultDocsFullRefreshPeriod)
+	signozURL := strings.TrimSuffix(s.getEnv(SignozURL, ""), "/")
+
+	cacheSize := s.getEnvInt(ClientCacheSize, defaultClientCacheSize)
+	cacheTTLMinutes := s.getEnvInt(ClientCacheTTL, defaultClientCacheTTLMinutes)
+	accessTTLMinutes := s.getEnvInt(OAuthAccessTTLMinutes, defaultAccessTTLMinutes)
+	refreshTTLMinutes := s.getEnvInt(OAuthRefreshTTLMinutes, defaultRefreshTTLMinutes)
+	authCodeTTLSeconds := s.getEnvInt(OAuthAuthCodeTTLSeconds, defaultAuthCodeTTLSeconds)
+	docsRefreshInterval := s.getEnvDuration(DocsRefreshIntervalEnv, defaultDocsRefreshInterval)
+	docsFullRefreshInterval := s.getEnvDuration(DocsFullRefreshIntervalEnv, defaultDocsFullRefreshPeriod)
 	if docsFullRefreshInterval < docsRefreshInterval {
 		log.Printf("WARN: %s (%s) is shorter than %s (%s); falling back to defaults",
 			DocsFullRefreshIntervalEnv, docsFullRefreshInterval, DocsRefreshIntervalEnv, docsRefreshInterval)
@@ -105,36 +291,27 @@ func LoadConfig() (*Config, error) {
 		docsFullRefreshInterval = defaultDocsFullRefreshPeriod
 	}
 
-	// Parse custom headers from SIGNOZ_CUSTOM_HEADERS env var (format: "Key1:Value1,Key2:Value2")
-	customHeaders := make(map[string]string)
-	if headersStr := getEnv(SignozCustomHeaders, ""); headersStr != "" {
-		for _, pair := range strings.Split(headersStr, ",") {
-			parts := strings.SplitN(pair, ":", 2)
-			if len(parts) != 2 {
-				log.Printf("WARN: skipping malformed custom header entry (missing ':'): %q", strings.TrimSpace(pair))
-			} else if strings.TrimSpace(parts[0]) == "" {
-				log.Printf("WARN: skipping custom header entry with empty name: %q", strings.TrimSpace(pair))
-			} else {
-				customHeaders[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
-			}
-		}
-	}
+	customHeaders := parseCustomHeaders(s.getEnv(SignozCustomHeaders, ""))
 
-	instanceURLAllowlist := util.ParseInstanceURLAllowlist(getEnv(InstanceURLAllowlistEnv, ""))
+	instanceURLAllowlist := util.ParseInstanceURLAllowlist(s.getEnv(InstanceURLAllowlistEnv, ""))
 	if instanceURLAllowlist.Configured() {
 		log.Printf("INFO: SigNoz URL allowlist enabled via %s; only matching SigNoz hosts will be served", InstanceURLAllowlistEnv)
 	}
 
 	return &Config{
-		URL:                     url,
-		APIKey:                  getEnv(SignozApiKey, ""),
-		LogLevel:                getEnv(LogLevel, "info"),
-		TransportMode:           getEnv(TransportMode, "stdio"),
-		Host:                    getEnv(MCPHost, ""),
-		Port:                    getEnv(MCPPort, "8000"),
-		OAuthEnabled:            getEnvBool(OAuthEnabledEnv, false),
-		OAuthTokenSecret:        getEnv(OAuthTokenSecretEnv, ""),
-		OAuthIssuerURL:          strings.TrimSuffix(getEnv(OAuthIssuerURLEnv, ""), "/"),
+		URL:                     signozURL,
+		APIKey:                  s.getEnv(SignozApiKey, ""),
+		AuthMode:                strings.ToLower(strings.TrimSpace(s.getEnv(AuthModeEnv, AuthModeAPIKey))),
+		LogLevel:                s.getEnv(LogLevel, "info"),
+		LogFormat:               strings.ToLower(strings.TrimSpace(s.getEnv(LogFormatEnv, logpkg.FormatJSON))),
+		TransportMode:           s.getEnv(TransportMode, "stdio"),
+		Host:                    s.getEnv(MCPHost, ""),
+		Port:                    s.getEnv(MCPPort, "8000"),
+		RequireCredentials:      s.getEnvBool(RequireCredentialsEnv, false),
+		MockMode:                s.getEnvBool(MockModeEnv, false),
+		OAuthEnabled:            s.getEnvBool(OAuthEnabledEnv, false),
+		OAuthTokenSecret:        s.getEnv(OAuthTokenSecretEnv, ""),
+		OAuthIssuerURL:          strings.TrimSuffix(s.getEnv(OAuthIssuerURLEnv, ""), "/"),
 		AccessTokenTTL:          time.Duration(accessTTLMinutes) * time.Minute,
 		RefreshTokenTTL:         time.Duration(refreshTTLMinutes) * time.Minute,
 		AuthCodeTTL:             time.Duration(authCodeTTLSeconds) * time.Second,
@@ -142,23 +319,144 @@ func LoadConfig() (*Config, error) {
 		ClientCacheTTL:          time.Duration(cacheTTLMinutes) * time.Minute,
 		CustomHeaders:           customHeaders,
 		InstanceURLAllowlist:    instanceURLAllowlist,
-		AnalyticsEnabled:        getEnvBool(AnalyticsEnabledEnv, false),
-		SegmentKey:              getEnv(SegmentKeyEnv, ""),
+		AnalyticsEnabled:        s.getEnvBool(AnalyticsEnabledEnv, false),
+		SegmentKey:              s.getEnv(SegmentKeyEnv, ""),
 		DocsRefreshInterval:     docsRefreshInterval,
 		DocsFullRefreshInterval: docsFullRefreshInterval,
-		MaxRequestBytes:         getEnvInt(MaxRequestBytesEnv, defaultMaxRequestBytes),
+		MaxRequestBytes:         s.getEnvInt(MaxRequestBytesEnv, defaultMaxRequestBytes),
+		MaxResponseBytes:        s.getEnvInt(MaxResponseBytesEnv, defaultMaxResponseBytes),
+		MaxListLimit:            s.getEnvInt(MaxListLimitEnv, paginate.MaxLimit),
+		MaxAlertHistoryLimit:    s.getEnvInt(MaxAlertHistoryLimitEnv, defaultMaxAlertHistoryLimit),
+		RequestTimeout:          s.getEnvDuration(RequestTimeoutEnv, defaultRequestTimeout),
+		FieldCacheTTL:           s.getFieldCacheTTL(),
+		MaxQueryTimeout:         s.getEnvDuration(MaxQueryTimeoutEnv, defaultMaxQueryTimeout),
+		ShutdownTimeout:         s.getEnvDuration(ShutdownTimeoutEnv, defaultShutdownTimeout),
+		MaxConcurrentRequests:   s.getEnvInt(MaxConcurrentRequestsEnv, 0),
+		RequestQueueTimeout:     s.getRequestQueueTimeout(),
+		PrettyJSON:              s.getEnvBool(PrettyJSONEnv, false),
+		RequestStats:            s.getEnvBool(RequestStatsEnv, false),
+		DebugRequests:           s.getEnvBool(DebugRequestsEnv, strings.EqualFold(strings.TrimSpace(s.getEnv(LogLevel, "info")), "debug")),
+		StartupHealthCheck:      s.getEnvBool(StartupHealthEnv, false),
+		EnabledToolGroups:       parseNameList(s.getEnv(EnabledToolGroupsEnv, "")),
+		DisabledTools:           parseNameList(s.getEnv(DisabledToolsEnv, "")),
+		TLSCAFile:               strings.TrimSpace(s.getEnv(TLSCAFileEnv, "")),
+		TLSInsecureSkipVerify:   s.getEnvBool(TLSInsecureSkipVerifyEnv, false),
+		ProxyURL:                strings.TrimSpace(s.getEnv(ProxyURLEnv, "")),
 	}, nil
 }
 
-func getEnv(key, defaultValue string) string {
+// parseCustomHeaders parses SIGNOZ_CUSTOM_HEADERS, either as
+// "Key1:Value1,Key2:Value2" or, when a value itself contains a comma, as a
+// JSON object of string values. Malformed pair entries are skipped with a
+// warning; invalid names or values are left for ValidateConfig to reject.
+func parseCustomHeaders(raw string) map[string]string {
+	customHeaders := make(map[string]string)
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return customHeaders
+	}
+	if strings.HasPrefix(raw, "{") {
+		var parsed map[string]string
+		if err := json.Unmarshal([]byte(raw), &parsed); err != nil {
+			log.Printf("WARN: ignoring %s: not a JSON object of string values: %v", SignozCustomHeaders, err)
+			return customHeaders
+		}
+		for name, value := range parsed {
+			customHeaders[strings.TrimSpace(name)] = strings.TrimSpace(value)
+		}
+		return customHeaders
+	}
+	for _, pair := range strings.Split(raw, ",") {
+		parts := strings.SplitN(pair, ":", 2)
+		if len(parts) != 2 {
+			log.Printf("WARN: skipping malformed custom header entry (missing ':'): %q", strings.TrimSpace(pair))
+		} else if strings.TrimSpace(parts[0]) == "" {
+			log.Printf("WARN: skipping custom header entry with empty name: %q", strings.TrimSpace(pair))
+		} else {
+			customHeaders[strings.TrimSpace(parts[0])] = strings.TrimSpace(parts[1])
+		}
+	}
+	return customHeaders
+}
+
+// parseNameList splits a comma-separated list of names, trimming spaces,
+// lowercasing, and dropping empty entries.
+func parseNameList(raw string) []string {
+	var names []string
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// settings resolves configuration values: a non-empty environment variable
+// wins over a config file value, which wins over the default.
+type settings struct {
+	file map[string]string
+	// read records every key looked up, when non-nil.
+	read map[string]bool
+}
+
+func (s *settings) get(key string) string {
+	if s.read != nil {
+		s.read[key] = true
+	}
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
+	return s.file[key]
+}
+
+// readConfigFile parses a config file into setting values. YAML is a
+// superset of JSON, so one decoder reads both. Lists are joined with commas
+// and objects are re-encoded as JSON, matching the environment variable
+// forms (e.g. ENABLED_TOOL_GROUPS, SIGNOZ_CUSTOM_HEADERS).
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read config file: %w", err)
+	}
+	var raw map[string]any
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("parse config file %s: %w", path, err)
+	}
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case string:
+			values[key] = v
+		case []any:
+			parts := make([]string, len(v))
+			for i, item := range v {
+				parts[i] = fmt.Sprint(item)
+			}
+			values[key] = strings.Join(parts, ",")
+		case map[string]any:
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("config file %s: %s: %w", path, key, err)
+			}
+			values[key] = string(encoded)
+		default:
+			values[key] = fmt.Sprint(v)
+		}
+	}
+	return values, nil
+}
+
+func (s *settings) getEnv(key, defaultValue string) string {
+	if value := s.get(key); value != "" {
+		return value
+	}
 	return defaultValue
 }
 
-func getEnvInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
+func (s *settings) getEnvInt(key string, defaultValue int) int {
+	if value := s.get(key); value != "" {
 		if parsed, err := strconv.Atoi(value); err == nil && parsed > 0 {
 			return parsed
 		}
@@ -166,8 +464,8 @@ func getEnvInt(key string, defaultValue int) int {
 	return defaultValue
 }
 
-func getEnvBool(key string, defaultValue bool) bool {
-	if value := os.Getenv(key); value != "" {
+func (s *settings) getEnvBool(key string, defaultValue bool) bool {
+	if value := s.get(key); value != "" {
 		if parsed, err := strconv.ParseBool(value); err == nil {
 			return parsed
 		}
@@ -175,8 +473,8 @@ func getEnvBool(key string, defaultValue bool) bool {
 	return defaultValue
 }
 
-func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
-	if value := os.Getenv(key); value != "" {
+func (s *settings) getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value := s.get(key); value != "" {
 		if parsed, err := time.ParseDuration(value); err == nil && parsed > 0 {
 			return parsed
 		}
@@ -185,15 +483,56 @@ func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
 	return defaultValue
 }
 
+// getFieldCacheTTL reads SIGNOZ_FIELD_CACHE_TTL, where "0" disables the
+// cache.
+func (s *settings) getFieldCacheTTL() time.Duration {
+	if value := strings.TrimSpace(s.get(FieldCacheTTLEnv)); value == "0" || value == "0s" {
+		return 0
+	}
+	return s.getEnvDuration(FieldCacheTTLEnv, defaultFieldCacheTTL)
+}
+
+// getRequestQueueTimeout reads SIGNOZ_REQUEST_QUEUE_TIMEOUT, where "0"
+// rejects requests past the concurrency cap instead of queueing them.
+func (s *settings) getRequestQueueTimeout() time.Duration {
+	if value := strings.TrimSpace(s.get(RequestQueueTimeoutEnv)); value == "0" || value == "0s" {
+		return 0
+	}
+	return s.getEnvDuration(RequestQueueTimeoutEnv, defaultRequestQueueTimeout)
+}
+
 func (c *Config) ValidateConfig() error {
-	// In HTTP mode, API key can come from Authorization header, so it's optional.
-	// In stdio mode, API key must be provided via environment variable.
-	if c.TransportMode == "stdio" && c.APIKey == "" {
-		return fmt.Errorf("SIGNOZ_API_KEY is required for stdio mode")
+	if c.MockMode {
+		if strings.TrimSpace(c.URL) == "" {
+			c.URL = MockURL
+		}
+		if strings.TrimSpace(c.APIKey) == "" {
+			c.APIKey = MockAPIKey
+		}
+	}
+
+	// In HTTP mode, credentials can come from request headers or OAuth, so
+	// they are optional unless RequireCredentials is set. In stdio mode they
+	// must be configured.
+	if c.TransportMode == "stdio" || c.RequireCredentials {
+		reason := "for stdio mode"
+		if c.TransportMode != "stdio" {
+			reason = "when " + RequireCredentialsEnv + "=true"
+		}
+		if strings.TrimSpace(c.APIKey) == "" {
+			return fmt.Errorf("%s is required %s; generate one in SigNoz under Settings → API Keys", SignozApiKey, reason)
+		}
+		if strings.TrimSpace(c.URL) == "" {
+			return fmt.Errorf("%s is required %s; set it to your SigNoz instance URL, e.g. https://signoz.example.com or http://localhost:8080", SignozURL, reason)
+		}
 	}
 
-	if c.TransportMode == "stdio" && c.URL == "" {
-		return fmt.Errorf("SIGNOZ_URL is required for stdio mode")
+	if c.URL != "" {
+		normalized, err := normalizeConfigURL(c.URL)
+		if err != nil {
+			return fmt.Errorf("%s: %w", SignozURL, err)
+		}
+		c.URL = normalized
 	}
 
 	if c.TransportMode == "http" {
@@ -202,6 +541,47 @@ func (c *Config) ValidateConfig() error {
 		}
 	}
 
+	// Go's HTTP client rejects these at request time, so a bad entry would
+	// otherwise fail every upstream call with an opaque transport error.
+	for name, value := range c.CustomHeaders {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return fmt.Errorf("%s: invalid header name %q", SignozCustomHeaders, name)
+		}
+		if !httpguts.ValidHeaderFieldValue(value) {
+			return fmt.Errorf("%s: invalid value for header %q (control characters such as CR/LF are not allowed)", SignozCustomHeaders, name)
+		}
+	}
+
+	switch c.LogFormat {
+	case "", logpkg.FormatJSON, logpkg.FormatConsole:
+	default:
+		return fmt.Errorf("%s must be %q or %q, got %q", LogFormatEnv, logpkg.FormatJSON, logpkg.FormatConsole, c.LogFormat)
+	}
+
+	switch c.AuthMode {
+	case "", AuthModeAPIKey:
+	case AuthModeBearer:
+		if c.APIKey == "" {
+			return fmt.Errorf("AUTH_MODE=bearer requires SIGNOZ_API_KEY to hold the bearer token")
+		}
+	default:
+		return fmt.Errorf("AUTH_MODE must be %q or %q, got %q", AuthModeAPIKey, AuthModeBearer, c.AuthMode)
+	}
+
+	tlsConfig, err := c.buildTLSConfig()
+	if err != nil {
+		return err
+	}
+	c.tlsConfig = tlsConfig
+
+	if c.ProxyURL != "" {
+		proxy, err := parseProxyURL(c.ProxyURL)
+		if err != nil {
+			return fmt.Errorf("%s: %w", ProxyURLEnv, err)
+		}
+		c.proxy = proxy
+	}
+
 	if c.OAuthEnabled {
 		if len(c.OAuthTokenSecret) < 32 {
 			return fmt.Errorf("OAUTH_TOKEN_SECRET is required and must be at least 32 bytes when OAUTH_ENABLED=true")
@@ -212,3 +592,110 @@ func (c *Config) ValidateConfig() error {
 	}
 	return nil
 }
+
+// TLSConfig returns the TLS settings for connections to SIGNOZ_URL, or nil
+// when the defaults apply. It is populated by ValidateConfig.
+func (c *Config) TLSConfig() *tls.Config {
+	return c.tlsConfig
+}
+
+// Proxy returns the parsed ProxyURL, or nil when the environment proxy
+// settings apply. It is populated by ValidateConfig.
+func (c *Config) Proxy() *url.URL {
+	return c.proxy
+}
+
+// parseProxyURL accepts the proxy schemes net/http supports.
+func parseProxyURL(rawURL string) (*url.URL, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("malformed URL: %w", err)
+	}
+	switch strings.ToLower(parsed.Scheme) {
+	case "http", "https", "socks5", "socks5h":
+	case "":
+		return nil, fmt.Errorf("URL has no scheme; use e.g. http://proxy.example.com:3128")
+	default:
+		return nil, fmt.Errorf("scheme %q not allowed, must be http, https, socks5, or socks5h", parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return nil, fmt.Errorf("URL %q has no host", parsed.Redacted())
+	}
+	return parsed, nil
+}
+
+// buildTLSConfig loads TLSCAFile on top of the system roots and applies
+// TLSInsecureSkipVerify, so an unreadable or empty CA file fails at startup
+// rather than on the first request.
+func (c *Config) buildTLSConfig() (*tls.Config, error) {
+	if c.TLSCAFile == "" && !c.TLSInsecureSkipVerify {
+		return nil, nil
+	}
+	cfg := &tls.Config{MinVersion: tls.VersionTLS12}
+	if c.TLSCAFile != "" {
+		pem, err := os.ReadFile(c.TLSCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", TLSCAFileEnv, err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("%s: no PEM certificates found in %s", TLSCAFileEnv, c.TLSCAFile)
+		}
+		cfg.RootCAs = pool
+	}
+	if c.TLSInsecureSkipVerify {
+		log.Printf("WARN: %s=true disables TLS certificate verification for %s; do not use this in production", TLSInsecureSkipVerifyEnv, SignozURL)
+		cfg.InsecureSkipVerify = true
+	}
+	return cfg, nil
+}
+
+// normalizeConfigURL checks that SIGNOZ_URL is an absolute http(s) URL and
+// strips trailing slashes. Unlike the per-request X-SigNoz-URL header, a
+// path prefix is kept for deployments behind a reverse proxy. Plain http
+// is allowed but logged, since the API key would travel unencrypted.
+func normalizeConfigURL(rawURL string) (string, error) {
+	trimmed := strings.TrimSpace(rawURL)
+	parsed, err := url.Parse(trimmed)
+	if err != nil {
+		return "", fmt.Errorf("malformed URL %q: %w", trimmed, err)
+	}
+	scheme := strings.ToLower(parsed.Scheme)
+	if scheme == "" || parsed.Opaque != "" {
+		return "", fmt.Errorf("URL %q has no scheme; use e.g. https://%s", trimmed, strings.TrimPrefix(trimmed, "//"))
+	}
+	if scheme != "http" && scheme != "https" {
+		return "", fmt.Errorf("scheme %q not allowed, must be http or https", parsed.Scheme)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("URL %q has no host", trimmed)
+	}
+	if parsed.RawQuery != "" || parsed.Fragment != "" {
+		return "", fmt.Errorf("URL %q must not include query parameters or a fragment", trimmed)
+	}
+	if scheme == "http" {
+		log.Printf("WARN: %s uses plain http; credentials are sent unencrypted", SignozURL)
+	}
+	parsed.Scheme = scheme
+	parsed.Path = strings.TrimRight(parsed.Path, "/")
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
+
+// EnvCredential returns the configured SIGNOZ_API_KEY as the credential
+// value and header name to send upstream, following AuthMode. Bearer mode
+// forwards it the same way a client's own Authorization header is
+// forwarded: as "Bearer <token>" in Authorization.
+func (c *Config) EnvCredential() (credential, authHeader string) {
+	if c.AuthMode != AuthModeBearer || c.APIKey == "" {
+		return c.APIKey, "SIGNOZ-API-KEY"
+	}
+	token := strings.TrimSpace(c.APIKey)
+	if len(token) >= len("Bearer ") && strings.EqualFold(token[:len("Bearer ")], "Bearer ") {
+		token = strings.TrimSpace(token[len("Bearer "):])
+	}
+	return "Bearer " + token, "Authorization"
+}
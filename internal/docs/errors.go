@@ -13,7 +13,7 @@ const (
 )
 
 func ToolError(code, message string, extra map[string]any) *mcp.CallToolResult {
-	structured := map[string]any{"code": code}
+	structured := map[string]any{"code": code, "retryable": toolerrors.Retryable(code)}
 	for k, v := range extra {
 		structured[k] = v
 	}
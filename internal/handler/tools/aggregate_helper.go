@@ -187,6 +187,7 @@ type AggregateRequest struct {
 	EndTime          int64
 	RequestType      string // "scalar" (default) or "time_series"
 	StepInterval     *int64 // nil = let backend auto-select
+	Format           string // formatJSON, formatCSV, or formatCompact
 	// StepIntervalWarning is set when a stepInterval value was provided but could
 	// not be parsed as a positive integer. The handler logs it (WARN) so a
 	// silently-dropped value is detectable rather than vanishing.
@@ -236,19 +237,9 @@ func parseAggregateArgs(args map[string]any, signal string, filterExpr string) (
 	}
 
 	orderByRaw, _ := args["orderBy"].(string)
-	orderByStr := strings.TrimSpace(orderByRaw)
-	orderExpr, orderDir := aggregationExpr, "desc"
-	if orderByStr != "" {
-		lower := strings.ToLower(orderByStr)
-		switch {
-		case strings.HasSuffix(lower, " asc"):
-			orderExpr = strings.TrimSpace(orderByStr[:len(orderByStr)-4])
-			orderDir = "asc"
-		case strings.HasSuffix(lower, " desc"):
-			orderExpr = strings.TrimSpace(orderByStr[:len(orderByStr)-5])
-		default:
-			orderExpr = orderByStr
-		}
+	orderExpr, orderDir := splitOrderBy(orderByRaw)
+	if orderExpr == "" {
+		orderExpr = aggregationExpr
 	}
 
 	limit, err := intArg(args, "limit", types.DefaultAggregateQueryLimit)
@@ -277,6 +268,11 @@ func parseAggregateArgs(args map[string]any, signal string, filterExpr string) (
 
 	stepInterval, stepIntervalWarning := parseStepInterval(args["stepInterval"])
 
+	format, err := parseOutputFormat(args, formatCSV, formatCompact)
+	if err != nil {
+		return nil, err
+	}
+
 	return &AggregateRequest{
 		AggregationExpr:     aggregationExpr,
 		FilterExpression:    filterExpr,
@@ -290,6 +286,7 @@ func parseAggregateArgs(args map[string]any, signal string, filterExpr string) (
 		RequestType:         requestType,
 		StepInterval:        stepInterval,
 		StepIntervalWarning: stepIntervalWarning,
+		Format:              format,
 	}, nil
 }
 
@@ -750,28 +747,6 @@ func alertHistoryCompletenessNote(payload []byte, returnedRows, limit int, rowsK
 		returnedRows, limit)
 }
 
-// limitOnlyCompletenessNote is the completeness advisory for list tools that
-// expose a `limit` but NO offset pagination (e.g. signoz_list_metrics). It must
-// not tell callers to "fetch the next page with offset" — there is no offset
-// param, so the caller would loop on the same page. Instead it advises
-// narrowing the result set (searchText / time range / source). narrowHint names
-// the concrete params for the tool.
-func limitOnlyCompletenessNote(returnedRows, limit int, rowsKnown bool, narrowHint string) string {
-	if !rowsKnown {
-		return fmt.Sprintf(
-			"note: limit %d applied; this tool cannot count returned rows, so more results may exist. Narrow the result set (%s) to be sure.",
-			limit, narrowHint)
-	}
-	if limit > 0 && returnedRows >= limit {
-		return fmt.Sprintf(
-			"note: returned %d rows (limit %d) — more results likely exist (hasMore=true). This tool has no offset paging; narrow the result set (%s) to surface the rest.",
-			returnedRows, limit, narrowHint)
-	}
-	return fmt.Sprintf(
-		"note: returned %d rows (limit %d) — all matching results returned (hasMore=false).",
-		returnedRows, limit)
-}
-
 // isTrivialBody reports whether a payload is effectively empty ("", {}, [], null)
 // so the drift WARN doesn't fire on a legitimately empty response.
 func isTrivialBody(payload []byte) bool {
@@ -805,39 +780,97 @@ func warnRowCountUnknown(ctx context.Context, logger *slog.Logger, toolName stri
 // search_traces), which support offset pagination. It appends a completeness
 // note (hasMore + nextOffset) inferred from the returned row count so callers
 // never silently assume a truncated page is complete.
-func rawSearchResult(ctx context.Context, logger *slog.Logger, toolName string, payload []byte, limit, offset int, limitClamped bool) *mcp.CallToolResult {
+func rawSearchResult(ctx context.Context, logger *slog.Logger, toolName string, payload []byte, limit, offset int, limitClamped bool, extraNotes ...string) *mcp.CallToolResult {
 	var notes []string
 	if limitClamped {
 		notes = append(notes, fmt.Sprintf(
 			"note: result limited to %d rows to bound server memory; paginate with \"offset\" (or narrow the time range/filters) for more.",
 			MaxRawResultLimit))
 	}
+	notes = append(notes, extraNotes...)
 	returnedRows, rowsKnown := countQueryRangeRows(payload)
 	warnRowCountUnknown(ctx, logger, toolName, payload, rowsKnown)
 	notes = append(notes, completenessNote(returnedRows, limit, offset, rowsKnown))
+	notes = append(notes, backendWarningNotes(ctx, logger, toolName, payload)...)
+	return resultWithNotes(payload, notes...)
+}
+
+// cursorSearchResult is rawSearchResult for keyset (cursor) paging, where the
+// continuation is nextCursor rather than an offset.
+func cursorSearchResult(ctx context.Context, logger *slog.Logger, toolName string, payload []byte, limit int, limitClamped bool, nextCursor string, extraNotes ...string) *mcp.CallToolResult {
+	var notes []string
+	if limitClamped {
+		notes = append(notes, fmt.Sprintf(
+			"note: result limited to %d rows to bound server memory; continue with \"cursor\" (or narrow the time range/filters) for more.",
+			MaxRawResultLimit))
+	}
+	notes = append(notes, extraNotes...)
+	returnedRows, rowsKnown := countQueryRangeRows(payload)
+	warnRowCountUnknown(ctx, logger, toolName, payload, rowsKnown)
+	notes = append(notes, cursorCompletenessNote(returnedRows, limit, nextCursor, rowsKnown))
+	notes = append(notes, backendWarningNotes(ctx, logger, toolName, payload)...)
+	return resultWithNotes(payload, notes...)
+}
+
+// cursorCompletenessNote is completenessNote for cursor paging.
+func cursorCompletenessNote(returnedRows, limit int, nextCursor string, rowsKnown bool) string {
+	switch {
+	case !rowsKnown:
+		return fmt.Sprintf(
+			"note: limit %d applied; this tool cannot count returned rows, so more results may exist. Narrow the query to be sure.",
+			limit)
+	case nextCursor != "":
+		return fmt.Sprintf(
+			"note: returned %d rows (limit %d) — more results likely exist (hasMore=true). Fetch the next page with cursor=%q and the same filters and time range.",
+			returnedRows, limit, nextCursor)
+	case limit > 0 && returnedRows >= limit:
+		return fmt.Sprintf(
+			"note: returned %d rows (limit %d) — more results likely exist (hasMore=true), but the last row has no timestamp/id to continue from. Narrow the time range to page further.",
+			returnedRows, limit)
+	default:
+		return fmt.Sprintf(
+			"note: returned %d rows (limit %d) — all matching results returned (hasMore=false).",
+			returnedRows, limit)
+	}
+}
+
+// backendWarningNotes logs any non-fatal backend warnings in a QB v5 body
+// and returns them as a note, if there are any.
+func backendWarningNotes(ctx context.Context, logger *slog.Logger, toolName string, payload []byte) []string {
 	warnings := extractBackendWarningMessages(payload)
 	warnBackendWarnings(ctx, logger, toolName, warnings)
 	warnUnparsedWarningEnvelope(ctx, logger, toolName, payload, len(warnings))
-	if len(warnings) > 0 {
-		notes = append(notes, backendWarningsNote(warnings))
+	if len(warnings) == 0 {
+		return nil
 	}
-	return resultWithNotes(payload, notes...)
+	return []string{backendWarningsNote(warnings)}
 }
 
 // aggregateResult is the result wrapper for aggregation tools. Aggregations
 // have no offset pagination, so the note advises narrowing the query instead.
-func aggregateResult(ctx context.Context, logger *slog.Logger, toolName string, payload []byte, limitClamped bool) *mcp.CallToolResult {
+func aggregateResult(ctx context.Context, logger *slog.Logger, toolName string, payload []byte, limitClamped bool, extraNotes ...string) *mcp.CallToolResult {
 	var notes []string
 	if limitClamped {
 		notes = append(notes, fmt.Sprintf(
 			"note: result limited to %d groups to bound server memory; narrow the time range, filters, or groupBy cardinality for fewer, more-specific groups.",
 			MaxRawResultLimit))
 	}
-	warnings := extractBackendWarningMessages(payload)
-	warnBackendWarnings(ctx, logger, toolName, warnings)
-	warnUnparsedWarningEnvelope(ctx, logger, toolName, payload, len(warnings))
-	if len(warnings) > 0 {
-		notes = append(notes, backendWarningsNote(warnings))
-	}
+	notes = append(notes, extraNotes...)
+	notes = append(notes, backendWarningNotes(ctx, logger, toolName, payload)...)
 	return resultWithNotes(payload, notes...)
 }
+
+// splitOrderBy splits an orderBy argument of the form '<expression> [asc|desc]'.
+// The direction defaults to "desc"; expr is empty when raw is blank.
+func splitOrderBy(raw string) (expr, dir string) {
+	raw = strings.TrimSpace(raw)
+	lower := strings.ToLower(raw)
+	switch {
+	case strings.HasSuffix(lower, " asc"):
+		return strings.TrimSpace(raw[:len(raw)-4]), "asc"
+	case strings.HasSuffix(lower, " desc"):
+		return strings.TrimSpace(raw[:len(raw)-5]), "desc"
+	default:
+		return raw, "desc"
+	}
+}
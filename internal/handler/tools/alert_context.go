@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/url"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+func (h *Handler) RegisterAlertContextHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering alert context handlers")
+
+	tool := mcp.NewTool("signoz_get_alert_context",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user asks what an alert is about and whether it is still true. For one rule it returns the readable condition and the query it fires on, re-runs that query over the rule's evaluation window to report the current value per series and whether each threshold is met now, and links the dashboard and panel the rule was created from when its source URL names one. anomaly_rule conditions are described but not re-evaluated. Use signoz_get_alert for the raw rule and signoz_get_alert_history for past firings."),
+		mcp.WithString("ruleId", mcp.Required(), mcp.Description("Alert rule ID, from signoz_list_alerts or signoz_list_alert_rules.")),
+		mcp.WithString("timeRange", mcp.Description(timeRangeDesc("Defaults to the rule's evaluation.spec.evalWindow, or '5m' when it has none."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetAlertContext)
+}
+
+type alertContextResponse struct {
+	Rule alertRuleSummary `json:"rule"`
+	// ConditionMet is whether any threshold is met over the window; it is
+	// omitted when the rule could not be re-evaluated.
+	ConditionMet *bool                 `json:"conditionMet,omitempty"`
+	Evaluation   *alertPreviewResponse `json:"evaluation,omitempty"`
+	Source       string                `json:"source,omitempty"`
+	DashboardID  string                `json:"dashboardId,omitempty"`
+	PanelID      string                `json:"panelId,omitempty"`
+	DashboardURL string                `json:"dashboardUrl,omitempty"`
+}
+
+func (h *Handler) handleGetAlertContext(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	ruleID, errResult := requireStringArg(args, "ruleId")
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_alert_context", slog.String("ruleId", ruleID))
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	respJSON, err := client.GetAlertByRuleID(ctx, ruleID)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to get alert", err, slog.String("ruleId", ruleID))
+		return upstreamError(err), nil
+	}
+
+	respJSON = enrichAlertWebURL(ctx, respJSON, ruleID)
+	summary, ok := summarizeAlertRule(respJSON)
+	if !ok {
+		h.logger.WarnContext(ctx, "Unrecognized alert rule shape", slog.String("ruleId", ruleID), slog.String("response", logpkg.TruncBody(respJSON)))
+		return upstreamResponseError("alert rule response could not be read; use signoz_get_alert for the raw definition"), nil
+	}
+	ruleBody := alertRuleObject(respJSON)
+	var rule types.AlertRule
+	var ruleObj map[string]any
+	if err := json.Unmarshal(ruleBody, &rule); err != nil {
+		return upstreamResponseError("failed to parse alert rule: " + err.Error()), nil
+	}
+	if err := json.Unmarshal(ruleBody, &ruleObj); err != nil {
+		return upstreamResponseError("failed to parse alert rule: " + err.Error()), nil
+	}
+
+	out := alertContextResponse{Rule: summary, Source: rule.Source}
+	out.DashboardID, out.PanelID = dashboardFromAlertSource(rule.Source)
+	if out.DashboardID != "" {
+		base, _ := util.GetSigNozURL(ctx)
+		out.DashboardURL, _ = util.ResourceWebURL(base, "dashboard", out.DashboardID)
+	}
+
+	var notes []string
+	thresholds, thresholdErr := previewThresholds(rule.Condition)
+	switch {
+	case rule.RuleType == types.RuleTypeAnomaly:
+		notes = append(notes, "note: anomaly_rule conditions are not re-evaluated because SigNoz computes anomaly scores during evaluation; use signoz_get_alert_history for recent firings.")
+	case thresholdErr != nil:
+		notes = append(notes, "note: the rule has no complete threshold to re-evaluate; use signoz_get_alert for the raw definition.")
+	default:
+		defaultWindow := defaultPreviewWindow
+		if rule.Evaluation != nil && rule.Evaluation.Spec.EvalWindow != "" {
+			defaultWindow = rule.Evaluation.Spec.EvalWindow
+		}
+		start, end, err := resolveTimestamps(args, defaultWindow)
+		if err != nil {
+			return errorWithCode(CodeValidationFailed, err.Error()), nil
+		}
+		eval, evalNotes, errResult := h.previewAlertRule(ctx, client, rule, ruleObj, thresholds, start, end)
+		if errResult != nil {
+			// The rule itself is still useful; report why the current value
+			// is missing instead of failing the whole call.
+			notes = append(notes, "note: the rule's query could not be re-run, so the current value is omitted: "+errorResultText(errResult))
+			break
+		}
+		out.Evaluation = &eval
+		out.ConditionMet = &eval.WouldFire
+		notes = append(notes, evalNotes...)
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal alert context", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal alert context: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// dashboardFromAlertSource reads the dashboard and panel IDs from a rule's
+// source URL when the rule was created from a dashboard panel, e.g.
+// "<base>/dashboard/<id>?widgetId=<panel>". Both are empty otherwise.
+func dashboardFromAlertSource(source string) (dashboardID, panelID string) {
+	u, err := url.Parse(strings.TrimSpace(source))
+	if err != nil {
+		return "", ""
+	}
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i+1 < len(segments); i++ {
+		if segments[i] == "dashboard" && segments[i+1] != "" {
+			dashboardID = segments[i+1]
+			break
+		}
+	}
+	if dashboardID == "" {
+		return "", ""
+	}
+	return dashboardID, u.Query().Get("widgetId")
+}
+
+// errorResultText returns the message of an error tool result.
+func errorResultText(res *mcp.CallToolResult) string {
+	if res != nil && len(res.Content) > 0 {
+		if tc, ok := res.Content[0].(mcp.TextContent); ok {
+			return tc.Text
+		}
+	}
+	return "unknown error"
+}
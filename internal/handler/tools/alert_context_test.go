@@ -0,0 +1,117 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func alertContextRuleResponse(t *testing.T, rule map[string]any) json.RawMessage {
+	t.Helper()
+	rule["id"] = "0196634d-5d66-75c4-b778-e317f49dab7a"
+	rule["alert"] = "Checkout p99"
+	rule["state"] = "firing"
+	body, err := json.Marshal(map[string]any{"status": "success", "data": rule})
+	require.NoError(t, err)
+	return body
+}
+
+func TestHandleGetAlertContext(t *testing.T) {
+	rule := previewRule(500, "at_least_once")
+	rule["source"] = "https://example.signoz.io/dashboard/dash-1?widgetId=panel-9&relativeTime=1h"
+	mock := &client.MockClient{
+		GetAlertByRuleIDFn: func(ctx context.Context, ruleID string) (json.RawMessage, error) {
+			return alertContextRuleResponse(t, rule), nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(previewSeriesResponse), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetAlertContext(ctxWithURL(), makeToolRequest("signoz_get_alert_context", map[string]any{
+		"ruleId": "0196634d-5d66-75c4-b778-e317f49dab7a",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out alertContextResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "Checkout p99", out.Rule.Name)
+	assert.Contains(t, out.Rule.Query, "p99(duration_nano)")
+	require.NotNil(t, out.ConditionMet)
+	assert.True(t, *out.ConditionMet)
+	require.NotNil(t, out.Evaluation)
+	assert.Equal(t, 650.0, out.Evaluation.Series[0].Thresholds[0].Value)
+	assert.Equal(t, "dash-1", out.DashboardID)
+	assert.Equal(t, "panel-9", out.PanelID)
+	assert.Equal(t, "https://signoz.example.com/dashboard/dash-1", out.DashboardURL)
+}
+
+func TestHandleGetAlertContext_QueryFailureKeepsRule(t *testing.T) {
+	mock := &client.MockClient{
+		GetAlertByRuleIDFn: func(ctx context.Context, ruleID string) (json.RawMessage, error) {
+			return alertContextRuleResponse(t, previewRule(500, "at_least_once")), nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return nil, errors.New("unexpected status 500: query failed")
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetAlertContext(testCtx(), makeToolRequest("signoz_get_alert_context", map[string]any{"ruleId": "r1"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out alertContextResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "Checkout p99", out.Rule.Name)
+	assert.Nil(t, out.ConditionMet)
+	assert.Nil(t, out.Evaluation)
+	assert.Empty(t, out.DashboardID)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "could not be re-run")
+}
+
+func TestHandleGetAlertContext_AnomalyRuleNotEvaluated(t *testing.T) {
+	rule := previewRule(3, "at_least_once")
+	rule["ruleType"] = "anomaly_rule"
+	mock := &client.MockClient{
+		GetAlertByRuleIDFn: func(ctx context.Context, ruleID string) (json.RawMessage, error) {
+			return alertContextRuleResponse(t, rule), nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			t.Fatal("anomaly rules must not be re-run")
+			return nil, nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetAlertContext(testCtx(), makeToolRequest("signoz_get_alert_context", map[string]any{"ruleId": "r1"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Contains(t, allTextBlocks(result)[1], "anomaly_rule")
+}
+
+func TestDashboardFromAlertSource(t *testing.T) {
+	tests := []struct {
+		source, dashboard, panel string
+	}{
+		{"", "", ""},
+		{"https://x.signoz.io/alerts/new?ruleType=threshold_rule", "", ""},
+		{"https://x.signoz.io/dashboard/abc", "abc", ""},
+		{"https://x.signoz.io/dashboard/abc/new?widgetId=w1", "abc", "w1"},
+	}
+	for _, tt := range tests {
+		dashboard, panel := dashboardFromAlertSource(tt.source)
+		assert.Equal(t, tt.dashboard, dashboard, tt.source)
+		assert.Equal(t, tt.panel, panel, tt.source)
+	}
+}
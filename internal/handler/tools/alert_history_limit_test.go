@@ -3,6 +3,7 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"strings"
 	"testing"
 
@@ -12,42 +13,56 @@ import (
 )
 
 // TestHandleGetAlertHistory_LimitClamped pins that an oversized limit is
-// clamped to MaxRawResultLimit BEFORE it is forwarded to the backend (so the
-// per-request memory guard can't be bypassed), and that the clamp is surfaced
-// as an advisory note on the result. The mock records the outgoing request, so
-// we assert against the limit the backend actually receives.
+// clamped to the page cap (MCP_MAX_ALERT_HISTORY_LIMIT, default 200) BEFORE it
+// is forwarded to the backend, and that the clamp is surfaced as an advisory
+// note on the result. The mock records the outgoing request, so we assert
+// against the limit the backend actually receives.
 func TestHandleGetAlertHistory_LimitClamped(t *testing.T) {
-	var capturedReq types.AlertHistoryRequest
-	mock := &client.MockClient{
-		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
-			capturedReq = req
-			return json.RawMessage(`{"data":{"items":[]}}`), nil
-		},
+	cases := []struct {
+		name   string
+		maxCfg int
+		limit  string
+		want   int
+	}{
+		{name: "default cap", limit: "100000", want: defaultAlertHistoryLimitCap},
+		{name: "configured cap", maxCfg: 30, limit: "50", want: 30},
 	}
-	h := newTestHandler(mock)
-	req := makeToolRequest("signoz_get_alert_history", map[string]any{
-		"ruleId":    "rule-hist",
-		"timeRange": "24h",
-		"limit":     "100000",
-	})
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var capturedReq types.AlertHistoryRequest
+			mock := &client.MockClient{
+				GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+					capturedReq = req
+					return json.RawMessage(`{"data":{"items":[]}}`), nil
+				},
+			}
+			h := newTestHandler(mock)
+			h.maxAlertHistoryLimit = tc.maxCfg
+			req := makeToolRequest("signoz_get_alert_history", map[string]any{
+				"ruleId":    "rule-hist",
+				"timeRange": "24h",
+				"limit":     tc.limit,
+			})
 
-	result, err := h.handleGetAlertHistory(testCtx(), req)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
-	if result.IsError {
-		t.Fatalf("handler returned error result: %v", result.Content)
-	}
+			result, err := h.handleGetAlertHistory(testCtx(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("handler returned error result: %v", result.Content)
+			}
 
-	// The limit forwarded upstream must be clamped, not the raw 100000.
-	if capturedReq.Limit != MaxRawResultLimit {
-		t.Errorf("forwarded limit = %d, want clamped to MaxRawResultLimit (%d)", capturedReq.Limit, MaxRawResultLimit)
-	}
+			// The limit forwarded upstream must be clamped, not the raw request.
+			if capturedReq.Limit != tc.want {
+				t.Errorf("forwarded limit = %d, want clamped to %d", capturedReq.Limit, tc.want)
+			}
 
-	// The clamp must be surfaced in the result notes so the caller knows the
-	// page was bounded server-side.
-	if !resultNotesContain(result, "result limited to") {
-		t.Errorf("expected a clamp advisory note in result content, got: %v", allTextBlocks(result))
+			// The clamp must be surfaced in the result notes so the caller
+			// knows the page was bounded server-side.
+			if !resultNotesContain(result, fmt.Sprintf("result limited to %d rows", tc.want)) {
+				t.Errorf("expected a clamp advisory note in result content, got: %v", allTextBlocks(result))
+			}
+		})
 	}
 }
 
@@ -104,6 +119,52 @@ func TestHandleGetAlertHistory_CursorKeepsEncodedLimitWhenOmitted(t *testing.T)
 	}
 }
 
+// TestHandleGetAlertHistory_OffsetRejected pins that any "offset", including a
+// negative one, is rejected before the backend is called: v2 history pages
+// only by cursor, so an offset would otherwise be silently ignored.
+func TestHandleGetAlertHistory_OffsetRejected(t *testing.T) {
+	mock := &client.MockClient{
+		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+			t.Error("backend must not be called when offset is present")
+			return nil, nil
+		},
+	}
+	result, err := newTestHandler(mock).handleGetAlertHistory(testCtx(), makeToolRequest("signoz_get_alert_history", map[string]any{
+		"ruleId": "rule-hist",
+		"offset": "-5",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := resultCode(t, result); got != CodeValidationFailed {
+		t.Fatalf("code = %q, want %q", got, CodeValidationFailed)
+	}
+	if !resultNotesContain(result, `"cursor"`) {
+		t.Errorf("expected cursor guidance, got: %v", allTextBlocks(result))
+	}
+}
+
+// TestHandleGetAlertHistory_OrderDefaultsAscending pins the default order and
+// the default page size forwarded when neither is given.
+func TestHandleGetAlertHistory_OrderDefaultsAscending(t *testing.T) {
+	var capturedReq types.AlertHistoryRequest
+	mock := &client.MockClient{
+		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+			capturedReq = req
+			return json.RawMessage(`{"data":{"items":[]}}`), nil
+		},
+	}
+	result, err := newTestHandler(mock).handleGetAlertHistory(testCtx(), makeToolRequest("signoz_get_alert_history", map[string]any{
+		"ruleId": "rule-hist",
+	}))
+	if err != nil || result.IsError {
+		t.Fatalf("request failed: err=%v result=%v", err, result.Content)
+	}
+	if capturedReq.Order != "asc" || capturedReq.Limit != 20 {
+		t.Fatalf("forwarded order/limit = %q/%d, want asc/20", capturedReq.Order, capturedReq.Limit)
+	}
+}
+
 // resultNotesContain reports whether any text content block of the result
 // contains the given substring. resultWithNotes appends notes as content blocks
 // trailing the JSON payload block.
@@ -0,0 +1,262 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/alert"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	// defaultPreviewWindow matches the rolling evalWindow SigNoz applies when
+	// a rule omits its evaluation block.
+	defaultPreviewWindow = "5m"
+	// maxPreviewSeries bounds the per-series results; firing series sort
+	// first so they are never the ones dropped.
+	maxPreviewSeries = 20
+)
+
+func (h *Handler) RegisterAlertPreviewHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering alert preview handlers")
+
+	tool := mcp.NewTool("signoz_test_alert_rule",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this before signoz_create_alert or signoz_update_alert to check whether a threshold_rule or promql_rule would fire. It runs the rule's condition.compositeQuery as a time_series query over a recent window, applies each threshold's op and matchType to every series of the selected query the way SigNoz does, and reports the compared value, whether each threshold would trigger, and wouldFire overall. Nothing is saved. It compares raw values, so a threshold targetUnit that differs from compositeQuery.unit is not converted. anomaly_rule is not supported because SigNoz computes anomaly scores during evaluation."),
+		mcp.WithObject("rule", mcp.Required(), mcp.Description("The alert rule as it would be passed to signoz_create_alert. Only ruleType, condition (compositeQuery, selectedQueryName, thresholds, alertOnAbsent), and evaluation.spec.evalWindow are used; name, labels, and channels may be omitted.")),
+		mcp.WithString("timeRange", mcp.Description(timeRangeDesc("Defaults to the rule's evaluation.spec.evalWindow, or '5m' when it has none."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleTestAlertRule)
+}
+
+type alertPreviewThreshold struct {
+	Name      string  `json:"name,omitempty"`
+	Op        string  `json:"op"`
+	Target    float64 `json:"target"`
+	MatchType string  `json:"matchType"`
+	Value     float64 `json:"value"`
+	Fires     bool    `json:"fires"`
+}
+
+type alertPreviewSeries struct {
+	Labels     map[string]any          `json:"labels,omitempty"`
+	Points     int                     `json:"points"`
+	Fires      bool                    `json:"fires"`
+	Thresholds []alertPreviewThreshold `json:"thresholds"`
+}
+
+type alertPreviewResponse struct {
+	Start       int64                `json:"start"`
+	End         int64                `json:"end"`
+	Query       string               `json:"query"`
+	WouldFire   bool                 `json:"wouldFire"`
+	NoData      bool                 `json:"noData"`
+	TotalSeries int                  `json:"totalSeries"`
+	Series      []alertPreviewSeries `json:"series"`
+}
+
+func (h *Handler) handleTestAlertRule(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	ruleObj, ok := args["rule"].(map[string]any)
+	if !ok {
+		return validationError("rule", "must be a JSON object"), nil
+	}
+	ruleJSON, err := json.Marshal(ruleObj)
+	if err != nil {
+		return InternalErrorResult("failed to marshal rule: " + err.Error()), nil
+	}
+	var rule types.AlertRule
+	if err := json.Unmarshal(ruleJSON, &rule); err != nil {
+		return errorWithCode(CodeValidationFailed, "invalid alert rule structure: "+err.Error()), nil
+	}
+	if rule.RuleType == types.RuleTypeAnomaly {
+		return validationError("rule.ruleType", "anomaly_rule cannot be previewed: SigNoz computes anomaly scores during evaluation. Preview threshold_rule or promql_rule instead."), nil
+	}
+	thresholds, errResult := previewThresholds(rule.Condition)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	defaultWindow := defaultPreviewWindow
+	if rule.Evaluation != nil && rule.Evaluation.Spec.EvalWindow != "" {
+		defaultWindow = rule.Evaluation.Spec.EvalWindow
+	}
+	start, end, err := resolveTimestamps(args, defaultWindow)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	selectedSpec, _ := selectedAlertQuerySpec(rule.Condition)
+	h.logger.DebugContext(ctx, "Tool called: signoz_test_alert_rule",
+		slog.String("selectedQuery", selectedSpec.Name), slog.Int64("start", start), slog.Int64("end", end))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	out, notes, errResult := h.previewAlertRule(ctx, client, rule, ruleObj, thresholds, start, end)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal alert preview", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal alert preview: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// previewAlertRule runs the rule's compositeQuery over [start, end] and
+// evaluates thresholds against every series of the selected query. ruleObj
+// is the rule as raw JSON, so its queries are passed through unchanged.
+func (h *Handler) previewAlertRule(ctx context.Context, client signozclient.Client, rule types.AlertRule, ruleObj map[string]any, thresholds []types.BasicThreshold, start, end int64) (alertPreviewResponse, []string, *mcp.CallToolResult) {
+	condition, _ := ruleObj["condition"].(map[string]any)
+	compositeQuery, _ := condition["compositeQuery"].(map[string]any)
+	queryJSON, err := json.Marshal(map[string]any{
+		"schemaVersion":  "v1",
+		"start":          start,
+		"end":            end,
+		"requestType":    "time_series",
+		"compositeQuery": map[string]any{"queries": compositeQuery["queries"]},
+	})
+	if err != nil {
+		return alertPreviewResponse{}, nil, InternalErrorResult("failed to marshal preview query: " + err.Error())
+	}
+	var payload types.QueryPayload
+	if err := json.Unmarshal(queryJSON, &payload); err != nil {
+		return alertPreviewResponse{}, nil, errorWithCode(CodeValidationFailed, "invalid condition.compositeQuery.queries: "+err.Error())
+	}
+	if err := payload.Validate(); err != nil {
+		return alertPreviewResponse{}, nil, errorWithCode(CodeValidationFailed, "condition.compositeQuery validation error: "+err.Error())
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return alertPreviewResponse{}, nil, InternalErrorResult("failed to marshal preview query: " + err.Error())
+	}
+
+	query := selectedAlertQuery(rule.Condition)
+	selectedSpec, _ := selectedAlertQuerySpec(rule.Condition)
+	selected := selectedSpec.Name
+	data, err := client.QueryBuilderV5(ctx, body)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to run alert preview query", err)
+		return alertPreviewResponse{}, nil, upstreamQueryError(err, "")
+	}
+
+	series := previewSeriesPoints(data, selected)
+	out := alertPreviewResponse{Start: start, End: end, Query: query, TotalSeries: len(series), NoData: len(series) == 0, Series: []alertPreviewSeries{}}
+	for _, s := range series {
+		preview := alertPreviewSeries{Labels: s.labels, Points: len(s.points)}
+		for _, th := range thresholds {
+			eval, err := alert.EvaluateThreshold(s.points, th.CompareOp, th.MatchType, *th.Target)
+			if err != nil {
+				return alertPreviewResponse{}, nil, validationErrorf("rule.condition.thresholds", "threshold %q: %s", th.Name, err.Error())
+			}
+			preview.Thresholds = append(preview.Thresholds, alertPreviewThreshold{
+				Name: th.Name, Op: th.CompareOp, Target: *th.Target, MatchType: th.MatchType,
+				Value: eval.Value, Fires: eval.Fires,
+			})
+			preview.Fires = preview.Fires || eval.Fires
+		}
+		out.WouldFire = out.WouldFire || preview.Fires
+		out.Series = append(out.Series, preview)
+	}
+	sort.SliceStable(out.Series, func(i, j int) bool { return out.Series[i].Fires && !out.Series[j].Fires })
+	out.Series = out.Series[:min(len(out.Series), maxPreviewSeries)]
+
+	var notes []string
+	if out.NoData {
+		if rule.Condition.AlertOnAbsent {
+			out.WouldFire = true
+			notes = append(notes, "note: the query returned no data in this window; with alertOnAbsent the rule would raise a no-data alert once absentFor elapses.")
+		} else {
+			notes = append(notes, "note: the query returned no data in this window, so no threshold can fire. Check the filter and metric names, or widen timeRange.")
+		}
+	}
+	if out.TotalSeries > maxPreviewSeries {
+		notes = append(notes, fmt.Sprintf("note: showing %d of %d series, firing series first.", maxPreviewSeries, out.TotalSeries))
+	}
+	for _, th := range thresholds {
+		if unit := rule.Condition.CompositeQuery.Unit; th.TargetUnit != "" && unit != "" && th.TargetUnit != unit {
+			notes = append(notes, fmt.Sprintf("note: threshold %q targets %s but the query is in %s; SigNoz converts units at evaluation, this preview does not.", th.Name, th.TargetUnit, unit))
+		}
+	}
+
+	return out, notes, nil
+}
+
+// previewThresholds returns the thresholds to evaluate, rejecting a rule
+// with none unless it only alerts on absent data.
+func previewThresholds(cond types.AlertCondition) ([]types.BasicThreshold, *mcp.CallToolResult) {
+	if cond.Thresholds == nil || len(cond.Thresholds.Spec) == 0 {
+		if cond.AlertOnAbsent {
+			return nil, nil
+		}
+		return nil, validationError("rule.condition.thresholds", "is required: add at least one threshold with op, target, and matchType")
+	}
+	for i, th := range cond.Thresholds.Spec {
+		if th.Target == nil {
+			return nil, validationErrorf(fmt.Sprintf("rule.condition.thresholds.spec[%d].target", i), "is required")
+		}
+	}
+	return cond.Thresholds.Spec, nil
+}
+
+// previewSeries is one series of the selected query with its finite points
+// in time order.
+type previewSeries struct {
+	labels map[string]any
+	points []float64
+}
+
+// previewSeriesPoints collects the series of queryName from a time_series
+// response. Series without any finite point are skipped.
+func previewSeriesPoints(data json.RawMessage, queryName string) []previewSeries {
+	var env qbResultEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil
+	}
+	var out []previewSeries
+	for _, res := range env.Data.Data.Results {
+		if queryName != "" && res.QueryName != queryName {
+			continue
+		}
+		for _, agg := range res.Aggregations {
+			for _, series := range agg.Series {
+				s := previewSeries{}
+				for _, v := range series.Values {
+					if f, ok := finiteFloat(v.Value); ok {
+						s.points = append(s.points, f)
+					}
+				}
+				if len(s.points) == 0 {
+					continue
+				}
+				if len(series.Labels) > 0 {
+					s.labels = make(map[string]any, len(series.Labels))
+					for _, l := range series.Labels {
+						s.labels[l.Key.Name] = l.Value
+					}
+				}
+				out = append(out, s)
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,158 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const previewSeriesResponse = `{"status":"success","data":{"type":"time_series","data":{"results":[
+	{"queryName":"A","aggregations":[{"index":0,"series":[
+		{"labels":[{"key":{"name":"http.route"},"value":"/cart"}],"values":[{"timestamp":1,"value":120},{"timestamp":2,"value":180}]},
+		{"labels":[{"key":{"name":"http.route"},"value":"/pay"}],"values":[{"timestamp":1,"value":400},{"timestamp":2,"value":650}]}]}]},
+	{"queryName":"B","aggregations":[{"index":0,"series":[{"labels":[],"values":[{"timestamp":1,"value":9000}]}]}]}]}}}`
+
+func previewRule(target float64, matchType string) map[string]any {
+	return map[string]any{
+		"ruleType": "threshold_rule",
+		"condition": map[string]any{
+			"selectedQueryName": "A",
+			"compositeQuery": map[string]any{"queryType": "builder", "unit": "ms", "queries": []any{
+				map[string]any{"type": "builder_query", "spec": map[string]any{
+					"name": "A", "signal": "traces", "aggregations": []any{map[string]any{"expression": "p99(duration_nano)"}},
+					"groupBy": []any{map[string]any{"name": "http.route"}},
+				}},
+				map[string]any{"type": "builder_query", "spec": map[string]any{
+					"name": "B", "signal": "traces", "aggregations": []any{map[string]any{"expression": "count()"}},
+				}},
+			}},
+			"thresholds": map[string]any{"kind": "basic", "spec": []any{
+				map[string]any{"name": "critical", "target": target, "op": "above", "matchType": matchType},
+			}},
+		},
+		"evaluation": map[string]any{"kind": "rolling", "spec": map[string]any{"evalWindow": "10m", "frequency": "1m"}},
+	}
+}
+
+func TestHandleTestAlertRule_Fires(t *testing.T) {
+	var sent types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			require.NoError(t, json.Unmarshal(body, &sent))
+			return json.RawMessage(previewSeriesResponse), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleTestAlertRule(testCtx(), makeToolRequest("signoz_test_alert_rule", map[string]any{
+		"rule": previewRule(500, "at_least_once"),
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	assert.Equal(t, "time_series", sent.RequestType)
+	assert.Equal(t, int64(10*60*1000), sent.End-sent.Start, "window defaults to the rule's evalWindow")
+	require.Len(t, sent.CompositeQuery.Queries, 2)
+
+	var out alertPreviewResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.True(t, out.WouldFire)
+	assert.False(t, out.NoData)
+	assert.Equal(t, 2, out.TotalSeries, "series of other queries are ignored")
+	require.Len(t, out.Series, 2)
+	assert.Equal(t, "/pay", out.Series[0].Labels["http.route"], "firing series sort first")
+	assert.True(t, out.Series[0].Fires)
+	assert.Equal(t, 650.0, out.Series[0].Thresholds[0].Value)
+	assert.False(t, out.Series[1].Fires)
+	assert.Equal(t, 180.0, out.Series[1].Thresholds[0].Value)
+}
+
+func TestHandleTestAlertRule_DoesNotFire(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(previewSeriesResponse), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleTestAlertRule(testCtx(), makeToolRequest("signoz_test_alert_rule", map[string]any{
+		"rule":      previewRule(600, "on_average"),
+		"timeRange": "1h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out alertPreviewResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.False(t, out.WouldFire)
+	assert.Equal(t, int64(60*60*1000), out.End-out.Start)
+	require.Len(t, out.Series, 2)
+	assert.Equal(t, 150.0, out.Series[0].Thresholds[0].Value)
+	assert.Equal(t, 525.0, out.Series[1].Thresholds[0].Value)
+}
+
+func TestHandleTestAlertRule_NoData(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"type":"time_series","data":{"results":[]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	rule := previewRule(500, "at_least_once")
+	rule["condition"].(map[string]any)["alertOnAbsent"] = true
+	result, err := h.handleTestAlertRule(testCtx(), makeToolRequest("signoz_test_alert_rule", map[string]any{"rule": rule}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out alertPreviewResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.True(t, out.NoData)
+	assert.True(t, out.WouldFire)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "no-data alert")
+}
+
+func TestHandleTestAlertRule_Validation(t *testing.T) {
+	h := newTestHandler(&client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			t.Error("invalid rules must not be queried")
+			return nil, nil
+		},
+	})
+
+	anomaly := previewRule(3, "at_least_once")
+	anomaly["ruleType"] = "anomaly_rule"
+	noThresholds := previewRule(3, "at_least_once")
+	delete(noThresholds["condition"].(map[string]any), "thresholds")
+	badMatch := previewRule(3, "sometimes")
+
+	for name, rule := range map[string]any{
+		"not an object": "threshold_rule",
+		"anomaly":       anomaly,
+		"no thresholds": noThresholds,
+	} {
+		t.Run(name, func(t *testing.T) {
+			result, err := h.handleTestAlertRule(testCtx(), makeToolRequest("signoz_test_alert_rule", map[string]any{"rule": rule}))
+			require.NoError(t, err)
+			assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+		})
+	}
+
+	h = newTestHandler(&client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(previewSeriesResponse), nil
+		},
+	})
+	result, err := h.handleTestAlertRule(testCtx(), makeToolRequest("signoz_test_alert_rule", map[string]any{"rule": badMatch}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	assert.Contains(t, textContent(t, result), `unsupported matchType "sometimes"`)
+}
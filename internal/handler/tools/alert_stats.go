@@ -0,0 +1,254 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	// alertStatsPageLimit and alertStatsMaxPages bound the timeline scan: up
+	// to 10,000 transitions, which covers weeks of a noisy rule.
+	alertStatsPageLimit = 1000
+	alertStatsMaxPages  = 10
+)
+
+func (h *Handler) RegisterAlertStatsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering alert stats handlers")
+
+	tool := mcp.NewTool("signoz_get_alert_stats",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user wants a reliability summary of one configured alert rule: how often it fired, for how long, and how quickly it resolved. It reads the rule's full state-history timeline for the window, pairs each series' firing transition with its next inactive or disabled transition, and returns the episode count, total firing time, mean time to resolve (MTTR), and the longest episode. Use signoz_get_alert_history for the raw transitions. Episodes that began before the window are not counted, so widen timeRange for long-running alerts."),
+		mcp.WithString("id", mcp.Description("Alert rule ID. Required; obtain it from signoz_list_alert_rules.")),
+		mcp.WithString("timeRange", mcp.DefaultString("7d"), mcp.Description(timeRangeDesc("Defaults to last 7 days if not provided."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start timestamp in unix milliseconds (optional, defaults to 7 days ago).")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End timestamp in unix milliseconds (optional, defaults to now).")),
+		mcp.WithString("filter", mcp.Description("Filter timeline labels using SigNoz query-builder syntax, as in signoz_get_alert_history, e.g. \"service.name = 'checkout'\". Omit to include every series of the rule.")),
+	)
+
+	h.addTool(s, tool, h.handleGetAlertStats)
+}
+
+// alertHistoryItem is the subset of a v2 timeline entry the stats need.
+type alertHistoryItem struct {
+	RuleName    string          `json:"ruleName"`
+	State       string          `json:"state"`
+	UnixMilli   int64           `json:"unixMilli"`
+	Fingerprint json.RawMessage `json:"fingerprint"`
+	Labels      []struct {
+		Key struct {
+			Name string `json:"name"`
+		} `json:"key"`
+		Value any `json:"value"`
+	} `json:"labels"`
+}
+
+type alertEpisode struct {
+	Labels     map[string]any `json:"labels,omitempty"`
+	Start      int64          `json:"start"`
+	End        int64          `json:"end"`
+	DurationMs int64          `json:"durationMs"`
+	Duration   string         `json:"duration"`
+	Ongoing    bool           `json:"ongoing,omitempty"`
+}
+
+type alertStatsResponse struct {
+	RuleID           string        `json:"ruleId"`
+	RuleName         string        `json:"ruleName,omitempty"`
+	Start            int64         `json:"start"`
+	End              int64         `json:"end"`
+	Transitions      int           `json:"transitions"`
+	Series           int           `json:"series"`
+	Episodes         int           `json:"episodes"`
+	Resolved         int           `json:"resolved"`
+	Ongoing          int           `json:"ongoing"`
+	TotalFiringMs    int64         `json:"totalFiringMs"`
+	TotalFiringTime  string        `json:"totalFiringTime"`
+	MTTRMs           int64         `json:"mttrMs,omitempty"`
+	MTTR             string        `json:"mttr,omitempty"`
+	LongestEpisode   *alertEpisode `json:"longestEpisode,omitempty"`
+	TimelineComplete bool          `json:"timelineComplete"`
+}
+
+func (h *Handler) handleGetAlertStats(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	ruleID := readResourceID(args, "ruleId")
+	if ruleID == "" {
+		return errorWithCode(CodeValidationFailed, `Parameter validation failed: "id" is required. Example: {"id": "0196634d-5d66-75c4-b778-e317f49dab7a", "timeRange": "7d"}`), nil
+	}
+	start, end, err := resolveTimestamps(args, "7d")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if start >= end {
+		return errorWithCode(CodeValidationFailed, `Parameter validation failed: "start" must be earlier than "end".`), nil
+	}
+	filterExpression := strings.TrimSpace(stringArg(args, "filter"))
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_alert_stats",
+		slog.String("ruleId", ruleID), slog.Int64("start", start), slog.Int64("end", end))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	var items []alertHistoryItem
+	complete := false
+	cursor := ""
+	for page := 0; page < alertStatsMaxPages; page++ {
+		historyReq := types.AlertHistoryRequest{
+			Start:            start,
+			End:              end,
+			FilterExpression: filterExpression,
+			Limit:            alertStatsPageLimit,
+			Order:            "asc",
+			Cursor:           cursor,
+		}
+		if cursor != "" {
+			historyReq.Limit = 0 // the cursor carries its own page size
+		}
+		respJSON, err := client.GetAlertHistory(ctx, ruleID, historyReq)
+		if err != nil {
+			h.logUpstreamFailure(ctx, "Failed to get alert history for stats", err, slog.String("ruleId", ruleID))
+			return upstreamError(err), nil
+		}
+		var resp struct {
+			Data struct {
+				Items      []alertHistoryItem `json:"items"`
+				NextCursor string             `json:"nextCursor"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(respJSON, &resp); err != nil {
+			h.logger.ErrorContext(ctx, "Failed to parse alert history", logpkg.ErrAttr(err), slog.String("body", logpkg.TruncBody(respJSON)))
+			return InternalErrorResult("failed to parse alert history: " + err.Error()), nil
+		}
+		items = append(items, resp.Data.Items...)
+		if resp.Data.NextCursor == "" {
+			complete = true
+			break
+		}
+		cursor = resp.Data.NextCursor
+	}
+
+	out := summarizeAlertHistory(items, end)
+	out.RuleID = ruleID
+	out.Start = start
+	out.End = end
+	out.TimelineComplete = complete
+
+	var notes []string
+	if !complete {
+		notes = append(notes, fmt.Sprintf("note: stopped reading the timeline after %d pages, so stats cover only the earliest %d transitions. Narrow timeRange or filter for complete numbers.", alertStatsMaxPages, len(items)))
+	}
+	if out.Ongoing > 0 {
+		notes = append(notes, fmt.Sprintf("note: %d episode(s) were still firing at the end of the window; their duration runs to the window end and they are excluded from MTTR.", out.Ongoing))
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal alert stats", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal alert stats: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// summarizeAlertHistory pairs each series' firing transition with the next
+// inactive or disabled one. items must be in ascending time order. A series
+// still firing at the end is closed at windowEnd and marked ongoing;
+// resolutions without a firing transition in the window are ignored.
+func summarizeAlertHistory(items []alertHistoryItem, windowEnd int64) alertStatsResponse {
+	type seriesState struct {
+		labels    map[string]any
+		openSince int64
+		open      bool
+	}
+	series := map[string]*seriesState{}
+	var keys []string
+	out := alertStatsResponse{Transitions: len(items)}
+	var resolvedMs int64
+
+	record := func(s *seriesState, endMs int64, ongoing bool) {
+		ep := alertEpisode{Labels: s.labels, Start: s.openSince, End: endMs, DurationMs: max(endMs-s.openSince, 0), Ongoing: ongoing}
+		ep.Duration = formatMillis(ep.DurationMs)
+		out.Episodes++
+		out.TotalFiringMs += ep.DurationMs
+		if ongoing {
+			out.Ongoing++
+		} else {
+			out.Resolved++
+			resolvedMs += ep.DurationMs
+		}
+		if out.LongestEpisode == nil || ep.DurationMs > out.LongestEpisode.DurationMs {
+			out.LongestEpisode = &ep
+		}
+		s.open = false
+	}
+
+	for _, item := range items {
+		if out.RuleName == "" {
+			out.RuleName = item.RuleName
+		}
+		key := string(item.Fingerprint)
+		if key == "" || key == "null" {
+			labels, _ := json.Marshal(item.Labels)
+			key = string(labels)
+		}
+		s, ok := series[key]
+		if !ok {
+			s = &seriesState{}
+			series[key] = s
+			keys = append(keys, key)
+		}
+		if s.labels == nil && len(item.Labels) > 0 {
+			s.labels = make(map[string]any, len(item.Labels))
+			for _, l := range item.Labels {
+				s.labels[l.Key.Name] = l.Value
+			}
+		}
+		switch item.State {
+		case "firing":
+			if !s.open {
+				s.open, s.openSince = true, item.UnixMilli
+			}
+		case "inactive", "disabled":
+			if s.open {
+				record(s, item.UnixMilli, false)
+			}
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if s := series[key]; s.open {
+			record(s, windowEnd, true)
+		}
+	}
+
+	out.Series = len(series)
+	out.TotalFiringTime = formatMillis(out.TotalFiringMs)
+	if out.Resolved > 0 {
+		out.MTTRMs = resolvedMs / int64(out.Resolved)
+		out.MTTR = formatMillis(out.MTTRMs)
+	}
+	return out
+}
+
+// formatMillis renders a millisecond duration at second precision, e.g.
+// "1h2m5s".
+func formatMillis(ms int64) string {
+	return (time.Duration(ms) * time.Millisecond).Round(time.Second).String()
+}
@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestSummarizeAlertHistory(t *testing.T) {
+	items := []alertHistoryItem{}
+	require.NoError(t, json.Unmarshal([]byte(`[
+		{"ruleName":"High latency","state":"inactive","unixMilli":500,"fingerprint":1},
+		{"ruleName":"High latency","state":"firing","unixMilli":1000,"fingerprint":1,"labels":[{"key":{"name":"route"},"value":"/cart"}]},
+		{"ruleName":"High latency","state":"firing","unixMilli":2000,"fingerprint":2,"labels":[{"key":{"name":"route"},"value":"/pay"}]},
+		{"ruleName":"High latency","state":"recovering","unixMilli":3000,"fingerprint":1},
+		{"ruleName":"High latency","state":"inactive","unixMilli":61000,"fingerprint":1},
+		{"ruleName":"High latency","state":"inactive","unixMilli":5000,"fingerprint":2},
+		{"ruleName":"High latency","state":"firing","unixMilli":100000,"fingerprint":2}
+	]`), &items))
+
+	out := summarizeAlertHistory(items, 130000)
+	assert.Equal(t, "High latency", out.RuleName)
+	assert.Equal(t, 7, out.Transitions)
+	assert.Equal(t, 2, out.Series)
+	assert.Equal(t, 3, out.Episodes)
+	assert.Equal(t, 2, out.Resolved)
+	assert.Equal(t, 1, out.Ongoing)
+	assert.Equal(t, int64(60000+3000+30000), out.TotalFiringMs)
+	assert.Equal(t, "1m33s", out.TotalFiringTime)
+	assert.Equal(t, int64(31500), out.MTTRMs)
+	require.NotNil(t, out.LongestEpisode)
+	assert.Equal(t, int64(60000), out.LongestEpisode.DurationMs)
+	assert.Equal(t, "/cart", out.LongestEpisode.Labels["route"])
+}
+
+func TestHandleGetAlertStats_Paginates(t *testing.T) {
+	var requests []types.AlertHistoryRequest
+	mock := &client.MockClient{
+		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+			assert.Equal(t, "rule-1", ruleID)
+			requests = append(requests, req)
+			if req.Cursor == "" {
+				return json.RawMessage(`{"status":"success","data":{"items":[{"ruleName":"CPU","state":"firing","unixMilli":1000,"fingerprint":7}],"total":2,"nextCursor":"page2"}}`), nil
+			}
+			return json.RawMessage(`{"status":"success","data":{"items":[{"ruleName":"CPU","state":"inactive","unixMilli":121000,"fingerprint":7}],"total":2}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetAlertStats(testCtx(), makeToolRequest("signoz_get_alert_stats", map[string]any{
+		"id": "rule-1", "start": "0", "end": "200000", "filter": "host = 'a'",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, alertStatsPageLimit, requests[0].Limit)
+	assert.Equal(t, "asc", requests[0].Order)
+	assert.Equal(t, "host = 'a'", requests[0].FilterExpression)
+	assert.Equal(t, "page2", requests[1].Cursor)
+	assert.Zero(t, requests[1].Limit)
+
+	var out alertStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "rule-1", out.RuleID)
+	assert.True(t, out.TimelineComplete)
+	assert.Equal(t, 1, out.Episodes)
+	assert.Equal(t, "2m0s", out.MTTR)
+	assert.Len(t, result.Content, 1)
+}
+
+func TestHandleGetAlertStats_IncompleteTimeline(t *testing.T) {
+	calls := 0
+	mock := &client.MockClient{
+		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+			calls++
+			return json.RawMessage(`{"status":"success","data":{"items":[{"state":"firing","unixMilli":1000,"fingerprint":1}],"nextCursor":"more"}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetAlertStats(testCtx(), makeToolRequest("signoz_get_alert_stats", map[string]any{"id": "rule-1"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, alertStatsMaxPages, calls)
+
+	var out alertStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.False(t, out.TimelineComplete)
+	assert.Equal(t, int64(7*24*60*60*1000), out.End-out.Start)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 3)
+	assert.Contains(t, blocks[1], "stats cover only the earliest 10 transitions")
+	assert.Contains(t, blocks[2], "still firing")
+}
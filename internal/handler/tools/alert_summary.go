@@ -0,0 +1,224 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+// alertRuleSummary is the flattened rule returned by signoz_get_alert with
+// summary=true.
+type alertRuleSummary struct {
+	RuleID     string                  `json:"ruleId,omitempty"`
+	Name       string                  `json:"name"`
+	AlertType  types.AlertType         `json:"alertType,omitempty"`
+	RuleType   types.RuleType          `json:"ruleType,omitempty"`
+	Severity   string                  `json:"severity,omitempty"`
+	State      string                  `json:"state,omitempty"`
+	Disabled   bool                    `json:"disabled"`
+	Condition  string                  `json:"condition"`
+	Query      string                  `json:"query,omitempty"`
+	EvalWindow string                  `json:"evalWindow,omitempty"`
+	Frequency  string                  `json:"frequency,omitempty"`
+	Thresholds []alertThresholdSummary `json:"thresholds,omitempty"`
+	Channels   []string                `json:"channels,omitempty"`
+	UsePolicy  bool                    `json:"usePolicy,omitempty"`
+	Labels     map[string]string       `json:"labels,omitempty"`
+	WebURL     string                  `json:"webUrl,omitempty"`
+}
+
+type alertThresholdSummary struct {
+	Name           string   `json:"name,omitempty"`
+	Op             string   `json:"op"`
+	Target         *float64 `json:"target,omitempty"`
+	TargetUnit     string   `json:"targetUnit,omitempty"`
+	RecoveryTarget *float64 `json:"recoveryTarget,omitempty"`
+	MatchType      string   `json:"matchType,omitempty"`
+}
+
+// summarizeAlertRule flattens a GET /api/v2/rules/{id} body, with the rule
+// either at the top level or under "data". ok is false when the body is
+// not a rule the summary can describe.
+func summarizeAlertRule(body []byte) (alertRuleSummary, bool) {
+	body = alertRuleObject(body)
+	var rule struct {
+		types.AlertRule
+		ID     string `json:"id"`
+		State  string `json:"state"`
+		WebURL string `json:"webUrl"`
+	}
+	if err := json.Unmarshal(body, &rule); err != nil || rule.Alert == "" {
+		return alertRuleSummary{}, false
+	}
+
+	out := alertRuleSummary{
+		RuleID:     rule.ID,
+		Name:       rule.Alert,
+		AlertType:  rule.AlertType,
+		RuleType:   rule.RuleType,
+		Severity:   rule.Labels["severity"],
+		State:      rule.State,
+		Disabled:   rule.Disabled,
+		Query:      selectedAlertQuery(rule.Condition),
+		EvalWindow: rule.EvalWindow,
+		Frequency:  rule.Frequency,
+		Labels:     rule.Labels,
+		WebURL:     rule.WebURL,
+	}
+	if rule.Evaluation != nil {
+		out.EvalWindow = rule.Evaluation.Spec.EvalWindow
+		out.Frequency = rule.Evaluation.Spec.Frequency
+		if schedule := rule.Evaluation.Spec.Schedule; schedule != nil && out.EvalWindow == "" {
+			out.EvalWindow = strings.TrimSpace(fmt.Sprintf("cumulative, resets %s at %02d:%02d %s",
+				schedule.Type, schedule.Hour, schedule.Minute, rule.Evaluation.Spec.Timezone))
+		}
+	}
+	if rule.NotificationSettings != nil {
+		out.UsePolicy = rule.NotificationSettings.UsePolicy
+	}
+
+	channels := slices.Clone(rule.PreferredChannels)
+	if rule.Condition.Thresholds != nil {
+		for _, th := range rule.Condition.Thresholds.Spec {
+			out.Thresholds = append(out.Thresholds, alertThresholdSummary{
+				Name:           th.Name,
+				Op:             th.CompareOp,
+				Target:         th.Target,
+				TargetUnit:     th.TargetUnit,
+				RecoveryTarget: th.RecoveryTarget,
+				MatchType:      th.MatchType,
+			})
+			channels = append(channels, th.Channels...)
+		}
+	} else if rule.Condition.Op != "" {
+		// v1 (anomaly) rules keep a single comparison on the condition.
+		th := alertThresholdSummary{Op: rule.Condition.Op, MatchType: rule.Condition.MatchType}
+		if target, ok := alertTargetValue(rule.Condition.Target); ok {
+			th.Target = &target
+		}
+		out.Thresholds = append(out.Thresholds, th)
+	}
+	sort.Strings(channels)
+	out.Channels = slices.Compact(channels)
+	out.Condition = alertConditionText(rule.RuleType, rule.Condition, out.Query, out.Thresholds, rule.Condition.CompositeQuery.Unit)
+	return out, true
+}
+
+// alertRuleObject returns the rule object of a GET /api/v2/rules/{id} body,
+// unwrapping "data" when the rule is nested under it.
+func alertRuleObject(body []byte) []byte {
+	var envelope struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(body, &envelope); err == nil && len(envelope.Data) > 0 && envelope.Data[0] == '{' {
+		return envelope.Data
+	}
+	return body
+}
+
+// selectedAlertQuerySpec returns the query the rule fires on: the one named
+// by selectedQueryName, else the first.
+func selectedAlertQuerySpec(cond types.AlertCondition) (types.AlertQuerySpec, bool) {
+	queries := cond.CompositeQuery.Queries
+	if len(queries) == 0 {
+		return types.AlertQuerySpec{}, false
+	}
+	for _, candidate := range queries {
+		if candidate.Spec.Name == cond.SelectedQuery {
+			return candidate.Spec, true
+		}
+	}
+	return queries[0].Spec, true
+}
+
+// selectedAlertQuery describes the query the rule fires on.
+func selectedAlertQuery(cond types.AlertCondition) string {
+	spec, ok := selectedAlertQuerySpec(cond)
+	if !ok {
+		return ""
+	}
+	switch {
+	case spec.Query != "":
+		return spec.Name + ": " + spec.Query
+	case spec.Expression != "":
+		return spec.Name + ": " + spec.Expression
+	}
+
+	aggs := make([]string, 0, len(spec.Aggregations))
+	for _, agg := range spec.Aggregations {
+		if agg.Expression != "" {
+			aggs = append(aggs, agg.Expression)
+			continue
+		}
+		aggs = append(aggs, fmt.Sprintf("%s(%s(%s))", agg.SpaceAggregation, agg.TimeAggregation, agg.MetricName))
+	}
+	text := spec.Name + ": " + strings.Join(aggs, ", ")
+	if spec.Signal != "" {
+		text += " of " + spec.Signal
+	}
+	if spec.Filter != nil && strings.TrimSpace(spec.Filter.Expression) != "" {
+		text += " where " + spec.Filter.Expression
+	}
+	if len(spec.GroupBy) > 0 {
+		names := make([]string, len(spec.GroupBy))
+		for i, g := range spec.GroupBy {
+			names[i] = g.Name
+		}
+		text += " by " + strings.Join(names, ", ")
+	}
+	return text
+}
+
+// alertConditionText renders when the rule fires, one clause per threshold
+// tier, e.g. "critical: A above 500 ms (at_least_once)".
+func alertConditionText(ruleType types.RuleType, cond types.AlertCondition, query string, thresholds []alertThresholdSummary, unit string) string {
+	name, _, _ := strings.Cut(query, ":")
+	if ruleType == types.RuleTypeAnomaly {
+		name += " anomaly score"
+	}
+	var clauses []string
+	for _, th := range thresholds {
+		clause := name + " " + th.Op
+		if th.Target != nil {
+			clause += " " + strconv.FormatFloat(*th.Target, 'f', -1, 64)
+			if th.TargetUnit != "" {
+				clause += " " + th.TargetUnit
+			} else if unit != "" && ruleType != types.RuleTypeAnomaly {
+				clause += " " + unit
+			}
+		}
+		if th.MatchType != "" {
+			clause += " (" + th.MatchType + ")"
+		}
+		if th.Name != "" {
+			clause = th.Name + ": " + clause
+		}
+		clauses = append(clauses, clause)
+	}
+	if cond.AlertOnAbsent {
+		absent := "no data"
+		if cond.AbsentFor > 0 {
+			absent += fmt.Sprintf(" for %d minutes", cond.AbsentFor)
+		}
+		clauses = append(clauses, absent)
+	}
+	return strings.Join(clauses, "; ")
+}
+
+// alertTargetValue reads a v1 condition target, which SigNoz stores as a
+// number but may echo back as a string.
+func alertTargetValue(target any) (float64, bool) {
+	switch v := target.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
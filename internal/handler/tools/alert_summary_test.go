@@ -0,0 +1,80 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+const thresholdRuleJSON = `{"status":"success","data":{
+	"id":"0196634d-5d66-75c4-b778-e317f49dab7a","alert":"High checkout latency","alertType":"TRACES_BASED_ALERT",
+	"ruleType":"threshold_rule","state":"firing","disabled":false,"schemaVersion":"v2alpha1",
+	"labels":{"severity":"critical","team":"payments"},"preferredChannels":["pagerduty"],
+	"condition":{"selectedQueryName":"A","compositeQuery":{"queryType":"builder","unit":"ms","queries":[
+		{"type":"builder_query","spec":{"name":"A","signal":"traces","aggregations":[{"expression":"p99(duration_nano)"}],
+			"filter":{"expression":"service.name = 'checkout'"},"groupBy":[{"name":"http.route"}]}}]},
+		"thresholds":{"kind":"basic","spec":[
+			{"name":"critical","target":500,"matchType":"at_least_once","op":"above","channels":["pagerduty","slack-oncall"]},
+			{"name":"warning","target":300,"targetUnit":"ms","matchType":"on_average","op":"above","channels":["slack-oncall"]}]}},
+	"evaluation":{"kind":"rolling","spec":{"evalWindow":"5m","frequency":"1m"}},
+	"notificationSettings":{"usePolicy":false}}}`
+
+func TestSummarizeAlertRule_Threshold(t *testing.T) {
+	summary, ok := summarizeAlertRule([]byte(thresholdRuleJSON))
+	require.True(t, ok)
+	assert.Equal(t, "High checkout latency", summary.Name)
+	assert.Equal(t, "critical", summary.Severity)
+	assert.Equal(t, "firing", summary.State)
+	assert.Equal(t, "A: p99(duration_nano) of traces where service.name = 'checkout' by http.route", summary.Query)
+	assert.Equal(t, "critical: A above 500 ms (at_least_once); warning: A above 300 ms (on_average)", summary.Condition)
+	assert.Equal(t, "5m", summary.EvalWindow)
+	assert.Equal(t, "1m", summary.Frequency)
+	assert.Equal(t, []string{"pagerduty", "slack-oncall"}, summary.Channels)
+	require.Len(t, summary.Thresholds, 2)
+	assert.Equal(t, 500.0, *summary.Thresholds[0].Target)
+}
+
+func TestSummarizeAlertRule_AnomalyAndAbsent(t *testing.T) {
+	summary, ok := summarizeAlertRule([]byte(`{"alert":"CPU anomaly","ruleType":"anomaly_rule","evalWindow":"24h","frequency":"3h",
+		"condition":{"op":"above","matchType":"at_least_once","target":"3","alertOnAbsent":true,"absentFor":15,
+			"compositeQuery":{"queryType":"builder","queries":[{"type":"builder_query","spec":{"name":"A","signal":"metrics",
+				"aggregations":[{"metricName":"k8s.pod.cpu.usage","timeAggregation":"avg","spaceAggregation":"max"}]}}]}}}`))
+	require.True(t, ok)
+	assert.Equal(t, "A: max(avg(k8s.pod.cpu.usage)) of metrics", summary.Query)
+	assert.Equal(t, "A anomaly score above 3 (at_least_once); no data for 15 minutes", summary.Condition)
+	assert.Equal(t, "24h", summary.EvalWindow)
+
+	_, ok = summarizeAlertRule([]byte(`{"status":"success","data":[]}`))
+	assert.False(t, ok)
+}
+
+func TestHandleGetAlert_Summary(t *testing.T) {
+	mock := &client.MockClient{
+		GetAlertByRuleIDFn: func(ctx context.Context, ruleID string) (json.RawMessage, error) {
+			return json.RawMessage(thresholdRuleJSON), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetAlert(ctxWithURL(), makeToolRequest("signoz_get_alert", map[string]any{
+		"id": "0196634d-5d66-75c4-b778-e317f49dab7a", "summary": "true",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	var out alertRuleSummary
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "0196634d-5d66-75c4-b778-e317f49dab7a", out.RuleID)
+	assert.Equal(t, "https://signoz.example.com/alerts/overview?ruleId=0196634d-5d66-75c4-b778-e317f49dab7a", out.WebURL)
+	assert.NotEmpty(t, out.Condition)
+
+	result, err = h.handleGetAlert(testCtx(), makeToolRequest("signoz_get_alert", map[string]any{
+		"id": "rule-1", "summary": "sometimes",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+	"golang.org/x/sync/errgroup"
 
 	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
 	"github.com/SigNoz/signoz-mcp-server/pkg/alert"
@@ -24,11 +25,13 @@ import (
 type alertListOutput struct {
 	Data       []types.Alert     `json:"data"`
 	Pagination paginate.Metadata `json:"pagination"`
+	Warnings   []string          `json:"warnings,omitempty"`
 }
 
 type alertRuleListOutput struct {
 	Data       []types.AlertRuleSummary `json:"data"`
 	Pagination paginate.Metadata        `json:"pagination"`
+	Warnings   []string                 `json:"warnings,omitempty"`
 }
 
 var serverPopulatedAlertFields = []string{
@@ -48,7 +51,7 @@ func (h *Handler) RegisterAlertsHandlers(s *server.MCPServer) {
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
 		mcp.WithDescription("Use this when the user wants current firing, silenced, or inhibited Alertmanager alert instances and their state, severity, timing, and rule IDs. Do not use it for configured rules or history: use signoz_list_alert_rules for rule summaries, signoz_get_alert for one definition, or signoz_get_alert_history for its timeline. Filter by alert labels, state, or receiver before paginating."),
-		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum number of alerts to return per page. Default: 50, max: 1000 (higher values are clamped).")),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("alerts"))),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of results to skip for pagination. Default: 0.")),
 		mcp.WithBoolean("active", boolOrStringType(), mcp.Description("Include active (firing) alerts. Default: true (server-side).")),
 		mcp.WithBoolean("silenced", boolOrStringType(), mcp.Description("Include silenced alerts. Default: true (server-side).")),
@@ -62,9 +65,12 @@ func (h *Handler) RegisterAlertsHandlers(s *server.MCPServer) {
 		mcp.WithOutputSchema[alertRuleListOutput](),
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user wants configured alert-rule summaries, including inactive/OK and disabled rules. It returns rule IDs, names, types, state, severity, labels, and timestamps; use signoz_get_alert with an ID for the full definition. Do not use it for current firing/silenced/inhibited instances: use signoz_list_alerts. Paginate with limit and offset."),
-		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum number of alert rules to return per page. Default: 50, max: 1000 (higher values are clamped).")),
+		mcp.WithDescription("Use this when the user wants configured alert-rule summaries, including inactive/OK and disabled rules. It returns rule IDs, names, types, state, severity, labels, and timestamps; use signoz_get_alert with an ID for the full definition. Do not use it for current firing/silenced/inhibited instances: use signoz_list_alerts. Pass timeRange (or start and end) to keep only rules that fired in that window, such as alerts resolved in the last 24h. Paginate with limit and offset."),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("alert rules"))),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of results to skip for pagination. Default: 0.")),
+		mcp.WithString("timeRange", mcp.Description(timeRangeDesc("Optional. When set, only rules that fired in the window are returned, each with lastFiredAt; rules among them now in state 'inactive' are the recently resolved ones. Omit to list every rule."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange and restrict results as timeRange does.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
 	)
 	h.addTool(s, alertRulesTool, h.handleListAlertRules)
 
@@ -77,6 +83,7 @@ func (h *Handler) RegisterAlertsHandlers(s *server.MCPServer) {
 		// advertised inputSchema. The handler validates that one of id/ruleId is
 		// present. See readResourceID.
 		mcp.WithString("id", mcp.Description("Alert rule ID (UUIDv7 on v2 servers). Required; obtain it from signoz_list_alert_rules.")),
+		mcp.WithBoolean("summary", boolOrStringType(), mcp.Description("When true, return a flattened view instead of the raw rule: name, severity, state, a readable condition (query, operator, target, match type per threshold tier), evaluation window and frequency, notification channels, and labels (default: false). Use the raw rule (the default) before signoz_update_alert.")),
 	)
 	h.addTool(s, getAlertTool, h.handleGetAlert)
 
@@ -91,7 +98,7 @@ func (h *Handler) RegisterAlertsHandlers(s *server.MCPServer) {
 		mcp.WithString("state", mcp.Enum(alertHistoryStateValues...), mcp.Description("Filter by alert state: inactive, pending, recovering, firing, nodata, or disabled. Omit to return all transitions.")),
 		mcp.WithString("filter", mcp.Description("Filter timeline labels using SigNoz query-builder syntax. Combine conditions with AND, OR, and parentheses; quote string values with single quotes and use operators such as =, !=, IN, and NOT IN. Example: \"severity = 'critical' AND (team = 'payments' OR service.name = 'checkout')\". To discover label keys, first call without a filter and inspect data.items[].labels[].key.name. If a filter returns no matches, retry unfiltered and verify the key spelling; malformed expressions return validation errors.")),
 		mcp.WithString("cursor", mcp.Description("Opaque continuation cursor. Repeat the original time range, state, filter, and order when fetching the next page. Omit cursor for the first page.")),
-		mcp.WithString("limit", mcp.DefaultString("20"), intOrStringType(), mcp.Description("Rows per page. Default: 20; max: 10000 (higher values are clamped).")),
+		mcp.WithString("limit", mcp.DefaultString("20"), intOrStringType(), mcp.Description(fmt.Sprintf("Rows per page. Default: 20; max: %d (higher values are clamped).", h.alertHistoryLimitCap()))),
 		mcp.WithString("order", mcp.DefaultString("asc"), mcp.Enum("asc", "desc"), mcp.Description("Sort order: 'asc' or 'desc' (default: 'asc')")),
 	)
 	h.addTool(s, alertHistoryTool, h.handleGetAlertHistory)
@@ -148,8 +155,11 @@ func parseTriStateBool(args map[string]any, key string) (*bool, error) {
 
 func (h *Handler) handleListAlerts(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	h.logger.DebugContext(ctx, "Tool called: signoz_list_alerts")
-	args := req.GetArguments()
-	limit, offset, limitClamped := paginate.ParseParamsClamped(args)
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit, offset, limitClamped := h.parseListParams(args)
 
 	active, err := parseTriStateBool(args, "active")
 	if err != nil {
@@ -199,16 +209,8 @@ func (h *Handler) handleListAlerts(ctx context.Context, req mcp.CallToolRequest)
 	base, _ := util.GetSigNozURL(ctx)
 	alertsList := make([]types.Alert, 0, len(apiResponse.Data))
 	for _, apiAlert := range apiResponse.Data {
-		webURL, _ := util.ResourceWebURL(base, "alert", apiAlert.Labels.RuleID)
-		alertsList = append(alertsList, types.Alert{
-			Alertname: apiAlert.Labels.Alertname,
-			RuleID:    apiAlert.Labels.RuleID,
-			Severity:  apiAlert.Labels.Severity,
-			StartsAt:  apiAlert.StartsAt,
-			EndsAt:    apiAlert.EndsAt,
-			State:     apiAlert.Status.State,
-			WebURL:    webURL,
-		})
+		webURL, _ := util.ResourceWebURL(base, "alert", apiAlert.Labels["ruleId"])
+		alertsList = append(alertsList, apiAlert.Alert(webURL))
 	}
 
 	total := len(alertsList)
@@ -218,18 +220,30 @@ func (h *Handler) handleListAlerts(ctx context.Context, req mcp.CallToolRequest)
 	}
 	pagedAlerts := paginate.Array(alertsArray, offset, limit)
 
-	resultJSON, err := paginate.Wrap(pagedAlerts, total, offset, limit)
+	toolResult, err := newToolResult(listResponse(pagedAlerts, total, offset, limit, limitClamped))
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to wrap alerts with pagination", logpkg.ErrAttr(err))
+		h.logger.ErrorContext(ctx, "Failed to marshal alerts response", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
 	}
 
-	return listResult(resultJSON, limitClamped), nil
+	return toolResult, nil
 }
 
 func (h *Handler) handleListAlertRules(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	h.logger.DebugContext(ctx, "Tool called: signoz_list_alert_rules")
-	limit, offset, limitClamped := paginate.ParseParamsClamped(req.Params.Arguments)
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit, offset, limitClamped := h.parseListParams(args)
+	windowed := hasTimeWindowArg(args)
+	var start, end int64
+	if windowed {
+		var err error
+		if start, end, err = resolveTimestamps(args, ""); err != nil {
+			return errorWithCode(CodeValidationFailed, err.Error()), nil
+		}
+	}
 
 	client, err := h.GetClient(ctx)
 	if err != nil {
@@ -276,6 +290,15 @@ func (h *Handler) handleListAlertRules(ctx context.Context, req mcp.CallToolRequ
 		})
 	}
 
+	var warnings []string
+	if windowed {
+		var errResult *mcp.CallToolResult
+		ruleSummaries, warnings, errResult = h.rulesFiredInWindow(ctx, client, ruleSummaries, start, end)
+		if errResult != nil {
+			return errResult, nil
+		}
+	}
+
 	total := len(ruleSummaries)
 	rulesArray := make([]any, len(ruleSummaries))
 	for i, v := range ruleSummaries {
@@ -283,13 +306,91 @@ func (h *Handler) handleListAlertRules(ctx context.Context, req mcp.CallToolRequ
 	}
 	pagedRules := paginate.Array(rulesArray, offset, limit)
 
-	resultJSON, err := paginate.Wrap(pagedRules, total, offset, limit)
+	listResp := listResponse(pagedRules, total, offset, limit, limitClamped)
+	listResp.Warnings = append(listResp.Warnings, warnings...)
+	toolResult, err := newToolResult(listResp)
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to wrap alert rules with pagination", logpkg.ErrAttr(err))
+		h.logger.ErrorContext(ctx, "Failed to marshal alert rules response", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
 	}
 
-	return listResult(resultJSON, limitClamped), nil
+	return toolResult, nil
+}
+
+// hasTimeWindowArg reports whether the caller asked for a time window through
+// a non-empty timeRange or a usable start timestamp.
+func hasTimeWindowArg(args map[string]any) bool {
+	if tr, ok := args["timeRange"].(string); ok && tr != "" {
+		return true
+	}
+	return timeutil.HasUsableTimestamp(args, "start")
+}
+
+// rulesFiredInWindow keeps the rules that fired between start and end, or
+// that are firing now, and sets LastFiredAt from each rule's newest firing
+// history entry. History lookups run concurrently; a failed lookup keeps the
+// rule unfiltered and is reported as a warning. When every lookup fails it
+// returns an upstream error instead.
+func (h *Handler) rulesFiredInWindow(ctx context.Context, client signozclient.Client, rules []types.AlertRuleSummary, start, end int64) ([]types.AlertRuleSummary, []string, *mcp.CallToolResult) {
+	lastFired := make([]int64, len(rules))
+	errs := make([]error, len(rules))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxParallelQueries)
+	for i, rule := range rules {
+		g.Go(func() error {
+			respJSON, err := client.GetAlertHistory(gctx, rule.RuleID, types.AlertHistoryRequest{
+				Start: start,
+				End:   end,
+				State: "firing",
+				Limit: 1,
+				Order: "desc",
+			})
+			if err != nil {
+				errs[i] = err
+				return nil
+			}
+			var resp struct {
+				Data struct {
+					Items []alertHistoryItem `json:"items"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(respJSON, &resp); err != nil {
+				errs[i] = fmt.Errorf("failed to parse alert history: %w", err)
+				return nil
+			}
+			if len(resp.Data.Items) > 0 {
+				lastFired[i] = resp.Data.Items[0].UnixMilli
+			}
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	out := make([]types.AlertRuleSummary, 0, len(rules))
+	var warnings []string
+	var firstErr error
+	failed := 0
+	for i, rule := range rules {
+		if errs[i] != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = errs[i]
+			}
+			h.logUpstreamFailure(ctx, "Failed to get alert history", errs[i], slog.String("ruleId", rule.RuleID))
+			warnings = append(warnings, fmt.Sprintf("history lookup failed for rule %s (%s), so it is listed without time filtering: %s", rule.RuleID, rule.Alert, errs[i].Error()))
+			out = append(out, rule)
+			continue
+		}
+		if lastFired[i] == 0 && rule.State != "firing" {
+			continue
+		}
+		rule.LastFiredAt = lastFired[i]
+		out = append(out, rule)
+	}
+	if len(rules) > 0 && failed == len(rules) {
+		return nil, nil, upstreamError(firstErr)
+	}
+	return out, warnings, nil
 }
 
 func (h *Handler) handleGetAlert(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -302,6 +403,10 @@ func (h *Handler) handleGetAlert(ctx context.Context, req mcp.CallToolRequest) (
 		h.logger.WarnContext(ctx, "Empty id parameter")
 		return errorWithCode(CodeValidationFailed, `Parameter validation failed: "id" is required. Provide a valid alert rule ID (UUID format). Example: {"id": "0196634d-5d66-75c4-b778-e317f49dab7a"}`), nil
 	}
+	summary, _, err := parseBoolArg(args, "summary")
+	if err != nil {
+		return validationError("summary", err.Error()), nil
+	}
 
 	h.logger.DebugContext(ctx, "Tool called: signoz_get_alert", slog.String("id", ruleID))
 	client, err := h.GetClient(ctx)
@@ -315,7 +420,21 @@ func (h *Handler) handleGetAlert(ctx context.Context, req mcp.CallToolRequest) (
 	}
 
 	respJSON = enrichAlertWebURL(ctx, respJSON, ruleID)
-	return structuredResult(respJSON), nil
+	if !summary {
+		return structuredResult(respJSON), nil
+	}
+
+	ruleSummary, ok := summarizeAlertRule(respJSON)
+	if !ok {
+		h.logger.WarnContext(ctx, "Unrecognized alert rule shape; returning raw rule", slog.String("ruleId", ruleID), slog.String("response", logpkg.TruncBody(respJSON)))
+		return structuredResultWithNotes(respJSON, "note: the rule could not be summarized; returning the raw definition."), nil
+	}
+	body, err := json.Marshal(ruleSummary)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal alert rule summary", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal alert rule summary: " + err.Error()), nil
+	}
+	return structuredResult(body), nil
 }
 
 // enrichAlertWebURL injects a webUrl deep link into a single-alert passthrough
@@ -326,6 +445,19 @@ func enrichAlertWebURL(ctx context.Context, data []byte, ruleID string) []byte {
 	return util.InjectWebURL(data, base, "alert", ruleID)
 }
 
+// defaultAlertHistoryLimitCap is the signoz_get_alert_history page cap when
+// MCP_MAX_ALERT_HISTORY_LIMIT is unset.
+const defaultAlertHistoryLimitCap = 200
+
+// alertHistoryLimitCap is the configured per-page cap of
+// signoz_get_alert_history, or defaultAlertHistoryLimitCap when unset.
+func (h *Handler) alertHistoryLimitCap() int {
+	if h.maxAlertHistoryLimit > 0 {
+		return h.maxAlertHistoryLimit
+	}
+	return defaultAlertHistoryLimitCap
+}
+
 func (h *Handler) handleGetAlertHistory(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args, errResult := requireArgsMap(req.Params.Arguments)
 	if errResult != nil {
@@ -373,7 +505,10 @@ func (h *Handler) handleGetAlertHistory(ctx context.Context, req mcp.CallToolReq
 		h.logger.WarnContext(ctx, "Invalid limit format", slog.Any("limit", args["limit"]), logpkg.ErrAttr(err))
 		return errorWithCode(CodeValidationFailed, err.Error()), nil
 	}
-	limit, limitClamped := clampLimit(limit)
+	limitClamped := false
+	if maxLimit := h.alertHistoryLimitCap(); limit > maxLimit {
+		limit, limitClamped = maxLimit, true
+	}
 
 	order := "asc"
 	if orderArg := strings.TrimSpace(stringArg(args, "order")); orderArg != "" {
@@ -443,8 +578,8 @@ func (h *Handler) handleGetAlertHistory(ctx context.Context, req mcp.CallToolReq
 	var notes []string
 	if limitClamped {
 		notes = append(notes, fmt.Sprintf(
-			"note: result limited to %d rows to bound server memory; paginate with \"cursor\" (or narrow the time range) for more.",
-			MaxRawResultLimit))
+			"note: result limited to %d rows per page (MCP_MAX_ALERT_HISTORY_LIMIT); paginate with \"cursor\" (or narrow the time range) for more.",
+			limit))
 	}
 	notes = append(notes, alertHistoryCompletenessNote(
 		respJSON, returnedRows, historyReq.Limit, rowsKnown,
@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
 
 	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
 	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 )
 
@@ -94,6 +96,122 @@ func TestHandleListAlerts_ClientError(t *testing.T) {
 	}
 }
 
+// TestHandleListAlerts_ResponseShapes feeds list_alerts each Alertmanager
+// response shape and checks the normalized alerts and pagination envelope.
+// Shapes other than {"data": [...]} must fail loudly instead of listing
+// nothing.
+func TestHandleListAlerts_ResponseShapes(t *testing.T) {
+	const threeAlerts = `{"status":"success","data":[
+		{"labels":{"alertname":"A1","ruleId":"1","severity":"critical"},"status":{"state":"active"},"startsAt":"s1","endsAt":"e1"},
+		{"labels":{"alertname":"A2","ruleId":"2","severity":"warning"},"status":{"state":"suppressed"},"startsAt":"s2","endsAt":"e2"},
+		{"labels":{"alertname":"A3","ruleId":"3","severity":"info"},"status":{"state":"active"},"startsAt":"s3","endsAt":"e3"}
+	]}`
+	tests := []struct {
+		name     string
+		body     string
+		args     map[string]any
+		wantCode string
+		want     []types.Alert
+		wantPage paginate.Metadata
+	}{
+		{
+			name: "labels and status are flattened",
+			body: threeAlerts,
+			want: []types.Alert{
+				{Alertname: "A1", RuleID: "1", Severity: "critical", StartsAt: "s1", EndsAt: "e1", State: "active"},
+				{Alertname: "A2", RuleID: "2", Severity: "warning", StartsAt: "s2", EndsAt: "e2", State: "suppressed"},
+				{Alertname: "A3", RuleID: "3", Severity: "info", StartsAt: "s3", EndsAt: "e3", State: "active"},
+			},
+			wantPage: paginate.Metadata{Total: 3, Offset: 0, Limit: 50, NextOffset: -1},
+		},
+		{
+			name: "paged",
+			body: threeAlerts,
+			args: map[string]any{"limit": "1", "offset": "1"},
+			want: []types.Alert{
+				{Alertname: "A2", RuleID: "2", Severity: "warning", StartsAt: "s2", EndsAt: "e2", State: "suppressed"},
+			},
+			wantPage: paginate.Metadata{Total: 3, Offset: 1, Limit: 1, HasMore: true, NextOffset: 2},
+		},
+		{
+			name:     "other labels and annotations kept, missing fields left empty",
+			body:     `{"status":"success","data":[{"labels":{"alertname":"A1","service.name":"cart","env":"prod"},"annotations":{"summary":"p99 high"},"status":{}}]}`,
+			want:     []types.Alert{{Alertname: "A1", Labels: map[string]string{"service.name": "cart", "env": "prod"}, Annotations: map[string]string{"summary": "p99 high"}}},
+			wantPage: paginate.Metadata{Total: 1, Offset: 0, Limit: 50, NextOffset: -1},
+		},
+		{
+			name:     "empty data",
+			body:     `{"status":"success","data":[]}`,
+			want:     []types.Alert{},
+			wantPage: paginate.Metadata{Total: 0, Offset: 0, Limit: 50, NextOffset: -1},
+		},
+		{
+			name:     "null data",
+			body:     `{"status":"success","data":null}`,
+			want:     []types.Alert{},
+			wantPage: paginate.Metadata{Total: 0, Offset: 0, Limit: 50, NextOffset: -1},
+		},
+		{
+			name:     "alerts nested under data",
+			body:     `{"status":"success","data":{"alerts":[{"labels":{"alertname":"A1"}}]}}`,
+			wantCode: CodeUpstreamError,
+		},
+		{
+			name:     "bare array",
+			body:     `[{"labels":{"alertname":"A1"}}]`,
+			wantCode: CodeUpstreamError,
+		},
+		{
+			name:     "not JSON",
+			body:     `<html>bad gateway</html>`,
+			wantCode: CodeUpstreamError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mock := &client.MockClient{
+				ListAlertsFn: func(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
+					return json.RawMessage(tt.body), nil
+				},
+			}
+			h := newTestHandler(mock)
+			args := tt.args
+			if args == nil {
+				args = map[string]any{}
+			}
+			result, err := h.handleListAlerts(testCtx(), makeToolRequest("signoz_list_alerts", args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if tt.wantCode != "" {
+				if !result.IsError {
+					t.Fatalf("expected an error result, got %s", textContent(t, result))
+				}
+				if code := resultCode(t, result); code != tt.wantCode {
+					t.Fatalf("code = %q, want %q", code, tt.wantCode)
+				}
+				return
+			}
+			if result.IsError {
+				t.Fatalf("handler returned error result: %v", result.Content)
+			}
+			var got struct {
+				Data       []types.Alert     `json:"data"`
+				Pagination paginate.Metadata `json:"pagination"`
+			}
+			if err := json.Unmarshal([]byte(textContent(t, result)), &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if !reflect.DeepEqual(got.Data, tt.want) {
+				t.Errorf("alerts = %+v, want %+v", got.Data, tt.want)
+			}
+			if got.Pagination != tt.wantPage {
+				t.Errorf("pagination = %+v, want %+v", got.Pagination, tt.wantPage)
+			}
+		})
+	}
+}
+
 func TestHandleListAlertRules(t *testing.T) {
 	mock := &client.MockClient{
 		ListAlertRulesFn: func(ctx context.Context) (json.RawMessage, error) {
@@ -199,6 +317,83 @@ func TestHandleListAlertRules_NoArguments(t *testing.T) {
 	}
 }
 
+func TestHandleListAlertRules_TimeWindow(t *testing.T) {
+	mock := &client.MockClient{
+		ListAlertRulesFn: func(ctx context.Context) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":[
+				{"id":"resolved","alert":"Resolved","state":"inactive"},
+				{"id":"quiet","alert":"Quiet","state":"inactive"},
+				{"id":"firing","alert":"Firing","state":"firing"},
+				{"id":"broken","alert":"Broken","state":"inactive"}
+			]}`), nil
+		},
+		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+			if req.Start != 1767225600000 || req.End != 1767229200000 || req.State != "firing" || req.Limit != 1 || req.Order != "desc" {
+				t.Errorf("unexpected history request for %s: %+v", ruleID, req)
+			}
+			switch ruleID {
+			case "resolved":
+				return json.RawMessage(`{"status":"success","data":{"items":[{"state":"firing","unixMilli":1767227400000}]}}`), nil
+			case "broken":
+				return nil, fmt.Errorf("unexpected status 500: history unavailable")
+			default:
+				return json.RawMessage(`{"status":"success","data":{"items":[]}}`), nil
+			}
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_list_alert_rules", map[string]any{"start": "1767225600000", "end": "1767229200000"})
+
+	result, err := h.handleListAlertRules(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+
+	var resp struct {
+		Data     []types.AlertRuleSummary `json:"data"`
+		Warnings []string                 `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(result.Content[0].(mcp.TextContent).Text), &resp); err != nil {
+		t.Fatalf("failed to parse response: %v", err)
+	}
+	var ids []string
+	for _, rule := range resp.Data {
+		ids = append(ids, rule.RuleID)
+	}
+	if want := []string{"resolved", "firing", "broken"}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("rule IDs = %v, want %v", ids, want)
+	}
+	if resp.Data[0].LastFiredAt != 1767227400000 {
+		t.Errorf("lastFiredAt = %d, want 1767227400000", resp.Data[0].LastFiredAt)
+	}
+	if len(resp.Warnings) != 1 || !strings.Contains(resp.Warnings[0], "broken") {
+		t.Errorf("warnings = %v, want one naming the broken rule", resp.Warnings)
+	}
+}
+
+func TestHandleListAlertRules_TimeWindowAllHistoryFails(t *testing.T) {
+	mock := &client.MockClient{
+		ListAlertRulesFn: func(ctx context.Context) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":[{"id":"r1","alert":"A","state":"inactive"}]}`), nil
+		},
+		GetAlertHistoryFn: func(ctx context.Context, ruleID string, req types.AlertHistoryRequest) (json.RawMessage, error) {
+			return nil, fmt.Errorf("unexpected status 503: unavailable")
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleListAlertRules(testCtx(), makeToolRequest("signoz_list_alert_rules", map[string]any{"timeRange": "24h"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatal("expected error result when every history lookup fails")
+	}
+}
+
 func TestHandleListAlertRules_ClientError(t *testing.T) {
 	mock := &client.MockClient{
 		ListAlertRulesFn: func(ctx context.Context) (json.RawMessage, error) {
@@ -24,47 +24,73 @@ var (
 // registered tool. A new tool must be classified here (read/create/update/
 // delete) before it can ship; see annotations.go for the class definitions.
 var expectedToolAnnotations = map[string]annotationTriple{
-	"signoz_aggregate_logs":              readTriple,
-	"signoz_aggregate_traces":            readTriple,
-	"signoz_check_metric_cardinality":    readTriple,
-	"signoz_check_metric_usage":          readTriple,
-	"signoz_execute_builder_query":       readTriple,
-	"signoz_fetch_doc":                   readTriple,
-	"signoz_get_alert":                   readTriple,
-	"signoz_get_alert_history":           readTriple,
-	"signoz_get_dashboard":               readTriple,
-	"signoz_get_field_keys":              readTriple,
-	"signoz_get_field_values":            readTriple,
-	"signoz_get_notification_channel":    readTriple,
-	"signoz_get_service_top_operations":  readTriple,
-	"signoz_get_top_metrics":             readTriple,
-	"signoz_get_trace_details":           readTriple,
-	"signoz_get_view":                    readTriple,
-	"signoz_list_alert_rules":            readTriple,
-	"signoz_list_alerts":                 readTriple,
-	"signoz_list_dashboard_templates":    readTriple,
-	"signoz_list_dashboards":             readTriple,
-	"signoz_list_metrics":                readTriple,
-	"signoz_list_notification_channels":  readTriple,
-	"signoz_list_services":               readTriple,
-	"signoz_list_views":                  readTriple,
-	"signoz_query_metrics":               readTriple,
-	"signoz_search_docs":                 readTriple,
-	"signoz_search_logs":                 readTriple,
-	"signoz_search_traces":               readTriple,
-	"signoz_create_alert":                createTriple,
-	"signoz_create_dashboard":            createTriple,
-	"signoz_create_notification_channel": createTriple,
-	"signoz_create_view":                 createTriple,
-	"signoz_import_dashboard":            createTriple,
-	"signoz_update_alert":                updateTriple,
-	"signoz_update_dashboard":            updateTriple,
-	"signoz_update_notification_channel": nonIdempotentUpdateTriple,
-	"signoz_update_view":                 updateTriple,
-	"signoz_delete_alert":                deleteTriple,
-	"signoz_delete_dashboard":            deleteTriple,
-	"signoz_delete_notification_channel": deleteTriple,
-	"signoz_delete_view":                 deleteTriple,
+	"signoz_aggregate_logs":                     readTriple,
+	"signoz_aggregate_traces":                   readTriple,
+	"signoz_check_metric_cardinality":           readTriple,
+	"signoz_check_metric_usage":                 readTriple,
+	"signoz_compare_time_windows":               readTriple,
+	"signoz_execute_builder_query":              readTriple,
+	"signoz_fetch_doc":                          readTriple,
+	"signoz_get_alert":                          readTriple,
+	"signoz_get_alert_history":                  readTriple,
+	"signoz_get_alert_stats":                    readTriple,
+	"signoz_get_dashboard":                      readTriple,
+	"signoz_get_dashboard_panel":                readTriple,
+	"signoz_get_dashboard_variables":            readTriple,
+	"signoz_get_error_sample_traces":            readTriple,
+	"signoz_get_exemplar_traces":                readTriple,
+	"signoz_get_top_errors":                     readTriple,
+	"signoz_get_trace_latency_histogram":        readTriple,
+	"signoz_get_field_cardinality":              readTriple,
+	"signoz_get_field_keys":                     readTriple,
+	"signoz_get_field_values":                   readTriple,
+	"signoz_get_notification_channel":           readTriple,
+	"signoz_get_service_dependencies_for_trace": readTriple,
+	"signoz_get_service_errors":                 readTriple,
+	"signoz_investigate_service":                readTriple,
+	"signoz_get_service_externals":              readTriple,
+	"signoz_get_service_top_operations":         readTriple,
+	"signoz_get_top_metrics":                    readTriple,
+	"signoz_get_trace_details":                  readTriple,
+	"signoz_get_view":                           readTriple,
+	"signoz_list_alert_rules":                   readTriple,
+	"signoz_list_alerts":                        readTriple,
+	"signoz_list_dashboard_templates":           readTriple,
+	"signoz_list_dashboards":                    readTriple,
+	"signoz_list_metrics":                       readTriple,
+	"signoz_list_notification_channels":         readTriple,
+	"signoz_list_services":                      readTriple,
+	"signoz_list_views":                         readTriple,
+	"signoz_query_metrics":                      readTriple,
+	"signoz_search_docs":                        readTriple,
+	"signoz_search_logs":                        readTriple,
+	"signoz_export_logs":                        readTriple,
+	"signoz_tail_logs":                          readTriple,
+	"signoz_get_logs_histogram":                 readTriple,
+	"signoz_search_traces":                      readTriple,
+	"signoz_search_traces_advanced":             readTriple,
+	"signoz_server_stats":                       readTriple,
+	"signoz_debug_last_request":                 readTriple,
+	"signoz_test_alert_rule":                    readTriple,
+	"signoz_get_alert_context":                  readTriple,
+	"signoz_health_check":                       readTriple,
+	"signoz_get_version":                        readTriple,
+	"signoz_list_tools":                         readTriple,
+	"signoz_get_query_examples":                 readTriple,
+	"signoz_create_alert":                       createTriple,
+	"signoz_create_dashboard":                   createTriple,
+	"signoz_create_notification_channel":        createTriple,
+	"signoz_create_view":                        createTriple,
+	"signoz_import_dashboard":                   createTriple,
+	"signoz_clone_dashboard":                    createTriple,
+	"signoz_update_alert":                       updateTriple,
+	"signoz_update_dashboard":                   updateTriple,
+	"signoz_update_notification_channel":        nonIdempotentUpdateTriple,
+	"signoz_update_view":                        updateTriple,
+	"signoz_delete_alert":                       deleteTriple,
+	"signoz_delete_dashboard":                   deleteTriple,
+	"signoz_delete_notification_channel":        deleteTriple,
+	"signoz_delete_view":                        deleteTriple,
 }
 
 func TestRegisteredToolAnnotationsMatchPinnedInventory(t *testing.T) {
@@ -0,0 +1,404 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/timeutil"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func (h *Handler) RegisterCompareHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering compare handlers")
+
+	tool := mcp.NewTool("signoz_compare_time_windows",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription(
+			"Use this for before/after questions such as whether latency or error counts changed after a deploy. It runs one Query Builder v5 request over a baseline window and a comparison window and returns both results plus per-series deltas (absolute and percent change) where series align on query, aggregation, and group labels. "+
+				"Time-series values are summarized as the mean of each series' points before comparing. Only time_series and scalar request types are supported; the query's own start/end are replaced by each window."),
+		mcp.WithString("searchContext",
+			mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithObject("query", mcp.Required(),
+			mcp.Description("SigNoz Query Builder v5 JSON object (same shape as signoz_execute_builder_query) with requestType time_series or scalar. start/end may be omitted; they are set from each window.")),
+		mcp.WithString("baselineStart", mcp.Required(), intOrStringType(),
+			mcp.Description("Baseline window start as a unix epoch (seconds, milliseconds, or nanoseconds are auto-detected).")),
+		mcp.WithString("baselineEnd", mcp.Required(), intOrStringType(),
+			mcp.Description("Baseline window end as a unix epoch.")),
+		mcp.WithString("comparisonStart", mcp.Required(), intOrStringType(),
+			mcp.Description("Comparison window start as a unix epoch.")),
+		mcp.WithString("comparisonEnd", mcp.Required(), intOrStringType(),
+			mcp.Description("Comparison window end as a unix epoch.")),
+	)
+
+	h.addTool(s, tool, h.handleCompareTimeWindows)
+}
+
+// compareWindow is one side of a comparison in the tool response.
+type compareWindow struct {
+	Start  int64           `json:"start"`
+	End    int64           `json:"end"`
+	Result json.RawMessage `json:"result"`
+}
+
+// seriesDelta is the change of one aligned series between the two windows.
+// PercentChange is nil when the baseline is zero.
+type seriesDelta struct {
+	Query         string         `json:"query"`
+	Aggregation   int            `json:"aggregation"`
+	Labels        map[string]any `json:"labels,omitempty"`
+	Baseline      float64        `json:"baseline"`
+	Comparison    float64        `json:"comparison"`
+	Change        float64        `json:"change"`
+	PercentChange *float64       `json:"percentChange"`
+}
+
+type compareResponse struct {
+	Baseline   compareWindow `json:"baseline"`
+	Comparison compareWindow `json:"comparison"`
+	Deltas     []seriesDelta `json:"deltas"`
+}
+
+func (h *Handler) handleCompareTimeWindows(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.logger.DebugContext(ctx, "Tool called: signoz_compare_time_windows")
+
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	queryObj, ok := args["query"].(map[string]any)
+	if !ok {
+		return validationError("query", "must be a JSON object"), nil
+	}
+	baseStart, baseEnd, errResult := parseCompareWindow(args, "baseline")
+	if errResult != nil {
+		return errResult, nil
+	}
+	cmpStart, cmpEnd, errResult := parseCompareWindow(args, "comparison")
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	queryJSON, err := json.Marshal(queryObj)
+	if err != nil {
+		return InternalErrorResult("failed to marshal query object: " + err.Error()), nil
+	}
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	windows := []*compareWindow{
+		{Start: baseStart, End: baseEnd},
+		{Start: cmpStart, End: cmpEnd},
+	}
+	bodies := make([][]byte, len(windows))
+	for i, w := range windows {
+		var payload types.QueryPayload
+		if err := json.Unmarshal(queryJSON, &payload); err != nil {
+			return errorWithCode(CodeValidationFailed, "invalid query payload structure: "+err.Error()), nil
+		}
+		payload.Start, payload.End = w.Start, w.End
+		if err := payload.Validate(); err != nil {
+			return errorWithCode(CodeValidationFailed, "query validation error: "+err.Error()), nil
+		}
+		if payload.RequestType != "time_series" && payload.RequestType != "scalar" {
+			return validationErrorf("query", "requestType must be time_series or scalar, got %q", payload.RequestType), nil
+		}
+		body, err := json.Marshal(payload)
+		if err != nil {
+			return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+		}
+		bodies[i] = body
+	}
+
+	results, err := runQueriesParallel(ctx, client, bodies, true)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to execute comparison query", err,
+			slog.Int64("baselineStart", baseStart), slog.Int64("comparisonStart", cmpStart))
+		return upstreamQueryError(err, ""), nil
+	}
+	for i, res := range results {
+		windows[i].Result = res.Data
+	}
+
+	// Deltas are computed from the full results; only the raw results
+	// embedded in the response are capped.
+	deltas, unmatched := computeWindowDeltas(windows[0].Result, windows[1].Result)
+	resp := compareResponse{
+		Baseline:   *windows[0],
+		Comparison: *windows[1],
+		Deltas:     deltas,
+	}
+
+	var notes []string
+	if h.maxResponseBytes > 0 {
+		capNotes, errResult := h.capCompareWindows(ctx, &resp)
+		if errResult != nil {
+			return errResult, nil
+		}
+		notes = append(notes, capNotes...)
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal comparison result", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal comparison result: " + err.Error()), nil
+	}
+
+	if unmatched > 0 {
+		notes = append(notes, fmt.Sprintf("note: %d series appear in only one window and have no delta; inspect the raw results for them.", unmatched))
+	}
+	return structuredResultWithNotes(out, notes...), nil
+}
+
+// capCompareWindows fits both raw window results into h.maxResponseBytes
+// alongside the deltas, giving each window an equal share of what the
+// deltas leave over.
+func (h *Handler) capCompareWindows(ctx context.Context, resp *compareResponse) ([]string, *mcp.CallToolResult) {
+	skeleton := *resp
+	skeleton.Baseline.Result, skeleton.Comparison.Result = nil, nil
+	overhead, err := json.Marshal(skeleton)
+	if err != nil {
+		return nil, InternalErrorResult("failed to marshal comparison result: " + err.Error())
+	}
+	budget := (h.maxResponseBytes - h.serializedSize(overhead)) / 2
+	if budget <= 0 {
+		return nil, validationResult(fmt.Sprintf(
+			"the comparison deltas alone exceed the %d-byte limit (%s); reduce groupBy cardinality.",
+			h.maxResponseBytes, config.MaxResponseBytesEnv))
+	}
+
+	var notes []string
+	for _, w := range []struct {
+		name   string
+		window *compareWindow
+	}{{"baseline", &resp.Baseline}, {"comparison", &resp.Comparison}} {
+		capped, _, note, errResult := h.capQueryResponseWithin(ctx, "signoz_compare_time_windows", w.name+" result", w.window.Result, budget)
+		if errResult != nil {
+			return nil, errResult
+		}
+		w.window.Result = capped
+		if note != "" {
+			notes = append(notes, note)
+		}
+	}
+	return notes, nil
+}
+
+// parseCompareWindow reads the <prefix>Start/<prefix>End pair, normalizing
+// epoch magnitude to milliseconds.
+func parseCompareWindow(args map[string]any, prefix string) (int64, int64, *mcp.CallToolResult) {
+	startKey, endKey := prefix+"Start", prefix+"End"
+	window := map[string]any{"start": args[startKey], "end": args[endKey]}
+	if err := timeutil.ValidateExplicitTimestamps(window); err != nil {
+		return 0, 0, validationErrorf(startKey, "and %s must be unix epoch integers", endKey)
+	}
+	if !timeutil.HasUsableTimestamp(window, "start") || !timeutil.HasUsableTimestamp(window, "end") {
+		return 0, 0, validationErrorf(startKey, "and %s are required", endKey)
+	}
+	startStr, endStr := timeutil.GetTimestampsWithDefaults(window, "ms")
+	start, _ := strconv.ParseInt(startStr, 10, 64)
+	end, _ := strconv.ParseInt(endStr, 10, 64)
+	if start >= end {
+		return 0, 0, validationErrorf(startKey, "must be before %s", endKey)
+	}
+	return start, end, nil
+}
+
+// windowSeries is one summarized series from a QB v5 time_series or scalar
+// response, keyed by query name, aggregation index, and group labels.
+type windowSeries struct {
+	query       string
+	aggregation int
+	labels      map[string]any
+	value       float64
+}
+
+type qbResultEnvelope struct {
+	Data struct {
+		Data struct {
+			Results []qbResult `json:"results"`
+		} `json:"data"`
+	} `json:"data"`
+}
+
+type qbResult struct {
+	QueryName    string `json:"queryName"`
+	Aggregations []struct {
+		Index  int `json:"index"`
+		Series []struct {
+			Labels []struct {
+				Key struct {
+					Name string `json:"name"`
+				} `json:"key"`
+				Value any `json:"value"`
+			} `json:"labels"`
+			Values []struct {
+				Value any `json:"value"`
+			} `json:"values"`
+		} `json:"series"`
+	} `json:"aggregations"`
+	Columns []struct {
+		Name             string `json:"name"`
+		QueryName        string `json:"queryName"`
+		AggregationIndex int    `json:"aggregationIndex"`
+		ColumnType       string `json:"columnType"`
+	} `json:"columns"`
+	Data [][]any `json:"data"`
+}
+
+// summarizeWindow flattens a QB v5 response into comparable series. Points
+// of a time series are averaged; scalar rows are used as-is. Values that are
+// not finite numbers are skipped.
+func summarizeWindow(data json.RawMessage) map[string]windowSeries {
+	out := make(map[string]windowSeries)
+	var env qbResultEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return out
+	}
+	for _, res := range env.Data.Data.Results {
+		for _, agg := range res.Aggregations {
+			for _, series := range agg.Series {
+				labels := make(map[string]any, len(series.Labels))
+				for _, l := range series.Labels {
+					labels[l.Key.Name] = l.Value
+				}
+				var sum float64
+				var n int
+				for _, v := range series.Values {
+					if f, ok := finiteFloat(v.Value); ok {
+						sum += f
+						n++
+					}
+				}
+				if n == 0 {
+					continue
+				}
+				s := windowSeries{query: res.QueryName, aggregation: agg.Index, labels: labels, value: sum / float64(n)}
+				out[s.key()] = s
+			}
+		}
+		for _, row := range res.Data {
+			labels := make(map[string]any)
+			for i, col := range res.Columns {
+				if col.ColumnType == "group" && i < len(row) {
+					labels[col.Name] = row[i]
+				}
+			}
+			for i, col := range res.Columns {
+				if col.ColumnType != "aggregation" || i >= len(row) {
+					continue
+				}
+				f, ok := finiteFloat(row[i])
+				if !ok {
+					continue
+				}
+				query := col.QueryName
+				if query == "" {
+					query = res.QueryName
+				}
+				s := windowSeries{query: query, aggregation: col.AggregationIndex, labels: labels, value: f}
+				out[s.key()] = s
+			}
+		}
+	}
+	return out
+}
+
+func (s windowSeries) key() string {
+	names := make([]string, 0, len(s.labels))
+	for name := range s.labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s\x00%d", s.query, s.aggregation)
+	for _, name := range names {
+		fmt.Fprintf(&b, "\x00%s=%v", name, s.labels[name])
+	}
+	return b.String()
+}
+
+// computeWindowDeltas aligns the two responses and returns deltas sorted by
+// key, plus the number of series present in only one window.
+func computeWindowDeltas(baseline, comparison json.RawMessage) ([]seriesDelta, int) {
+	base := summarizeWindow(baseline)
+	cmp := summarizeWindow(comparison)
+
+	keys := make([]string, 0, len(base))
+	unmatched := 0
+	for k := range base {
+		if _, ok := cmp[k]; ok {
+			keys = append(keys, k)
+		} else {
+			unmatched++
+		}
+	}
+	for k := range cmp {
+		if _, ok := base[k]; !ok {
+			unmatched++
+		}
+	}
+	sort.Strings(keys)
+
+	deltas := make([]seriesDelta, 0, len(keys))
+	for _, k := range keys {
+		b, c := base[k], cmp[k]
+		d := seriesDelta{
+			Query:       b.query,
+			Aggregation: b.aggregation,
+			Labels:      b.labels,
+			Baseline:    b.value,
+			Comparison:  c.value,
+			Change:      c.value - b.value,
+		}
+		if len(d.Labels) == 0 {
+			d.Labels = nil
+		}
+		if b.value != 0 {
+			pct := (c.value - b.value) / math.Abs(b.value) * 100
+			d.PercentChange = &pct
+		}
+		deltas = append(deltas, d)
+	}
+	return deltas, unmatched
+}
+
+func finiteFloat(v any) (float64, bool) {
+	var f float64
+	switch n := v.(type) {
+	case float64:
+		f = n
+	case json.Number:
+		parsed, err := n.Float64()
+		if err != nil {
+			return 0, false
+		}
+		f = parsed
+	case string:
+		parsed, err := strconv.ParseFloat(n, 64)
+		if err != nil {
+			return 0, false
+		}
+		f = parsed
+	default:
+		return 0, false
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, false
+	}
+	return f, true
+}
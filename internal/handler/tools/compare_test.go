@@ -0,0 +1,225 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func compareTestQuery(requestType string) map[string]any {
+	return map[string]any{
+		"schemaVersion": "v1",
+		"requestType":   requestType,
+		"compositeQuery": map[string]any{
+			"queries": []any{
+				map[string]any{
+					"type": "builder_query",
+					"spec": map[string]any{
+						"name":         "A",
+						"signal":       "traces",
+						"aggregations": []any{map[string]any{"expression": "p99(duration_nano)"}},
+						"groupBy":      []any{map[string]any{"name": "service.name"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func timeSeriesBody(points map[string][]float64) string {
+	var series []string
+	for svc, values := range points {
+		var vals []string
+		for i, v := range values {
+			vals = append(vals, fmt.Sprintf(`{"timestamp":%d,"value":%g}`, 1000*(i+1), v))
+		}
+		series = append(series, fmt.Sprintf(`{"labels":[{"key":{"name":"service.name"},"value":%q}],"values":[%s]}`, svc, strings.Join(vals, ",")))
+	}
+	return fmt.Sprintf(`{"status":"success","data":{"type":"time_series","data":{"results":[{"queryName":"A","aggregations":[{"index":0,"series":[%s]}]}]}}}`, strings.Join(series, ","))
+}
+
+func TestHandleCompareTimeWindows_TimeSeriesDeltas(t *testing.T) {
+	// The windows are queried concurrently, so payloads are recorded under a
+	// lock and told apart by start time.
+	var mu sync.Mutex
+	var payloads []types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			if err := json.Unmarshal(body, &p); err != nil {
+				return nil, err
+			}
+			mu.Lock()
+			payloads = append(payloads, p)
+			mu.Unlock()
+			if p.Start == 1711000000000 {
+				return json.RawMessage(timeSeriesBody(map[string][]float64{"frontend": {100, 200}, "cart": {0, 0}, "gone": {5}})), nil
+			}
+			return json.RawMessage(timeSeriesBody(map[string][]float64{"frontend": {300, 300}, "cart": {10, 10}})), nil
+		},
+	}
+	h := newTestHandler(mock)
+	result, err := h.handleCompareTimeWindows(testCtx(), makeToolRequest("signoz_compare_time_windows", map[string]any{
+		"query":           compareTestQuery("time_series"),
+		"baselineStart":   "1711000000000",
+		"baselineEnd":     "1711003600000",
+		"comparisonStart": "1711100000", // seconds, normalized to ms
+		"comparisonEnd":   "1711103600",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", allTextBlocks(result))
+	}
+	if len(payloads) != 2 {
+		t.Fatalf("QueryBuilderV5 calls = %d, want 2", len(payloads))
+	}
+	sort.Slice(payloads, func(i, j int) bool { return payloads[i].Start < payloads[j].Start })
+	if payloads[0].Start != 1711000000000 || payloads[0].End != 1711003600000 {
+		t.Fatalf("baseline window = %d..%d", payloads[0].Start, payloads[0].End)
+	}
+	if payloads[1].Start != 1711100000000 || payloads[1].End != 1711103600000 {
+		t.Fatalf("comparison window = %d..%d", payloads[1].Start, payloads[1].End)
+	}
+
+	var resp compareResponse
+	if err := json.Unmarshal([]byte(allTextBlocks(result)[0]), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(resp.Deltas) != 2 {
+		t.Fatalf("deltas = %+v, want 2 aligned series", resp.Deltas)
+	}
+	bySvc := map[string]seriesDelta{}
+	for _, d := range resp.Deltas {
+		bySvc[fmt.Sprint(d.Labels["service.name"])] = d
+	}
+	fe := bySvc["frontend"]
+	if fe.Baseline != 150 || fe.Comparison != 300 || fe.Change != 150 || fe.PercentChange == nil || *fe.PercentChange != 100 {
+		t.Fatalf("frontend delta = %+v", fe)
+	}
+	if cart := bySvc["cart"]; cart.PercentChange != nil || cart.Change != 10 {
+		t.Fatalf("cart delta = %+v, want nil percentChange for a zero baseline", cart)
+	}
+	if notes := allTextBlocks(result); len(notes) != 2 || !strings.Contains(notes[1], "1 series appear in only one window") {
+		t.Fatalf("notes = %v, want unmatched-series note", notes)
+	}
+}
+
+func TestHandleCompareTimeWindows_CapsRawResults(t *testing.T) {
+	baseline := map[string][]float64{}
+	comparison := map[string][]float64{}
+	for i := 0; i < 40; i++ {
+		svc := fmt.Sprintf("service-%02d", i)
+		baseline[svc] = []float64{1, 2, 3, 4}
+		comparison[svc] = []float64{2, 4, 6, 8}
+	}
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			if err := json.Unmarshal(body, &p); err != nil {
+				return nil, err
+			}
+			if p.Start == 1711000000000 {
+				return json.RawMessage(timeSeriesBody(baseline)), nil
+			}
+			return json.RawMessage(timeSeriesBody(comparison)), nil
+		},
+	}
+	h := newTestHandler(mock)
+	h.maxResponseBytes = 12000
+	result, err := h.handleCompareTimeWindows(testCtx(), makeToolRequest("signoz_compare_time_windows", map[string]any{
+		"query":           compareTestQuery("time_series"),
+		"baselineStart":   "1711000000000",
+		"baselineEnd":     "1711003600000",
+		"comparisonStart": "1711100000000",
+		"comparisonEnd":   "1711103600000",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", allTextBlocks(result))
+	}
+	blocks := allTextBlocks(result)
+	if len(blocks[0]) > h.maxResponseBytes {
+		t.Fatalf("response is %d bytes, want at most %d", len(blocks[0]), h.maxResponseBytes)
+	}
+
+	var resp compareResponse
+	if err := json.Unmarshal([]byte(blocks[0]), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if len(resp.Deltas) != 40 {
+		t.Fatalf("deltas = %d, want all 40 series from the uncapped results", len(resp.Deltas))
+	}
+	for name, raw := range map[string]json.RawMessage{"baseline": resp.Baseline.Result, "comparison": resp.Comparison.Result} {
+		var marker struct {
+			Truncated bool `json:"truncated"`
+		}
+		if err := json.Unmarshal(raw, &marker); err != nil || !marker.Truncated {
+			t.Fatalf("%s result not marked truncated: %s", name, raw)
+		}
+	}
+	if len(blocks) != 3 || !strings.HasPrefix(blocks[1], "note: baseline result exceeded") || !strings.HasPrefix(blocks[2], "note: comparison result exceeded") {
+		t.Fatalf("notes = %v, want a truncation note per window", blocks[1:])
+	}
+}
+
+func TestComputeWindowDeltas_Scalar(t *testing.T) {
+	scalar := func(v float64) json.RawMessage {
+		return json.RawMessage(fmt.Sprintf(`{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A","columns":[{"name":"service.name","queryName":"A","columnType":"group"},{"name":"__result_0","queryName":"A","aggregationIndex":0,"columnType":"aggregation"}],"data":[["api",%g]]}]}}}`, v))
+	}
+	deltas, unmatched := computeWindowDeltas(scalar(40), scalar(30))
+	if unmatched != 0 || len(deltas) != 1 {
+		t.Fatalf("deltas = %+v unmatched = %d", deltas, unmatched)
+	}
+	if d := deltas[0]; d.Labels["service.name"] != "api" || d.Change != -10 || *d.PercentChange != -25 {
+		t.Fatalf("delta = %+v", d)
+	}
+}
+
+func TestHandleCompareTimeWindows_Validation(t *testing.T) {
+	valid := func() map[string]any {
+		return map[string]any{
+			"query":           compareTestQuery("time_series"),
+			"baselineStart":   "1711000000000",
+			"baselineEnd":     "1711003600000",
+			"comparisonStart": "1711100000000",
+			"comparisonEnd":   "1711103600000",
+		}
+	}
+	cases := map[string]func(map[string]any){
+		"missing comparison": func(a map[string]any) { delete(a, "comparisonEnd") },
+		"malformed baseline": func(a map[string]any) { a["baselineStart"] = "yesterday" },
+		"inverted window":    func(a map[string]any) { a["baselineStart"], a["baselineEnd"] = a["baselineEnd"], a["baselineStart"] },
+		"raw request type":   func(a map[string]any) { a["query"] = compareTestQuery("raw") },
+		"query not object":   func(a map[string]any) { a["query"] = "A" },
+	}
+	for name, mutate := range cases {
+		t.Run(name, func(t *testing.T) {
+			mock := &client.MockClient{
+				QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+					t.Fatal("upstream must not be called for invalid input")
+					return nil, nil
+				},
+			}
+			args := valid()
+			mutate(args)
+			result, err := newTestHandler(mock).handleCompareTimeWindows(testCtx(), makeToolRequest("signoz_compare_time_windows", args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code := resultCode(t, result); code != CodeValidationFailed {
+				t.Fatalf("resultCode = %q, want %q", code, CodeValidationFailed)
+			}
+		})
+	}
+}
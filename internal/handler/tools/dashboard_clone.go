@@ -0,0 +1,147 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/SigNoz/signoz-mcp-server/pkg/dashboard"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+func (h *Handler) handleCloneDashboard(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	source := readResourceID(args, "uuid")
+	if source == "" {
+		return errorWithCode(CodeValidationFailed, `Parameter validation failed: "id" is required. Provide the UUID of the dashboard to clone. Use signoz_list_dashboards tool to see available dashboards.`), nil
+	}
+	title, errResult := requireStringArg(args, "title")
+	if errResult != nil {
+		return errResult, nil
+	}
+	title = strings.TrimSpace(title)
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_clone_dashboard", slog.String("id", source))
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	raw, err := client.GetDashboard(ctx, source)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to get dashboard", err, slog.String("uuid", source))
+		return upstreamError(err), nil
+	}
+	data, err := dashboardDocument(raw)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to parse dashboard response", logpkg.ErrAttr(err))
+		return upstreamResponseError("failed to parse dashboard: " + err.Error()), nil
+	}
+
+	cloneDashboardData(data, title)
+
+	cleanJSON, err := dashboard.ValidateFromMap(data)
+	if err != nil {
+		h.logger.WarnContext(ctx, "Cloned dashboard validation failed", slog.String("uuid", source), logpkg.ErrAttr(err))
+		return upstreamResponseError(fmt.Sprintf("Source dashboard validation error: %s", err.Error())), nil
+	}
+	created, err := client.CreateDashboardRaw(ctx, cleanJSON)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to create cloned dashboard", err, slog.String("uuid", source))
+		return upstreamError(err), nil
+	}
+	return mcp.NewToolResultText(string(created)), nil
+}
+
+// dashboardDocument returns the dashboard definition from a get-dashboard
+// response, which nests it either as data or as data.data.
+func dashboardDocument(raw []byte) (map[string]any, error) {
+	var env struct {
+		Data map[string]any `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return nil, err
+	}
+	if inner, ok := env.Data["data"].(map[string]any); ok {
+		return inner, nil
+	}
+	if env.Data == nil {
+		return nil, fmt.Errorf("response has no dashboard data")
+	}
+	return env.Data, nil
+}
+
+// cloneDashboardData turns a stored dashboard definition into a new one
+// titled title. The dashboard's own identifiers are dropped so SigNoz assigns
+// fresh ones, and every widget, query, and variable gets a new ID. Layout and
+// panelMap entries follow their widget's new ID so rows and positions survive.
+func cloneDashboardData(data map[string]any, title string) {
+	delete(data, "id")
+	delete(data, "uuid")
+	data["title"] = title
+
+	ids := map[string]string{}
+	widgets, _ := data["widgets"].([]any)
+	for _, item := range widgets {
+		w, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		newID := uuid.NewString()
+		if old, ok := w["id"].(string); ok && old != "" {
+			ids[old] = newID
+		}
+		w["id"] = newID
+		if q, ok := w["query"].(map[string]any); ok {
+			if _, has := q["id"]; has {
+				q["id"] = uuid.NewString()
+			}
+		}
+	}
+
+	remapLayout := func(items []any) {
+		for _, item := range items {
+			l, ok := item.(map[string]any)
+			if !ok {
+				continue
+			}
+			if old, ok := l["i"].(string); ok {
+				if newID, ok := ids[old]; ok {
+					l["i"] = newID
+				}
+			}
+		}
+	}
+	layout, _ := data["layout"].([]any)
+	remapLayout(layout)
+
+	if panelMap, ok := data["panelMap"].(map[string]any); ok {
+		remapped := make(map[string]any, len(panelMap))
+		for key, entry := range panelMap {
+			if e, ok := entry.(map[string]any); ok {
+				rows, _ := e["widgets"].([]any)
+				remapLayout(rows)
+			}
+			if newID, ok := ids[key]; ok {
+				key = newID
+			}
+			remapped[key] = entry
+		}
+		data["panelMap"] = remapped
+	}
+
+	if variables, ok := data["variables"].(map[string]any); ok {
+		for _, item := range variables {
+			if v, ok := item.(map[string]any); ok {
+				v["id"] = uuid.NewString()
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+const cloneSourceBody = `{"status":"success","data":{"id":"src","createdBy":"me@example.com","data":{"title":"Prod API","uuid":"src","tags":["api"],
+	"variables":{"env":{"id":"var-1","name":"env","type":"CUSTOM","customValue":"prod,staging","sort":"DISABLED"}},
+	"layout":[{"i":"row-1","x":0,"y":0,"w":12,"h":1},{"i":"w-cpu","x":0,"y":1,"w":6,"h":6}],
+	"panelMap":{"row-1":{"collapsed":false,"widgets":[{"i":"w-cpu","x":0,"y":1,"w":6,"h":6}]}},
+	"widgets":[
+		{"id":"row-1","title":"Hosts","panelTypes":"row"},
+		{"id":"w-cpu","title":"CPU","panelTypes":"graph","query":{"id":"q-1","queryType":"promql","promql":[{"name":"A","query":"sum(rate(cpu[5m]))","disabled":false,"legend":""}],"builder":{"queryData":[],"queryFormulas":[]},"clickhouse_sql":[]}}
+	]}}}`
+
+func TestHandleCloneDashboard(t *testing.T) {
+	var created map[string]any
+	mock := &client.MockClient{
+		GetDashboardFn: func(ctx context.Context, uuid string) (json.RawMessage, error) {
+			assert.Equal(t, "src", uuid)
+			return json.RawMessage(cloneSourceBody), nil
+		},
+		CreateDashboardRawFn: func(ctx context.Context, dashboardJSON []byte) (json.RawMessage, error) {
+			require.NoError(t, json.Unmarshal(dashboardJSON, &created))
+			return json.RawMessage(`{"status":"success","data":{"id":"new-uuid"}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleCloneDashboard(testCtx(), makeToolRequest("signoz_clone_dashboard", map[string]any{
+		"uuid": "src", "title": " Staging API ",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Contains(t, textContent(t, result), "new-uuid")
+
+	require.NotNil(t, created)
+	assert.Equal(t, "Staging API", created["title"])
+	assert.Equal(t, []any{"api"}, created["tags"])
+	assert.NotContains(t, created, "uuid")
+	assert.NotContains(t, created, "id")
+
+	widgets := created["widgets"].([]any)
+	require.Len(t, widgets, 2)
+	rowID := widgets[0].(map[string]any)["id"].(string)
+	cpu := widgets[1].(map[string]any)
+	cpuID := cpu["id"].(string)
+	assert.NotEqual(t, "row-1", rowID)
+	assert.NotEqual(t, "w-cpu", cpuID)
+	assert.NotEqual(t, "q-1", cpu["query"].(map[string]any)["id"])
+
+	layout := created["layout"].([]any)
+	assert.Equal(t, rowID, layout[0].(map[string]any)["i"])
+	assert.Equal(t, cpuID, layout[1].(map[string]any)["i"])
+
+	panelMap := created["panelMap"].(map[string]any)
+	require.Contains(t, panelMap, rowID)
+	rowWidgets := panelMap[rowID].(map[string]any)["widgets"].([]any)
+	assert.Equal(t, cpuID, rowWidgets[0].(map[string]any)["i"])
+
+	env := created["variables"].(map[string]any)["env"].(map[string]any)
+	assert.NotEqual(t, "var-1", env["id"])
+	assert.Equal(t, "prod,staging", env["customValue"])
+}
+
+func TestHandleCloneDashboard_Validation(t *testing.T) {
+	h := newTestHandler(&client.MockClient{
+		CreateDashboardRawFn: func(ctx context.Context, dashboardJSON []byte) (json.RawMessage, error) {
+			t.Fatal("CreateDashboardRaw should not be called")
+			return nil, nil
+		},
+	})
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{name: "missing id", args: map[string]any{"title": "Copy"}},
+		{name: "missing title", args: map[string]any{"id": "src"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := h.handleCloneDashboard(testCtx(), makeToolRequest("signoz_clone_dashboard", tt.args))
+			require.NoError(t, err)
+			assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+		})
+	}
+}
@@ -0,0 +1,482 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+type dashboardPanelResponse struct {
+	Dashboard struct {
+		ID    string `json:"id"`
+		Title string `json:"title,omitempty"`
+	} `json:"dashboard"`
+	Panel struct {
+		ID        string `json:"id"`
+		Title     string `json:"title"`
+		PanelType string `json:"panelType"`
+		QueryType string `json:"queryType"`
+		YAxisUnit string `json:"yAxisUnit,omitempty"`
+	} `json:"panel"`
+	RequestType string          `json:"requestType"`
+	Result      json.RawMessage `json:"result"`
+}
+
+func (h *Handler) handleGetDashboardPanel(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	uuid, errResult := requireStringArg(args, "id")
+	if errResult != nil {
+		return errResult, nil
+	}
+	panelRef, errResult := requireStringArg(args, "panel")
+	if errResult != nil {
+		return errResult, nil
+	}
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_dashboard_panel",
+		slog.String("id", uuid), slog.String("panel", panelRef))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	raw, err := client.GetDashboard(ctx, uuid)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to get dashboard", err, slog.String("uuid", uuid))
+		return upstreamError(err), nil
+	}
+
+	title, widgets, err := dashboardWidgets(raw)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to parse dashboard response", logpkg.ErrAttr(err))
+		return upstreamResponseError("failed to parse dashboard: " + err.Error()), nil
+	}
+	widget, errResult := findDashboardWidget(widgets, panelRef)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	payload, notes, err := widgetQueryPayload(widget, startTime, endTime)
+	if err != nil {
+		return validationErrorf("panel", "panel %q cannot be queried: %s", widget.Title, err.Error()), nil
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+	}
+
+	data, err := client.QueryBuilderV5(ctx, body)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to query dashboard panel", err)
+		return upstreamQueryError(err, ""), nil
+	}
+	data, _, capNote, errResult := h.capQueryResponse(ctx, "signoz_get_dashboard_panel", data)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var out dashboardPanelResponse
+	out.Dashboard.ID = uuid
+	out.Dashboard.Title = title
+	out.Panel.ID = widget.ID
+	out.Panel.Title = widget.Title
+	out.Panel.PanelType = string(widget.PanelTypes)
+	out.Panel.QueryType = string(widget.Query.QueryType)
+	out.Panel.YAxisUnit = widget.YAxisUnit
+	out.RequestType = payload.RequestType
+	out.Result = data
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal dashboard panel response", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
+	}
+
+	notes = append(notes, backendWarningNotes(ctx, h.logger, "signoz_get_dashboard_panel", data)...)
+	notes = append(notes, capNote)
+	return resultWithNotes(resp, notes...), nil
+}
+
+// dashboardWidgets returns the title and widgets of a get-dashboard body.
+// The dashboard document sits under data.data on current backends and
+// under data on older ones.
+func dashboardWidgets(raw []byte) (string, []json.RawMessage, error) {
+	type doc struct {
+		Title   string            `json:"title"`
+		Widgets []json.RawMessage `json:"widgets"`
+	}
+	var env struct {
+		Data struct {
+			doc
+			Data *doc `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return "", nil, err
+	}
+	if d := env.Data.Data; d != nil {
+		return d.Title, d.Widgets, nil
+	}
+	return env.Data.Title, env.Data.Widgets, nil
+}
+
+// findDashboardWidget matches ref against widget ids first, then titles
+// case-insensitively. A title shared by several widgets is ambiguous.
+func findDashboardWidget(widgets []json.RawMessage, ref string) (*types.Widget, *mcp.CallToolResult) {
+	ref = strings.TrimSpace(ref)
+	var byTitle []*types.Widget
+	var titles []string
+	for _, raw := range widgets {
+		var w types.Widget
+		if err := json.Unmarshal(raw, &w); err != nil {
+			continue
+		}
+		if w.ID == ref {
+			return &w, nil
+		}
+		if strings.EqualFold(strings.TrimSpace(w.Title), ref) {
+			byTitle = append(byTitle, &w)
+		}
+		if w.PanelTypes != types.PanelTypeRow {
+			titles = append(titles, fmt.Sprintf("%q (id %s)", w.Title, w.ID))
+		}
+	}
+	switch len(byTitle) {
+	case 1:
+		return byTitle[0], nil
+	case 0:
+		return nil, errorWithCode(CodeNotFound, fmt.Sprintf("no panel with id or title %q on this dashboard. Panels: %s", ref, strings.Join(titles, ", ")))
+	default:
+		ids := make([]string, len(byTitle))
+		for i, w := range byTitle {
+			ids[i] = w.ID
+		}
+		return nil, validationErrorf("panel", "title %q matches %d panels; pass one of their ids instead: %s", ref, len(byTitle), strings.Join(ids, ", "))
+	}
+}
+
+// panelRequestType maps a widget's panel type to the QB v5 request type the
+// frontend uses to render it.
+func panelRequestType(panelType types.PanelType) (string, error) {
+	switch panelType {
+	case types.PanelTypeGraph, types.PanelTypeBar, types.PanelTypeHistogram:
+		return "time_series", nil
+	case types.PanelTypeValue, types.PanelTypeTable, types.PanelTypePie:
+		return "scalar", nil
+	case types.PanelTypeList:
+		return "raw", nil
+	case types.PanelTypeTrace:
+		return "trace", nil
+	case types.PanelTypeRow:
+		return "", fmt.Errorf("row widgets are section separators and have no query")
+	default:
+		return "", fmt.Errorf("unsupported panel type %q", panelType)
+	}
+}
+
+// widgetQueryPayload converts a widget's query into a validated QB v5
+// payload over [start, end]. The returned notes describe anything the
+// conversion could not carry over.
+func widgetQueryPayload(w *types.Widget, start, end int64) (*types.QueryPayload, []string, error) {
+	requestType, err := panelRequestType(w.PanelTypes)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var queries []any
+	var notes []string
+	switch w.Query.QueryType {
+	case types.QueryTypePromQL:
+		for _, q := range w.Query.PromQL {
+			queries = append(queries, map[string]any{"type": "promql", "spec": types.PromQLSpec{
+				Name: q.Name, Query: q.Query, Disabled: q.Disabled, Legend: q.Legend,
+			}})
+		}
+	case types.QueryTypeClickHouseSQL:
+		for _, q := range w.Query.ClickHouseSQL {
+			queries = append(queries, map[string]any{"type": "clickhouse_sql", "spec": types.ClickHouseSQLSpec{
+				Name: q.Name, Query: q.Query, Disabled: q.Disabled, Legend: q.Legend,
+			}})
+		}
+	case types.QueryTypeBuilder:
+		for _, q := range w.Query.Builder.QueryData {
+			spec, err := builderQuerySpec(q, requestType)
+			if err != nil {
+				return nil, nil, err
+			}
+			queries = append(queries, map[string]any{"type": "builder_query", "spec": spec})
+		}
+		for _, f := range w.Query.Builder.QueryFormulas {
+			queries = append(queries, map[string]any{"type": "builder_formula", "spec": types.FormulaSpec{
+				Name: f.QueryName, Expression: f.Expression, Legend: f.Legend, Disabled: f.Disabled, Limit: int(f.Limit),
+			}})
+		}
+		if len(w.Query.Builder.QueryTraceOperator) > 0 {
+			notes = append(notes, "note: this panel's trace-operator queries were not run; only its builder queries and formulas are included.")
+		}
+	default:
+		return nil, nil, fmt.Errorf("unsupported query type %q", w.Query.QueryType)
+	}
+	if len(queries) == 0 {
+		return nil, nil, fmt.Errorf("the widget has no %s queries", w.Query.QueryType)
+	}
+
+	// Round-trip through JSON so each envelope decodes into its typed spec
+	// the same way signoz_execute_builder_query payloads do.
+	raw, err := json.Marshal(map[string]any{
+		"schemaVersion":  "v1",
+		"start":          start,
+		"end":            end,
+		"requestType":    requestType,
+		"compositeQuery": map[string]any{"queries": queries},
+		"formatOptions":  types.FormatOptions{},
+		"variables":      map[string]any{},
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	var payload types.QueryPayload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return nil, nil, err
+	}
+	if err := payload.Validate(); err != nil {
+		return nil, nil, err
+	}
+	if widgetUsesVariables(w) {
+		notes = append(notes, "note: this panel references dashboard variables, which are not substituted here; results may differ from the dashboard view or the query may fail.")
+	}
+	return &payload, notes, nil
+}
+
+// widgetUsesVariables reports whether any query text or filter of w refers
+// to a dashboard variable ($name, or {{.name}} in ClickHouse SQL).
+func widgetUsesVariables(w *types.Widget) bool {
+	var texts []string
+	for _, q := range w.Query.PromQL {
+		texts = append(texts, q.Query)
+	}
+	for _, q := range w.Query.ClickHouseSQL {
+		texts = append(texts, q.Query)
+	}
+	for _, q := range w.Query.Builder.QueryData {
+		if q.Filter != nil {
+			texts = append(texts, q.Filter.Expression)
+		}
+		for _, item := range q.Filters.Items {
+			texts = append(texts, fmt.Sprint(item.Value))
+		}
+	}
+	for _, t := range texts {
+		if strings.Contains(t, "$") || strings.Contains(t, "{{.") {
+			return true
+		}
+	}
+	return false
+}
+
+// builderQuerySpec converts one dashboard builder query into a v5
+// builder_query spec. Widgets saved by older frontends carry
+// aggregateOperator/aggregateAttribute and filters.items instead of
+// aggregations and filter.expression; both shapes are accepted.
+func builderQuerySpec(q types.BuilderQuery, requestType string) (types.QuerySpec, error) {
+	signal := string(q.DataSource)
+	spec := types.QuerySpec{
+		Name:     q.QueryName,
+		Signal:   signal,
+		Source:   q.Source,
+		Disabled: q.Disabled,
+		Limit:    int(q.Limit),
+		Offset:   int(q.Offset),
+		Legend:   q.Legend,
+	}
+	if requestType == "time_series" && q.StepInterval != nil && *q.StepInterval > 0 {
+		step := *q.StepInterval
+		spec.StepInterval = &step
+	}
+	if requestType == "raw" && spec.Limit == 0 {
+		spec.Limit = int(q.PageSize)
+	}
+
+	filterExpr := ""
+	if q.Filter != nil {
+		filterExpr = strings.TrimSpace(q.Filter.Expression)
+	}
+	if filterExpr == "" {
+		expr, err := legacyFilterExpr(q.Filters)
+		if err != nil {
+			return spec, err
+		}
+		filterExpr = expr
+	}
+	if filterExpr != "" {
+		spec.Filter = &types.Filter{Expression: filterExpr}
+	}
+
+	if having, ok := q.Having.(map[string]any); ok {
+		if expr, _ := having["expression"].(string); expr != "" {
+			spec.Having = types.Having{Expression: expr}
+		}
+	}
+
+	if requestType != "raw" && requestType != "trace" {
+		aggs, err := builderAggregations(q)
+		if err != nil {
+			return spec, err
+		}
+		spec.Aggregations = aggs
+	}
+	for _, g := range q.GroupBy {
+		spec.GroupBy = append(spec.GroupBy, attributeSelectField(g))
+	}
+	for _, c := range q.SelectColumns {
+		spec.SelectFields = append(spec.SelectFields, attributeSelectField(c))
+	}
+	for _, o := range q.OrderBy {
+		// #SIGNOZ_VALUE is the pre-v5 name for the aggregation result; let
+		// validation pick the default order instead.
+		if o.ColumnName == "" || o.ColumnName == "#SIGNOZ_VALUE" {
+			continue
+		}
+		spec.Order = append(spec.Order, types.Order{Key: types.Key{Name: o.ColumnName}, Direction: strings.ToLower(o.Order)})
+	}
+	for _, fn := range q.Functions {
+		b, err := json.Marshal(fn)
+		if err != nil {
+			return spec, err
+		}
+		spec.Functions = append(spec.Functions, b)
+	}
+	return spec, nil
+}
+
+func builderAggregations(q types.BuilderQuery) ([]any, error) {
+	var out []any
+	for _, a := range q.Aggregations {
+		if q.DataSource == types.DataSourceMetrics {
+			m := types.MetricAggregation{
+				MetricName:       a.MetricName,
+				TimeAggregation:  string(a.TimeAggregation),
+				SpaceAggregation: string(a.SpaceAggregation),
+				ReduceTo:         string(a.ReduceTo),
+			}
+			if a.Temporality != nil {
+				m.Temporality = string(*a.Temporality)
+			}
+			out = append(out, m)
+			continue
+		}
+		if a.Expression != "" {
+			out = append(out, types.QueryAggregation{Expression: a.Expression})
+		}
+	}
+	if len(out) > 0 {
+		return out, nil
+	}
+
+	op := strings.ToLower(string(q.AggregateOperator))
+	attr := q.AggregateAttribute.Key
+	if attr == "" {
+		attr = q.AggregateAttribute.Name
+	}
+	if q.DataSource == types.DataSourceMetrics {
+		if attr == "" {
+			return nil, fmt.Errorf("query %s has no metric name", q.QueryName)
+		}
+		return []any{types.MetricAggregation{
+			MetricName:       attr,
+			Temporality:      string(q.Temporality),
+			TimeAggregation:  string(q.TimeAggregation),
+			SpaceAggregation: string(q.SpaceAggregation),
+			ReduceTo:         string(q.ReduceTo),
+		}}, nil
+	}
+	switch {
+	case op == "" || op == "noop" || (op == "count" && attr == ""):
+		return []any{types.QueryAggregation{Expression: "count()"}}, nil
+	case attr == "":
+		return nil, fmt.Errorf("query %s uses %s without an attribute", q.QueryName, op)
+	default:
+		return []any{types.QueryAggregation{Expression: fmt.Sprintf("%s(%s)", op, attr)}}, nil
+	}
+}
+
+func attributeSelectField(k types.AttributeKey) types.SelectField {
+	name := k.Key
+	if name == "" {
+		name = k.Name
+	}
+	dataType := k.FieldDataType
+	if dataType == "" {
+		dataType = k.DataType
+	}
+	return types.SelectField{Name: name, FieldDataType: dataType, Signal: k.Signal, FieldContext: k.FieldContext}
+}
+
+// legacyFilterOps maps pre-v5 filters.items operators to filter-expression
+// operators.
+var legacyFilterOps = map[string]string{
+	"=": "=", "!=": "!=", ">": ">", ">=": ">=", "<": "<", "<=": "<=",
+	"in": "IN", "nin": "NOT IN", "not_in": "NOT IN",
+	"like": "LIKE", "nlike": "NOT LIKE", "not_like": "NOT LIKE",
+	"ilike": "ILIKE", "nilike": "NOT ILIKE", "not_ilike": "NOT ILIKE",
+	"contains": "CONTAINS", "ncontains": "NOT CONTAINS", "not_contains": "NOT CONTAINS",
+	"regex": "REGEXP", "nregex": "NOT REGEXP", "not_regex": "NOT REGEXP",
+	"exists": "EXISTS", "nexists": "NOT EXISTS", "not_exists": "NOT EXISTS",
+}
+
+// legacyFilterExpr renders a pre-v5 structured filter as a filter expression.
+func legacyFilterExpr(fs types.FilterSet) (string, error) {
+	var parts []string
+	for _, item := range fs.Items {
+		key := item.Key.Key
+		if key == "" {
+			key = item.Key.Name
+		}
+		op, ok := legacyFilterOps[strings.ToLower(strings.TrimSpace(item.Op))]
+		if !ok || key == "" {
+			return "", fmt.Errorf("cannot convert filter item %q %q", key, item.Op)
+		}
+		if op == "EXISTS" || op == "NOT EXISTS" {
+			parts = append(parts, key+" "+op)
+			continue
+		}
+		parts = append(parts, key+" "+op+" "+legacyFilterValue(item.Value, op == "IN" || op == "NOT IN"))
+	}
+	joiner := " AND "
+	if strings.EqualFold(fs.Op, "OR") {
+		joiner = " OR "
+	}
+	return strings.Join(parts, joiner), nil
+}
+
+func legacyFilterValue(v any, list bool) string {
+	switch x := v.(type) {
+	case []any:
+		vals := make([]string, len(x))
+		for i, e := range x {
+			vals[i] = legacyFilterValue(e, false)
+		}
+		return "(" + strings.Join(vals, ", ") + ")"
+	case string:
+		quoted := quoteFilterString(x)
+		if list {
+			return "(" + quoted + ")"
+		}
+		return quoted
+	default:
+		return fmt.Sprint(x)
+	}
+}
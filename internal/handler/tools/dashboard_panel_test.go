@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const panelDashboardBody = `{"status":"success","data":{"id":"d1","data":{"title":"API","widgets":[
+	{"id":"w-lat","title":"P99 latency","panelTypes":"graph","yAxisUnit":"ns","query":{"queryType":"builder","builder":{"queryData":[
+		{"queryName":"A","dataSource":"traces","stepInterval":60,"aggregations":[{"expression":"p99(duration_nano)"}],
+		 "filter":{"expression":"service.name = 'api'"},"groupBy":[{"key":"http.route","dataType":"string","type":"tag"}],"legend":"{{http.route}}","orderBy":[]}
+	],"queryFormulas":[]}}},
+	{"id":"w-err","title":"Errors","panelTypes":"value","query":{"queryType":"builder","builder":{"queryData":[
+		{"queryName":"A","dataSource":"logs","aggregateOperator":"count","aggregateAttribute":{"key":""},
+		 "filters":{"op":"AND","items":[{"key":{"key":"severity_text"},"op":"in","value":["ERROR","FATAL"]},{"key":{"key":"service.name"},"op":"=","value":"$service"}]}}
+	],"queryFormulas":[]}}},
+	{"id":"w-cpu","title":"CPU","panelTypes":"graph","query":{"queryType":"promql","promql":[{"name":"A","query":"sum(rate(cpu[5m]))","disabled":false}]}},
+	{"id":"w-dup1","title":"Dup","panelTypes":"graph","query":{"queryType":"promql","promql":[{"name":"A","query":"up"}]}},
+	{"id":"w-dup2","title":"dup","panelTypes":"graph","query":{"queryType":"promql","promql":[{"name":"A","query":"up"}]}}
+]}}}`
+
+func panelMock(t *testing.T, payloads *[]types.QueryPayload) *client.MockClient {
+	return &client.MockClient{
+		GetDashboardFn: func(ctx context.Context, uuid string) (json.RawMessage, error) {
+			assert.Equal(t, "d1", uuid)
+			return json.RawMessage(panelDashboardBody), nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &p))
+			*payloads = append(*payloads, p)
+			return json.RawMessage(`{"status":"success","data":{"type":"time_series","data":{"results":[]}}}`), nil
+		},
+	}
+}
+
+func TestHandleGetDashboardPanel_BuilderByTitle(t *testing.T) {
+	var payloads []types.QueryPayload
+	h := newTestHandler(panelMock(t, &payloads))
+
+	result, err := h.handleGetDashboardPanel(testCtx(), makeToolRequest("signoz_get_dashboard_panel", map[string]any{
+		"id": "d1", "panel": "p99 LATENCY", "timeRange": "6h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	require.Len(t, payloads, 1)
+	p := payloads[0]
+	assert.Equal(t, "time_series", p.RequestType)
+	assert.Equal(t, int64(6*60*60*1000), p.End-p.Start)
+	spec := p.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, "traces", spec.Signal)
+	assert.Equal(t, "service.name = 'api'", spec.Filter.Expression)
+	require.NotNil(t, spec.StepInterval)
+	assert.Equal(t, int64(60), *spec.StepInterval)
+	require.Len(t, spec.GroupBy, 1)
+	assert.Equal(t, "http.route", spec.GroupBy[0].Name)
+
+	var out dashboardPanelResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "API", out.Dashboard.Title)
+	assert.Equal(t, "w-lat", out.Panel.ID)
+	assert.Equal(t, "ns", out.Panel.YAxisUnit)
+	assert.JSONEq(t, `{"status":"success","data":{"type":"time_series","data":{"results":[]}}}`, string(out.Result))
+	assert.Len(t, result.Content, 1, "no variable note expected for a legend placeholder")
+}
+
+func TestHandleGetDashboardPanel_LegacyBuilderWidget(t *testing.T) {
+	var payloads []types.QueryPayload
+	h := newTestHandler(panelMock(t, &payloads))
+
+	result, err := h.handleGetDashboardPanel(testCtx(), makeToolRequest("signoz_get_dashboard_panel", map[string]any{
+		"id": "d1", "panel": "w-err",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	require.Len(t, payloads, 1)
+	assert.Equal(t, "scalar", payloads[0].RequestType)
+	spec := payloads[0].CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, "severity_text IN ('ERROR', 'FATAL') AND service.name = '$service'", spec.Filter.Expression)
+	require.Len(t, spec.Aggregations, 1)
+	agg, _ := json.Marshal(spec.Aggregations[0])
+	assert.JSONEq(t, `{"expression":"count()"}`, string(agg))
+	require.Greater(t, len(result.Content), 1)
+	assert.Contains(t, result.Content[1].(mcp.TextContent).Text, "dashboard variables")
+}
+
+func TestHandleGetDashboardPanel_PromQL(t *testing.T) {
+	var payloads []types.QueryPayload
+	h := newTestHandler(panelMock(t, &payloads))
+
+	result, err := h.handleGetDashboardPanel(testCtx(), makeToolRequest("signoz_get_dashboard_panel", map[string]any{
+		"id": "d1", "panel": "CPU",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	require.Len(t, payloads, 1)
+	q := payloads[0].CompositeQuery.Queries[0]
+	assert.Equal(t, "promql", q.Type)
+	assert.Equal(t, "sum(rate(cpu[5m]))", q.Spec.(types.PromQLSpec).Query)
+}
+
+func TestHandleGetDashboardPanel_NotFoundAndAmbiguous(t *testing.T) {
+	var payloads []types.QueryPayload
+	h := newTestHandler(panelMock(t, &payloads))
+
+	result, err := h.handleGetDashboardPanel(testCtx(), makeToolRequest("signoz_get_dashboard_panel", map[string]any{
+		"id": "d1", "panel": "Memory",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeNotFound, resultCode(t, result))
+	assert.Contains(t, textContent(t, result), `"CPU" (id w-cpu)`)
+
+	result, err = h.handleGetDashboardPanel(testCtx(), makeToolRequest("signoz_get_dashboard_panel", map[string]any{
+		"id": "d1", "panel": "Dup",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	assert.Contains(t, textContent(t, result), "w-dup1, w-dup2")
+	assert.Empty(t, payloads)
+}
@@ -0,0 +1,294 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	defaultDashboardVariableValues = 100
+	maxDashboardVariableValues     = 1000
+)
+
+type dashboardVariable struct {
+	Name          string `json:"name"`
+	Type          string `json:"type"`
+	Description   string `json:"description,omitempty"`
+	MultiSelect   bool   `json:"multiSelect"`
+	ShowALLOption bool   `json:"showAllOption"`
+	SelectedValue any    `json:"selectedValue,omitempty"`
+	DefaultValue  string `json:"defaultValue,omitempty"`
+	// Query is the ClickHouse query of a QUERY variable.
+	Query string `json:"query,omitempty"`
+	// Attribute and Source define a DYNAMIC variable.
+	Attribute string `json:"attribute,omitempty"`
+	Source    string `json:"source,omitempty"`
+	// Values lists the choices the variable offers; nil when they were not
+	// resolved. ValuesTotal counts them before the maxValues cut.
+	Values      []any  `json:"values,omitempty"`
+	ValuesTotal int    `json:"valuesTotal,omitempty"`
+	Error       string `json:"error,omitempty"`
+	order       int
+	sort        types.VariableSort
+}
+
+type dashboardVariablesResponse struct {
+	Dashboard struct {
+		ID    string `json:"id"`
+		Title string `json:"title,omitempty"`
+	} `json:"dashboard"`
+	Variables []dashboardVariable `json:"variables"`
+}
+
+func (h *Handler) handleGetDashboardVariables(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	uuid, errResult := requireStringArg(args, "id")
+	if errResult != nil {
+		return errResult, nil
+	}
+	resolve, _, err := parseBoolArg(args, "resolveValues")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, fmt.Sprintf(`Parameter validation failed: %s`, err.Error())), nil
+	}
+	if _, present := args["resolveValues"]; !present {
+		resolve = true
+	}
+	maxValues, err := intArg(args, "maxValues", defaultDashboardVariableValues)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	maxValues = min(maxValues, maxDashboardVariableValues)
+	start, end, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_dashboard_variables", slog.String("id", uuid))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	raw, err := client.GetDashboard(ctx, uuid)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to get dashboard", err, slog.String("uuid", uuid))
+		return upstreamError(err), nil
+	}
+	title, variables, err := dashboardVariables(raw)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to parse dashboard response", logpkg.ErrAttr(err))
+		return upstreamResponseError("failed to parse dashboard: " + err.Error()), nil
+	}
+
+	out := dashboardVariablesResponse{Variables: variables}
+	out.Dashboard.ID = uuid
+	out.Dashboard.Title = title
+
+	if resolve {
+		// QUERY variables may reference other variables as {{.name}}; pass
+		// each one's current selection along with the time range.
+		queryVars := map[string]any{"SIGNOZ_START_TIME": start, "SIGNOZ_END_TIME": end}
+		for _, v := range variables {
+			if v.SelectedValue != nil {
+				queryVars[v.Name] = v.SelectedValue
+			}
+		}
+		for i := range out.Variables {
+			v := &out.Variables[i]
+			h.resolveDashboardVariable(ctx, client, v, queryVars)
+			if v.Values != nil {
+				sortVariableValues(v.Values, v.sort)
+				v.ValuesTotal = len(v.Values)
+				v.Values = v.Values[:min(len(v.Values), maxValues)]
+			}
+		}
+	}
+
+	var notes []string
+	if len(out.Variables) == 0 {
+		notes = append(notes, "note: this dashboard defines no variables.")
+	}
+	for _, v := range out.Variables {
+		if v.ValuesTotal > len(v.Values) {
+			notes = append(notes, fmt.Sprintf("note: variable %q has %d values; only the first %d are listed. Raise maxValues to see more.", v.Name, v.ValuesTotal, len(v.Values)))
+		}
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal dashboard variables", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal dashboard variables: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// dashboardVariables reads the variables of a dashboard response, ordered
+// as the dashboard shows them. A variable that does not decode is skipped.
+func dashboardVariables(raw []byte) (string, []dashboardVariable, error) {
+	type doc struct {
+		Title     string                     `json:"title"`
+		Variables map[string]json.RawMessage `json:"variables"`
+	}
+	var env struct {
+		Data struct {
+			doc
+			Data *doc `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &env); err != nil {
+		return "", nil, err
+	}
+	d := env.Data.doc
+	if env.Data.Data != nil {
+		d = *env.Data.Data
+	}
+
+	out := []dashboardVariable{}
+	for key, rawVar := range d.Variables {
+		var v types.Variable
+		if err := json.Unmarshal(rawVar, &v); err != nil {
+			continue
+		}
+		name := v.Name
+		if name == "" {
+			name = key
+		}
+		if v.Sort == "" && strings.EqualFold(string(v.Type), "DYNAMIC") {
+			v.Sort = types.VariableSortAsc
+		}
+		out = append(out, dashboardVariable{
+			Name:          name,
+			Type:          string(v.Type),
+			Description:   v.Description,
+			MultiSelect:   v.MultiSelect,
+			ShowALLOption: v.ShowALLOption,
+			SelectedValue: v.SelectedValue,
+			DefaultValue:  v.DefaultValue,
+			Query:         v.QueryValue,
+			Attribute:     v.DynamicVariablesAttribute,
+			Source:        v.DynamicVariablesSource,
+			Values:        staticVariableValues(v),
+			order:         v.Order,
+			sort:          v.Sort,
+		})
+	}
+	slices.SortFunc(out, func(a, b dashboardVariable) int {
+		if c := cmp.Compare(a.order, b.order); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+	return d.Title, out, nil
+}
+
+// staticVariableValues returns the choices of a variable that need no query:
+// the comma-separated list of a CUSTOM variable or a TEXTBOX's text.
+func staticVariableValues(v types.Variable) []any {
+	switch strings.ToUpper(string(v.Type)) {
+	case "CUSTOM":
+		values := []any{}
+		for _, part := range strings.Split(v.CustomValue, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+		return values
+	case "TEXTBOX":
+		if v.TextboxValue != "" {
+			return []any{v.TextboxValue}
+		}
+		return []any{}
+	}
+	return nil
+}
+
+// resolveDashboardVariable fills v.Values for QUERY and DYNAMIC variables.
+// A failure is recorded on the variable so the others still resolve.
+func (h *Handler) resolveDashboardVariable(ctx context.Context, client signozclient.Client, v *dashboardVariable, queryVars map[string]any) {
+	switch strings.ToUpper(v.Type) {
+	case "QUERY":
+		if strings.TrimSpace(v.Query) == "" {
+			v.Error = "the variable has no query"
+			return
+		}
+		body, err := client.QueryDashboardVariable(ctx, v.Query, queryVars)
+		if err != nil {
+			h.logUpstreamFailure(ctx, "Failed to query dashboard variable", err, slog.String("variable", v.Name))
+			v.Error = err.Error()
+			return
+		}
+		var resp struct {
+			Data struct {
+				VariableValues []any `json:"variableValues"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(body, &resp); err != nil {
+			v.Error = "unrecognized variable query response: " + err.Error()
+			return
+		}
+		v.Values = append([]any{}, resp.Data.VariableValues...)
+	case "DYNAMIC", "":
+		if v.Attribute == "" {
+			return
+		}
+		v.Values = []any{}
+		seen := map[string]bool{}
+		for _, signal := range dynamicVariableSignals(v.Source) {
+			body, err := client.GetFieldValues(ctx, signal, v.Attribute, "", "", "", "")
+			if err != nil {
+				h.logUpstreamFailure(ctx, "Failed to get dynamic variable values", err, slog.String("variable", v.Name), slog.String("signal", signal))
+				v.Error = err.Error()
+				continue
+			}
+			items, _, ok := fieldValuesList(body)
+			if !ok {
+				continue
+			}
+			for _, item := range items {
+				if key := fmt.Sprint(item); !seen[key] {
+					seen[key] = true
+					v.Values = append(v.Values, item)
+				}
+			}
+		}
+	}
+}
+
+// dynamicVariableSignals maps a DYNAMIC variable's source to the signals
+// whose field values it draws from; "All telemetry" (or no source) uses all.
+func dynamicVariableSignals(source string) []string {
+	switch strings.ToLower(strings.TrimSpace(source)) {
+	case "traces":
+		return []string{"traces"}
+	case "logs":
+		return []string{"logs"}
+	case "metrics":
+		return []string{"metrics"}
+	default:
+		return []string{"traces", "logs", "metrics"}
+	}
+}
+
+// sortVariableValues orders values as the dashboard would show them.
+func sortVariableValues(values []any, order types.VariableSort) {
+	switch strings.ToUpper(string(order)) {
+	case "ASC":
+		slices.SortStableFunc(values, func(a, b any) int { return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b)) })
+	case "DESC":
+		slices.SortStableFunc(values, func(a, b any) int { return cmp.Compare(fmt.Sprint(b), fmt.Sprint(a)) })
+	}
+}
@@ -0,0 +1,144 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+const variablesDashboardBody = `{"status":"success","data":{"id":"d1","data":{"title":"API","variables":{
+	"v-host":{"name":"host","type":"QUERY","order":2,"queryValue":"SELECT DISTINCT host FROM hosts WHERE service = {{.service}}","multiSelect":true,"showALLOption":true,"sort":"DESC"},
+	"v-svc":{"name":"service","type":"DYNAMIC","order":0,"dynamicVariablesAttribute":"service.name","dynamicVariablesSource":"All telemetry","selectedValue":"api"},
+	"v-env":{"name":"env","type":"CUSTOM","order":1,"customValue":"prod, staging ,,dev","sort":"DISABLED"},
+	"v-note":{"name":"note","type":"TEXTBOX","order":3,"textboxValue":"hello"},
+	"v-bad":{"name":"bad","type":"QUERY","order":"first"}
+}}}}`
+
+func variablesMock(t *testing.T, queries *[]map[string]any, signals *[]string) *client.MockClient {
+	return &client.MockClient{
+		GetDashboardFn: func(ctx context.Context, uuid string) (json.RawMessage, error) {
+			assert.Equal(t, "d1", uuid)
+			return json.RawMessage(variablesDashboardBody), nil
+		},
+		QueryDashboardVariableFn: func(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
+			*queries = append(*queries, variables)
+			return json.RawMessage(`{"status":"success","data":{"variableValues":["h1","h3","h2"]}}`), nil
+		},
+		GetFieldValuesFn: func(ctx context.Context, signal, name, metricName, searchText, fieldContext, source string) (json.RawMessage, error) {
+			*signals = append(*signals, signal)
+			assert.Equal(t, "service.name", name)
+			if signal == "metrics" {
+				return json.RawMessage(`{"status":"success","data":{"values":{"stringValues":["api","web"]}}}`), nil
+			}
+			return json.RawMessage(`{"status":"success","data":{"values":{"stringValues":["web","api","db"]}}}`), nil
+		},
+	}
+}
+
+func TestHandleGetDashboardVariables(t *testing.T) {
+	var queries []map[string]any
+	var signals []string
+	h := newTestHandler(variablesMock(t, &queries, &signals))
+
+	result, err := h.handleGetDashboardVariables(testCtx(), makeToolRequest("signoz_get_dashboard_variables", map[string]any{
+		"id": "d1", "start": "1700000000000", "end": "1700003600000",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out dashboardVariablesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "d1", out.Dashboard.ID)
+	assert.Equal(t, "API", out.Dashboard.Title)
+
+	names := []string{}
+	for _, v := range out.Variables {
+		names = append(names, v.Name)
+	}
+	assert.Equal(t, []string{"service", "env", "host", "note"}, names, "undecodable variables are skipped")
+
+	assert.Equal(t, []any{"api", "db", "web"}, out.Variables[0].Values, "DYNAMIC values are merged across signals and sorted ascending")
+	assert.Equal(t, []string{"traces", "logs", "metrics"}, signals)
+	assert.Equal(t, []any{"prod", "staging", "dev"}, out.Variables[1].Values)
+	assert.Equal(t, []any{"h3", "h2", "h1"}, out.Variables[2].Values)
+	assert.True(t, out.Variables[2].MultiSelect)
+	assert.Contains(t, out.Variables[2].Query, "{{.service}}")
+	assert.Equal(t, []any{"hello"}, out.Variables[3].Values)
+
+	require.Len(t, queries, 1)
+	assert.Equal(t, "api", queries[0]["service"])
+	assert.EqualValues(t, 1700000000000, queries[0]["SIGNOZ_START_TIME"])
+	assert.EqualValues(t, 1700003600000, queries[0]["SIGNOZ_END_TIME"])
+}
+
+func TestHandleGetDashboardVariables_MaxValuesAndNoResolve(t *testing.T) {
+	var queries []map[string]any
+	var signals []string
+	h := newTestHandler(variablesMock(t, &queries, &signals))
+
+	result, err := h.handleGetDashboardVariables(testCtx(), makeToolRequest("signoz_get_dashboard_variables", map[string]any{
+		"id": "d1", "maxValues": 2,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	var out dashboardVariablesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Len(t, out.Variables[0].Values, 2)
+	assert.Equal(t, 3, out.Variables[0].ValuesTotal)
+	assert.Contains(t, allTextBlocks(result)[1], `variable "service" has 3 values`)
+
+	queries, signals = nil, nil
+	result, err = h.handleGetDashboardVariables(testCtx(), makeToolRequest("signoz_get_dashboard_variables", map[string]any{
+		"id": "d1", "resolveValues": "false",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Empty(t, queries)
+	assert.Empty(t, signals)
+	out = dashboardVariablesResponse{}
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Nil(t, out.Variables[0].Values)
+	assert.Equal(t, []any{"prod", "staging", "dev"}, out.Variables[1].Values, "static values need no query")
+}
+
+func TestHandleGetDashboardVariables_QueryFailureIsPerVariable(t *testing.T) {
+	var queries []map[string]any
+	var signals []string
+	mock := variablesMock(t, &queries, &signals)
+	mock.QueryDashboardVariableFn = func(ctx context.Context, query string, variables map[string]any) (json.RawMessage, error) {
+		return nil, errors.New("code: 400, message: syntax error")
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetDashboardVariables(testCtx(), makeToolRequest("signoz_get_dashboard_variables", map[string]any{"id": "d1"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	var out dashboardVariablesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Contains(t, out.Variables[2].Error, "syntax error")
+	assert.NotEmpty(t, out.Variables[0].Values)
+}
+
+func TestHandleGetDashboardVariables_Errors(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	result, err := h.handleGetDashboardVariables(testCtx(), makeToolRequest("signoz_get_dashboard_variables", map[string]any{}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+
+	h = newTestHandler(&client.MockClient{
+		GetDashboardFn: func(ctx context.Context, uuid string) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"title":"Empty"}}`), nil
+		},
+	})
+	result, err = h.handleGetDashboardVariables(testCtx(), makeToolRequest("signoz_get_dashboard_variables", map[string]any{"id": "d1"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Contains(t, textContent(t, result), `"variables":[]`)
+	assert.Contains(t, allTextBlocks(result)[1], "defines no variables")
+}
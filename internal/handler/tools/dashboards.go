@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"regexp"
 	"strings"
 	"time"
 
@@ -43,9 +44,14 @@ func (h *Handler) RegisterDashboardHandlers(s *server.MCPServer) {
 	tool := mcp.NewTool("signoz_list_dashboards",
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user wants to discover tenant dashboards, browse their summaries, or find a dashboard UUID. It returns names, descriptions, tags, timestamps, and pagination metadata, not widget/query definitions; use signoz_get_dashboard for one full definition. When looking for a specific dashboard, follow pagination.nextOffset while pagination.hasMore is true before concluding it is absent."),
-		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum dashboard summaries per page. Default 50; values above 1000 are clamped.")),
+		mcp.WithDescription("Use this when the user wants to discover tenant dashboards, browse their summaries, or find a dashboard UUID. It returns names, descriptions, tags, timestamps, and pagination metadata, not widget/query definitions; use signoz_get_dashboard for one full definition. Narrow the list with tags, searchText (a substring), or namePattern (an RE2 regex), matched against name and description case-insensitively unless caseSensitive is true. When looking for a specific dashboard, follow pagination.nextOffset while pagination.hasMore is true before concluding it is absent."),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("dashboard summaries"))),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of dashboard summaries to skip. Default 0; use pagination.nextOffset for the next page.")),
+		mcp.WithString("tags", mcp.Description("Optional comma-separated tags, e.g. 'kubernetes,prod'. Keeps only dashboards carrying the tags (case-insensitive); applied before pagination.")),
+		mcp.WithString("tagMatch", mcp.DefaultString("all"), mcp.Enum("all", "any"), mcp.Description("With tags: 'all' (default) keeps dashboards carrying every tag; 'any' keeps dashboards carrying at least one.")),
+		mcp.WithString("namePattern", mcp.Description("Optional regular expression matched against dashboard name and description, e.g. 'prod|production'. Case-insensitive unless caseSensitive is true; applied before pagination.")),
+		mcp.WithString("searchText", mcp.Description("Optional plain substring matched against dashboard name and description; no regex syntax. Case-insensitive unless caseSensitive is true.")),
+		mcp.WithBoolean("caseSensitive", boolOrStringType(), mcp.Description("Match namePattern and searchText case-sensitively (default: false).")),
 	)
 
 	h.addTool(s, tool, h.handleListDashboards)
@@ -61,6 +67,33 @@ func (h *Handler) RegisterDashboardHandlers(s *server.MCPServer) {
 
 	h.addTool(s, getDashboardTool, h.handleGetDashboard)
 
+	getDashboardPanelTool := mcp.NewTool("signoz_get_dashboard_panel",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user asks what one panel on a known dashboard shows, for example the latency panel on dashboard X. It finds the widget by id or title, runs its builder, PromQL, or ClickHouse query over the requested time range, and returns that panel's data instead of the whole dashboard definition. Use signoz_get_dashboard for the full configuration and signoz_list_dashboards to discover the UUID. Defaults to the last 1 hour."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Dashboard UUID. Use signoz_list_dashboards to discover it.")),
+		mcp.WithString("panel", mcp.Required(), mcp.Description("Widget id, or the panel title (case-insensitive exact match).")),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, getDashboardPanelTool, h.handleGetDashboardPanel)
+
+	getDashboardVariablesTool := mcp.NewTool("signoz_get_dashboard_variables",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user asks which variables a known dashboard has or which values they can pick, for example the services the $service dropdown offers. Returns each variable's type, query or attribute, current selection, and its resolved values: CUSTOM lists are split, DYNAMIC variables read the attribute's field values, and QUERY variables run their query over the requested time range. Use signoz_get_dashboard for the full definition and signoz_list_dashboards to discover the UUID."),
+		mcp.WithString("id", mcp.Required(), mcp.Description("Dashboard UUID. Use signoz_list_dashboards to discover it.")),
+		mcp.WithBoolean("resolveValues", boolOrStringType(), mcp.Description("Resolve each variable's values (default: true). Set false to list the definitions without querying SigNoz.")),
+		mcp.WithString("maxValues", mcp.DefaultString("100"), intOrStringType(), mcp.Description("Maximum values listed per variable. Default: 100; max: 1000 (higher values are clamped).")),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Used by QUERY variables. Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, getDashboardVariablesTool, h.handleGetDashboardVariables)
+
 	createDashboardTool := mcp.NewTool(
 		"signoz_create_dashboard",
 		withCreateToolAnnotations(),
@@ -106,6 +139,18 @@ func (h *Handler) RegisterDashboardHandlers(s *server.MCPServer) {
 
 	h.addTool(s, importDashboardTool, h.handleImportDashboard)
 
+	cloneDashboardTool := mcp.NewTool("signoz_clone_dashboard",
+		withCreateToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user wants a copy of an existing tenant dashboard under a new title, for example to start a staging dashboard from the production one. The server fetches the source, gives the copy and all its widgets new IDs while keeping layout and rows intact, and creates it; the source is unchanged. Use signoz_update_dashboard afterwards to change the copy's queries or variables, and signoz_import_dashboard to start from a curated template instead."),
+		// Not mcp.Required(): the alias "uuid" must remain a valid call for
+		// schema-aware clients. The handler validates id/uuid presence.
+		mcp.WithString("id", mcp.Description("UUID of the dashboard to copy. Required; use signoz_list_dashboards to discover it.")),
+		mcp.WithString("title", mcp.Required(), mcp.Description("Title of the new dashboard.")),
+	)
+
+	h.addTool(s, cloneDashboardTool, h.handleCloneDashboard)
+
 	listTemplatesTool := mcp.NewTool(
 		"signoz_list_dashboard_templates",
 		withReadOnlyToolAnnotations(),
@@ -123,7 +168,19 @@ func (h *Handler) RegisterDashboardHandlers(s *server.MCPServer) {
 
 func (h *Handler) handleListDashboards(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	h.logger.DebugContext(ctx, "Tool called: signoz_list_dashboards")
-	limit, offset, limitClamped := paginate.ParseParamsClamped(req.Params.Arguments)
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit, offset, limitClamped := h.parseListParams(args)
+	tags, matchAll, errResult := parseDashboardTagFilter(args)
+	if errResult != nil {
+		return errResult, nil
+	}
+	nameFilters, errResult := parseDashboardNameFilters(args)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	client, err := h.GetClient(ctx)
 	if err != nil {
@@ -168,16 +225,138 @@ func (h *Handler) handleListDashboards(ctx context.Context, req mcp.CallToolRequ
 		}
 	}
 
+	if len(tags) > 0 {
+		data = filterDashboardsByTags(data, tags, matchAll)
+	}
+	if len(nameFilters) > 0 {
+		data = filterDashboardsByName(data, nameFilters)
+	}
+
 	total := len(data)
 	pagedData := paginate.Array(data, offset, limit)
 
-	resultJSON, err := paginate.Wrap(pagedData, total, offset, limit)
+	toolResult, err := newToolResult(listResponse(pagedData, total, offset, limit, limitClamped))
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to wrap dashboards with pagination", logpkg.ErrAttr(err))
+		h.logger.ErrorContext(ctx, "Failed to marshal dashboards response", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
 	}
 
-	return listResult(resultJSON, limitClamped), nil
+	return toolResult, nil
+}
+
+// parseDashboardTagFilter reads the tags and tagMatch arguments of
+// signoz_list_dashboards. Tags are lower-cased for case-insensitive matching.
+func parseDashboardTagFilter(args map[string]any) ([]string, bool, *mcp.CallToolResult) {
+	var tags []string
+	if raw, ok := args["tags"].(string); ok {
+		for _, t := range strings.Split(raw, ",") {
+			if trimmed := strings.TrimSpace(t); trimmed != "" {
+				tags = append(tags, strings.ToLower(trimmed))
+			}
+		}
+	}
+	mode, _ := args["tagMatch"].(string)
+	switch strings.ToLower(strings.TrimSpace(mode)) {
+	case "", "all":
+		return tags, true, nil
+	case "any":
+		return tags, false, nil
+	default:
+		return nil, false, validationErrorf("tagMatch", `must be "all" or "any", got %q`, mode)
+	}
+}
+
+// parseDashboardNameFilters builds one name/description predicate per
+// set argument (namePattern, searchText). Matching is case-insensitive
+// unless caseSensitive is set; (?i) is not added twice to a pattern that
+// already carries it.
+func parseDashboardNameFilters(args map[string]any) ([]func(string) bool, *mcp.CallToolResult) {
+	pattern, _ := args["namePattern"].(string)
+	searchText, _ := args["searchText"].(string)
+	searchText = strings.TrimSpace(searchText)
+	if strings.TrimSpace(pattern) == "" && searchText == "" {
+		return nil, nil
+	}
+	caseSensitive, _, err := parseBoolArg(args, "caseSensitive")
+	if err != nil {
+		return nil, errorWithCode(CodeValidationFailed, fmt.Sprintf(`Parameter validation failed: %s`, err.Error()))
+	}
+
+	var filters []func(string) bool
+	if strings.TrimSpace(pattern) != "" {
+		if !caseSensitive && !strings.HasPrefix(pattern, "(?i)") {
+			pattern = "(?i)" + pattern
+		}
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, validationErrorf("namePattern", "invalid regex pattern: %s. Patterns use Go RE2 syntax, which has no lookahead, lookbehind, or backreferences; for a plain substring match use searchText instead", err.Error())
+		}
+		filters = append(filters, re.MatchString)
+	}
+	if searchText != "" {
+		if caseSensitive {
+			filters = append(filters, func(s string) bool { return strings.Contains(s, searchText) })
+		} else {
+			lower := strings.ToLower(searchText)
+			filters = append(filters, func(s string) bool { return strings.Contains(strings.ToLower(s), lower) })
+		}
+	}
+	return filters, nil
+}
+
+// filterDashboardsByName keeps the dashboard summaries whose name or
+// description satisfies every filter.
+func filterDashboardsByName(data []any, filters []func(string) bool) []any {
+	kept := make([]any, 0, len(data))
+	for _, item := range data {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := m["name"].(string)
+		desc, _ := m["description"].(string)
+		matched := true
+		for _, match := range filters {
+			if !match(name) && !match(desc) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			kept = append(kept, item)
+		}
+	}
+	return kept
+}
+
+// filterDashboardsByTags keeps the dashboard summaries whose tags contain
+// all (matchAll) or any of the wanted tags.
+func filterDashboardsByTags(data []any, wanted []string, matchAll bool) []any {
+	kept := make([]any, 0, len(data))
+	for _, item := range data {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		have := map[string]bool{}
+		if list, ok := m["tags"].([]any); ok {
+			for _, t := range list {
+				if s, ok := t.(string); ok {
+					have[strings.ToLower(strings.TrimSpace(s))] = true
+				}
+			}
+		}
+		matched := 0
+		for _, t := range wanted {
+			if have[t] {
+				matched++
+			}
+		}
+		if (matchAll && matched == len(wanted)) || (!matchAll && matched > 0) {
+			kept = append(kept, item)
+		}
+	}
+	return kept
 }
 
 func (h *Handler) handleGetDashboard(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -346,6 +346,112 @@ func TestHandleListDashboards_OmitsWebURLWhenNoBaseURL(t *testing.T) {
 	}
 }
 
+func TestHandleListDashboards_FiltersByTags(t *testing.T) {
+	mock := &client.MockClient{
+		ListDashboardsFn: func(ctx context.Context) (json.RawMessage, error) {
+			return json.RawMessage(`{"data":[
+				{"uuid":"a","name":"K8s prod","tags":["Kubernetes","prod"]},
+				{"uuid":"b","name":"K8s staging","tags":["kubernetes","staging"]},
+				{"uuid":"c","name":"Hosts","tags":null},
+				{"uuid":"d","name":"Prod DB","tags":["prod","db"]}
+			]}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	cases := []struct {
+		args  map[string]any
+		want  []string
+		total int
+	}{
+		{map[string]any{"tags": "kubernetes, PROD"}, []string{"a"}, 1},
+		{map[string]any{"tags": "kubernetes,prod", "tagMatch": "any"}, []string{"a", "b", "d"}, 3},
+		{map[string]any{"tags": "kubernetes", "limit": "1", "offset": "1"}, []string{"b"}, 2},
+	}
+	for _, tc := range cases {
+		result, err := h.handleListDashboards(testCtx(), makeToolRequest("signoz_list_dashboards", tc.args))
+		if err != nil || result.IsError {
+			t.Fatalf("args %v: unexpected failure: %v %v", tc.args, err, result.Content)
+		}
+		var out struct {
+			Data       []struct{ UUID string } `json:"data"`
+			Pagination struct{ Total int }     `json:"pagination"`
+		}
+		if err := json.Unmarshal([]byte(textContent(t, result)), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var got []string
+		for _, d := range out.Data {
+			got = append(got, d.UUID)
+		}
+		if strings.Join(got, ",") != strings.Join(tc.want, ",") {
+			t.Errorf("args %v: got %v, want %v", tc.args, got, tc.want)
+		}
+		if out.Pagination.Total != tc.total {
+			t.Errorf("args %v: total = %d, want %d", tc.args, out.Pagination.Total, tc.total)
+		}
+	}
+
+	result, _ := h.handleListDashboards(testCtx(), makeToolRequest("signoz_list_dashboards", map[string]any{"tags": "prod", "tagMatch": "some"}))
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Errorf("tagMatch=some: code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
+func TestHandleListDashboards_NamePatternCaseInsensitive(t *testing.T) {
+	mock := &client.MockClient{
+		ListDashboardsFn: func(ctx context.Context) (json.RawMessage, error) {
+			return json.RawMessage(`{"data":[
+				{"uuid":"a","name":"Production API"},
+				{"uuid":"b","name":"staging api","description":"mirrors PRODUCTION"},
+				{"uuid":"c","name":"Hosts"}
+			]}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	cases := []struct {
+		args map[string]any
+		want string
+	}{
+		{map[string]any{"namePattern": "production"}, "a,b"},
+		{map[string]any{"namePattern": "(?i)PRODUCTION"}, "a,b"},
+		{map[string]any{"namePattern": "Production", "caseSensitive": true}, "a"},
+		{map[string]any{"namePattern": "^.*API$", "caseSensitive": "false"}, "a,b"},
+		{map[string]any{"searchText": "ion a"}, "a"},
+		{map[string]any{"searchText": "(prod"}, ""},
+		{map[string]any{"searchText": "api", "caseSensitive": true}, "b"},
+		{map[string]any{"searchText": "mirrors", "namePattern": "staging"}, "b"},
+	}
+	for _, tc := range cases {
+		result, err := h.handleListDashboards(testCtx(), makeToolRequest("signoz_list_dashboards", tc.args))
+		if err != nil || result.IsError {
+			t.Fatalf("args %v: unexpected failure: %v %v", tc.args, err, result.Content)
+		}
+		var out struct {
+			Data []struct{ UUID string } `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(textContent(t, result)), &out); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		var got []string
+		for _, d := range out.Data {
+			got = append(got, d.UUID)
+		}
+		if strings.Join(got, ",") != tc.want {
+			t.Errorf("args %v: got %v, want %s", tc.args, got, tc.want)
+		}
+	}
+
+	result, _ := h.handleListDashboards(testCtx(), makeToolRequest("signoz_list_dashboards", map[string]any{"namePattern": "(prod"}))
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Errorf("bad regex: code = %q, want %q", code, CodeValidationFailed)
+	}
+	if text := textContent(t, result); !strings.Contains(text, "RE2") || !strings.Contains(text, "searchText") {
+		t.Errorf("bad regex message should explain RE2 and suggest searchText, got: %s", text)
+	}
+}
+
 func TestHandleGetDashboard_WrappedBodyGetsWebURL(t *testing.T) {
 	mock := &client.MockClient{
 		GetDashboardFn: func(ctx context.Context, uuid string) (json.RawMessage, error) {
@@ -0,0 +1,72 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+func (h *Handler) RegisterDebugLastRequestHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering debug last request handlers")
+
+	tool := mcp.NewTool("signoz_debug_last_request",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when a previous tool call returned unexpected data and you need to see what was actually sent to SigNoz, e.g. whether timeRange replaced the start and end you passed. It returns, for the latest tool call that reached SigNoz (or the one with requestId), each upstream request's method, URL, status, and duration, plus the resolved start/end and filter expressions of query payloads. Response bodies are not included. Requires the server to run with "+config.DebugRequestsEnv+"=true or LOG_LEVEL=debug; otherwise it returns an UNSUPPORTED error."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithString("requestId", mcp.Description("Optional request ID of the tool call to inspect, as sent in that call's _meta.requestId. Omit for the latest call; set it when other calls may have run since.")),
+	)
+
+	h.addTool(s, tool, h.handleDebugLastRequest)
+}
+
+type debugLastRequestResponse struct {
+	Recorded bool `json:"recorded"`
+	signozclient.CallRecord
+}
+
+func (h *Handler) handleDebugLastRequest(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.logger.DebugContext(ctx, "Tool called: signoz_debug_last_request")
+
+	if !h.debugRequests {
+		return errorWithCode(CodeUnsupported, "request debugging is disabled on this server; set "+config.DebugRequestsEnv+"=true or LOG_LEVEL=debug to enable it"), nil
+	}
+	args, _ := req.Params.Arguments.(map[string]any)
+	var requestID string
+	if raw, present := args["requestId"]; present && raw != nil {
+		id, ok := raw.(string)
+		if !ok {
+			return validationError("requestId", "must be a string"), nil
+		}
+		requestID = strings.TrimSpace(id)
+	}
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	record, ok := client.LastCall(requestID)
+	if !ok {
+		record.Requests = []signozclient.RecordedRequest{}
+	}
+	out, err := json.Marshal(debugLastRequestResponse{Recorded: ok, CallRecord: record})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal last request", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal last request: " + err.Error()), nil
+	}
+	if !ok && requestID != "" {
+		return structuredResultWithNotes(out, "No upstream SigNoz request is recorded for requestId "+strconv.Quote(requestID)+". Only the latest calls are kept; check the ID or omit it for the latest call."), nil
+	}
+	if !ok {
+		return structuredResultWithNotes(out, "No upstream SigNoz request has been recorded for these credentials yet. Run the tool you want to inspect first."), nil
+	}
+	return structuredResult(out), nil
+}
@@ -0,0 +1,87 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestHandleDebugLastRequest_DisabledIsUnsupported(t *testing.T) {
+	h := newTestHandler(&signozclient.MockClient{})
+
+	result, err := h.handleDebugLastRequest(testCtx(), makeToolRequest("signoz_debug_last_request", nil))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, CodeUnsupported, resultCode(t, result))
+	assert.Contains(t, resultText(t, result), "SIGNOZ_DEBUG_REQUESTS")
+}
+
+func TestHandleDebugLastRequest_ReportsLastCall(t *testing.T) {
+	h := newTestHandler(&signozclient.MockClient{
+		LastCallFn: func(requestID string) (signozclient.CallRecord, bool) {
+			return signozclient.CallRecord{
+				RequestID: "req-1",
+				Tool:      "signoz_search_logs",
+				Requests: []signozclient.RecordedRequest{{
+					Method:  http.MethodPost,
+					URL:     "https://signoz.example.com/api/v5/query_range",
+					Status:  200,
+					Start:   1767225600000,
+					End:     1767229200000,
+					Filters: []string{"service.name = 'checkout'"},
+				}},
+			}, true
+		},
+	})
+	h.debugRequests = true
+
+	result, err := h.handleDebugLastRequest(testCtx(), makeToolRequest("signoz_debug_last_request", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got debugLastRequestResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &got))
+	assert.True(t, got.Recorded)
+	assert.Equal(t, "signoz_search_logs", got.Tool)
+	require.Len(t, got.Requests, 1)
+	assert.EqualValues(t, 1767225600000, got.Requests[0].Start)
+	assert.Equal(t, []string{"service.name = 'checkout'"}, got.Requests[0].Filters)
+}
+
+func TestHandleDebugLastRequest_NothingRecorded(t *testing.T) {
+	h := newTestHandler(&signozclient.MockClient{})
+	h.debugRequests = true
+
+	result, err := h.handleDebugLastRequest(testCtx(), makeToolRequest("signoz_debug_last_request", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Contains(t, strings.Join(allTextBlocks(result), "\n"), "No upstream SigNoz request has been recorded")
+
+	var got debugLastRequestResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &got))
+	assert.False(t, got.Recorded)
+	assert.NotNil(t, got.Requests)
+}
+
+func TestHandleDebugLastRequest_SelectsRequestID(t *testing.T) {
+	var gotID string
+	h := newTestHandler(&signozclient.MockClient{
+		LastCallFn: func(requestID string) (signozclient.CallRecord, bool) {
+			gotID = requestID
+			return signozclient.CallRecord{}, false
+		},
+	})
+	h.debugRequests = true
+
+	result, err := h.handleDebugLastRequest(testCtx(), makeToolRequest("signoz_debug_last_request", map[string]any{"requestId": " req-7 "}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Equal(t, "req-7", gotID)
+	assert.Contains(t, strings.Join(allTextBlocks(result), "\n"), `requestId "req-7"`)
+}
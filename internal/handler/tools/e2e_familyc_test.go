@@ -104,7 +104,7 @@ func assertNoStructured(t *testing.T, name string, r *mcp.CallToolResult) {
 }
 
 // firstDataID pulls the first element's value for any of the given keys out of a
-// paginate.Wrap-style {"data":[...],"pagination":{...}} payload. Returns "" if
+// list-envelope {"data":[...],"pagination":{...}} payload. Returns "" if
 // the collection is empty or no key matched.
 func firstDataID(text string, keys ...string) string {
 	var env struct {
@@ -126,7 +126,7 @@ func firstDataID(text string, keys ...string) string {
 func TestE2EFamilyC_StructuredContentOnListTools(t *testing.T) {
 	h, ctx := e2eHandlerC(t)
 
-	// list_* tools — all paginate.Wrap, all code-controlled.
+	// list_* tools — all types.ToolResponse envelopes, all code-controlled.
 	assertStructuredMatchesText(t, "list_services", callOK(t, h.handleListServices, ctx, "signoz_list_services", map[string]any{"timeRange": "1h"}))
 	assertStructuredMatchesText(t, "list_alerts", callOK(t, h.handleListAlerts, ctx, "signoz_list_alerts", map[string]any{}))
 	assertStructuredMatchesText(t, "list_alert_rules", callOK(t, h.handleListAlertRules, ctx, "signoz_list_alert_rules", map[string]any{}))
@@ -0,0 +1,277 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+const (
+	defaultErrorSampleCount = 5
+	maxErrorSampleCount     = 20
+	// errorSampleRowsPerBucket leaves headroom to skip traces already picked
+	// from an earlier bucket without a second query.
+	errorSampleRowsPerBucket = 5
+)
+
+func (h *Handler) RegisterErrorSampleHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering error sample handlers")
+
+	tool := mcp.NewTool("signoz_get_error_sample_traces",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when the user wants representative failing traces for one service to drill into, rather than only the most recent ones. It splits the window into equal time buckets and picks at most one distinct error trace per bucket, returning each trace ID with its erroring operation, error message, and root operation. Use signoz_search_traces for exhaustive paginated error spans and signoz_aggregate_traces for error counts or rates. Defaults to the last 1 hour."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithString("service", mcp.Required(), mcp.Description("Service name whose error traces to sample.")),
+		mcp.WithString("filter", mcp.Description(tracesFilterParamDescription+" Combined with the service and has_error = true using AND.")),
+		mcp.WithString("limit", mcp.DefaultString(fmt.Sprint(defaultErrorSampleCount)), intOrStringType(), mcp.Description(fmt.Sprintf("Number of time buckets, and so the maximum number of sampled traces (default: %d, max: %d).", defaultErrorSampleCount, maxErrorSampleCount))),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetErrorSampleTraces)
+}
+
+// errorSpanRow is the subset of a raw trace row the sampler needs.
+type errorSpanRow struct {
+	Timestamp string
+	TraceID   string
+	SpanID    string
+	Name      string
+	Message   string
+}
+
+// errorSample is one sampled trace in the tool response.
+type errorSample struct {
+	TraceID        string `json:"traceId"`
+	SpanID         string `json:"spanId"`
+	Timestamp      string `json:"timestamp"`
+	Bucket         int    `json:"bucket"`
+	ErrorOperation string `json:"errorOperation"`
+	ErrorMessage   string `json:"errorMessage,omitempty"`
+	RootOperation  string `json:"rootOperation,omitempty"`
+	WebURL         string `json:"webUrl,omitempty"`
+}
+
+type errorSamplesResponse struct {
+	Service      string        `json:"service"`
+	Start        int64         `json:"start"`
+	End          int64         `json:"end"`
+	Buckets      int           `json:"buckets"`
+	EmptyBuckets int           `json:"emptyBuckets"`
+	Samples      []errorSample `json:"samples"`
+}
+
+func (h *Handler) handleGetErrorSampleTraces(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	service, errResult := requireStringArg(args, "service")
+	if errResult != nil {
+		return errResult, nil
+	}
+	filter, err := readFilterExpr(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	buckets, err := intArg(args, "limit", defaultErrorSampleCount)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if buckets > maxErrorSampleCount {
+		buckets = maxErrorSampleCount
+	}
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if endTime <= startTime {
+		return validationError("start", "must be before end"), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_error_sample_traces",
+		slog.String("service", service), slog.Int("buckets", buckets))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	filterExpr := buildTraceFilterExpr(filter, service, "", true, true, "", "")
+	ranges := splitTimeBuckets(startTime, endTime, buckets)
+	bucketRows := make([][]errorSpanRow, len(ranges))
+	for i, r := range ranges {
+		rows, errResult := h.queryErrorSpanRows(ctx, client, r[0], r[1], filterExpr, errorSampleRowsPerBucket)
+		if errResult != nil {
+			return errResult, nil
+		}
+		bucketRows[i] = rows
+	}
+
+	samples := pickBucketSamples(bucketRows)
+	if len(samples) > 0 {
+		roots, errResult := h.queryRootOperations(ctx, client, startTime, endTime, samples)
+		if errResult != nil {
+			return errResult, nil
+		}
+		base, _ := util.GetSigNozURL(ctx)
+		for i := range samples {
+			samples[i].RootOperation = roots[samples[i].TraceID]
+			if link, ok := util.ResourceWebURL(base, "trace", samples[i].TraceID); ok {
+				samples[i].WebURL = link
+			}
+		}
+	}
+
+	out, err := json.Marshal(errorSamplesResponse{
+		Service:      service,
+		Start:        startTime,
+		End:          endTime,
+		Buckets:      len(ranges),
+		EmptyBuckets: len(ranges) - len(samples),
+		Samples:      samples,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal error samples", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal error samples: " + err.Error()), nil
+	}
+	return structuredResult(out), nil
+}
+
+func (h *Handler) queryErrorSpanRows(ctx context.Context, client signozclient.Client, start, end int64, filterExpr string, limit int) ([]errorSpanRow, *mcp.CallToolResult) {
+	payload := types.BuildTracesQueryPayload(start, end, filterExpr, limit, 0, "", "")
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, InternalErrorResult("failed to marshal query payload: " + err.Error())
+	}
+	data, err := client.QueryBuilderV5(ctx, body)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to sample error traces", err)
+		return nil, upstreamQueryError(err, "traces")
+	}
+	return parseErrorSpanRows(data), nil
+}
+
+// queryRootOperations resolves the root span name of each sampled trace in a
+// single query. Missing roots (e.g. a root outside the window) are left blank.
+func (h *Handler) queryRootOperations(ctx context.Context, client signozclient.Client, start, end int64, samples []errorSample) (map[string]string, *mcp.CallToolResult) {
+	ids := make([]string, 0, len(samples))
+	for _, s := range samples {
+		ids = append(ids, quoteFilterString(s.TraceID))
+	}
+	filterExpr := fmt.Sprintf("trace_id IN (%s) AND parent_span_id = ''", strings.Join(ids, ", "))
+	rows, errResult := h.queryErrorSpanRows(ctx, client, start, end, filterExpr, len(samples))
+	if errResult != nil {
+		return nil, errResult
+	}
+	roots := make(map[string]string, len(rows))
+	for _, r := range rows {
+		roots[r.TraceID] = r.Name
+	}
+	return roots, nil
+}
+
+// splitTimeBuckets divides [start, end) into n contiguous ranges of equal
+// width; the last range absorbs any remainder.
+func splitTimeBuckets(start, end int64, n int) [][2]int64 {
+	if n < 1 {
+		n = 1
+	}
+	width := (end - start) / int64(n)
+	if width < 1 {
+		return [][2]int64{{start, end}}
+	}
+	out := make([][2]int64, 0, n)
+	for i := range n {
+		bStart := start + int64(i)*width
+		bEnd := bStart + width
+		if i == n-1 {
+			bEnd = end
+		}
+		out = append(out, [2]int64{bStart, bEnd})
+	}
+	return out
+}
+
+// pickBucketSamples takes the first not-yet-seen trace from each bucket, so
+// one long failing trace cannot fill the sample from several buckets.
+func pickBucketSamples(buckets [][]errorSpanRow) []errorSample {
+	seen := make(map[string]struct{})
+	samples := make([]errorSample, 0, len(buckets))
+	for i, rows := range buckets {
+		for _, r := range rows {
+			if r.TraceID == "" {
+				continue
+			}
+			if _, dup := seen[r.TraceID]; dup {
+				continue
+			}
+			seen[r.TraceID] = struct{}{}
+			samples = append(samples, errorSample{
+				TraceID:        r.TraceID,
+				SpanID:         r.SpanID,
+				Timestamp:      r.Timestamp,
+				Bucket:         i,
+				ErrorOperation: r.Name,
+				ErrorMessage:   r.Message,
+			})
+			break
+		}
+	}
+	return samples
+}
+
+// parseErrorSpanRows walks data.data.results[].rows[] of a raw traces
+// response. It fails open to no rows on an unexpected shape.
+func parseErrorSpanRows(data json.RawMessage) []errorSpanRow {
+	var env struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Rows []struct {
+						Timestamp any            `json:"timestamp"`
+						Data      map[string]any `json:"data"`
+					} `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil
+	}
+	var out []errorSpanRow
+	for _, res := range env.Data.Data.Results {
+		for _, row := range res.Rows {
+			out = append(out, errorSpanRow{
+				Timestamp: rowTimestamp(row.Timestamp),
+				TraceID:   stringValue(row.Data["trace_id"]),
+				SpanID:    stringValue(row.Data["span_id"]),
+				Name:      stringValue(row.Data["name"]),
+				Message:   stringValue(row.Data["status_message"]),
+			})
+		}
+	}
+	return out
+}
+
+func rowTimestamp(v any) string {
+	if v == nil {
+		return ""
+	}
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
@@ -0,0 +1,121 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func rawTraceRowsBody(rows ...map[string]any) string {
+	var encoded []string
+	for _, r := range rows {
+		b, _ := json.Marshal(map[string]any{"timestamp": "2026-01-01T00:00:00Z", "data": r})
+		encoded = append(encoded, string(b))
+	}
+	return fmt.Sprintf(`{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":[%s]}]}}}`, strings.Join(encoded, ","))
+}
+
+func TestSplitTimeBuckets(t *testing.T) {
+	got := splitTimeBuckets(0, 100, 3)
+	want := [][2]int64{{0, 33}, {33, 66}, {66, 100}}
+	if fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Fatalf("splitTimeBuckets = %v, want %v", got, want)
+	}
+	if got := splitTimeBuckets(0, 2, 5); len(got) != 1 || got[0] != [2]int64{0, 2} {
+		t.Fatalf("narrow window = %v, want a single bucket", got)
+	}
+}
+
+func TestPickBucketSamples_SpreadsAcrossBuckets(t *testing.T) {
+	buckets := [][]errorSpanRow{
+		{{TraceID: "t1", Name: "GET /a"}, {TraceID: "t2"}},
+		{{TraceID: "t1", Name: "GET /a"}, {TraceID: "t3", Name: "POST /b"}}, // t1 already sampled
+		{},
+		{{TraceID: "t4", Name: "GET /c", Message: "boom"}},
+	}
+	samples := pickBucketSamples(buckets)
+	var ids []string
+	var bucketsHit []int
+	for _, s := range samples {
+		ids = append(ids, s.TraceID)
+		bucketsHit = append(bucketsHit, s.Bucket)
+	}
+	if fmt.Sprint(ids) != "[t1 t3 t4]" || fmt.Sprint(bucketsHit) != "[0 1 3]" {
+		t.Fatalf("samples = %v from buckets %v, want [t1 t3 t4] from [0 1 3]", ids, bucketsHit)
+	}
+	if samples[2].ErrorMessage != "boom" {
+		t.Fatalf("error message not carried: %+v", samples[2])
+	}
+}
+
+func TestHandleGetErrorSampleTraces_BucketsAndRoots(t *testing.T) {
+	var windows [][2]int64
+	var filters []string
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			if err := json.Unmarshal(body, &p); err != nil {
+				t.Fatalf("unmarshal payload: %v", err)
+			}
+			spec := p.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+			filters = append(filters, spec.Filter.Expression)
+			if strings.Contains(spec.Filter.Expression, "parent_span_id") {
+				return json.RawMessage(rawTraceRowsBody(
+					map[string]any{"trace_id": "trace-0", "name": "HTTP GET /checkout"},
+					map[string]any{"trace_id": "trace-2", "name": "HTTP POST /pay"},
+				)), nil
+			}
+			windows = append(windows, [2]int64{p.Start, p.End})
+			idx := len(windows) - 1
+			if idx == 1 {
+				return json.RawMessage(rawTraceRowsBody()), nil // an empty bucket
+			}
+			return json.RawMessage(rawTraceRowsBody(map[string]any{
+				"trace_id":       fmt.Sprintf("trace-%d", idx),
+				"span_id":        "span",
+				"name":           "db.query",
+				"status_message": "timeout",
+			})), nil
+		},
+	}
+	h := newTestHandler(mock)
+	result, err := h.handleGetErrorSampleTraces(testCtx(), makeToolRequest("signoz_get_error_sample_traces", map[string]any{
+		"service": "checkout",
+		"limit":   "3",
+		"start":   "1711000000000",
+		"end":     "1711000003000",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", allTextBlocks(result))
+	}
+
+	wantWindows := [][2]int64{{1711000000000, 1711000001000}, {1711000001000, 1711000002000}, {1711000002000, 1711000003000}}
+	if fmt.Sprint(windows) != fmt.Sprint(wantWindows) {
+		t.Fatalf("bucket windows = %v, want %v", windows, wantWindows)
+	}
+	if !strings.Contains(filters[0], "service.name = 'checkout'") || !strings.Contains(filters[0], "has_error = true") {
+		t.Fatalf("bucket filter = %q", filters[0])
+	}
+	if last := filters[len(filters)-1]; last != "trace_id IN ('trace-0', 'trace-2') AND parent_span_id = ''" {
+		t.Fatalf("root filter = %q", last)
+	}
+
+	var resp errorSamplesResponse
+	if err := json.Unmarshal([]byte(allTextBlocks(result)[0]), &resp); err != nil {
+		t.Fatalf("unmarshal response: %v", err)
+	}
+	if resp.Buckets != 3 || resp.EmptyBuckets != 1 || len(resp.Samples) != 2 {
+		t.Fatalf("response = %+v", resp)
+	}
+	if s := resp.Samples[1]; s.TraceID != "trace-2" || s.Bucket != 2 || s.RootOperation != "HTTP POST /pay" || s.ErrorMessage != "timeout" || s.ErrorOperation != "db.query" {
+		t.Fatalf("sample = %+v", s)
+	}
+}
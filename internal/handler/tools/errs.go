@@ -75,7 +75,8 @@ const (
 	// CodeRateLimited marks an upstream HTTP 429.
 	CodeRateLimited = toolerrors.CodeRateLimited
 
-	// CodeUnsupported marks an upstream HTTP 501.
+	// CodeUnsupported marks an upstream HTTP 501 or a feature disabled on this
+	// server.
 	CodeUnsupported = toolerrors.CodeUnsupported
 
 	// CodeLicenseUnavailable marks an upstream HTTP 451.
@@ -116,8 +117,8 @@ func errorWithCode(code, message string) *mcp.CallToolResult {
 	return errorWithStructuredContent(code, message, nil)
 }
 
-// clientError classifies GetClient failures. GetClient currently fails only
-// when the request context does not contain tenant credentials.
+// clientError classifies GetClient failures. GetClient fails only when the
+// request context lacks tenant credentials or carries a malformed API key.
 func clientError(err error) *mcp.CallToolResult {
 	return errorWithCode(CodeUnauthorized, err.Error())
 }
@@ -151,9 +152,9 @@ func errorWithCause(err error, fallbackCode, message string) *mcp.CallToolResult
 
 func errorWithStructuredContent(code, message string, fields map[string]any) *mcp.CallToolResult {
 	res := mcp.NewToolResultError(message)
-	structured := map[string]any{"code": code}
+	structured := map[string]any{"code": code, "retryable": toolerrors.Retryable(code)}
 	for key, value := range fields {
-		if key == "code" || value == nil {
+		if key == "code" || key == "retryable" || value == nil {
 			continue
 		}
 		if text, ok := value.(string); ok && text == "" {
@@ -177,9 +178,9 @@ func ensureCodedToolError(res *mcp.CallToolResult) (*mcp.CallToolResult, bool) {
 	}
 	// MCP structuredContent is object-shaped. Invalid non-object values cannot
 	// be merged, so the fallback replaces them while preserving the text block.
-	structured := map[string]any{"code": CodeInternalError}
+	structured := map[string]any{"code": CodeInternalError, "retryable": false}
 	for key, value := range existing {
-		if key != "code" {
+		if key != "code" && key != "retryable" {
 			structured[key] = value
 		}
 	}
@@ -420,10 +421,22 @@ func (h *Handler) logQueryFailure(ctx context.Context, msg string, err error, at
 	h.logger.Log(ctx, slog.LevelWarn, msg+" (filter references keys missing from workspace field metadata)", args...)
 }
 
+// isUpstreamAuthError reports whether err is a SigNoz 401 or 403. Those
+// concern the whole call, so a tool returning partial results must not
+// absorb them.
+func isUpstreamAuthError(err error) bool {
+	var statusErr *signozclient.HTTPStatusError
+	return errors.As(err, &statusErr) &&
+		(statusErr.StatusCode == http.StatusUnauthorized || statusErr.StatusCode == http.StatusForbidden)
+}
+
 // upstreamError wraps a SigNoz backend client error with the uniform text prefix
 // and the most specific structured code we can derive from the HTTP response.
 func upstreamError(err error) *mcp.CallToolResult {
 	var statusErr *signozclient.HTTPStatusError
+	if errors.Is(err, signozclient.ErrServerBusy) {
+		return errorWithCode(CodeRateLimited, err.Error())
+	}
 	if !errors.As(err, &statusErr) {
 		return errorWithCause(err, CodeUpstreamError, fmt.Sprintf("%s %s", upstreamErrorPrefix, err.Error()))
 	}
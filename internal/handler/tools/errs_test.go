@@ -12,6 +12,7 @@ import (
 	"github.com/mark3labs/mcp-go/mcp"
 
 	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/toolerrors"
 )
 
 // resultText extracts the first text content block of a tool result, asserting
@@ -262,6 +263,20 @@ func TestUpstreamError_UniformPrefix(t *testing.T) {
 	}
 }
 
+func TestUpstreamError_ServerBusyIsRateLimited(t *testing.T) {
+	res := upstreamError(fmt.Errorf("failed to list alert rules: %w", signozclient.ErrServerBusy))
+	if got := resultText(t, res); !strings.Contains(got, "server busy") || strings.HasPrefix(got, upstreamErrorPrefix) {
+		t.Fatalf("upstreamError text = %q, want a local server-busy message", got)
+	}
+	structured := resultStructuredMap(t, res)
+	if got := structured["code"]; got != CodeRateLimited {
+		t.Fatalf("code = %v, want %s", got, CodeRateLimited)
+	}
+	if got := structured["retryable"]; got != true {
+		t.Fatalf("retryable = %v, want true", got)
+	}
+}
+
 func TestUpstreamError_ForbiddenHTTPStatus(t *testing.T) {
 	statusErr := &signozclient.HTTPStatusError{
 		StatusCode: http.StatusForbidden,
@@ -670,6 +685,10 @@ func TestErrorHelpers_StructuredCodes(t *testing.T) {
 			if got := resultCode(t, tc.res); got != tc.want {
 				t.Fatalf("%s code = %q, want %q", tc.name, got, tc.want)
 			}
+			retryable, ok := resultStructuredMap(t, tc.res)["retryable"].(bool)
+			if !ok || retryable != toolerrors.Retryable(tc.want) {
+				t.Fatalf("%s retryable = %v (present=%v), want %v", tc.name, retryable, ok, toolerrors.Retryable(tc.want))
+			}
 		})
 	}
 }
@@ -0,0 +1,281 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/timeutil"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+const (
+	defaultExemplarCount = 5
+	maxExemplarCount     = 20
+	// exemplarRowsPerTrace over-fetches span rows so several slow spans of
+	// one trace do not crowd out other traces.
+	exemplarRowsPerTrace = 3
+)
+
+var exemplarPercentiles = []string{"p50", "p75", "p90", "p95", "p99"}
+
+func (h *Handler) RegisterExemplarTraceHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering exemplar trace handlers")
+
+	tool := mcp.NewTool("signoz_get_exemplar_traces",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when the user sees a latency spike (e.g. a p99 jump on a metric or dashboard) and wants example slow traces behind it. It computes the chosen latency percentile for the services over the window—or takes an explicit minDuration—and returns the slowest distinct traces at or above that threshold, each with its trace ID, operation, and duration. Use signoz_search_traces for exhaustive paginated spans and signoz_aggregate_traces for latency statistics. Defaults to the last 1 hour."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithString("service", mcp.Required(), mcp.Description("Service name, or a comma-separated list of service names, whose slow traces to return.")),
+		mcp.WithString("filter", mcp.Description(tracesFilterParamDescription+" Combined with the service filter using AND; also applied when computing the percentile.")),
+		mcp.WithString("percentile", mcp.DefaultString("p99"), mcp.Enum(exemplarPercentiles...), mcp.Description("Latency percentile used as the threshold when minDuration is not set (default: 'p99').")),
+		mcp.WithString("minDuration", mcp.Description("Explicit latency threshold instead of a percentile, with a unit (e.g. '500ms', '2s') or as bare nanoseconds.")),
+		mcp.WithString("limit", mcp.DefaultString(fmt.Sprint(defaultExemplarCount)), intOrStringType(), mcp.Description(fmt.Sprintf("Maximum number of distinct traces to return (default: %d, max: %d).", defaultExemplarCount, maxExemplarCount))),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetExemplarTraces)
+}
+
+// exemplarTrace is one slow trace in the tool response.
+type exemplarTrace struct {
+	TraceID      string `json:"traceId"`
+	SpanID       string `json:"spanId"`
+	Service      string `json:"service,omitempty"`
+	Operation    string `json:"operation"`
+	DurationNano int64  `json:"durationNano"`
+	Duration     string `json:"duration"`
+	Timestamp    string `json:"timestamp"`
+	WebURL       string `json:"webUrl,omitempty"`
+}
+
+type exemplarTracesResponse struct {
+	Services      []string        `json:"services"`
+	Start         int64           `json:"start"`
+	End           int64           `json:"end"`
+	Percentile    string          `json:"percentile,omitempty"`
+	ThresholdNano int64           `json:"thresholdNano"`
+	Threshold     string          `json:"threshold"`
+	Exemplars     []exemplarTrace `json:"exemplars"`
+}
+
+func (h *Handler) handleGetExemplarTraces(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	serviceArg, errResult := requireStringArg(args, "service")
+	if errResult != nil {
+		return errResult, nil
+	}
+	var services []string
+	for _, s := range strings.Split(serviceArg, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			services = append(services, s)
+		}
+	}
+	if len(services) == 0 {
+		return validationError("service", "must name at least one service"), nil
+	}
+	filter, err := readFilterExpr(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	percentile := strings.ToLower(strings.TrimSpace(stringArg(args, "percentile")))
+	if percentile == "" {
+		percentile = "p99"
+	}
+	if !slices.Contains(exemplarPercentiles, percentile) {
+		return validationErrorf("percentile", "must be one of %s, got %q", strings.Join(exemplarPercentiles, ", "), percentile), nil
+	}
+	var threshold int64 = -1
+	if raw := strings.TrimSpace(stringArg(args, "minDuration")); raw != "" {
+		threshold, err = timeutil.ParseDurationNanos(raw)
+		if err != nil {
+			return validationError("minDuration", err.Error()), nil
+		}
+		percentile = ""
+	}
+	limit, err := intArg(args, "limit", defaultExemplarCount)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if limit < 1 {
+		limit = defaultExemplarCount
+	}
+	if limit > maxExemplarCount {
+		limit = maxExemplarCount
+	}
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_exemplar_traces",
+		slog.String("service", serviceArg), slog.String("percentile", percentile))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	baseFilter := serviceFilterExpr(services)
+	if filter != "" {
+		baseFilter = "(" + filter + ") AND " + baseFilter
+	}
+
+	out := exemplarTracesResponse{
+		Services:   services,
+		Start:      startTime,
+		End:        endTime,
+		Percentile: percentile,
+		Exemplars:  []exemplarTrace{},
+	}
+	if threshold < 0 {
+		var found bool
+		threshold, found, errResult = h.queryLatencyPercentile(ctx, client, startTime, endTime, percentile, baseFilter)
+		if errResult != nil {
+			return errResult, nil
+		}
+		if !found {
+			// No spans in the window: nothing to threshold, nothing to return.
+			return h.exemplarResult(ctx, out)
+		}
+	}
+	out.ThresholdNano = threshold
+	out.Threshold = time.Duration(threshold).String()
+
+	payload := types.BuildTracesQueryPayload(startTime, endTime,
+		fmt.Sprintf("%s AND duration_nano >= %d", baseFilter, threshold),
+		limit*exemplarRowsPerTrace, 0, "duration_nano", "desc")
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+	}
+	data, err := client.QueryBuilderV5(ctx, body)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to query exemplar traces", err)
+		return upstreamQueryError(err, "traces"), nil
+	}
+
+	out.Exemplars = pickExemplars(parseExemplarRows(data), limit)
+	base, _ := util.GetSigNozURL(ctx)
+	for i := range out.Exemplars {
+		if link, ok := util.ResourceWebURL(base, "trace", out.Exemplars[i].TraceID); ok {
+			out.Exemplars[i].WebURL = link
+		}
+	}
+	return h.exemplarResult(ctx, out)
+}
+
+func (h *Handler) exemplarResult(ctx context.Context, out exemplarTracesResponse) (*mcp.CallToolResult, error) {
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal exemplar traces", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal exemplar traces: " + err.Error()), nil
+	}
+	return structuredResult(body), nil
+}
+
+// queryLatencyPercentile returns the percentile of duration_nano over the
+// spans matching filterExpr. found is false when the window has no spans.
+func (h *Handler) queryLatencyPercentile(ctx context.Context, client signozclient.Client, start, end int64, percentile, filterExpr string) (int64, bool, *mcp.CallToolResult) {
+	expr := percentile + "(duration_nano)"
+	payload := types.BuildAggregateQueryPayload("traces", start, end, expr, filterExpr, nil, expr, "desc", 1, "scalar", nil)
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, false, InternalErrorResult("failed to marshal query payload: " + err.Error())
+	}
+	data, err := client.QueryBuilderV5(ctx, body)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to compute latency percentile", err)
+		return 0, false, upstreamQueryError(err, "traces")
+	}
+	for _, s := range summarizeWindow(data) {
+		if s.value > 0 {
+			return int64(s.value), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// serviceFilterExpr matches spans of any of the services.
+func serviceFilterExpr(services []string) string {
+	quoted := make([]string, len(services))
+	for i, s := range services {
+		quoted[i] = quoteFilterString(s)
+	}
+	if len(quoted) == 1 {
+		return "service.name = " + quoted[0]
+	}
+	return fmt.Sprintf("service.name IN (%s)", strings.Join(quoted, ", "))
+}
+
+// parseExemplarRows walks data.data.results[].rows[] of a raw traces
+// response. It fails open to no rows on an unexpected shape.
+func parseExemplarRows(data json.RawMessage) []exemplarTrace {
+	var env struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Rows []struct {
+						Timestamp any            `json:"timestamp"`
+						Data      map[string]any `json:"data"`
+					} `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil
+	}
+	var out []exemplarTrace
+	for _, res := range env.Data.Data.Results {
+		for _, row := range res.Rows {
+			d, _ := finiteFloat(row.Data["duration_nano"])
+			out = append(out, exemplarTrace{
+				TraceID:      stringValue(row.Data["trace_id"]),
+				SpanID:       stringValue(row.Data["span_id"]),
+				Service:      stringValue(row.Data["service.name"]),
+				Operation:    stringValue(row.Data["name"]),
+				DurationNano: int64(d),
+				Duration:     time.Duration(int64(d)).String(),
+				Timestamp:    rowTimestamp(row.Timestamp),
+			})
+		}
+	}
+	return out
+}
+
+// pickExemplars keeps the first (slowest) span of each trace, up to limit.
+func pickExemplars(rows []exemplarTrace, limit int) []exemplarTrace {
+	seen := make(map[string]struct{})
+	out := make([]exemplarTrace, 0, limit)
+	for _, r := range rows {
+		if len(out) == limit {
+			break
+		}
+		if r.TraceID == "" {
+			continue
+		}
+		if _, dup := seen[r.TraceID]; dup {
+			continue
+		}
+		seen[r.TraceID] = struct{}{}
+		out = append(out, r)
+	}
+	return out
+}
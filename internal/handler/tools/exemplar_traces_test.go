@@ -0,0 +1,111 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func scalarBody(value float64) string {
+	return fmt.Sprintf(`{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A","columns":[{"name":"p99(duration_nano)","queryName":"A","aggregationIndex":0,"columnType":"aggregation"}],"data":[[%v]]}]}}}`, value)
+}
+
+func TestHandleGetExemplarTraces_PercentileThreshold(t *testing.T) {
+	var filters []string
+	var orders []types.Order
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &p))
+			spec := p.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+			filters = append(filters, spec.Filter.Expression)
+			if p.RequestType == "scalar" {
+				return json.RawMessage(scalarBody(1.5e9)), nil
+			}
+			orders = spec.Order
+			return json.RawMessage(rawTraceRowsBody(
+				map[string]any{"trace_id": "t1", "span_id": "s1", "name": "GET /slow", "duration_nano": 3e9, "service.name": "api"},
+				map[string]any{"trace_id": "t1", "span_id": "s2", "name": "db.query", "duration_nano": 2.9e9},
+				map[string]any{"trace_id": "t2", "span_id": "s3", "name": "GET /slow", "duration_nano": 2e9},
+			)), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetExemplarTraces(testCtx(), makeToolRequest("signoz_get_exemplar_traces", map[string]any{
+		"service": "api, web", "timeRange": "1h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	require.Len(t, filters, 2)
+	assert.Equal(t, "service.name IN ('api', 'web')", filters[0])
+	assert.Equal(t, "service.name IN ('api', 'web') AND duration_nano >= 1500000000", filters[1])
+	require.Len(t, orders, 1)
+	assert.Equal(t, "duration_nano", orders[0].Key.Name)
+	assert.Equal(t, "desc", orders[0].Direction)
+
+	var out exemplarTracesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, "p99", out.Percentile)
+	assert.Equal(t, int64(1500000000), out.ThresholdNano)
+	assert.Equal(t, "1.5s", out.Threshold)
+	require.Len(t, out.Exemplars, 2)
+	assert.Equal(t, "t1", out.Exemplars[0].TraceID)
+	assert.Equal(t, "s1", out.Exemplars[0].SpanID)
+	assert.Equal(t, "3s", out.Exemplars[0].Duration)
+	assert.Equal(t, "t2", out.Exemplars[1].TraceID)
+}
+
+func TestHandleGetExemplarTraces_MinDurationSkipsPercentile(t *testing.T) {
+	var calls []string
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &p))
+			calls = append(calls, p.CompositeQuery.Queries[0].Spec.(types.QuerySpec).Filter.Expression)
+			return json.RawMessage(rawTraceRowsBody()), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetExemplarTraces(testCtx(), makeToolRequest("signoz_get_exemplar_traces", map[string]any{
+		"service": "api", "minDuration": "500ms", "filter": "a = 1 OR b = 2",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, []string{"(a = 1 OR b = 2) AND service.name = 'api' AND duration_nano >= 500000000"}, calls)
+}
+
+func TestHandleGetExemplarTraces_NoSpansInWindow(t *testing.T) {
+	calls := 0
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			calls++
+			return json.RawMessage(`{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A","columns":[],"data":[]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetExemplarTraces(testCtx(), makeToolRequest("signoz_get_exemplar_traces", map[string]any{"service": "api"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, 1, calls, "span query should be skipped when no percentile is available")
+	assert.Contains(t, textContent(t, result), `"exemplars":[]`)
+}
+
+func TestHandleGetExemplarTraces_InvalidPercentile(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	result, err := h.handleGetExemplarTraces(testCtx(), makeToolRequest("signoz_get_exemplar_traces", map[string]any{
+		"service": "api", "percentile": "p42",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+}
@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	defaultExportLogsRows = 1000
+	// maxExportLogsRows bounds one export call. Larger exports continue
+	// from the returned nextCursor.
+	maxExportLogsRows  = 5000
+	exportLogsPageSize = 1000
+)
+
+// exportStop values report why an export ended before the window was drained.
+const (
+	exportStopRowCap        = "maxRows"
+	exportStopResponseBytes = "responseBytes"
+	exportStopNoCursor      = "noCursor"
+)
+
+func (h *Handler) RegisterExportLogsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering export logs handlers")
+
+	tool := mcp.NewTool("signoz_export_logs",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription(fmt.Sprintf("Use this when the user wants a bounded bulk export of log records—more rows than one signoz_search_logs page—in a single call, for example to hand them to another tool. It pages through the matching logs newest first and returns up to maxRows rows (at most %d) as one array. Use signoz_search_logs to inspect a few records and signoz_aggregate_logs for counts or trends. Defaults to the last 1 hour.", maxExportLogsRows)),
+		mcp.WithString("filter", mcp.Description(logsFilterParamDescription)),
+		mcp.WithString("service", mcp.Description("Optional service name to filter by (adds service.name = '<value>').")),
+		mcp.WithString("severity", mcp.Description("Filter on severity_text, e.g. ERROR.")),
+		mcp.WithString("searchText", mcp.Description("Text to search for in log body (uses CONTAINS matching).")),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("maxRows", mcp.DefaultString(fmt.Sprint(defaultExportLogsRows)), intOrStringType(), mcp.Description(fmt.Sprintf("Maximum number of log rows to export (default: %d, max: %d; higher values are clamped).", defaultExportLogsRows, maxExportLogsRows))),
+		mcp.WithString("cursor", mcp.Description("Opaque nextCursor from a previous signoz_export_logs or signoz_search_logs response, to continue an export. Keep the same filters and an explicit start/end.")),
+		mcp.WithString("timeoutSeconds", intOrStringType(), mcp.Description(timeoutSecondsParamDescription+" Applies to each page request.")),
+	)
+
+	h.addTool(s, tool, h.handleExportLogs)
+}
+
+type exportLogsResponse struct {
+	Rows       []json.RawMessage `json:"rows"`
+	RowCount   int               `json:"rowCount"`
+	Pages      int               `json:"pages"`
+	Complete   bool              `json:"complete"`
+	StoppedBy  string            `json:"stoppedBy,omitempty"`
+	NextCursor string            `json:"nextCursor,omitempty"`
+}
+
+func (h *Handler) handleExportLogs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := req.Params.Arguments.(map[string]any)
+	if !ok {
+		return notAJSONObjectError(), nil
+	}
+
+	filter, err := readFilterExpr(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	service, _ := args["service"].(string)
+	severity, _ := args["severity"].(string)
+	searchText, _ := args["searchText"].(string)
+	filterExpr := buildLogFilterExpr(filter, service, severity, searchText)
+
+	maxRows, err := intArg(args, "maxRows", defaultExportLogsRows)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if maxRows < 1 {
+		maxRows = defaultExportLogsRows
+	}
+	rowsClamped := maxRows > maxExportLogsRows
+	if rowsClamped {
+		maxRows = maxExportLogsRows
+	}
+
+	var cursor *logCursor
+	if raw, _ := args["cursor"].(string); strings.TrimSpace(raw) != "" {
+		c, err := decodeLogCursor(strings.TrimSpace(raw))
+		if err != nil {
+			return validationError("cursor", err.Error()), nil
+		}
+		cursor = &c
+	}
+
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	ctx, timeoutNote, err := h.withRequestTimeout(ctx, args)
+	if err != nil {
+		return validationError("timeoutSeconds", err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_export_logs",
+		slog.String("filter", filterExpr), slog.Int("max_rows", maxRows))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	out, errResult := h.exportLogPages(ctx, client, startTime, endTime, filterExpr, cursor, maxRows)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal exported logs", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal exported logs: " + err.Error()), nil
+	}
+
+	var notes []string
+	if timeoutNote != "" {
+		notes = append(notes, timeoutNote)
+	}
+	if rowsClamped {
+		notes = append(notes, fmt.Sprintf("note: maxRows was clamped to %d.", maxExportLogsRows))
+	}
+	switch out.StoppedBy {
+	case exportStopRowCap:
+		notes = append(notes, fmt.Sprintf("note: export stopped at maxRows=%d and more logs match; continue with cursor=%q.", maxRows, out.NextCursor))
+	case exportStopNoCursor:
+		notes = append(notes, "note: export stopped because the last row has no timestamp or id to continue from; more logs may match. Narrow the time range to export the rest.")
+	case exportStopResponseBytes:
+		notes = append(notes, fmt.Sprintf("note: export stopped after %d rows to stay under the %d-byte response limit; continue with cursor=%q or narrow the query.", out.RowCount, h.maxResponseBytes, out.NextCursor))
+	}
+	return structuredResultWithNotes(body, notes...), nil
+}
+
+// exportLogPages fetches successive cursor pages until the window is drained,
+// maxRows rows are collected, or the next page would push the export past
+// the response byte cap.
+func (h *Handler) exportLogPages(ctx context.Context, client signozclient.Client, start, end int64, filterExpr string, cursor *logCursor, maxRows int) (*exportLogsResponse, *mcp.CallToolResult) {
+	out := &exportLogsResponse{Rows: []json.RawMessage{}}
+	if cursor != nil {
+		out.NextCursor = cursor.encode()
+	}
+	size := 0
+	for {
+		pageLimit := min(exportLogsPageSize, maxRows-out.RowCount)
+		pageFilter := filterExpr
+		if cursor != nil {
+			pageFilter = withLogCursor(filterExpr, *cursor)
+		}
+		body, err := json.Marshal(types.BuildLogsQueryPayload(start, end, pageFilter, pageLimit, 0))
+		if err != nil {
+			return nil, InternalErrorResult("failed to marshal query payload: " + err.Error())
+		}
+		page, err := client.QueryBuilderV5(ctx, body)
+		if err != nil {
+			h.logQueryFailure(ctx, "Failed to export logs", err)
+			return nil, upstreamQueryError(err, "logs")
+		}
+		out.Pages++
+
+		rows := queryResultRows(page)
+		lastOK := false
+		for _, row := range rows {
+			if h.maxResponseBytes > 0 && size+len(row) > h.maxResponseBytes {
+				if out.RowCount == 0 {
+					return nil, validationResult(fmt.Sprintf("a single log row exceeds the %d-byte response limit (%s); narrow the query or use signoz_search_logs.", h.maxResponseBytes, config.MaxResponseBytesEnv))
+				}
+				out.StoppedBy = exportStopResponseBytes
+				return out, nil
+			}
+			size += len(row) + 1
+			out.Rows = append(out.Rows, row)
+			out.RowCount++
+			var c logCursor
+			if c, lastOK = rowLogCursor(row); lastOK {
+				out.NextCursor = c.encode()
+				cursor = &c
+			}
+		}
+
+		if len(rows) < pageLimit {
+			out.Complete = true
+			out.NextCursor = ""
+			return out, nil
+		}
+		if !lastOK {
+			// Without the last row's timestamp and id there is no keyset
+			// to continue from; stop rather than refetch the same page.
+			out.StoppedBy = exportStopNoCursor
+			out.NextCursor = ""
+			return out, nil
+		}
+		if out.RowCount >= maxRows {
+			out.StoppedBy = exportStopRowCap
+			return out, nil
+		}
+	}
+}
+
+// queryResultRows returns the raw rows of a QB v5 raw response, in result
+// order. It fails open to no rows on an unexpected shape.
+func queryResultRows(payload []byte) []json.RawMessage {
+	var env struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Rows []json.RawMessage `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return nil
+	}
+	var rows []json.RawMessage
+	for _, res := range env.Data.Data.Results {
+		rows = append(rows, res.Rows...)
+	}
+	return rows
+}
@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+// pagedLogsMock serves total log rows newest first, honouring each query's
+// limit and the keyset cursor embedded in its filter by counting calls.
+func pagedLogsMock(t *testing.T, total int, filters *[]string) *client.MockClient {
+	served := 0
+	return &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &p))
+			spec := p.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+			expr := ""
+			if spec.Filter != nil {
+				expr = spec.Filter.Expression
+			}
+			*filters = append(*filters, expr)
+
+			n := min(spec.Limit, total-served)
+			rows := make([]string, 0, n)
+			for i := 0; i < n; i++ {
+				idx := served + i
+				rows = append(rows, fmt.Sprintf(`{"timestamp":%d,"data":{"id":"id%05d","body":"line %d"}}`, 1_000_000-idx, 99999-idx, idx))
+			}
+			served += n
+			return json.RawMessage(`{"status":"success","data":{"data":{"results":[{"rows":[` + strings.Join(rows, ",") + `]}]}}}`), nil
+		},
+	}
+}
+
+func decodeExport(t *testing.T, text string) exportLogsResponse {
+	t.Helper()
+	var out exportLogsResponse
+	require.NoError(t, json.Unmarshal([]byte(text), &out))
+	return out
+}
+
+func TestHandleExportLogs_DrainsWindow(t *testing.T) {
+	var filters []string
+	h := newTestHandler(pagedLogsMock(t, 2500, &filters))
+
+	result, err := h.handleExportLogs(testCtx(), makeToolRequest("signoz_export_logs", map[string]any{
+		"severity": "ERROR", "maxRows": "5000", "timeRange": "1h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	out := decodeExport(t, textContent(t, result))
+	assert.Equal(t, 2500, out.RowCount)
+	assert.Len(t, out.Rows, 2500)
+	assert.Equal(t, 3, out.Pages)
+	assert.True(t, out.Complete)
+	assert.Empty(t, out.NextCursor)
+
+	require.Len(t, filters, 3)
+	assert.Equal(t, "severity_text = 'ERROR'", filters[0])
+	// The second page continues after row 999 of the first.
+	assert.Equal(t, "(severity_text = 'ERROR') AND (timestamp < 999001 OR (timestamp = 999001 AND id < 'id99000'))", filters[1])
+}
+
+func TestHandleExportLogs_StopsAtMaxRows(t *testing.T) {
+	var filters []string
+	h := newTestHandler(pagedLogsMock(t, 10000, &filters))
+
+	result, err := h.handleExportLogs(testCtx(), makeToolRequest("signoz_export_logs", map[string]any{"maxRows": "1500"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	out := decodeExport(t, textContent(t, result))
+	assert.Equal(t, 1500, out.RowCount)
+	assert.Equal(t, 2, out.Pages)
+	assert.False(t, out.Complete)
+	assert.Equal(t, exportStopRowCap, out.StoppedBy)
+	c, err := decodeLogCursor(out.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, logCursor{TimestampNano: "998501", ID: "id98500"}, c)
+}
+
+func TestHandleExportLogs_StopsAtResponseBytes(t *testing.T) {
+	var filters []string
+	h := newTestHandler(pagedLogsMock(t, 2000, &filters))
+	h.maxResponseBytes = 1000
+
+	result, err := h.handleExportLogs(testCtx(), makeToolRequest("signoz_export_logs", map[string]any{}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	out := decodeExport(t, textContent(t, result))
+	assert.Equal(t, exportStopResponseBytes, out.StoppedBy)
+	assert.Greater(t, out.RowCount, 0)
+	assert.Less(t, out.RowCount, 1000)
+	assert.NotEmpty(t, out.NextCursor)
+}
+
+func TestHandleExportLogs_TimeoutSecondsAppliesToEveryPage(t *testing.T) {
+	var filters []string
+	var timeouts []time.Duration
+	mock := pagedLogsMock(t, 1500, &filters)
+	serve := mock.QueryBuilderV5Fn
+	mock.QueryBuilderV5Fn = func(ctx context.Context, body []byte) (json.RawMessage, error) {
+		timeout, ok := util.GetRequestTimeout(ctx)
+		require.True(t, ok, "page request has no timeout override")
+		timeouts = append(timeouts, timeout)
+		return serve(ctx, body)
+	}
+	h := newTestHandler(mock)
+	h.maxQueryTimeout = 5 * time.Minute
+
+	result, err := h.handleExportLogs(testCtx(), makeToolRequest("signoz_export_logs", map[string]any{
+		"maxRows": "5000", "timeRange": "1h", "timeoutSeconds": 900,
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, []time.Duration{5 * time.Minute, 5 * time.Minute}, timeouts)
+	assert.Contains(t, strings.Join(allTextBlocks(result), "\n"), "timeoutSeconds=900 exceeds the server maximum; clamped to 300")
+
+	result, err = h.handleExportLogs(testCtx(), makeToolRequest("signoz_export_logs", map[string]any{"timeoutSeconds": 0}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+}
@@ -0,0 +1,103 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func fieldCardinalityResponseBody(distinct int, samples string) string {
+	return `{"status":"success","data":{"type":"scalar","data":{"results":[
+		{"queryName":"A","columns":[{"name":"count_distinct(http.route)","queryName":"A","aggregationIndex":0,"columnType":"aggregation"}],"data":[[` + jsonInt(distinct) + `]]},
+		{"queryName":"B","columns":[{"name":"http.route","queryName":"B","columnType":"group"},{"name":"count()","queryName":"B","aggregationIndex":0,"columnType":"aggregation"}],"data":[` + samples + `]}
+	]}}}`
+}
+
+func jsonInt(n int) string {
+	b, _ := json.Marshal(n)
+	return string(b)
+}
+
+func TestHandleGetFieldCardinality(t *testing.T) {
+	var payload types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			require.NoError(t, json.Unmarshal(body, &payload))
+			return json.RawMessage(fieldCardinalityResponseBody(3, `["/cart",20],["/pay",120],["/health",20]`)), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetFieldCardinality(testCtx(), makeToolRequest("signoz_get_field_cardinality", map[string]any{
+		"signal":     "traces",
+		"field":      "http.route",
+		"filter":     "service.name = 'checkout'",
+		"sampleSize": "3",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	assert.Equal(t, "scalar", payload.RequestType)
+	require.Len(t, payload.CompositeQuery.Queries, 2)
+	distinct := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, "(service.name = 'checkout') AND http.route EXISTS", distinct.Filter.Expression)
+	assert.Equal(t, []any{map[string]any{"expression": "count_distinct(http.route)"}}, distinct.Aggregations)
+	samples := payload.CompositeQuery.Queries[1].Spec.(types.QuerySpec)
+	assert.Equal(t, 3, samples.Limit)
+	assert.Equal(t, "http.route", samples.GroupBy[0].Name)
+
+	var out fieldCardinalityResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(3), out.DistinctValues)
+	assert.Equal(t, []fieldValueSample{
+		{Value: "/pay", Count: 120},
+		{Value: "/cart", Count: 20},
+		{Value: "/health", Count: 20},
+	}, out.SampleValues)
+	assert.Len(t, allTextBlocks(result), 1)
+}
+
+func TestHandleGetFieldCardinality_HighCardinalityNote(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(fieldCardinalityResponseBody(4200, `["a",1]`)), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetFieldCardinality(testCtx(), makeToolRequest("signoz_get_field_cardinality", map[string]any{
+		"signal": "logs",
+		"field":  "http.route",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "about 4200 distinct values")
+}
+
+func TestHandleGetFieldCardinality_Validation(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{name: "metrics signal", args: map[string]any{"signal": "metrics", "field": "service.name"}},
+		{name: "missing field", args: map[string]any{"signal": "logs"}},
+		{name: "expression as field", args: map[string]any{"signal": "logs", "field": "x) OR count("}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := h.handleGetFieldCardinality(testCtx(), makeToolRequest("signoz_get_field_cardinality", tt.args))
+			require.NoError(t, err)
+			assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+		})
+	}
+}
@@ -2,10 +2,16 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
+	"sort"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
 )
 
 const fieldContextParamDesc = "Restrict results to a single field context (optional). Valid values: " +
@@ -24,13 +30,15 @@ func (h *Handler) RegisterFieldsHandlers(s *server.MCPServer) {
 	getFieldKeysTool := mcp.NewTool("signoz_get_field_keys",
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user needs to discover field names available for filtering or grouping metrics, traces, or logs. It returns keys, not their observed values, scoped by signal and optional metric, context, or data type. After choosing a key, use signoz_get_field_values to discover valid values."),
+		mcp.WithDescription("Use this when the user needs to discover field names available for filtering or grouping metrics, traces, or logs. It returns keys, not their observed values, scoped by signal and optional metric, context, or data type. After choosing a key, use signoz_get_field_values to discover valid values. Results are paginated and sorted by name; follow pagination.nextOffset while pagination.hasMore is true."),
 		mcp.WithString("signal", mcp.Required(), mcp.Enum("metrics", "traces", "logs"), mcp.Description("Signal type: 'metrics', 'traces', or 'logs'.")),
 		mcp.WithString("searchText", mcp.Description("Filter field names by substring (optional).")),
 		mcp.WithString("metricName", mcp.Description("Metric name to scope field keys (optional, only relevant when signal=metrics).")),
 		mcp.WithString("fieldContext", mcp.Description(fieldContextParamDesc)),
 		mcp.WithString("fieldDataType", mcp.Description(fieldDataTypeParamDesc)),
 		mcp.WithString("source", mcp.Description("For signal=metrics, set \"meter\" to discover Cost Meter fields; omit for the default metrics store. Omit for logs and traces.")),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("field keys"))),
+		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of results to skip before returning results. Use 'pagination.nextOffset' from the previous page. Default: 0.")),
 	)
 
 	h.addTool(s, getFieldKeysTool, h.handleGetFieldKeys)
@@ -38,13 +46,15 @@ func (h *Handler) RegisterFieldsHandlers(s *server.MCPServer) {
 	getFieldValuesTool := mcp.NewTool("signoz_get_field_values",
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user knows a field key and needs its observed values for a metrics, traces, or logs filter. It returns values, not field names; use signoz_get_field_keys when the key is unknown. Match signal and fieldContext to the query that will use the value."),
+		mcp.WithDescription("Use this when the user knows a field key and needs its observed values for a metrics, traces, or logs filter. It returns values, not field names; use signoz_get_field_keys when the key is unknown. Match signal and fieldContext to the query that will use the value. Results are paginated; for high-cardinality fields, narrow with searchText rather than paging through every value."),
 		mcp.WithString("signal", mcp.Required(), mcp.Enum("metrics", "traces", "logs"), mcp.Description("Signal type: 'metrics', 'traces', or 'logs'.")),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Field name to get values for (e.g., 'service.name', 'http.status_code').")),
 		mcp.WithString("searchText", mcp.Description("Filter the returned values by substring (optional).")),
 		mcp.WithString("metricName", mcp.Description("Metric name to scope field values (optional, only relevant when signal=metrics).")),
 		mcp.WithString("fieldContext", mcp.Description(fieldContextParamDesc+" Set this when the same key name exists in more than one context to disambiguate which one to fetch values for.")),
 		mcp.WithString("source", mcp.Description("For signal=metrics, set \"meter\" to fetch Cost Meter field values; omit for the default metrics store. Omit for logs and traces.")),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("values"))),
+		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of results to skip before returning results. Use 'pagination.nextOffset' from the previous page. Default: 0.")),
 	)
 
 	h.addTool(s, getFieldValuesTool, h.handleGetFieldValues)
@@ -77,7 +87,13 @@ func (h *Handler) handleGetFieldKeys(ctx context.Context, req mcp.CallToolReques
 		h.logUpstreamFailure(ctx, "Failed to get field keys", err, slog.String("signal", signal))
 		return upstreamError(err), nil
 	}
-	return mcp.NewToolResultText(string(result)), nil
+	items, complete, ok := fieldKeysList(result)
+	if !ok {
+		h.logger.WarnContext(ctx, "Unrecognized field keys response; returning it unpaginated",
+			slog.String("body", logpkg.TruncBody(result)))
+		return mcp.NewToolResultText(string(result)), nil
+	}
+	return h.fieldListResult(ctx, req, items, complete, "keys")
 }
 
 func (h *Handler) handleGetFieldValues(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -111,5 +127,94 @@ func (h *Handler) handleGetFieldValues(ctx context.Context, req mcp.CallToolRequ
 		h.logUpstreamFailure(ctx, "Failed to get field values", err, slog.String("signal", signal), slog.String("name", name))
 		return upstreamError(err), nil
 	}
-	return mcp.NewToolResultText(string(result)), nil
+	items, complete, ok := fieldValuesList(result)
+	if !ok {
+		h.logger.WarnContext(ctx, "Unrecognized field values response; returning it unpaginated",
+			slog.String("body", logpkg.TruncBody(result)))
+		return mcp.NewToolResultText(string(result)), nil
+	}
+	return h.fieldListResult(ctx, req, items, complete, "values")
+}
+
+// fieldListResult pages a flattened field keys or values list into the
+// standard list envelope. complete=false from the backend means it stopped
+// early, so the total is only a lower bound.
+func (h *Handler) fieldListResult(ctx context.Context, req mcp.CallToolRequest, items []any, complete bool, what string) (*mcp.CallToolResult, error) {
+	limit, offset, limitClamped := h.parseListParams(req.Params.Arguments)
+	resp := listResponse(paginate.Array(items, offset, limit), len(items), offset, limit, limitClamped)
+	if !complete {
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf(
+			"SigNoz returned only part of the matching %s (complete=false), so pagination.total is a lower bound; narrow with searchText to find a specific one.", what))
+	}
+	toolResult, err := newToolResult(resp)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal field "+what+" response", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
+	}
+	return toolResult, nil
+}
+
+// fieldKeysList flattens a /api/v1/fields/keys body, which maps each key name
+// to its definitions (one per context or data type), into one list sorted by
+// name. ok is false when the body has no data.keys object.
+func fieldKeysList(body []byte) (items []any, complete, ok bool) {
+	var resp struct {
+		Data struct {
+			Keys     map[string][]any `json:"keys"`
+			Complete *bool            `json:"complete"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Data.Keys == nil {
+		return nil, false, false
+	}
+	names := make([]string, 0, len(resp.Data.Keys))
+	for name := range resp.Data.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	items = []any{}
+	for _, name := range names {
+		items = append(items, resp.Data.Keys[name]...)
+	}
+	return items, resp.Data.Complete == nil || *resp.Data.Complete, true
+}
+
+// fieldValuesList flattens a /api/v1/fields/values body into one list:
+// string, number, then bool values, followed by any related values not
+// already listed. ok is false when the body has no data.values object.
+func fieldValuesList(body []byte) (items []any, complete, ok bool) {
+	var resp struct {
+		Data struct {
+			Values *struct {
+				StringValues  []string  `json:"stringValues"`
+				NumberValues  []float64 `json:"numberValues"`
+				BoolValues    []bool    `json:"boolValues"`
+				RelatedValues []string  `json:"relatedValues"`
+			} `json:"values"`
+			Complete *bool `json:"complete"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(body, &resp); err != nil || resp.Data.Values == nil {
+		return nil, false, false
+	}
+	values := resp.Data.Values
+	items = []any{}
+	seen := make(map[string]bool, len(values.StringValues))
+	for _, v := range values.StringValues {
+		seen[v] = true
+		items = append(items, v)
+	}
+	for _, v := range values.NumberValues {
+		items = append(items, v)
+	}
+	for _, v := range values.BoolValues {
+		items = append(items, v)
+	}
+	for _, v := range values.RelatedValues {
+		if !seen[v] {
+			seen[v] = true
+			items = append(items, v)
+		}
+	}
+	return items, resp.Data.Complete == nil || *resp.Data.Complete, true
 }
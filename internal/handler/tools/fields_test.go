@@ -3,9 +3,11 @@ package tools
 import (
 	"context"
 	"encoding/json"
+	"strings"
 	"testing"
 
 	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
 )
 
 // TestHandleGetFieldValues_FieldContextPassedThrough guards against the silent-drop
@@ -67,3 +69,72 @@ func TestHandleGetFieldKeys_FieldContextAndDataTypePassedThrough(t *testing.T) {
 		t.Fatalf("field filters not passed through: context=%q dataType=%q", gotContext, gotDataType)
 	}
 }
+
+func TestHandleGetFieldValues_Paginates(t *testing.T) {
+	mock := &signozclient.MockClient{
+		GetFieldValuesFn: func(_ context.Context, _, _, _, _, _, _ string) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"values":{"stringValues":["a","b","c"],"numberValues":[200],"relatedValues":["b","d"]},"complete":false}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	res, err := h.handleGetFieldValues(testCtx(), makeToolRequest("signoz_get_field_values", map[string]any{
+		"signal": "logs", "name": "service.name", "limit": "2", "offset": "2",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res.IsError {
+		t.Fatalf("unexpected tool error: %s", textContent(t, res))
+	}
+	var out struct {
+		Data       []any             `json:"data"`
+		Pagination paginate.Metadata `json:"pagination"`
+		Warnings   []string          `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(textContent(t, res)), &out); err != nil {
+		t.Fatalf("response is not the list envelope: %v", err)
+	}
+	if len(out.Data) != 2 || out.Data[0] != "c" || out.Data[1] != float64(200) {
+		t.Fatalf("page = %v, want [c 200]", out.Data)
+	}
+	if out.Pagination.Total != 5 || !out.Pagination.HasMore || out.Pagination.NextOffset != 4 {
+		t.Fatalf("pagination = %+v, want total 5, hasMore, nextOffset 4", out.Pagination)
+	}
+	if len(out.Warnings) != 1 || !strings.Contains(out.Warnings[0], "complete=false") {
+		t.Fatalf("warnings = %v, want the incomplete-values warning", out.Warnings)
+	}
+}
+
+func TestHandleGetFieldKeys_PaginatesSortedByName(t *testing.T) {
+	mock := &signozclient.MockClient{
+		GetFieldKeysFn: func(_ context.Context, _, _, _, _, _, _ string) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"keys":{
+				"service.name":[{"name":"service.name","fieldContext":"resource"}],
+				"http.method":[{"name":"http.method","fieldContext":"attribute"},{"name":"http.method","fieldContext":"span"}]
+			},"complete":true}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	res, err := h.handleGetFieldKeys(testCtx(), makeToolRequest("signoz_get_field_keys", map[string]any{
+		"signal": "traces", "limit": "2",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var out struct {
+		Data       []map[string]any  `json:"data"`
+		Pagination paginate.Metadata `json:"pagination"`
+		Warnings   []string          `json:"warnings"`
+	}
+	if err := json.Unmarshal([]byte(textContent(t, res)), &out); err != nil {
+		t.Fatalf("response is not the list envelope: %v", err)
+	}
+	if len(out.Data) != 2 || out.Data[0]["name"] != "http.method" || out.Data[1]["fieldContext"] != "span" {
+		t.Fatalf("page = %v, want both http.method definitions first", out.Data)
+	}
+	if out.Pagination.Total != 3 || out.Pagination.NextOffset != 2 || len(out.Warnings) != 0 {
+		t.Fatalf("pagination = %+v warnings = %v", out.Pagination, out.Warnings)
+	}
+}
@@ -0,0 +1,219 @@
+package tools
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// conditionArity is how many values an operator in the conditions param
+// takes: none (EXISTS), one, a list (IN), or a pair (BETWEEN).
+type conditionArity int
+
+const (
+	arityNone conditionArity = iota
+	arityOne
+	arityList
+	arityPair
+)
+
+var conditionOperators = map[string]conditionArity{
+	"=": arityOne, "!=": arityOne, ">": arityOne, ">=": arityOne, "<": arityOne, "<=": arityOne,
+	"LIKE": arityOne, "NOT LIKE": arityOne, "ILIKE": arityOne, "NOT ILIKE": arityOne,
+	"CONTAINS": arityOne, "NOT CONTAINS": arityOne, "REGEXP": arityOne, "NOT REGEXP": arityOne,
+	"IN": arityList, "NOT IN": arityList,
+	"BETWEEN": arityPair, "NOT BETWEEN": arityPair,
+	"EXISTS": arityNone, "NOT EXISTS": arityNone,
+}
+
+const conditionsParamDescription = `Structured alternative to hand-written filter text: an array of {"field", "op", "value"} objects, ANDed together and with the other params, with strings quoted and escaped. op is one of =, !=, >, >=, <, <=, LIKE, ILIKE, CONTAINS, REGEXP (RE2 pattern, checked before querying; no lookahead or backreferences), their NOT forms, IN, NOT IN (array value), BETWEEN, NOT BETWEEN ([low, high]), EXISTS, NOT EXISTS (no value). Values are strings, numbers, or booleans. An entry {"or": [conditions]} matches when any of its conditions does. A negative condition such as service.name != 'redis' also matches rows without the field; add an EXISTS condition on it to exclude them. Example: [{"field": "http.status_code", "op": ">=", "value": 500}, {"or": [{"field": "http.method", "op": "IN", "value": ["POST", "PUT"]}, {"field": "has_error", "op": "=", "value": true}]}].`
+
+// withConditionsParam declares the parameter read by conditionsFilter.
+func withConditionsParam() mcp.ToolOption {
+	condition := map[string]any{
+		"field": map[string]any{"type": "string"},
+		"op":    map[string]any{"type": "string"},
+		"value": map[string]any{"type": []string{"string", "number", "boolean", "array"}},
+	}
+	entry := map[string]any{
+		"field": condition["field"],
+		"op":    condition["op"],
+		"value": condition["value"],
+		"or": map[string]any{
+			"type": "array",
+			"items": map[string]any{
+				"type":       "object",
+				"properties": condition,
+				"required":   []string{"field", "op"},
+			},
+		},
+	}
+	return mcp.WithArray("conditions",
+		mcp.Items(map[string]any{"type": "object", "properties": entry}),
+		mcp.Description(conditionsParamDescription),
+	)
+}
+
+// conditionsFilter renders the conditions argument as a filter expression.
+// Entries are joined with AND; an {"or": [...]} entry is rendered as a
+// parenthesized OR group. It returns "" when conditions is absent.
+func conditionsFilter(args map[string]any) (string, error) {
+	raw, present := args["conditions"]
+	if !present || raw == nil {
+		return "", nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return "", fmt.Errorf(`"conditions" must be an array of {"field", "op", "value"} objects`)
+	}
+
+	parts := make([]string, 0, len(items))
+	for i, item := range items {
+		cond, ok := item.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf(`"conditions" entry %d must be an object with "field", "op", and "value"`, i)
+		}
+		var rendered string
+		var err error
+		if group, isGroup := cond["or"]; isGroup {
+			rendered, err = renderOrGroup(group)
+		} else {
+			rendered, err = renderCondition(cond)
+		}
+		if err != nil {
+			return "", fmt.Errorf(`"conditions" entry %d: %w`, i, err)
+		}
+		parts = append(parts, rendered)
+	}
+	return strings.Join(parts, " AND "), nil
+}
+
+// renderOrGroup renders the conditions of an {"or": [...]} entry joined with
+// OR, parenthesized when there is more than one so the group can be ANDed.
+func renderOrGroup(group any) (string, error) {
+	items, ok := group.([]any)
+	if !ok || len(items) == 0 {
+		return "", fmt.Errorf(`"or" must be a non-empty array of {"field", "op", "value"} objects`)
+	}
+	parts := make([]string, 0, len(items))
+	for i, item := range items {
+		cond, ok := item.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf(`"or" entry %d must be an object with "field", "op", and "value"`, i)
+		}
+		rendered, err := renderCondition(cond)
+		if err != nil {
+			return "", fmt.Errorf(`"or" entry %d: %w`, i, err)
+		}
+		parts = append(parts, rendered)
+	}
+	if len(parts) == 1 {
+		return parts[0], nil
+	}
+	return "(" + strings.Join(parts, " OR ") + ")", nil
+}
+
+// renderCondition renders one {field, op, value} condition.
+func renderCondition(cond map[string]any) (string, error) {
+	field := strings.TrimSpace(stringValue(cond["field"]))
+	if !isTraceOrderField(field) {
+		return "", fmt.Errorf(`"field" must be a field name such as "service.name" or "http.status_code", got %q`, field)
+	}
+	op := strings.Join(strings.Fields(strings.ToUpper(stringValue(cond["op"]))), " ")
+	arity, ok := conditionOperators[op]
+	if !ok {
+		return "", fmt.Errorf(`unsupported "op" %q`, stringValue(cond["op"]))
+	}
+	value, hasValue := cond["value"]
+	hasValue = hasValue && value != nil
+
+	switch arity {
+	case arityNone:
+		if hasValue {
+			return "", fmt.Errorf(`%s takes no "value"`, op)
+		}
+		return field + " " + op, nil
+	case arityOne:
+		if !hasValue {
+			return "", fmt.Errorf(`%s requires a "value"`, op)
+		}
+		if op == "REGEXP" || op == "NOT REGEXP" {
+			if err := checkRE2Pattern(value); err != nil {
+				return "", err
+			}
+		}
+		lit, err := filterLiteral(value)
+		if err != nil {
+			return "", err
+		}
+		return field + " " + op + " " + lit, nil
+	}
+
+	list, ok := value.([]any)
+	if !ok || len(list) == 0 {
+		return "", fmt.Errorf(`%s requires "value" to be a non-empty array`, op)
+	}
+	if arity == arityPair && len(list) != 2 {
+		return "", fmt.Errorf(`%s requires "value" to be [low, high], got %d values`, op, len(list))
+	}
+	lits := make([]string, len(list))
+	for i, v := range list {
+		lit, err := filterLiteral(v)
+		if err != nil {
+			return "", err
+		}
+		lits[i] = lit
+	}
+	if arity == arityPair {
+		return fmt.Sprintf("%s %s %s AND %s", field, op, lits[0], lits[1]), nil
+	}
+	return fmt.Sprintf("%s %s (%s)", field, op, strings.Join(lits, ", ")), nil
+}
+
+// checkRE2Pattern rejects a REGEXP value that is not a string or does not
+// compile as RE2, the dialect ClickHouse evaluates, so a bad pattern fails
+// here with a clear message instead of as a backend error.
+func checkRE2Pattern(value any) error {
+	pattern, ok := value.(string)
+	if !ok {
+		return fmt.Errorf(`REGEXP requires "value" to be a pattern string, got %T`, value)
+	}
+	if _, err := regexp.Compile(pattern); err != nil {
+		return fmt.Errorf("invalid REGEXP pattern: %v. Patterns use RE2 syntax, which has no lookahead, lookbehind, or backreferences", err)
+	}
+	return nil
+}
+
+// filterStringEscaper escapes a filter-expression string literal in one pass.
+// The filter grammar treats a backslash as an escape character, so it is
+// escaped along with the quote.
+var filterStringEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+// quoteFilterString renders s as a single-quoted filter-expression string
+// literal.
+func quoteFilterString(s string) string {
+	return "'" + filterStringEscaper.Replace(s) + "'"
+}
+
+// filterLiteral renders a JSON scalar as a filter-expression literal:
+// strings are quoted by quoteFilterString, numbers and
+// booleans are written bare.
+func filterLiteral(v any) (string, error) {
+	switch x := v.(type) {
+	case string:
+		return quoteFilterString(x), nil
+	case bool:
+		return strconv.FormatBool(x), nil
+	case float64:
+		return strconv.FormatFloat(x, 'f', -1, 64), nil
+	case json.Number:
+		return x.String(), nil
+	case int:
+		return strconv.Itoa(x), nil
+	}
+	return "", fmt.Errorf(`"value" must be a string, number, or boolean, got %T`, v)
+}
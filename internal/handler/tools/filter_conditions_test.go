@@ -0,0 +1,149 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestConditionsFilter(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+		want string
+	}{
+		{"absent", map[string]any{}, ""},
+		{"string equality escapes quotes", map[string]any{"conditions": []any{
+			map[string]any{"field": "user.name", "op": "=", "value": "o'brien"},
+		}}, `user.name = 'o\'brien'`},
+		{"string escapes backslashes", map[string]any{"conditions": []any{
+			map[string]any{"field": "path", "op": "=", "value": `C:\temp\`},
+		}}, `path = 'C:\\temp\\'`},
+		{"numeric", map[string]any{"conditions": []any{
+			map[string]any{"field": "http.status_code", "op": ">=", "value": 500.0},
+		}}, "http.status_code >= 500"},
+		{"boolean", map[string]any{"conditions": []any{
+			map[string]any{"field": "has_error", "op": "=", "value": true},
+		}}, "has_error = true"},
+		{"IN list", map[string]any{"conditions": []any{
+			map[string]any{"field": "http.method", "op": "in", "value": []any{"POST", "PUT"}},
+		}}, "http.method IN ('POST', 'PUT')"},
+		{"NOT IN numbers", map[string]any{"conditions": []any{
+			map[string]any{"field": "http.status_code", "op": "not  in", "value": []any{404.0, 410.0}},
+		}}, "http.status_code NOT IN (404, 410)"},
+		{"LIKE", map[string]any{"conditions": []any{
+			map[string]any{"field": "name", "op": "LIKE", "value": "GET /api/%"},
+		}}, "name LIKE 'GET /api/%'"},
+		{"BETWEEN", map[string]any{"conditions": []any{
+			map[string]any{"field": "duration_nano", "op": "BETWEEN", "value": []any{1e6, 5e8}},
+		}}, "duration_nano BETWEEN 1000000 AND 500000000"},
+		{"EXISTS", map[string]any{"conditions": []any{
+			map[string]any{"field": "db.system", "op": "EXISTS"},
+		}}, "db.system EXISTS"},
+		{"AND by default", map[string]any{"conditions": []any{
+			map[string]any{"field": "service.name", "op": "!=", "value": "redis"},
+			map[string]any{"field": "service.name", "op": "EXISTS"},
+		}}, "service.name != 'redis' AND service.name EXISTS"},
+		{"OR group is parenthesized", map[string]any{"conditions": []any{
+			map[string]any{"field": "service.name", "op": "=", "value": "api"},
+			map[string]any{"or": []any{
+				map[string]any{"field": "severity_text", "op": "=", "value": "ERROR"},
+				map[string]any{"field": "body", "op": "CONTAINS", "value": "panic"},
+			}},
+		}}, "service.name = 'api' AND (severity_text = 'ERROR' OR body CONTAINS 'panic')"},
+		{"single-entry OR group", map[string]any{"conditions": []any{
+			map[string]any{"or": []any{map[string]any{"field": "has_error", "op": "=", "value": true}}},
+		}}, "has_error = true"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := conditionsFilter(tt.args)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestQuoteFilterString(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"checkout", `'checkout'`},
+		{"o'brien", `'o\'brien'`},
+		{`C:\temp\`, `'C:\\temp\\'`},
+		{`a\'b`, `'a\\\'b'`},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, quoteFilterString(tt.in), tt.in)
+	}
+}
+
+func TestConditionsFilter_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    map[string]any
+		wantErr string
+	}{
+		{"not an array", map[string]any{"conditions": "a = b"}, "must be an array"},
+		{"entry not an object", map[string]any{"conditions": []any{"a = b"}}, "entry 0 must be an object"},
+		{"field injection", map[string]any{"conditions": []any{
+			map[string]any{"field": "a = 1 OR b", "op": "=", "value": "x"},
+		}}, `"field" must be a field name`},
+		{"unknown op", map[string]any{"conditions": []any{
+			map[string]any{"field": "a", "op": "=~", "value": "x"},
+		}}, `unsupported "op"`},
+		{"missing value", map[string]any{"conditions": []any{
+			map[string]any{"field": "a", "op": "="},
+		}}, "requires a \"value\""},
+		{"IN needs array", map[string]any{"conditions": []any{
+			map[string]any{"field": "a", "op": "IN", "value": "x"},
+		}}, "non-empty array"},
+		{"BETWEEN needs pair", map[string]any{"conditions": []any{
+			map[string]any{"field": "a", "op": "BETWEEN", "value": []any{1.0}},
+		}}, "[low, high]"},
+		{"EXISTS takes no value", map[string]any{"conditions": []any{
+			map[string]any{"field": "a", "op": "EXISTS", "value": "x"},
+		}}, "takes no"},
+		{"object value", map[string]any{"conditions": []any{
+			map[string]any{"field": "a", "op": "=", "value": map[string]any{"x": 1.0}},
+		}}, "string, number, or boolean"},
+		{"empty OR group", map[string]any{"conditions": []any{map[string]any{"or": []any{}}}}, `"or" must be a non-empty array`},
+		{"invalid entry in OR group", map[string]any{"conditions": []any{
+			map[string]any{"or": []any{map[string]any{"field": "a", "op": "IN", "value": "x"}}},
+		}}, `entry 0: "or" entry 0: IN requires`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := conditionsFilter(tt.args)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestHandleSearchLogs_Conditions(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"service": "checkout",
+		"conditions": []any{
+			map[string]any{"or": []any{
+				map[string]any{"field": "severity_text", "op": "IN", "value": []any{"ERROR", "FATAL"}},
+				map[string]any{"field": "http.status_code", "op": ">=", "value": 500.0},
+			}},
+		},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, "service.name = 'checkout' AND (severity_text IN ('ERROR', 'FATAL') OR http.status_code >= 500)", payloadFilterExpression(t, captured))
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	expirable "github.com/hashicorp/golang-lru/v2/expirable"
+	"golang.org/x/net/http/httpguts"
 
 	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
 	"github.com/SigNoz/signoz-mcp-server/internal/config"
@@ -18,11 +21,47 @@ import (
 
 type Handler struct {
 	logger        *slog.Logger
-	clientCache   *expirable.LRU[string, *signozclient.SigNoz]
+	clientCache   *expirable.LRU[string, signozclient.Client]
 	configURL     string
 	customHeaders map[string]string
-	meters        *otelpkg.Meters
-	docsIndex     *docsindex.IndexRegistry
+	// requestTimeout is the read-only call deadline given to every tenant
+	// client; zero keeps signozclient.DefaultQueryTimeout.
+	requestTimeout time.Duration
+	// fieldCacheTTL is passed to every tenant client; zero disables
+	// field keys/values caching.
+	fieldCacheTTL time.Duration
+	// configTransport carries the TLS_CA_FILE / TLS_INSECURE_SKIP_VERIFY
+	// settings; like customHeaders it is only used for SIGNOZ_URL. Nil
+	// keeps the shared default transport.
+	configTransport *http.Transport
+	// maxQueryTimeout bounds per-call timeoutSeconds overrides; zero falls
+	// back to signozclient.DefaultQueryTimeout.
+	maxQueryTimeout time.Duration
+	// maxResponseBytes caps raw query responses; see capQueryResponse.
+	maxResponseBytes int
+	// maxListLimit caps the per-page limit of the summary list tools; zero
+	// falls back to paginate.MaxLimit.
+	maxListLimit int
+	// maxAlertHistoryLimit caps the per-page limit of
+	// signoz_get_alert_history; zero falls back to defaultAlertHistoryLimitCap.
+	maxAlertHistoryLimit int
+	// prettyJSON re-indents successful JSON text results; see prettyJSONDecorator.
+	prettyJSON bool
+	// requestStats is shared by every tenant client; nil when
+	// SIGNOZ_REQUEST_STATS is off.
+	requestStats *signozclient.RequestStats
+	// upstreamLimiter caps concurrent SigNoz requests across every tenant
+	// client; nil when SIGNOZ_MAX_CONCURRENT_REQUESTS is unset.
+	upstreamLimiter *signozclient.UpstreamLimiter
+	// debugRequests gives each new tenant client a CallRecorder for
+	// signoz_debug_last_request; see SIGNOZ_DEBUG_REQUESTS.
+	debugRequests bool
+	// enabledToolGroups and disabledTools come from ENABLED_TOOL_GROUPS and
+	// DISABLED_TOOLS; see tool_selection.go. Empty means no restriction.
+	enabledToolGroups []string
+	disabledTools     []string
+	meters            *otelpkg.Meters
+	docsIndex         *docsindex.IndexRegistry
 	// validationWarned deduplicates validation WARN logs per bounded
 	// (tool, direction, path, constraint) key; see warnValidationOnce.
 	validationWarned sync.Map
@@ -35,8 +74,8 @@ type Handler struct {
 	registrations  map[registrationKey]struct{}
 
 	// clientOverride, when non-nil, is returned by GetClient instead of
-	// looking up the cache. This exists solely to support unit testing
-	// with mock clients.
+	// looking up the cache. Unit tests set it to mock clients, and
+	// MOCK_MODE sets it to the canned fixture client.
 	clientOverride signozclient.Client
 }
 
@@ -59,11 +98,37 @@ func NewHandler(log *slog.Logger, cfg *config.Config) *Handler {
 	if n, err := util.NormalizeSigNozURL(cfg.URL); err == nil {
 		normalizedURL = n
 	}
+	var requestStats *signozclient.RequestStats
+	if cfg.RequestStats {
+		requestStats = signozclient.NewRequestStats()
+	}
+	var configTransport *http.Transport
+	if tlsConfig := cfg.TLSConfig(); tlsConfig != nil {
+		configTransport = signozclient.NewTLSTransport(tlsConfig)
+	}
+	var clientOverride signozclient.Client
+	if cfg.MockMode {
+		clientOverride = signozclient.NewCannedClient()
+	}
 	return &Handler{
-		logger:        log,
-		clientCache:   expirable.NewLRU[string, *signozclient.SigNoz](cfg.ClientCacheSize, nil, cfg.ClientCacheTTL),
-		configURL:     normalizedURL,
-		customHeaders: cfg.CustomHeaders,
+		logger:               log,
+		clientCache:          expirable.NewLRU[string, signozclient.Client](cfg.ClientCacheSize, nil, cfg.ClientCacheTTL),
+		configURL:            normalizedURL,
+		customHeaders:        cfg.CustomHeaders,
+		requestTimeout:       cfg.RequestTimeout,
+		fieldCacheTTL:        cfg.FieldCacheTTL,
+		configTransport:      configTransport,
+		maxQueryTimeout:      cfg.MaxQueryTimeout,
+		maxResponseBytes:     cfg.MaxResponseBytes,
+		maxListLimit:         cfg.MaxListLimit,
+		maxAlertHistoryLimit: cfg.MaxAlertHistoryLimit,
+		prettyJSON:           cfg.PrettyJSON,
+		requestStats:         requestStats,
+		upstreamLimiter:      signozclient.NewUpstreamLimiter(cfg.MaxConcurrentRequests, cfg.RequestQueueTimeout),
+		debugRequests:        cfg.DebugRequests,
+		enabledToolGroups:    cfg.EnabledToolGroups,
+		disabledTools:        cfg.DisabledTools,
+		clientOverride:       clientOverride,
 	}
 }
 
@@ -80,8 +145,18 @@ func (h *Handler) GetClient(ctx context.Context) (signozclient.Client, error) {
 	signozURL, _ := util.GetSigNozURL(ctx)
 	authHeader, _ := util.GetAuthHeader(ctx)
 
-	if apiKey == "" || signozURL == "" {
+	switch {
+	case strings.TrimSpace(apiKey) == "" && signozURL == "":
 		return nil, fmt.Errorf("missing tenant credentials in context (apiKey or signozURL)")
+	case strings.TrimSpace(apiKey) == "":
+		return nil, fmt.Errorf("missing tenant credentials in context: no API key for %s", signozURL)
+	case signozURL == "":
+		return nil, fmt.Errorf("missing tenant credentials in context: an API key is present but no SigNoz URL; set SIGNOZ_URL or send X-SigNoz-URL")
+	}
+	// The key becomes a header value; reject CR/LF and other control
+	// characters here so a crafted key can never inject extra headers.
+	if !httpguts.ValidHeaderFieldValue(apiKey) {
+		return nil, fmt.Errorf("invalid tenant API key: contains characters not allowed in an HTTP header")
 	}
 
 	if authHeader == "" {
@@ -98,13 +173,64 @@ func (h *Handler) GetClient(ctx context.Context) (signozclient.Client, error) {
 	// SIGNOZ_URL to prevent leaking proxy-auth credentials (e.g. Cloudflare
 	// Access tokens) to arbitrary third-party hosts.
 	var headers map[string]string
+	var transport *http.Transport
 	if strings.EqualFold(signozURL, h.configURL) {
 		headers = h.customHeaders
+		transport = h.configTransport
 	}
 
 	h.logger.DebugContext(ctx, "Creating new SigNoz client for tenant")
 	newClient := signozclient.NewClient(h.logger, signozURL, apiKey, authHeader, headers)
+	newClient.SetTransport(transport)
 	newClient.SetMeters(h.meters)
+	newClient.SetRequestStats(h.requestStats)
+	newClient.SetUpstreamLimiter(h.upstreamLimiter)
+	if h.debugRequests {
+		newClient.SetCallRecorder(signozclient.NewCallRecorder())
+	}
+	newClient.SetRequestTimeout(h.requestTimeout)
+	newClient.SetFieldCacheTTL(h.fieldCacheTTL)
 	h.clientCache.Add(cacheKey, newClient)
 	return newClient, nil
 }
+
+// CloseIdleConnections closes the idle SigNoz connections of every tenant
+// client, including those on the SIGNOZ_URL TLS transport.
+func (h *Handler) CloseIdleConnections() {
+	signozclient.CloseIdleConnections()
+	if h.configTransport != nil {
+		h.configTransport.CloseIdleConnections()
+	}
+}
+
+// timeoutSecondsParamDescription documents the argument read by
+// withRequestTimeout.
+const timeoutSecondsParamDescription = "Optional upstream timeout in seconds for this call. Lower it to fail fast or raise it for heavy queries; values above the server maximum (default 600) are clamped."
+
+// withRequestTimeout applies an optional timeoutSeconds argument to ctx so
+// the client uses it instead of its per-endpoint default. Values above the
+// configured maximum are clamped and reported through the returned note.
+func (h *Handler) withRequestTimeout(ctx context.Context, args map[string]any) (context.Context, string, error) {
+	seconds, present, ok := looseInt(args["timeoutSeconds"])
+	if !ok {
+		return ctx, "", fmt.Errorf("must be a positive integer number of seconds")
+	}
+	if !present {
+		return ctx, "", nil
+	}
+	if seconds <= 0 {
+		return ctx, "", fmt.Errorf("must be a positive integer number of seconds, got %d", seconds)
+	}
+
+	maxTimeout := h.maxQueryTimeout
+	if maxTimeout <= 0 {
+		maxTimeout = signozclient.DefaultQueryTimeout
+	}
+	timeout := time.Duration(seconds) * time.Second
+	var note string
+	if timeout > maxTimeout {
+		timeout = maxTimeout
+		note = fmt.Sprintf("note: timeoutSeconds=%d exceeds the server maximum; clamped to %d.", seconds, int64(maxTimeout/time.Second))
+	}
+	return util.SetRequestTimeout(ctx, timeout), note, nil
+}
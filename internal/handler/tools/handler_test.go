@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+func newCachingTestHandler() *Handler {
+	return NewHandler(logpkg.New("error"), &config.Config{ClientCacheSize: 4, ClientCacheTTL: time.Minute})
+}
+
+func TestGetClient_RejectsHeaderInjectionInAPIKey(t *testing.T) {
+	h := newCachingTestHandler()
+	for _, key := range []string{"key\r\nX-Injected: 1", "key\nX-Injected: 1", "key\x00"} {
+		ctx := util.SetSigNozURL(util.SetAPIKey(context.Background(), key), "https://signoz.example.com")
+		client, err := h.GetClient(ctx)
+		require.Error(t, err, "%q", key)
+		assert.Nil(t, client)
+		assert.Contains(t, err.Error(), "invalid tenant API key")
+		assert.NotContains(t, err.Error(), "X-Injected", "error must not echo the key")
+	}
+	assert.Zero(t, h.clientCache.Len(), "rejected keys must not be cached")
+}
+
+func TestGetClient_MissingCredentials(t *testing.T) {
+	h := newCachingTestHandler()
+	cases := []struct {
+		name, apiKey, url, want string
+	}{
+		{name: "neither", want: "(apiKey or signozURL)"},
+		{name: "key without URL", apiKey: "key", want: "no SigNoz URL"},
+		{name: "URL without key", url: "https://signoz.example.com", want: "no API key"},
+		{name: "blank key", apiKey: "   ", url: "https://signoz.example.com", want: "no API key"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.apiKey != "" {
+				ctx = util.SetAPIKey(ctx, tc.apiKey)
+			}
+			if tc.url != "" {
+				ctx = util.SetSigNozURL(ctx, tc.url)
+			}
+			_, err := h.GetClient(ctx)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "missing tenant credentials")
+			assert.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestGetClient_ValidKeyIsCached(t *testing.T) {
+	h := newCachingTestHandler()
+	ctx := util.SetSigNozURL(util.SetAPIKey(context.Background(), "key"), "https://signoz.example.com")
+	first, err := h.GetClient(ctx)
+	require.NoError(t, err)
+	second, err := h.GetClient(ctx)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+}
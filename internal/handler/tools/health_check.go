@@ -0,0 +1,79 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+func (h *Handler) RegisterHealthCheckHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering health check handlers")
+
+	tool := mcp.NewTool("signoz_health_check",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when other SigNoz tools fail with connection or authentication errors, or before a long investigation to confirm the connection works. It reports whether the SigNoz instance is reachable, whether the API key is accepted, the SigNoz version, and the round-trip latency. An unhealthy connection is reported in the result, not as a tool error. It does not query telemetry data."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+	)
+
+	h.addTool(s, tool, h.handleHealthCheck)
+}
+
+type healthCheckResponse struct {
+	Reachable     bool   `json:"reachable"`
+	Authenticated bool   `json:"authenticated"`
+	Version       string `json:"version,omitempty"`
+	LatencyMs     int64  `json:"latencyMs"`
+	Error         string `json:"error,omitempty"`
+	Hint          string `json:"hint,omitempty"`
+}
+
+func (h *Handler) handleHealthCheck(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.logger.DebugContext(ctx, "Tool called: signoz_health_check")
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	ping, pingErr := client.Ping(ctx)
+	if ping == nil {
+		ping = &signozclient.PingResult{}
+	}
+
+	resp := healthCheckResponse{
+		Reachable:     ping.Reachable,
+		Authenticated: ping.Authenticated,
+		Version:       ping.Version,
+		LatencyMs:     ping.Latency.Milliseconds(),
+	}
+	if pingErr != nil {
+		resp.Error = pingErr.Error()
+		resp.Hint = healthCheckHint(pingErr)
+	}
+
+	out, err := json.Marshal(resp)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal health check", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal health check: " + err.Error()), nil
+	}
+	return structuredResult(out), nil
+}
+
+// healthCheckHint turns a Ping failure into the next thing to check.
+func healthCheckHint(err error) string {
+	switch {
+	case errors.Is(err, signozclient.ErrUnauthorized):
+		return "the API key was rejected; check that it is valid, not revoked, and belongs to this SigNoz instance"
+	case errors.Is(err, signozclient.ErrInstanceNotFound):
+		return "the URL answers but is not a SigNoz API; check the instance URL and that the workspace is active"
+	case errors.Is(err, signozclient.ErrUnreachable):
+		return "no response from the instance URL; check the URL, network access, proxy, and TLS settings"
+	default:
+		return "SigNoz answered with an unexpected status; retry, and check the SigNoz server logs if it persists"
+	}
+}
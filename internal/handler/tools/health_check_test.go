@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestHandleHealthCheck_Healthy(t *testing.T) {
+	h := newTestHandler(&signozclient.MockClient{
+		PingFn: func(ctx context.Context) (*signozclient.PingResult, error) {
+			return &signozclient.PingResult{Reachable: true, Authenticated: true, Version: "v0.80.0", Latency: 25 * time.Millisecond}, nil
+		},
+	})
+
+	result, err := h.handleHealthCheck(testCtx(), makeToolRequest("signoz_health_check", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got healthCheckResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &got))
+	assert.Equal(t, healthCheckResponse{Reachable: true, Authenticated: true, Version: "v0.80.0", LatencyMs: 25}, got)
+}
+
+// An unhealthy connection is the answer the tool exists to give, so it is a
+// normal result rather than a tool error.
+func TestHandleHealthCheck_RejectedKeyIsReported(t *testing.T) {
+	h := newTestHandler(&signozclient.MockClient{
+		PingFn: func(ctx context.Context) (*signozclient.PingResult, error) {
+			return &signozclient.PingResult{Reachable: true}, fmt.Errorf("%w: status 401", signozclient.ErrUnauthorized)
+		},
+	})
+
+	result, err := h.handleHealthCheck(testCtx(), makeToolRequest("signoz_health_check", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got healthCheckResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &got))
+	assert.True(t, got.Reachable)
+	assert.False(t, got.Authenticated)
+	assert.Contains(t, got.Error, "status 401")
+	assert.Contains(t, got.Hint, "API key was rejected")
+}
@@ -0,0 +1,218 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+const (
+	defaultInvestigateTraces = 5
+	maxInvestigateTraces     = 20
+)
+
+// investigateParts names the parts of a signoz_investigate_service snapshot:
+// the three queries in request order, then the alerts lookup.
+var investigateParts = []string{"trace error rate", "error logs", "slowest traces", "firing alerts"}
+
+// alertServiceLabels are the alert labels that carry the service name, in
+// the dotted form v5 rules group by and the underscored form older rules use.
+var alertServiceLabels = []string{"service.name", "service_name"}
+
+func (h *Handler) RegisterInvestigateServiceHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering investigate service handlers")
+
+	tool := mcp.NewTool("signoz_investigate_service",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this at the start of an incident on one service to get a full snapshot in one call instead of separate log, trace, and alert queries. It returns the service's span error rate, its newest ERROR and FATAL logs, its slowest traces, and the alerts currently firing for it (matched on the service.name or service_name alert label). If one part fails, the others are still returned with a note. Follow up with signoz_get_service_errors for error operations, signoz_search_logs or signoz_search_traces for more rows, or signoz_get_trace_details for one trace. Defaults to the last 1 hour."),
+		mcp.WithString("service", mcp.Required(), mcp.Description("Service name to investigate, typically from signoz_list_services.")),
+		mcp.WithString("logLimit", mcp.DefaultString(fmt.Sprint(defaultServiceErrorLogs)), intOrStringType(), mcp.Description(fmt.Sprintf("Maximum recent ERROR/FATAL logs to return, newest first (default: %d, max: %d).", defaultServiceErrorLogs, maxServiceErrorLogs))),
+		mcp.WithString("traceLimit", mcp.DefaultString(fmt.Sprint(defaultInvestigateTraces)), intOrStringType(), mcp.Description(fmt.Sprintf("Maximum distinct slow traces to return, slowest first (default: %d, max: %d).", defaultInvestigateTraces, maxInvestigateTraces))),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleInvestigateService)
+}
+
+type investigateServiceResponse struct {
+	Service         string            `json:"service"`
+	Start           int64             `json:"start"`
+	End             int64             `json:"end"`
+	Calls           int64             `json:"calls"`
+	Errors          int64             `json:"errors"`
+	ErrorRate       float64           `json:"errorRate"`
+	RecentErrorLogs []serviceErrorLog `json:"recentErrorLogs"`
+	SlowestTraces   []exemplarTrace   `json:"slowestTraces"`
+	FiringAlerts    []types.Alert     `json:"firingAlerts"`
+}
+
+func (h *Handler) handleInvestigateService(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	service, errResult := requireStringArg(args, "service")
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	logLimit, err := intArg(args, "logLimit", defaultServiceErrorLogs)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	logLimit = min(logLimit, maxServiceErrorLogs)
+	traceLimit, err := intArg(args, "traceLimit", defaultInvestigateTraces)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	traceLimit = min(traceLimit, maxInvestigateTraces)
+
+	start, end, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	serviceFilter := serviceFilterExpr([]string{service})
+	payloads := []*types.QueryPayload{
+		buildServiceErrorsTracesPayload(serviceFilter, nil, start, end, 0),
+		types.BuildLogsQueryPayload(start, end, serviceFilter+" AND "+errorSeverityFilter, logLimit, 0),
+		types.BuildTracesQueryPayload(start, end, serviceFilter, traceLimit*exemplarRowsPerTrace, 0, "duration_nano", "desc"),
+	}
+	bodies := make([][]byte, len(payloads))
+	for i, payload := range payloads {
+		body, err := json.Marshal(payload)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to marshal investigate service query payload", logpkg.ErrAttr(err))
+			return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+		}
+		bodies[i] = body
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_investigate_service",
+		slog.String("service", service), slog.Int64("start", start), slog.Int64("end", end))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	// Alerts come from a different endpoint, so they are fetched alongside
+	// the parallel queries rather than through runQueriesParallel.
+	var (
+		alertsData json.RawMessage
+		alertsErr  error
+	)
+	alertsDone := make(chan struct{})
+	go func() {
+		defer close(alertsDone)
+		active := true
+		alertsData, alertsErr = client.ListAlerts(ctx, types.ListAlertsParams{Active: &active})
+	}()
+	results, err := runQueriesParallel(ctx, client, bodies, false)
+	<-alertsDone
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to investigate service", err)
+		return upstreamQueryError(err, "traces"), nil
+	}
+
+	out := investigateServiceResponse{
+		Service:         service,
+		Start:           start,
+		End:             end,
+		RecentErrorLogs: []serviceErrorLog{},
+		SlowestTraces:   []exemplarTrace{},
+		FiringAlerts:    []types.Alert{},
+	}
+	partErrs := []error{results[0].Err, results[1].Err, results[2].Err, alertsErr}
+	for _, partErr := range partErrs {
+		if isUpstreamAuthError(partErr) {
+			return upstreamError(partErr), nil
+		}
+	}
+	var notes []string
+	failed := 0
+	for i, partErr := range partErrs {
+		if partErr != nil {
+			failed++
+			h.logQueryFailure(ctx, "Failed to investigate service", partErr, slog.String("part", investigateParts[i]))
+			notes = append(notes, fmt.Sprintf("note: the %s lookup failed and is omitted: %s", investigateParts[i], partErr.Error()))
+		}
+	}
+	if failed == len(partErrs) {
+		return upstreamQueryError(results[0].Err, "traces"), nil
+	}
+
+	if results[0].Err == nil {
+		for _, row := range parseServiceErrorCounts(results[0].Data) {
+			out.Calls, out.Errors, out.ErrorRate = row.Calls, row.Errors, row.ErrorRate
+		}
+		if out.Calls == 0 {
+			notes = append(notes, fmt.Sprintf("note: no spans from %q in this window. Check the service name with signoz_list_services or widen timeRange.", service))
+		}
+	}
+	if results[1].Err == nil {
+		out.RecentErrorLogs = parseServiceErrorLogs(results[1].Data)
+	}
+	if results[2].Err == nil {
+		out.SlowestTraces = pickExemplars(parseExemplarRows(results[2].Data), traceLimit)
+		base, _ := util.GetSigNozURL(ctx)
+		for i := range out.SlowestTraces {
+			if link, ok := util.ResourceWebURL(base, "trace", out.SlowestTraces[i].TraceID); ok {
+				out.SlowestTraces[i].WebURL = link
+			}
+		}
+	}
+	if alertsErr == nil {
+		alerts, err := firingAlertsForService(ctx, alertsData, service)
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to parse alerts response", logpkg.ErrAttr(err), slog.String("response", logpkg.TruncBody(alertsData)))
+			notes = append(notes, "note: the firing alerts lookup returned an unreadable response and is omitted: "+err.Error())
+		} else {
+			out.FiringAlerts = alerts
+		}
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal service investigation", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal service investigation: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// firingAlertsForService keeps the alerts in a GET /api/v1/alerts response
+// whose service label names service.
+func firingAlertsForService(ctx context.Context, data json.RawMessage, service string) ([]types.Alert, error) {
+	var resp types.APIAlertsResponse
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, err
+	}
+	base, _ := util.GetSigNozURL(ctx)
+	out := []types.Alert{}
+	for _, a := range resp.Data {
+		matched := false
+		for _, key := range alertServiceLabels {
+			if a.Labels[key] == service {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			continue
+		}
+		webURL, _ := util.ResourceWebURL(base, "alert", a.Labels["ruleId"])
+		out = append(out, a.Alert(webURL))
+	}
+	return out, nil
+}
@@ -0,0 +1,122 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const investigateSlowTracesResponse = `{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":[
+	{"timestamp":"2026-01-01T00:00:03Z","data":{"trace_id":"t1","span_id":"s1","name":"POST /pay","duration_nano":3000000000}},
+	{"timestamp":"2026-01-01T00:00:03Z","data":{"trace_id":"t1","span_id":"s2","name":"db.query","duration_nano":2000000000}},
+	{"timestamp":"2026-01-01T00:00:02Z","data":{"trace_id":"t2","span_id":"s3","name":"GET /cart","duration_nano":1000000000}}
+]}]}}}`
+
+const investigateAlertsResponse = `{"status":"success","data":[
+	{"labels":{"alertname":"High error rate","ruleId":"7","severity":"critical","service.name":"checkout"},"status":{"state":"active"},"startsAt":"2026-01-01T00:00:00Z"},
+	{"labels":{"alertname":"Old style","ruleId":"8","severity":"warning","service_name":"checkout"},"status":{"state":"active"}},
+	{"labels":{"alertname":"Other service","ruleId":"9","service.name":"cart"},"status":{"state":"active"}}
+]}`
+
+func investigateQueryMock(t *testing.T, listAlerts func(context.Context, types.ListAlertsParams) (json.RawMessage, error)) *client.MockClient {
+	return &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var payload types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+			assert.Contains(t, spec.Filter.Expression, "service.name = 'checkout'")
+			switch {
+			case spec.Signal == "logs":
+				return json.RawMessage(serviceErrorLogsResponse), nil
+			case payload.RequestType == "scalar":
+				return json.RawMessage(serviceErrorsScalarResponse("", `[200,20]`)), nil
+			default:
+				assert.Equal(t, "duration_nano", spec.Order[0].Key.Name)
+				assert.Equal(t, "desc", spec.Order[0].Direction)
+				return json.RawMessage(investigateSlowTracesResponse), nil
+			}
+		},
+		ListAlertsFn: listAlerts,
+	}
+}
+
+func TestHandleInvestigateService(t *testing.T) {
+	mock := investigateQueryMock(t, func(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
+		require.NotNil(t, params.Active)
+		assert.True(t, *params.Active)
+		return json.RawMessage(investigateAlertsResponse), nil
+	})
+	h := newTestHandler(mock)
+
+	result, err := h.handleInvestigateService(testCtx(), makeToolRequest("signoz_investigate_service", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out investigateServiceResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(200), out.Calls)
+	assert.InDelta(t, 0.1, out.ErrorRate, 1e-9)
+	assert.Len(t, out.RecentErrorLogs, 2)
+	require.Len(t, out.SlowestTraces, 2)
+	assert.Equal(t, "t1", out.SlowestTraces[0].TraceID)
+	assert.Equal(t, "POST /pay", out.SlowestTraces[0].Operation)
+	assert.Equal(t, "t2", out.SlowestTraces[1].TraceID)
+	require.Len(t, out.FiringAlerts, 2)
+	assert.Equal(t, "High error rate", out.FiringAlerts[0].Alertname)
+	assert.Equal(t, "8", out.FiringAlerts[1].RuleID)
+	assert.Len(t, result.Content, 1)
+}
+
+func TestHandleInvestigateService_AlertsFailure(t *testing.T) {
+	mock := investigateQueryMock(t, func(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
+		return nil, errors.New("unexpected status 500: alertmanager down")
+	})
+	h := newTestHandler(mock)
+
+	result, err := h.handleInvestigateService(testCtx(), makeToolRequest("signoz_investigate_service", map[string]any{"service": "checkout", "traceLimit": "1"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out investigateServiceResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Len(t, out.SlowestTraces, 1)
+	assert.Empty(t, out.FiringAlerts)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "firing alerts lookup failed")
+}
+
+func TestHandleInvestigateService_AllPartsFail(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return nil, errors.New("unexpected status 503: unavailable")
+		},
+		ListAlertsFn: func(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
+			return nil, errors.New("unexpected status 503: unavailable")
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleInvestigateService(testCtx(), makeToolRequest("signoz_investigate_service", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
+
+func TestHandleInvestigateService_AuthFailurePropagates(t *testing.T) {
+	mock := investigateQueryMock(t, func(ctx context.Context, params types.ListAlertsParams) (json.RawMessage, error) {
+		return nil, &client.HTTPStatusError{StatusCode: 403, Body: `{"error":"forbidden"}`}
+	})
+	h := newTestHandler(mock)
+
+	result, err := h.handleInvestigateService(testCtx(), makeToolRequest("signoz_investigate_service", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, CodePermissionDenied, resultCode(t, result))
+}
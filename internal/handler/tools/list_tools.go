@@ -0,0 +1,124 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+// otherToolGroup holds registered tools that no built-in group owns, such
+// as tools added through AddTool during server composition.
+const otherToolGroup = "other"
+
+var (
+	toolGroupIndexOnce sync.Once
+	toolGroupIndexMap  map[string]string
+)
+
+// toolGroupIndex maps every built-in tool name to its group. It is built
+// once by registering each group on its own scratch server.
+func toolGroupIndex() map[string]string {
+	toolGroupIndexOnce.Do(func() {
+		h := &Handler{logger: slog.New(slog.DiscardHandler)}
+		toolGroupIndexMap = make(map[string]string)
+		for _, reg := range toolGroupRegistrations() {
+			s := server.NewMCPServer("tool-inventory", "0.0.0", server.WithToolCapabilities(false))
+			reg.register(h, s)
+			for name := range s.ListTools() {
+				toolGroupIndexMap[name] = reg.group
+			}
+		}
+	})
+	return toolGroupIndexMap
+}
+
+func (h *Handler) RegisterListToolsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering list tools handlers")
+
+	tool := mcp.NewTool("signoz_list_tools",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this to see which SigNoz tools this server exposes before picking one, or to check whether a tool is missing because ENABLED_TOOL_GROUPS or DISABLED_TOOLS turned it off. Returns every registered tool's name, first description sentence, and read-only flag, grouped by category (metrics, alerts, dashboards, services, logs, traces, and so on). It does not query SigNoz."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+	)
+
+	h.addTool(s, tool, func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return h.handleListTools(ctx, s, req)
+	})
+}
+
+type listToolsResponse struct {
+	Total  int                `json:"total"`
+	Groups []toolGroupSummary `json:"groups"`
+}
+
+type toolGroupSummary struct {
+	Group string        `json:"group"`
+	Tools []toolSummary `json:"tools"`
+}
+
+type toolSummary struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ReadOnly    bool   `json:"readOnly"`
+}
+
+// handleListTools reports the tools registered on s, so the listing always
+// matches what the client can call.
+func (h *Handler) handleListTools(ctx context.Context, s *server.MCPServer, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.logger.DebugContext(ctx, "Tool called: signoz_list_tools")
+
+	index := toolGroupIndex()
+	byGroup := make(map[string][]toolSummary)
+	registered := s.ListTools()
+	for name, entry := range registered {
+		group, ok := index[name]
+		if !ok {
+			group = otherToolGroup
+		}
+		readOnly := entry.Tool.Annotations.ReadOnlyHint
+		byGroup[group] = append(byGroup[group], toolSummary{
+			Name:        name,
+			Description: firstSentence(entry.Tool.Description),
+			ReadOnly:    readOnly != nil && *readOnly,
+		})
+	}
+
+	out := listToolsResponse{Total: len(registered), Groups: make([]toolGroupSummary, 0, len(byGroup))}
+	for group, tools := range byGroup {
+		slices.SortFunc(tools, func(a, b toolSummary) int { return cmp.Compare(a.Name, b.Name) })
+		out.Groups = append(out.Groups, toolGroupSummary{Group: group, Tools: tools})
+	}
+	slices.SortFunc(out.Groups, func(a, b toolGroupSummary) int { return cmp.Compare(a.Group, b.Group) })
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal tool list", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal tool list: " + err.Error()), nil
+	}
+	return structuredResult(resp), nil
+}
+
+// firstSentence returns desc up to the end of its first sentence, skipping
+// the periods in abbreviations such as "e.g." and "i.e.".
+func firstSentence(desc string) string {
+	desc = strings.TrimSpace(desc)
+	for i := 0; i < len(desc); i++ {
+		if desc[i] != '.' || (i+1 < len(desc) && desc[i+1] != ' ' && desc[i+1] != '\n') {
+			continue
+		}
+		if strings.HasSuffix(desc[:i], "e.g") || strings.HasSuffix(desc[:i], "i.e") || strings.HasSuffix(desc[:i], "vs") {
+			continue
+		}
+		return desc[:i+1]
+	}
+	return desc
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHandleListTools(t *testing.T) {
+	h := newTestHandler(nil)
+	h.enabledToolGroups = []string{"logs", "server"}
+	h.disabledTools = []string{"signoz_export_logs"}
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	h.RegisterAllToolHandlers(s)
+
+	result, err := h.handleListTools(testCtx(), s, makeToolRequest("signoz_list_tools", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out listToolsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, len(s.ListTools()), out.Total)
+	groups := map[string]map[string]toolSummary{}
+	for _, g := range out.Groups {
+		groups[g.Group] = map[string]toolSummary{}
+		for _, tool := range g.Tools {
+			groups[g.Group][tool.Name] = tool
+		}
+	}
+	assert.Len(t, groups, 2)
+	require.Contains(t, groups["logs"], "signoz_search_logs")
+	assert.NotContains(t, groups["logs"], "signoz_export_logs")
+	assert.Contains(t, groups["server"], "signoz_list_tools")
+
+	search := groups["logs"]["signoz_search_logs"]
+	assert.True(t, search.ReadOnly)
+	assert.NotEmpty(t, search.Description)
+	assert.Equal(t, firstSentence(search.Description), search.Description)
+}
+
+func TestFirstSentence(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"", ""},
+		{"One sentence", "One sentence"},
+		{"Lists things. Use it often.", "Lists things."},
+		{"Use a window, e.g. 1h. Then more.", "Use a window, e.g. 1h."},
+		{"Reads v1.2 rules. More.", "Reads v1.2 rules."},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, firstSentence(tt.in), tt.in)
+	}
+}
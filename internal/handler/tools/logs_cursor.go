@@ -0,0 +1,135 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// logCursor marks the last row of a signoz_search_logs page. Rows are ordered
+// by timestamp desc, id desc, so the next page is every row strictly after
+// it in that order. The cursor is opaque to callers: base64url of this JSON.
+type logCursor struct {
+	// TimestampNano is a decimal string so the value survives any JSON
+	// number handling on the client side.
+	TimestampNano string `json:"ts"`
+	ID            string `json:"id"`
+}
+
+func (c logCursor) encode() string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeLogCursor(s string) (logCursor, error) {
+	var c logCursor
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, fmt.Errorf("cursor is not a value returned by a previous signoz_search_logs call")
+	}
+	if err := json.Unmarshal(raw, &c); err != nil || c.ID == "" {
+		return c, fmt.Errorf("cursor is not a value returned by a previous signoz_search_logs call")
+	}
+	if _, err := strconv.ParseInt(c.TimestampNano, 10, 64); err != nil {
+		return c, fmt.Errorf("cursor is not a value returned by a previous signoz_search_logs call")
+	}
+	return c, nil
+}
+
+// filterExpr is the keyset condition selecting rows after the cursor.
+func (c logCursor) filterExpr() string {
+	return fmt.Sprintf("(timestamp < %s OR (timestamp = %s AND id < %s))", c.TimestampNano, c.TimestampNano, quoteFilterString(c.ID))
+}
+
+// withLogCursor ANDs the cursor condition onto filterExpr, parenthesizing
+// the existing expression so an OR inside it cannot escape the cursor.
+func withLogCursor(filterExpr string, c logCursor) string {
+	if filterExpr == "" {
+		return c.filterExpr()
+	}
+	return "(" + filterExpr + ") AND " + c.filterExpr()
+}
+
+// lastLogCursor builds the cursor for the last row of a raw logs response.
+// It returns false when the response has no rows or the last row lacks a
+// usable timestamp or id.
+func lastLogCursor(payload []byte) (logCursor, bool) {
+	var env struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Rows []json.RawMessage `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &env); err != nil {
+		return logCursor{}, false
+	}
+	results := env.Data.Data.Results
+	for i := len(results) - 1; i >= 0; i-- {
+		if rows := results[i].Rows; len(rows) > 0 {
+			return rowLogCursor(rows[len(rows)-1])
+		}
+	}
+	return logCursor{}, false
+}
+
+// rowLogCursor builds the cursor pointing at one raw logs row.
+func rowLogCursor(row json.RawMessage) (logCursor, bool) {
+	var r struct {
+		Timestamp json.RawMessage `json:"timestamp"`
+		Data      struct {
+			ID string `json:"id"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(row, &r); err != nil {
+		return logCursor{}, false
+	}
+	ts, ok := rowTimestampNano(r.Timestamp)
+	if !ok || r.Data.ID == "" {
+		return logCursor{}, false
+	}
+	return logCursor{TimestampNano: strconv.FormatInt(ts, 10), ID: r.Data.ID}, true
+}
+
+// rowTimestampNano reads a raw row timestamp, which QB v5 renders as an
+// RFC 3339 string; an integer is taken as unix nanoseconds.
+func rowTimestampNano(raw json.RawMessage) (int64, bool) {
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		t, err := time.Parse(time.RFC3339Nano, s)
+		if err != nil {
+			return 0, false
+		}
+		return t.UnixNano(), true
+	}
+	n, err := strconv.ParseInt(string(bytes.TrimSpace(raw)), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// injectNextCursor adds a top-level "nextCursor" field to a query response,
+// leaving every other field byte-for-byte intact. It fails open to the
+// original payload.
+func injectNextCursor(payload []byte, cursor string) []byte {
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &top); err != nil || top == nil {
+		return payload
+	}
+	encoded, err := json.Marshal(cursor)
+	if err != nil {
+		return payload
+	}
+	top["nextCursor"] = encoded
+	out, err := json.Marshal(top)
+	if err != nil {
+		return payload
+	}
+	return out
+}
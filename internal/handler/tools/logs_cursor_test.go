@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestLogCursor_RoundTrip(t *testing.T) {
+	c := logCursor{TimestampNano: "1700000000123456789", ID: "0abc"}
+	got, err := decodeLogCursor(c.encode())
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got != c {
+		t.Fatalf("round trip = %+v, want %+v", got, c)
+	}
+}
+
+func TestDecodeLogCursor_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"not base64!",
+		"e30",                                  // {}
+		logCursor{ID: "x"}.encode(),            // no timestamp
+		logCursor{TimestampNano: "1"}.encode(), // no id
+	} {
+		if _, err := decodeLogCursor(s); err == nil {
+			t.Errorf("decodeLogCursor(%q) = nil error, want error", s)
+		}
+	}
+}
+
+func TestWithLogCursor(t *testing.T) {
+	c := logCursor{TimestampNano: "42", ID: "a'b"}
+	if got, want := withLogCursor("", c), `(timestamp < 42 OR (timestamp = 42 AND id < 'a\'b'))`; got != want {
+		t.Fatalf("empty filter: got %q, want %q", got, want)
+	}
+	got := withLogCursor("a = 1 OR b = 2", c)
+	if !strings.HasPrefix(got, "(a = 1 OR b = 2) AND (timestamp < 42") {
+		t.Fatalf("existing filter not parenthesized: %q", got)
+	}
+}
+
+func TestLastLogCursor(t *testing.T) {
+	payload := []byte(`{"data":{"data":{"results":[{"rows":[
+		{"timestamp":"2024-01-02T03:04:05.000000001Z","data":{"id":"first"}},
+		{"timestamp":"2024-01-02T03:04:05.5Z","data":{"id":"last"}}
+	]}]}}}`)
+	c, ok := lastLogCursor(payload)
+	if !ok {
+		t.Fatal("lastLogCursor = !ok")
+	}
+	if c.ID != "last" || c.TimestampNano != "1704164645500000000" {
+		t.Fatalf("cursor = %+v", c)
+	}
+	if _, ok := lastLogCursor([]byte(`{"data":{"data":{"results":[{"rows":[]}]}}}`)); ok {
+		t.Fatal("empty rows: lastLogCursor = ok, want !ok")
+	}
+}
+
+func TestHandleSearchLogs_Cursor(t *testing.T) {
+	page := `{"status":"success","data":{"data":{"results":[{"rows":[
+		{"timestamp":"2024-01-02T03:04:05Z","data":{"id":"r1"}},
+		{"timestamp":"2024-01-02T03:04:04Z","data":{"id":"r2"}}
+	]}]}}}`
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(page), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	first, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"query": "severity_text = 'ERROR'", "limit": "2", "timeRange": "1h",
+	}))
+	if err != nil || first.IsError {
+		t.Fatalf("first page: err=%v result=%v", err, first)
+	}
+	var body struct {
+		NextCursor string `json:"nextCursor"`
+	}
+	if err := json.Unmarshal([]byte(textContent(t, first)), &body); err != nil {
+		t.Fatalf("unmarshal first page: %v", err)
+	}
+	if body.NextCursor == "" {
+		t.Fatal("full page has no nextCursor")
+	}
+
+	second, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"query": "severity_text = 'ERROR'", "limit": "2", "timeRange": "1h", "cursor": body.NextCursor,
+	}))
+	if err != nil || second.IsError {
+		t.Fatalf("second page: err=%v result=%v", err, second)
+	}
+	var payload types.QueryPayload
+	if err := json.Unmarshal(captured, &payload); err != nil {
+		t.Fatalf("failed to parse captured query: %v", err)
+	}
+	spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	want := "(severity_text = 'ERROR') AND (timestamp < 1704164644000000000 OR (timestamp = 1704164644000000000 AND id < 'r2'))"
+	if spec.Filter == nil || spec.Filter.Expression != want {
+		t.Fatalf("filter = %+v, want %q", spec.Filter, want)
+	}
+	if spec.Offset != 0 {
+		t.Fatalf("offset = %d, want 0 in cursor mode", spec.Offset)
+	}
+}
+
+func TestHandleSearchLogs_CursorWithOffsetRejected(t *testing.T) {
+	called := false
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			called = true
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"timeRange": "1h", "offset": "10",
+		"cursor": logCursor{TimestampNano: "1", ID: "x"}.encode(),
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Fatalf("code = %q, want %q", code, CodeValidationFailed)
+	}
+	if called {
+		t.Fatal("backend was called despite cursor+offset")
+	}
+}
@@ -2,6 +2,7 @@ package tools
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"github.com/SigNoz/signoz-mcp-server/pkg/types"
@@ -26,8 +27,14 @@ type SearchLogsRequest struct {
 	Limit            int
 	LimitClamped     bool
 	Offset           int
-	StartTime        int64
-	EndTime          int64
+	// Cursor, when set, switches to keyset paging after that row; Offset is
+	// then always 0.
+	Cursor *logCursor
+	// SelectFields, when set, replaces the default of every column.
+	SelectFields []types.SelectField
+	Format       string
+	StartTime    int64
+	EndTime      int64
 }
 
 func parseSearchLogsArgs(args map[string]any) (*SearchLogsRequest, error) {
@@ -39,6 +46,23 @@ func parseSearchLogsArgs(args map[string]any) (*SearchLogsRequest, error) {
 	severity, _ := args["severity"].(string)
 	searchText, _ := args["searchText"].(string)
 	filterExpr := buildLogFilterExpr(filter, service, severity, searchText)
+	bodyFilter, err := buildLogBodyFilter(args)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := conditionsFilter(args)
+	if err != nil {
+		return nil, err
+	}
+	for _, cond := range []string{conditions, bodyFilter} {
+		if cond == "" {
+			continue
+		}
+		if filterExpr != "" {
+			filterExpr += " AND "
+		}
+		filterExpr += cond
+	}
 
 	limit, err := intArg(args, "limit", types.DefaultRawQueryLimit)
 	if err != nil {
@@ -51,6 +75,29 @@ func parseSearchLogsArgs(args map[string]any) (*SearchLogsRequest, error) {
 		return nil, err
 	}
 
+	var cursor *logCursor
+	if raw, _ := args["cursor"].(string); strings.TrimSpace(raw) != "" {
+		if offset > 0 {
+			return nil, fmt.Errorf("cursor and offset cannot be combined; cursor paging continues directly after the cursor row")
+		}
+		c, err := decodeLogCursor(strings.TrimSpace(raw))
+		if err != nil {
+			return nil, err
+		}
+		cursor = &c
+		filterExpr = withLogCursor(filterExpr, c)
+	}
+
+	selectFields, err := logSelectFieldsArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := parseOutputFormat(args, formatNDJSON, formatCompact)
+	if err != nil {
+		return nil, err
+	}
+
 	startTime, endTime, err := resolveTimestamps(args, "1h")
 	if err != nil {
 		return nil, err
@@ -61,11 +108,64 @@ func parseSearchLogsArgs(args map[string]any) (*SearchLogsRequest, error) {
 		Limit:            limit,
 		LimitClamped:     clamped,
 		Offset:           offset,
+		Cursor:           cursor,
+		SelectFields:     selectFields,
+		Format:           format,
 		StartTime:        startTime,
 		EndTime:          endTime,
 	}, nil
 }
 
+// logColumnMetadata describes the built-in log columns, so a selectFields
+// entry naming one is sent with its context and type. Any other name is sent
+// bare and resolved by the backend.
+var logColumnMetadata = map[string]types.SelectField{
+	"timestamp":       {Name: "timestamp", FieldDataType: "number", Signal: "logs", FieldContext: "log"},
+	"id":              {Name: "id", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+	"body":            {Name: "body", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+	"severity_text":   {Name: "severity_text", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+	"severity_number": {Name: "severity_number", FieldDataType: "number", Signal: "logs", FieldContext: "log"},
+	"trace_id":        {Name: "trace_id", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+	"span_id":         {Name: "span_id", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+	"trace_flags":     {Name: "trace_flags", FieldDataType: "number", Signal: "logs", FieldContext: "log"},
+	"scope_name":      {Name: "scope_name", FieldDataType: "string", Signal: "logs", FieldContext: "scope"},
+	"scope_version":   {Name: "scope_version", FieldDataType: "string", Signal: "logs", FieldContext: "scope"},
+}
+
+// logSelectFieldsArg parses the comma-separated selectFields argument into
+// log columns. timestamp and id are added first when missing, because the
+// pagination cursor is built from them. It returns nil when the argument is
+// absent.
+func logSelectFieldsArg(args map[string]any) ([]types.SelectField, error) {
+	var fields []types.SelectField
+	seen := map[string]bool{}
+	for _, name := range strings.Split(stringValue(args["selectFields"]), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !isTraceOrderField(name) {
+			return nil, fmt.Errorf(`invalid "selectFields" field %q: use log field names such as "body" or "k8s.pod.name", separated by commas`, name)
+		}
+		seen[name] = true
+		field, ok := logColumnMetadata[name]
+		if !ok {
+			field = types.SelectField{Name: name, Signal: "logs"}
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	var required []types.SelectField
+	for _, name := range []string{"timestamp", "id"} {
+		if !seen[name] {
+			required = append(required, logColumnMetadata[name])
+		}
+	}
+	return append(required, fields...), nil
+}
+
 // buildLogFilterExpr combines with log specific  filters.
 func buildLogFilterExpr(query, service, severity, searchText string) string {
 	var parts []string
@@ -83,3 +183,35 @@ func buildLogFilterExpr(query, service, severity, searchText string) string {
 	}
 	return strings.Join(parts, " AND ")
 }
+
+// bodyPathPattern matches a JSON body path such as "tags" or "user.roles":
+// dot-separated keys of letters, digits, underscores, and dashes.
+var bodyPathPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_-]*(\.[A-Za-z_][A-Za-z0-9_-]*)*$`)
+
+// buildLogBodyFilter turns the bodyHasToken and bodyArrayField/
+// bodyHasArrayValue arguments into hasToken(body, ...) and
+// has(body.<path>[*], ...) conditions joined with AND. The array field may be
+// given with or without the "body." prefix and "[*]" suffix.
+func buildLogBodyFilter(args map[string]any) (string, error) {
+	var parts []string
+	if token, _ := args["bodyHasToken"].(string); strings.TrimSpace(token) != "" {
+		parts = append(parts, fmt.Sprintf("hasToken(body, %s)", quoteFilterString(strings.TrimSpace(token))))
+	}
+
+	field, _ := args["bodyArrayField"].(string)
+	field = strings.TrimSpace(field)
+	value, _ := args["bodyHasArrayValue"].(string)
+	value = strings.TrimSpace(value)
+	switch {
+	case field == "" && value == "":
+	case field == "" || value == "":
+		return "", fmt.Errorf(`"bodyArrayField" and "bodyHasArrayValue" must be set together, e.g. bodyArrayField="tags", bodyHasArrayValue="production"`)
+	default:
+		path := strings.TrimSuffix(strings.TrimPrefix(field, "body."), "[*]")
+		if !bodyPathPattern.MatchString(path) {
+			return "", fmt.Errorf(`invalid "bodyArrayField" %q: use a dot-separated JSON path into the log body such as "tags" or "request.ids"`, field)
+		}
+		parts = append(parts, fmt.Sprintf("has(body.%s[*], %s)", path, quoteFilterString(value)))
+	}
+	return strings.Join(parts, " AND "), nil
+}
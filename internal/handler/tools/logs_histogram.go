@@ -0,0 +1,182 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"math"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	// minHistogramStepSeconds is the finest bucket the histogram uses.
+	minHistogramStepSeconds = 5
+	// histogramTargetBuckets is how many buckets the auto step aims for.
+	histogramTargetBuckets = 300
+)
+
+func (h *Handler) RegisterLogsHistogramHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering logs histogram handlers")
+
+	tool := mcp.NewTool("signoz_get_logs_histogram",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user wants the shape of log volume over time—spikes, drops, or when an error burst started—before reading individual logs. Returns matching log counts per time bucket; the bucket size is chosen automatically (about 300 buckets, at least 5s each). Use signoz_aggregate_logs for grouped breakdowns or other aggregations and signoz_search_logs for the log records themselves. Defaults to the last 1 hour."),
+		mcp.WithString("filter", mcp.Description(logsFilterParamDescription)),
+		mcp.WithString("service", mcp.Description("Optional service name to filter by (adds service.name = '<value>').")),
+		mcp.WithString("severity", mcp.Description("Filter on severity_text, e.g. ERROR.")),
+		mcp.WithString("searchText", mcp.Description("Text to search for in log body (uses CONTAINS matching).")),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetLogsHistogram)
+}
+
+type logsHistogramBucket struct {
+	TS    int64 `json:"ts"`
+	Count int64 `json:"count"`
+}
+
+type logsHistogramResponse struct {
+	Start       int64                 `json:"start"`
+	End         int64                 `json:"end"`
+	StepSeconds int64                 `json:"stepSeconds"`
+	Total       int64                 `json:"total"`
+	Buckets     []logsHistogramBucket `json:"buckets"`
+}
+
+func (h *Handler) handleGetLogsHistogram(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := req.Params.Arguments.(map[string]any)
+	if !ok {
+		return notAJSONObjectError(), nil
+	}
+
+	filter, err := readFilterExpr(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	service, _ := args["service"].(string)
+	severity, _ := args["severity"].(string)
+	searchText, _ := args["searchText"].(string)
+	filterExpr := buildLogFilterExpr(filter, service, severity, searchText)
+
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	step := histogramStepSeconds(startTime, endTime)
+	queryPayload := types.BuildAggregateQueryPayload("logs",
+		startTime, endTime, "count()", filterExpr, nil,
+		"count()", "desc", types.DefaultAggregateQueryLimit,
+		"time_series", &step,
+	)
+	queryJSON, err := json.Marshal(queryPayload)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal logs histogram payload", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_logs_histogram",
+		slog.String("filter", filterExpr), slog.Int64("step_seconds", step))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	result, err := client.QueryBuilderV5(ctx, queryJSON)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to get logs histogram", err)
+		return upstreamQueryError(err, "logs"), nil
+	}
+
+	out := logsHistogramResponse{
+		Start:       startTime,
+		End:         endTime,
+		StepSeconds: step,
+		Buckets:     histogramBuckets(result, startTime, endTime, step),
+	}
+	for _, b := range out.Buckets {
+		out.Total += b.Count
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal logs histogram", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal logs histogram: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(body, backendWarningNotes(ctx, h.logger, "signoz_get_logs_histogram", result)...), nil
+}
+
+// histogramStepSeconds picks the bucket size for [start, end] in unix
+// milliseconds: the range split into histogramTargetBuckets, rounded up, and
+// never finer than minHistogramStepSeconds.
+func histogramStepSeconds(start, end int64) int64 {
+	rangeSeconds := float64(end-start) / 1000
+	step := int64(math.Ceil(rangeSeconds / histogramTargetBuckets))
+	return max(step, minHistogramStepSeconds)
+}
+
+// histogramBuckets sums the count series of a time_series response into
+// step-aligned buckets covering [start, end]. Buckets the backend omitted
+// are reported as zero so gaps show up as such rather than disappearing.
+func histogramBuckets(payload json.RawMessage, start, end, step int64) []logsHistogramBucket {
+	stepMs := step * 1000
+	counts := make(map[int64]int64)
+	var env struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Aggregations []struct {
+						Series []struct {
+							Values []struct {
+								Timestamp any `json:"timestamp"`
+								Value     any `json:"value"`
+							} `json:"values"`
+						} `json:"series"`
+					} `json:"aggregations"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &env); err == nil {
+		for _, res := range env.Data.Data.Results {
+			for _, agg := range res.Aggregations {
+				for _, series := range agg.Series {
+					for _, v := range series.Values {
+						ts, ok := finiteFloat(v.Timestamp)
+						if !ok {
+							continue
+						}
+						n, ok := finiteFloat(v.Value)
+						if !ok {
+							continue
+						}
+						bucket := int64(ts) / stepMs * stepMs
+						counts[bucket] += int64(math.Round(n))
+					}
+				}
+			}
+		}
+	}
+
+	buckets := []logsHistogramBucket{}
+	first := start / stepMs * stepMs
+	for ts := first; ts < end; ts += stepMs {
+		buckets = append(buckets, logsHistogramBucket{TS: ts, Count: counts[ts]})
+		delete(counts, ts)
+	}
+	// Points outside the requested window are kept rather than dropped.
+	for ts, n := range counts {
+		buckets = append(buckets, logsHistogramBucket{TS: ts, Count: n})
+	}
+	sort.Slice(buckets, func(i, j int) bool { return buckets[i].TS < buckets[j].TS })
+	return buckets
+}
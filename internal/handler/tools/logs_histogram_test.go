@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestHistogramStepSeconds(t *testing.T) {
+	const minute = int64(60 * 1000)
+	assert.Equal(t, int64(5), histogramStepSeconds(0, 15*minute), "short ranges use the 5s floor")
+	assert.Equal(t, int64(12), histogramStepSeconds(0, 60*minute))
+	assert.Equal(t, int64(288), histogramStepSeconds(0, 24*60*minute))
+	assert.Equal(t, int64(7), histogramStepSeconds(0, 31*minute), "partial seconds round up")
+}
+
+func TestHandleGetLogsHistogram(t *testing.T) {
+	var payload types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			require.NoError(t, json.Unmarshal(body, &payload))
+			return json.RawMessage(`{"status":"success","data":{"type":"time_series","data":{"results":[{"queryName":"A","aggregations":[{"index":0,"series":[{"labels":[],"values":[
+				{"timestamp":1700000000000,"value":3},
+				{"timestamp":1700000010000,"value":"7"}
+			]}]}]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetLogsHistogram(testCtx(), makeToolRequest("signoz_get_logs_histogram", map[string]any{
+		"severity": "ERROR",
+		"filter":   "k8s.namespace.name = 'prod'",
+		"start":    "1700000000000",
+		"end":      "1700000025000",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	assert.Equal(t, "time_series", payload.RequestType)
+	spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, "logs", spec.Signal)
+	require.NotNil(t, spec.StepInterval)
+	assert.Equal(t, int64(5), *spec.StepInterval)
+	assert.Contains(t, spec.Filter.Expression, "k8s.namespace.name = 'prod'")
+	assert.Contains(t, spec.Filter.Expression, "severity_text = 'ERROR'")
+	agg, _ := json.Marshal(spec.Aggregations[0])
+	assert.JSONEq(t, `{"expression":"count()"}`, string(agg))
+
+	var out logsHistogramResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(5), out.StepSeconds)
+	assert.Equal(t, int64(10), out.Total)
+	assert.Equal(t, []logsHistogramBucket{
+		{TS: 1700000000000, Count: 3},
+		{TS: 1700000005000, Count: 0},
+		{TS: 1700000010000, Count: 7},
+		{TS: 1700000015000, Count: 0},
+		{TS: 1700000020000, Count: 0},
+	}, out.Buckets)
+}
+
+func TestHandleGetLogsHistogram_EmptyResult(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"type":"time_series","data":{"results":[]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetLogsHistogram(testCtx(), makeToolRequest("signoz_get_logs_histogram", map[string]any{
+		"timeRange": "1h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out logsHistogramResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(12), out.StepSeconds)
+	assert.Zero(t, out.Total)
+	assert.GreaterOrEqual(t, len(out.Buckets), 300)
+	for _, b := range out.Buckets {
+		assert.Zero(t, b.Count)
+	}
+}
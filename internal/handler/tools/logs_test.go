@@ -144,6 +144,64 @@ func TestHandleSearchLogs_ExplicitStartEndOverrideTimeRange(t *testing.T) {
 	}
 }
 
+func TestHandleSearchLogs_BodyJSONFilters(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_logs", map[string]any{
+		"service":           "checkout",
+		"bodyHasToken":      "john@test.com",
+		"bodyArrayField":    "body.tags[*]",
+		"bodyHasArrayValue": "prod's",
+		"conditions": []any{
+			map[string]any{"field": "body", "op": "REGEXP", "value": "user [0-9]+ (created|deleted)"},
+			map[string]any{"field": "k8s.pod.name", "op": "EXISTS"},
+		},
+	})
+
+	result, err := h.handleSearchLogs(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	var payload types.QueryPayload
+	if err := json.Unmarshal(captured, &payload); err != nil {
+		t.Fatalf("failed to parse captured query: %v", err)
+	}
+	got := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec).Filter.Expression
+	want := `service.name = 'checkout' AND body REGEXP 'user [0-9]+ (created|deleted)' AND k8s.pod.name EXISTS AND hasToken(body, 'john@test.com') AND has(body.tags[*], 'prod\'s')`
+	if got != want {
+		t.Fatalf("filter = %q, want %q", got, want)
+	}
+}
+
+func TestHandleSearchLogs_BodyArrayValidation(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	for _, args := range []map[string]any{
+		{"bodyArrayField": "tags"},
+		{"bodyHasArrayValue": "production"},
+		{"bodyArrayField": "tags) OR (1", "bodyHasArrayValue": "x"},
+		{"bodyArrayField": "user..ids", "bodyHasArrayValue": "x"},
+		{"conditions": []any{map[string]any{"field": "body", "op": "REGEXP", "value": "(?<!x)y"}}},
+		{"conditions": []any{map[string]any{"field": "service.name'", "op": "NOT EXISTS"}}},
+	} {
+		result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", args))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code := resultCode(t, result); code != CodeValidationFailed {
+			t.Fatalf("%v: expected %s, got %s", args, CodeValidationFailed, code)
+		}
+	}
+}
+
 func TestHandleSearchLogs_InvalidLimit(t *testing.T) {
 	mock := &client.MockClient{}
 	h := newTestHandler(mock)
@@ -161,6 +219,78 @@ func TestHandleSearchLogs_InvalidLimit(t *testing.T) {
 	}
 }
 
+func TestHandleSearchLogs_SelectFields(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success","result":[]}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_logs", map[string]any{
+		"selectFields": "body, k8s.pod.name,body",
+	})
+
+	result, err := h.handleSearchLogs(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	var payload types.QueryPayload
+	if err := json.Unmarshal(captured, &payload); err != nil {
+		t.Fatalf("failed to parse captured query: %v", err)
+	}
+	spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	want := []types.SelectField{
+		{Name: "timestamp", FieldDataType: "number", Signal: "logs", FieldContext: "log"},
+		{Name: "id", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+		{Name: "body", FieldDataType: "string", Signal: "logs", FieldContext: "log"},
+		{Name: "k8s.pod.name", Signal: "logs"},
+	}
+	if len(spec.SelectFields) != len(want) {
+		t.Fatalf("selectFields = %#v, want %#v", spec.SelectFields, want)
+	}
+	for i := range want {
+		if spec.SelectFields[i] != want[i] {
+			t.Errorf("selectFields[%d] = %#v, want %#v", i, spec.SelectFields[i], want[i])
+		}
+	}
+}
+
+func TestHandleSearchLogs_NoSelectFieldsReturnsAllColumns(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success","result":[]}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	if _, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(captured), `"selectFields":null`) {
+		t.Errorf("expected no selectFields in payload, got: %s", captured)
+	}
+}
+
+func TestHandleSearchLogs_InvalidSelectFields(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"selectFields": "body,'x'",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Errorf("code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
 func TestHandleAggregateLogs_Count(t *testing.T) {
 	var captured []byte
 	mock := &client.MockClient{
@@ -32,7 +32,10 @@ func (h *Handler) RegisterMetricCardinalityHandlers(s *server.MCPServer) {
 }
 
 func (h *Handler) handleCheckMetricCardinality(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := req.GetArguments()
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	metricName, errResult := requireStringArg(args, "metricName")
 	if errResult != nil {
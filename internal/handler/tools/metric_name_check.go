@@ -0,0 +1,272 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	// metricCheckLimit is the page size of each existence lookup. A full
+	// page without an exact match is inconclusive, since the match may be on
+	// a later page.
+	metricCheckLimit = 100
+	// maxMetricSuggestions bounds the "did you mean" list per missing name.
+	maxMetricSuggestions = 5
+
+	// metricNamingRule is the short form of the dot-suffix rule in the query
+	// builder guide, for tool descriptions and name-check errors.
+	metricNamingRule = "OpenTelemetry histogram and summary parts take a dot suffix: name.sum, name.count, name.bucket (not name_sum, name_count, name_bucket)."
+)
+
+// metricPartSuffixes are the histogram and summary parts that OpenTelemetry
+// metric names join with a dot.
+var metricPartSuffixes = []string{"sum", "count", "bucket", "min", "max", "quantile"}
+
+// PromQL metric references: a quoted UTF-8 name as the first selector
+// element ({"a.b"}), an explicit __name__ matcher, or a bare identifier
+// directly followed by a selector or range ({ or [).
+var (
+	promQLQuotedNamePattern = regexp.MustCompile(`\{\s*"([^"]+)"\s*[,}]`)
+	promQLNameLabelPattern  = regexp.MustCompile(`__name__\s*=\s*"([^"]+)"`)
+	promQLBareNamePattern   = regexp.MustCompile(`(?:^|[^a-zA-Z0-9_:."])([a-zA-Z_:][a-zA-Z0-9_:]*)\s*[{\[]`)
+	promQLOnlyNamePattern   = regexp.MustCompile(`^\s*([a-zA-Z_:][a-zA-Z0-9_:]*)\s*$`)
+	promQLStringPattern     = regexp.MustCompile(`"(?:[^"\\]|\\.)*"`)
+)
+
+// metricRef is one metric name a query reads, with the store to look it up in.
+type metricRef struct {
+	Name   string
+	Source string
+}
+
+// queryMetricRefs lists the metric names read by the metrics builder
+// queries and PromQL queries of a payload, in order and without duplicates.
+func queryMetricRefs(payload types.QueryPayload) []metricRef {
+	var refs []metricRef
+	seen := make(map[metricRef]bool)
+	add := func(ref metricRef) {
+		if ref.Name != "" && !seen[ref] {
+			seen[ref] = true
+			refs = append(refs, ref)
+		}
+	}
+	for _, q := range payload.CompositeQuery.Queries {
+		switch spec := q.Spec.(type) {
+		case types.QuerySpec:
+			if spec.Signal != "metrics" || spec.Disabled {
+				continue
+			}
+			for _, agg := range spec.Aggregations {
+				raw, err := json.Marshal(agg)
+				if err != nil {
+					continue
+				}
+				var m struct {
+					MetricName string `json:"metricName"`
+				}
+				if json.Unmarshal(raw, &m) == nil {
+					add(metricRef{Name: m.MetricName, Source: spec.Source})
+				}
+			}
+		case types.PromQLSpec:
+			if spec.Disabled {
+				continue
+			}
+			for _, name := range promQLMetricNames(spec.Query) {
+				add(metricRef{Name: name})
+			}
+		}
+	}
+	return refs
+}
+
+// promQLMetricNames extracts the metric names a PromQL expression selects.
+// It only reports names it is sure of; a name used without a selector in a
+// larger expression (e.g. "foo > 5") is not detected.
+func promQLMetricNames(query string) []string {
+	var names []string
+	for _, re := range []*regexp.Regexp{promQLQuotedNamePattern, promQLNameLabelPattern} {
+		for _, m := range re.FindAllStringSubmatch(query, -1) {
+			names = append(names, m[1])
+		}
+	}
+	// Blank out string literals so label values such as "/a[0]" are not
+	// taken for bare names.
+	unquoted := promQLStringPattern.ReplaceAllString(query, `""`)
+	for _, re := range []*regexp.Regexp{promQLBareNamePattern, promQLOnlyNamePattern} {
+		for _, m := range re.FindAllStringSubmatch(unquoted, -1) {
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
+
+// checkMetricNames verifies that each referenced metric exists and returns
+// a validation error naming the closest existing metrics for those that do
+// not. Lookups that fail or are inconclusive let the query run, so the check
+// never blocks a query SigNoz could have answered.
+func (h *Handler) checkMetricNames(ctx context.Context, client signozclient.Client, refs []metricRef) *mcp.CallToolResult {
+	var problems []string
+	for _, ref := range refs {
+		names, complete, err := searchMetricNames(ctx, client, ref.Name, ref.Source)
+		if err != nil {
+			h.logger.WarnContext(ctx, "Metric name check skipped",
+				slog.String("metricName", ref.Name), logpkg.ErrAttr(err))
+			continue
+		}
+		if !complete || slices.Contains(names, ref.Name) {
+			continue
+		}
+		problems = append(problems, missingMetricMessage(ref.Name, suggestMetricNames(ctx, client, ref, names)))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errorWithCode(CodeValidationFailed, strings.Join(problems, "\n")+
+		"\nMetric names are exact. "+metricNamingRule+" "+
+		"Find names with signoz_list_metrics(searchText=...); in PromQL, quote dotted names as {\"name.with.dots\"}.")
+}
+
+func missingMetricMessage(name string, suggestions []string) string {
+	if len(suggestions) == 0 {
+		return fmt.Sprintf("metric %q not found and no similar metric names exist.", name)
+	}
+	msg := fmt.Sprintf("metric %q not found. Did you mean: %s?", name, strings.Join(suggestions, ", "))
+	if dotted, ok := dotSuffixForm(name); ok && suggestions[0] == dotted {
+		msg += fmt.Sprintf(" Use %q: the %s part takes a dot suffix.", dotted, dotted[strings.LastIndexByte(dotted, '.')+1:])
+	}
+	return msg
+}
+
+// dotSuffixForm rewrites a name ending in an underscore-joined histogram or
+// summary part ("signoz_latency_bucket") to its dot form
+// ("signoz_latency.bucket").
+func dotSuffixForm(name string) (string, bool) {
+	for _, suffix := range metricPartSuffixes {
+		base, ok := strings.CutSuffix(name, "_"+suffix)
+		if ok && base != "" {
+			return base + "." + suffix, true
+		}
+	}
+	return "", false
+}
+
+// searchMetricNames returns the metric names matching searchText. complete
+// is false when the response could not be read or filled a whole page.
+func searchMetricNames(ctx context.Context, client signozclient.Client, searchText, source string) ([]string, bool, error) {
+	data, err := client.ListMetrics(ctx, 0, 0, metricCheckLimit, searchText, source)
+	if err != nil {
+		return nil, false, err
+	}
+	var resp struct {
+		Data struct {
+			Metrics *[]struct {
+				MetricName string `json:"metricName"`
+			} `json:"metrics"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil || resp.Data.Metrics == nil {
+		return nil, false, nil
+	}
+	names := make([]string, 0, len(*resp.Data.Metrics))
+	for _, m := range *resp.Data.Metrics {
+		if m.MetricName != "" {
+			names = append(names, m.MetricName)
+		}
+	}
+	return names, len(*resp.Data.Metrics) < metricCheckLimit, nil
+}
+
+// suggestMetricNames ranks existing metric names by similarity to a missing
+// one. Besides the names already found for the full name, it searches for
+// shorter prefixes ("signoz_latency_sum" -> "signoz_latency"), since a wrong
+// suffix or separator usually keeps the name from matching at all.
+func suggestMetricNames(ctx context.Context, client signozclient.Client, ref metricRef, found []string) []string {
+	candidates := make(map[string]bool)
+	for _, name := range found {
+		candidates[name] = true
+	}
+	for _, stem := range metricNameStems(ref.Name) {
+		names, _, err := searchMetricNames(ctx, client, stem, ref.Source)
+		if err != nil {
+			break
+		}
+		for _, name := range names {
+			candidates[name] = true
+		}
+		if len(candidates) >= maxMetricSuggestions {
+			break
+		}
+	}
+
+	target := normalizeMetricName(ref.Name)
+	ranked := make([]string, 0, len(candidates))
+	for name := range candidates {
+		ranked = append(ranked, name)
+	}
+	distance := make(map[string]int, len(ranked))
+	for _, name := range ranked {
+		distance[name] = editDistance(target, normalizeMetricName(name))
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if distance[ranked[i]] != distance[ranked[j]] {
+			return distance[ranked[i]] < distance[ranked[j]]
+		}
+		return ranked[i] < ranked[j]
+	})
+	return ranked[:min(len(ranked), maxMetricSuggestions)]
+}
+
+// metricNameStems returns up to two successively shorter prefixes of name,
+// cut at its last "_" or "." separators.
+func metricNameStems(name string) []string {
+	var stems []string
+	for len(stems) < 2 {
+		i := strings.LastIndexAny(name, "._")
+		if i < 3 {
+			break
+		}
+		name = name[:i]
+		stems = append(stems, name)
+	}
+	return stems
+}
+
+// normalizeMetricName folds case and treats "." and "_" alike, so a wrong
+// separator costs nothing when ranking suggestions.
+func normalizeMetricName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), ".", "_")
+}
+
+// editDistance is the Levenshtein distance between a and b in bytes.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(b)]
+}
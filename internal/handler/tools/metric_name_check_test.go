@@ -0,0 +1,145 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+// metricCatalogMock answers ListMetrics with the catalog names containing
+// searchText, and fails the test if a query reaches the backend when
+// wantQuery is false.
+func metricCatalogMock(t *testing.T, catalog []string, wantQuery bool) *client.MockClient {
+	return &client.MockClient{
+		ListMetricsFn: func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
+			var rows []map[string]any
+			for _, name := range catalog {
+				if strings.Contains(name, searchText) {
+					rows = append(rows, map[string]any{"metricName": name, "type": "Sum", "temporality": "Cumulative", "isMonotonic": true})
+				}
+			}
+			body, _ := json.Marshal(map[string]any{"status": "success", "data": map[string]any{"metrics": append([]map[string]any{}, rows...)}})
+			return body, nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			if !wantQuery {
+				t.Error("query must not run for an unknown metric")
+			}
+			return json.RawMessage(`{"status":"success","data":{"type":"time_series","data":{"results":[]}}}`), nil
+		},
+	}
+}
+
+var testMetricCatalog = []string{"signoz_latency.bucket", "signoz_latency.sum", "signoz_latency.count", "signoz_calls_total", "http.server.duration"}
+
+func TestPromQLMetricNames(t *testing.T) {
+	cases := map[string][]string{
+		`sum(rate(signoz_calls_total{service_name="api"}[5m])) by (operation)`:                            {"signoz_calls_total"},
+		`histogram_quantile(0.99, sum by (le) (rate({"signoz_latency.bucket", service_name="api"}[5m])))`: {"signoz_latency.bucket"},
+		`{__name__="http.server.duration", job="x"}`:                                                      {"http.server.duration"},
+		`up`:                          {"up"},
+		`rate(foo{path="/a[0]"}[1m])`: {"foo"},
+		`foo > 5`:                     nil,
+	}
+	for query, want := range cases {
+		assert.Equal(t, want, promQLMetricNames(query), query)
+	}
+}
+
+func TestHandleExecuteBuilderQuery_UnknownMetricSuggestsNames(t *testing.T) {
+	h := newTestHandler(metricCatalogMock(t, testMetricCatalog, false))
+	result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"query": map[string]any{
+			"schemaVersion": "v1", "start": 1711123200000, "end": 1711130400000, "requestType": "time_series",
+			"compositeQuery": map[string]any{"queries": []any{
+				map[string]any{"type": "promql", "spec": map[string]any{"name": "A", "query": "sum(rate(signoz_latency_sum[5m]))"}},
+			}},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	text := textContent(t, result)
+	assert.Contains(t, text, `metric "signoz_latency_sum" not found. Did you mean: signoz_latency.sum,`)
+}
+
+func TestHandleQueryMetrics_UnderscoreSuffixSuggestsDotForm(t *testing.T) {
+	h := newTestHandler(metricCatalogMock(t, testMetricCatalog, false))
+	result, err := h.handleQueryMetrics(testCtx(), makeToolRequest("signoz_query_metrics", map[string]any{
+		"metricName": "signoz_latency_bucket",
+		"metricType": "histogram",
+		"timeRange":  "1h",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	text := textContent(t, result)
+	assert.Contains(t, text, `Use "signoz_latency.bucket": the bucket part takes a dot suffix.`)
+	assert.Contains(t, text, metricNamingRule)
+}
+
+func TestDotSuffixForm(t *testing.T) {
+	cases := map[string]string{
+		"signoz_latency_bucket": "signoz_latency.bucket",
+		"http_duration_sum":     "http_duration.sum",
+		"rpc_seconds_count":     "rpc_seconds.count",
+		"signoz_calls_total":    "",
+		"signoz_latency.bucket": "",
+		"_count":                "",
+	}
+	for name, want := range cases {
+		got, ok := dotSuffixForm(name)
+		assert.Equal(t, want != "", ok, name)
+		assert.Equal(t, want, got, name)
+	}
+}
+
+func TestHandleExecuteBuilderQuery_KnownMetricRuns(t *testing.T) {
+	h := newTestHandler(metricCatalogMock(t, testMetricCatalog, true))
+	result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"query": map[string]any{
+			"schemaVersion": "v1", "start": 1711123200000, "end": 1711130400000, "requestType": "time_series",
+			"compositeQuery": map[string]any{"queries": []any{
+				map[string]any{"type": "builder_query", "spec": map[string]any{
+					"name": "A", "signal": "metrics", "stepInterval": 60,
+					"aggregations": []any{map[string]any{"metricName": "signoz_calls_total", "timeAggregation": "rate", "spaceAggregation": "sum"}},
+				}},
+			}},
+		},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+}
+
+func TestCheckMetricNames_FailsOpen(t *testing.T) {
+	full := make([]string, metricCheckLimit)
+	for i := range full {
+		full[i] = "other.metric"
+	}
+	cases := map[string]*client.MockClient{
+		"lookup error": {ListMetricsFn: func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
+			return nil, errors.New("boom")
+		}},
+		"unreadable response":     {},
+		"full page without match": metricCatalogMock(t, append(full, "x"), true),
+	}
+	for name, mock := range cases {
+		h := newTestHandler(mock)
+		assert.Nil(t, h.checkMetricNames(testCtx(), mock, []metricRef{{Name: "other"}}), name)
+	}
+}
+
+func TestHandleQueryMetrics_UnknownMetricSuggestsNames(t *testing.T) {
+	h := newTestHandler(metricCatalogMock(t, testMetricCatalog, false))
+	result, err := h.handleQueryMetrics(testCtx(), makeToolRequest("signoz_query_metrics", map[string]any{
+		"metricName": "http_server_duration",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	assert.Contains(t, textContent(t, result), "Did you mean: http.server.duration")
+}
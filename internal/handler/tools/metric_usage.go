@@ -32,9 +32,9 @@ func (h *Handler) RegisterMetricUsageHandlers(s *server.MCPServer) {
 }
 
 func (h *Handler) handleCheckMetricUsage(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := req.GetArguments()
-	if args == nil {
-		return validationError("metricNames", "is required"), nil
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
 	}
 
 	rawNames, ok := args["metricNames"]
@@ -2,6 +2,8 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log/slog"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -10,15 +12,20 @@ import (
 	"github.com/SigNoz/signoz-mcp-server/pkg/metricsrules"
 )
 
+// maxListMetricsWindow bounds offset+limit for signoz_list_metrics, since
+// each page re-reads every row before it.
+const maxListMetricsWindow = 5000
+
 func (h *Handler) RegisterMetricsHandlers(s *server.MCPServer) {
 	h.logger.Debug("Registering metrics handlers")
 
 	listMetricsTool := mcp.NewTool("signoz_list_metrics",
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user needs to discover metric names or inspect catalog metadata such as type, temporality, unit, and monotonicity. It lists metrics active in the requested window; searchText filters names by substring. Do not use it for metric values or trends—use signoz_query_metrics, which can query a known exact name directly and auto-fetch missing metadata. Use source=\"meter\" only for Cost Meter metrics. Results have a limit but no offset pagination, so narrow the search if the cap is reached."),
+		mcp.WithDescription("Use this when the user needs to discover metric names or inspect catalog metadata such as type, temporality, unit, and monotonicity. It lists metrics active in the requested window; searchText filters names by substring. Do not use it for metric values or trends—use signoz_query_metrics, which can query a known exact name directly and auto-fetch missing metadata. Use source=\"meter\" only for Cost Meter metrics. Page with offset and limit, or narrow with searchText, when more metrics match."),
 		mcp.WithString("searchText", mcp.Description("Filter metrics by name substring (optional). Example: 'cpu', 'memory', 'http_requests'.")),
 		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum number of metrics to return (optional). Default: 50.")),
+		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description(fmt.Sprintf("Number of metrics to skip before the page (optional). Default: 0. offset + limit may not exceed %d.", maxListMetricsWindow))),
 		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
 		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
 		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
@@ -33,10 +40,10 @@ func (h *Handler) RegisterMetricsHandlers(s *server.MCPServer) {
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
 		mcp.WithDescription(
 			"Use this when the user wants metric values, trends, breakdowns, or formulas. It returns scalar or time-series results, applies metric-aware aggregation defaults, and reports the decisions used. If the exact metricName is known, call this directly: when metricType is omitted, type, temporality, and monotonicity are auto-fetched together. Use signoz_list_metrics only to discover names or inspect catalog metadata; use signoz_execute_builder_query for complex multi-query requests this tool cannot express. Standalone and formula results use top 100; formula inputs use 10000, and grouped time-series top-N is ranked over the whole window. Read signoz://metrics-aggregation-guide for rules and examples."),
-		mcp.WithString("metricName", mcp.Required(), mcp.Description("Name of the metric to query. Example: 'container.cpu.utilization', 'http_requests_total'.")),
+		mcp.WithString("metricName", mcp.Required(), mcp.Description("Exact name of the metric to query. Example: 'container.cpu.utilization', 'http_requests_total'. "+metricNamingRule)),
 		mcp.WithString("metricType", mcp.Description("Metric type: gauge, sum, histogram, or exponential_histogram. Omit to auto-fetch it with temporality and monotonicity.")),
 		mcp.WithBoolean("isMonotonic", boolOrStringType(), mcp.Description("Whether a type=sum metric is monotonically increasing. Auto-fetched when metricType is omitted; otherwise provide the correct value for sum metrics.")),
-		mcp.WithString("temporality", mcp.Description("Metric temporality: cumulative, delta, or unspecified. Auto-fetched when metricType is omitted; otherwise provide the metric's value when it affects aggregation.")),
+		mcp.WithString("temporality", mcp.Description("Metric temporality: cumulative, delta, or unspecified. Auto-fetched when omitted for sum, histogram, and exponential_histogram metrics; the decisions note says which value was used.")),
 		mcp.WithString("timeAggregation", mcp.Description("Aggregation over time buckets. Auto-defaulted based on metricType. Valid: latest, sum, avg, min, max, count, count_distinct, rate, increase (type-dependent).")),
 		mcp.WithString("spaceAggregation", mcp.Description("Aggregation across series/dimensions. Auto-defaulted based on metricType. Valid: sum, avg, min, max, count, p50, p75, p90, p95, p99 (type-dependent).")),
 		mcp.WithString("groupBy", stringOrStringArrayType(), mcp.Description("Comma-separated field names or an array of field names. Context is inferred as resource for k8s.*, container.*, host.*, cloud.*, deployment.*, process.*, service.*, telemetry.*, and os.*; all other names use attribute context.")),
@@ -77,9 +84,9 @@ func (h *Handler) RegisterMetricsHandlers(s *server.MCPServer) {
 }
 
 func (h *Handler) handleListMetrics(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := req.GetArguments()
-	if args == nil {
-		args = map[string]any{}
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
 	}
 
 	searchText, _ := args["searchText"].(string)
@@ -89,6 +96,19 @@ func (h *Handler) handleListMetrics(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return errorWithCode(CodeValidationFailed, err.Error()), nil
 	}
+	offset, err := intArg(args, "offset", 0)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if offset < 0 {
+		return validationError("offset", "must be zero or a positive integer"), nil
+	}
+	if offset > 0 && limit < 1 {
+		return validationError("limit", "must be a positive integer when offset is set"), nil
+	}
+	if offset+limit > maxListMetricsWindow {
+		return validationError("offset", fmt.Sprintf("offset + limit must not exceed %d; narrow the list with searchText instead", maxListMetricsWindow)), nil
+	}
 
 	// Route timestamps through the shared helper: standard 1h default window,
 	// magnitude auto-detect, and string-typed start/end. Returns canonical ms.
@@ -102,16 +122,48 @@ func (h *Handler) handleListMetrics(ctx context.Context, req mcp.CallToolRequest
 	if err != nil {
 		return clientError(err), nil
 	}
-	result, err := client.ListMetrics(ctx, start, end, limit, searchText, source)
+	// The metrics API has no offset, so a page is cut from the first
+	// offset+limit rows.
+	result, err := client.ListMetrics(ctx, start, end, offset+limit, searchText, source)
 	if err != nil {
 		h.logUpstreamFailure(ctx, "Failed to list metrics", err, slog.String("searchText", searchText))
 		return upstreamError(err), nil
 	}
+	if offset > 0 {
+		result = skipDataArrayRows(result, "metrics", offset)
+	}
 
-	// Completeness signal: list_metrics is a raw passthrough with a limit but NO
-	// offset paging — so the note must advise narrowing rather than claim
-	// offset pagination (a caller following an offset hint would loop the page).
 	returnedRows, rowsKnown := countDataArrayRows(result, "metrics")
-	note := limitOnlyCompletenessNote(returnedRows, limit, rowsKnown, `searchText, a tighter time range, or source`)
+	note := completenessNote(returnedRows, limit, offset, rowsKnown)
 	return resultWithNotes(result, note), nil
 }
+
+// skipDataArrayRows drops the first n elements of data.<key>, keeping the rest
+// of the body. A body without that array is returned unchanged.
+func skipDataArrayRows(payload []byte, key string, n int) []byte {
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &resp); err != nil {
+		return payload
+	}
+	var data map[string]json.RawMessage
+	if err := json.Unmarshal(resp["data"], &data); err != nil {
+		return payload
+	}
+	rows, ok := decodeArrayOrNull(data[key])
+	if !ok {
+		return payload
+	}
+	rows = rows[min(n, len(rows)):]
+	var err error
+	if data[key], err = json.Marshal(append([]json.RawMessage{}, rows...)); err != nil {
+		return payload
+	}
+	if resp["data"], err = json.Marshal(data); err != nil {
+		return payload
+	}
+	out, err := json.Marshal(resp)
+	if err != nil {
+		return payload
+	}
+	return out
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"slices"
 	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -49,6 +50,16 @@ func (h *Handler) handleQueryMetrics(ctx context.Context, req mcp.CallToolReques
 		return clientError(err), nil
 	}
 
+	refs := []metricRef{{Name: mqr.MetricName, Source: mqr.Source}}
+	for _, fq := range mqr.FormulaQueries {
+		if ref := (metricRef{Name: fq.MetricName, Source: mqr.Source}); !slices.Contains(refs, ref) {
+			refs = append(refs, ref)
+		}
+	}
+	if errResult := h.checkMetricNames(ctx, client, refs); errResult != nil {
+		return errResult, nil
+	}
+
 	// Track all decisions for the response
 	var decisions []string
 	decisions = append(decisions, fmt.Sprintf("metricName: %s", mqr.MetricName))
@@ -90,6 +101,14 @@ func (h *Handler) handleQueryMetrics(ctx context.Context, req mcp.CallToolReques
 		decisions = append(decisions, fmt.Sprintf("metricType: %s (caller-provided)", mqr.MetricType))
 		if mqr.Temporality != "" {
 			decisions = append(decisions, fmt.Sprintf("temporality: %s (caller-provided)", mqr.Temporality))
+		} else if needsTemporality(mqr.MetricType) {
+			temporality, reason := h.fetchTemporality(ctx, client, mqr.MetricName, mqr.Source)
+			mqr.Temporality = temporality
+			if temporality != "" {
+				decisions = append(decisions, fmt.Sprintf("temporality: %s (auto-fetched)", temporality))
+			} else {
+				decisions = append(decisions, fmt.Sprintf("temporality: not set (%s); pass temporality if the result is empty", reason))
+			}
 		}
 	}
 
@@ -157,7 +176,7 @@ func (h *Handler) handleQueryMetrics(ctx context.Context, req mcp.CallToolReques
 
 	// Formula sub-queries
 	for _, fq := range mqr.FormulaQueries {
-		subResolved, subErr := resolveFormulaSubQuery(ctx, h, client, fq, mqr.RequestType, mqr.Source, &decisions)
+		subResolved, subErr := resolveFormulaSubQuery(ctx, h, client, &fq, mqr.RequestType, mqr.Source, &decisions)
 		if subErr != nil {
 			// Upstream metadata-fetch failures get the uniform prefix; local
 			// validation errors ("metric not found"/"validation error") stay raw.
@@ -215,6 +234,10 @@ func (h *Handler) handleQueryMetrics(ctx context.Context, req mcp.CallToolReques
 		h.logQueryFailure(ctx, "Metrics query failed", err)
 		return upstreamQueryError(err, "metrics"), nil
 	}
+	result, _, capNote, errResult := h.capQueryResponse(ctx, "signoz_query_metrics", result)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	// Extract backend-determined stepInterval from response if caller didn't provide one
 	if !callerProvidedStep {
@@ -231,7 +254,7 @@ func (h *Handler) handleQueryMetrics(ctx context.Context, req mcp.CallToolReques
 	// rather than prepended. query_metrics is a raw QB passthrough, so it stays
 	// text-only (no structuredContent) — its upstream shape is variable.
 	note := buildMetricsDecisionsNote(decisions, resolved.Warnings, backendWarnings)
-	return resultWithNotes(result, note), nil
+	return resultWithNotes(result, note, capNote), nil
 }
 
 // buildMetricsDecisionsNote renders the decisions/warnings advisory block that
@@ -289,6 +312,37 @@ func (h *Handler) fetchMetricMetadata(ctx context.Context, client interface {
 	return meta, nil
 }
 
+// needsTemporality reports whether queries on metricType depend on its
+// temporality; a cumulative series read as delta (or the reverse) comes back
+// empty. Gauges carry no temporality.
+func needsTemporality(metricType string) bool {
+	switch normalizeMetricType(metricType) {
+	case "sum", "histogram", "exponential_histogram":
+		return true
+	}
+	return false
+}
+
+// fetchTemporality looks up the temporality of a metric whose type the caller
+// supplied without one. It fails open: when the lookup errors or the metadata
+// has no temporality, it returns "" and the reason for the decision note.
+func (h *Handler) fetchTemporality(ctx context.Context, client interface {
+	ListMetrics(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error)
+}, metricName, source string) (temporality, reason string) {
+	meta, err := h.fetchMetricMetadata(ctx, client, metricName, source)
+	switch {
+	case err != nil:
+		h.logger.WarnContext(ctx, "Failed to auto-fetch metric temporality",
+			slog.String("metricName", metricName), logpkg.ErrAttr(err))
+		return "", "auto-fetch failed"
+	case meta == nil:
+		return "", "metric not found in metadata"
+	case meta.Temporality == "":
+		return "", "not returned by metadata"
+	}
+	return meta.Temporality, ""
+}
+
 // metricMetadataRow mirrors one entry of a ListMetrics response. IsMonotonic and
 // Temporality are pointers so an ABSENT field differs from a present empty/false
 // value, enabling drift detection without flagging legitimate empty values.
@@ -368,10 +422,12 @@ func normalizeMetricType(t string) string {
 	}
 }
 
-// resolveFormulaSubQuery applies defaults for a formula sub-query, auto-fetching metadata if needed.
+// resolveFormulaSubQuery applies defaults for a formula sub-query, auto-fetching
+// metadata if needed. The resolved temporality is written back to fq so the
+// caller's query spec carries it.
 func resolveFormulaSubQuery(ctx context.Context, h *Handler, client interface {
 	ListMetrics(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error)
-}, fq formulaSubQuery, requestType, source string, decisions *[]string) (*metricsrules.ResolvedAggregation, error) {
+}, fq *formulaSubQuery, requestType, source string, decisions *[]string) (*metricsrules.ResolvedAggregation, error) {
 	metricType := fq.MetricType
 	isMonotonic := fq.IsMonotonic
 	temporality := fq.Temporality
@@ -400,6 +456,14 @@ func resolveFormulaSubQuery(ctx context.Context, h *Handler, client interface {
 		} else {
 			return nil, fmt.Errorf("metric %q not found for formula query %q. Check the metric name", fq.MetricName, fq.Name)
 		}
+	} else if temporality == "" && needsTemporality(metricType) {
+		var reason string
+		temporality, reason = h.fetchTemporality(ctx, client, fq.MetricName, source)
+		if temporality != "" {
+			*decisions = append(*decisions, fmt.Sprintf("query %s (%s): temporality=%s (auto-fetched)", fq.Name, fq.MetricName, temporality))
+		} else {
+			*decisions = append(*decisions, fmt.Sprintf("query %s (%s): temporality not set (%s)", fq.Name, fq.MetricName, reason))
+		}
 	}
 
 	resolved, err := metricsrules.ApplyDefaults(metricsrules.MetricQueryParams{
@@ -413,7 +477,6 @@ func resolveFormulaSubQuery(ctx context.Context, h *Handler, client interface {
 		return nil, fmt.Errorf("validation error for formula query %q (%s): %w", fq.Name, fq.MetricName, err)
 	}
 
-	// Update fq temporality for the aggregation struct
 	fq.Temporality = temporality
 
 	for _, d := range resolved.Decisions {
@@ -187,6 +187,87 @@ func TestHandleQueryMetrics_JSONFirstWithSeparateDecisionsNote(t *testing.T) {
 // builder_query with an unsupported requestType — surfaces the shared
 // VALIDATION_FAILED code, not a bare code-less error. The MockClient's
 // QueryBuilderV5 must never be reached because validation rejects the payload first.
+// TestHandleQueryMetrics_AutoFetchesTemporalityForCallerTypedMetric pins that
+// a caller-supplied metricType without temporality still gets the metric's
+// temporality from metadata, for the primary query and formula inputs alike.
+func TestHandleQueryMetrics_AutoFetchesTemporalityForCallerTypedMetric(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		ListMetricsFn: func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"metrics":[
+				{"metricName":"http.requests","type":"sum","temporality":"Delta","isMonotonic":true},
+				{"metricName":"http.errors","type":"sum","temporality":"Cumulative","isMonotonic":true}]}}`), nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success","data":{"results":[]}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_query_metrics", map[string]any{
+		"metricName":  "http.requests",
+		"metricType":  "sum",
+		"isMonotonic": true,
+		"requestType": "scalar",
+		"timeRange":   "1h",
+		"formula":     "B / A",
+		"formulaQueries": []any{map[string]any{
+			"name":        "B",
+			"metricName":  "http.errors",
+			"metricType":  "sum",
+			"isMonotonic": true,
+		}},
+	})
+
+	result, err := h.handleQueryMetrics(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	for _, want := range []string{`"temporality":"Delta"`, `"temporality":"Cumulative"`} {
+		if !strings.Contains(string(captured), want) {
+			t.Fatalf("query payload missing %s: %s", want, captured)
+		}
+	}
+	notes := strings.Join(allTextBlocks(result), "\n")
+	for _, want := range []string{"temporality: Delta (auto-fetched)", "query B (http.errors): temporality=Cumulative (auto-fetched)"} {
+		if !strings.Contains(notes, want) {
+			t.Fatalf("decisions missing %q:\n%s", want, notes)
+		}
+	}
+}
+
+func TestHandleQueryMetrics_TemporalityLookupFailsOpen(t *testing.T) {
+	mock := &client.MockClient{
+		ListMetricsFn: func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"metrics":[{"metricName":"http.requests","type":"sum","isMonotonic":true}]}}`), nil
+		},
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"results":[]}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_query_metrics", map[string]any{
+		"metricName":  "http.requests",
+		"metricType":  "sum",
+		"isMonotonic": true,
+		"timeRange":   "1h",
+	})
+
+	result, err := h.handleQueryMetrics(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	if notes := strings.Join(allTextBlocks(result), "\n"); !strings.Contains(notes, "temporality: not set (not returned by metadata)") {
+		t.Fatalf("decisions missing temporality note:\n%s", notes)
+	}
+}
+
 func TestHandleExecuteBuilderQuery_InvalidRequestTypeIsValidationFailed(t *testing.T) {
 	mock := &client.MockClient{
 		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
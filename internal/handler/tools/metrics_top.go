@@ -26,9 +26,9 @@ func (h *Handler) RegisterTopMetricsHandlers(s *server.MCPServer) {
 }
 
 func (h *Handler) handleGetTopMetrics(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := req.GetArguments()
-	if args == nil {
-		args = map[string]any{}
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
 	}
 
 	startTime, endTime, err := resolveTimestamps(args, "7d")
@@ -0,0 +1,51 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+// TestMockMode_ToolsAnswerFromFixtures runs read tools against the canned
+// client so fixture drift from what the handlers parse shows up here.
+func TestMockMode_ToolsAnswerFromFixtures(t *testing.T) {
+	h := NewHandler(logpkg.New("error"), &config.Config{MockMode: true, ClientCacheSize: 1})
+
+	tests := []struct {
+		tool   string
+		handle func(context.Context, mcp.CallToolRequest) (*mcp.CallToolResult, error)
+		args   map[string]any
+		want   string
+	}{
+		{"signoz_list_services", h.handleListServices, map[string]any{}, "checkout"},
+		{"signoz_get_service_top_operations", h.handleGetServiceTopOperations, map[string]any{"service": "checkout"}, "POST /api/checkout"},
+		{"signoz_search_logs", h.handleSearchLogs, map[string]any{"filter": "service.name = 'checkout'"}, "card declined"},
+		{"signoz_search_traces", h.handleSearchTraces, map[string]any{"filter": "has_error = true"}, "4bf92f3577b34da6a3ce929d0e0e4736"},
+		{"signoz_get_trace_details", h.handleGetTraceDetails, map[string]any{"traceId": "4bf92f3577b34da6a3ce929d0e0e4736"}, "payment.Charge"},
+		{"signoz_aggregate_traces", h.handleAggregateTraces, map[string]any{"aggregation": "p99", "aggregateOn": "duration_nano", "groupBy": "service.name"}, "checkout"},
+		{"signoz_get_field_cardinality", h.handleGetFieldCardinality, map[string]any{"signal": "traces", "field": "http.route"}, "/api/checkout"},
+		{"signoz_list_metrics", h.handleListMetrics, map[string]any{}, "http.server.request.duration"},
+		{"signoz_list_alerts", h.handleListAlerts, map[string]any{}, "High checkout latency"},
+		{"signoz_get_alert", h.handleGetAlert, map[string]any{"id": "0196634d-5d66-75c4-b778-e317f49dab7a"}, "p99(duration_nano)"},
+		{"signoz_list_dashboards", h.handleListDashboards, map[string]any{}, "Checkout service"},
+		{"signoz_get_dashboard_variables", h.handleGetDashboardVariables, map[string]any{"id": "0196634d-5d66-75c4-b778-e317f49dab7a"}, "payment"},
+		{"signoz_clone_dashboard", h.handleCloneDashboard, map[string]any{"id": "0196634d-5d66-75c4-b778-e317f49dab7a", "title": "Checkout staging"}, "Checkout staging"},
+		{"signoz_list_views", h.handleListViews, map[string]any{"sourcePage": "logs"}, "Checkout errors"},
+		{"signoz_get_field_keys", h.handleGetFieldKeys, map[string]any{"signal": "logs"}, "severity_text"},
+		{"signoz_list_notification_channels", h.handleListNotificationChannels, map[string]any{}, "slack-oncall"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tool, func(t *testing.T) {
+			result, err := tt.handle(testCtx(), makeToolRequest(tt.tool, tt.args))
+			require.NoError(t, err)
+			require.False(t, result.IsError, textContent(t, result))
+			assert.Contains(t, textContent(t, result), tt.want)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 
 	"github.com/SigNoz/signoz-mcp-server/internal/client"
 	"github.com/SigNoz/signoz-mcp-server/pkg/types"
@@ -36,12 +37,26 @@ func TestHandlers_NilArguments_NoPanic(t *testing.T) {
 	}{
 		{"signoz_get_alert", h.handleGetAlert},
 		{"signoz_get_alert_history", h.handleGetAlertHistory},
+		{"signoz_get_alert_stats", h.handleGetAlertStats},
+		{"signoz_test_alert_rule", h.handleTestAlertRule},
+		{"signoz_get_alert_context", h.handleGetAlertContext},
 		{"signoz_delete_alert", h.handleDeleteAlert},
 		{"signoz_get_dashboard", h.handleGetDashboard},
+		{"signoz_get_dashboard_panel", h.handleGetDashboardPanel},
 		{"signoz_delete_dashboard", h.handleDeleteDashboard},
 		{"signoz_get_trace_details", h.handleGetTraceDetails},
+		{"signoz_get_service_dependencies_for_trace", h.handleGetServiceDependenciesForTrace},
+		{"signoz_search_traces_advanced", h.handleSearchTracesAdvanced},
+		{"signoz_get_error_sample_traces", h.handleGetErrorSampleTraces},
+		{"signoz_get_exemplar_traces", h.handleGetExemplarTraces},
+		{"signoz_get_trace_latency_histogram", h.handleGetTraceLatencyHistogram},
 		{"signoz_get_service_top_operations", h.handleGetServiceTopOperations},
+		{"signoz_get_service_externals", h.handleGetServiceExternals},
+		{"signoz_get_service_errors", h.handleGetServiceErrors},
+		{"signoz_investigate_service", h.handleInvestigateService},
+		{"signoz_tail_logs", h.handleTailLogs},
 		{"signoz_query_metrics", h.handleQueryMetrics},
+		{"signoz_compare_time_windows", h.handleCompareTimeWindows},
 		{"signoz_create_notification_channel", h.handleCreateNotificationChannel},
 		{"signoz_get_notification_channel", h.handleGetNotificationChannel},
 		{"signoz_update_notification_channel", h.handleUpdateNotificationChannel},
@@ -123,3 +138,52 @@ func TestListHandlers_NilArguments_UseDefaults(t *testing.T) {
 		})
 	}
 }
+
+// nonObjectArgumentsExempt lists tools that never read their arguments, plus
+// the docs tools, which report the unbuilt test index before parsing them.
+var nonObjectArgumentsExempt = map[string]bool{
+	"signoz_health_check":             true,
+	"signoz_get_version":              true,
+	"signoz_list_tools":               true,
+	"signoz_server_stats":             true,
+	"signoz_debug_last_request":       true,
+	"signoz_list_dashboard_templates": true,
+	"signoz_search_docs":              true,
+	"signoz_fetch_doc":                true,
+}
+
+// TestHandlers_NonObjectArguments_Rejected sends a non-object arguments
+// payload (array, string, number) to every registered tool. Each must return
+// a JSON-object validation error rather than panic or silently treat the
+// payload as empty arguments.
+func TestHandlers_NonObjectArguments_Rejected(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	h.RegisterAllToolHandlers(s)
+
+	payloads := map[string]any{
+		"array":  []any{"service", "checkout"},
+		"string": "service=checkout",
+		"number": 42.0,
+	}
+	for name, entry := range s.ListTools() {
+		if nonObjectArgumentsExempt[name] {
+			continue
+		}
+		for kind, payload := range payloads {
+			t.Run(name+"/"+kind, func(t *testing.T) {
+				req := mcp.CallToolRequest{Params: mcp.CallToolParams{Name: name, Arguments: payload}}
+				result, err := entry.Handler(testCtx(), req)
+				if err != nil {
+					t.Fatalf("unexpected transport error: %v", err)
+				}
+				if result == nil || !result.IsError {
+					t.Fatalf("non-object arguments must be rejected, got %+v", result)
+				}
+				if msg := resultText(t, result); msg != notAJSONObjectMessage && msg != notAConfigObjectMessage {
+					t.Fatalf("got %q, want the JSON-object validation error %q", msg, notAJSONObjectMessage)
+				}
+			})
+		}
+	}
+}
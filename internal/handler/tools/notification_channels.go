@@ -32,7 +32,7 @@ func (h *Handler) RegisterNotificationChannelHandlers(s *server.MCPServer) {
 		mcp.WithDescription(
 			"Use this when the user wants to discover configured notification channels, verify exact channel names before creating or updating an alert, avoid a duplicate name before channel creation, or find a channel ID. It returns paginated summaries only: id, name, type, and timestamps; it does not return provider-specific settings. Use signoz_get_notification_channel with an ID for all settings.",
 		),
-		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum number of channels to return per page. Default: 50, max: 1000 (higher values are clamped).")),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("channels"))),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of results to skip before returning results. Use for pagination: offset=0 for first page, offset=50 for second page (if limit=50). Check 'pagination.nextOffset' in the response to get the next page offset. Default: 0.")),
 	)
 
@@ -200,7 +200,11 @@ func (h *Handler) handleDeleteNotificationChannel(ctx context.Context, req mcp.C
 
 func (h *Handler) handleListNotificationChannels(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	h.logger.DebugContext(ctx, "Tool called: signoz_list_notification_channels")
-	limit, offset, limitClamped := paginate.ParseParamsClamped(req.Params.Arguments)
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit, offset, limitClamped := h.parseListParams(args)
 
 	client, err := h.GetClient(ctx)
 	if err != nil {
@@ -263,13 +267,13 @@ func (h *Handler) handleListNotificationChannels(ctx context.Context, req mcp.Ca
 	total := len(summarized)
 	pagedData := paginate.Array(summarized, offset, limit)
 
-	resultJSON, err := paginate.Wrap(pagedData, total, offset, limit)
+	toolResult, err := newToolResult(listResponse(pagedData, total, offset, limit, limitClamped))
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to wrap notification channels with pagination", logpkg.ErrAttr(err))
+		h.logger.ErrorContext(ctx, "Failed to marshal notification channels response", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
 	}
 
-	return listResult(resultJSON, limitClamped), nil
+	return toolResult, nil
 }
 
 func (h *Handler) handleCreateNotificationChannel(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
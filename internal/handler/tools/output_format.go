@@ -0,0 +1,331 @@
+package tools
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// Output formats selectable with the "format" param of row-returning tools.
+const (
+	formatJSON    = "json"
+	formatNDJSON  = "ndjson"
+	formatCSV     = "csv"
+	formatCompact = "compact"
+)
+
+const ndjsonFormatDesc = `Output format: "json" (default) returns the full query response; "ndjson" returns one result row per line as a JSON object, for handing rows to tools that expect line-delimited records; "compact" returns only the result rows, without query metadata or null fields. Notes are still returned as separate content blocks.`
+
+const csvFormatDesc = `Output format: "json" (default) returns the full query response; "csv" returns the result table as CSV with a header row (group columns, then aggregation columns, in response order). Time series are flattened to one row per point with the group labels, aggregation index, timestamp, and value. Far fewer tokens for grouped answers such as p99 by service. "compact" returns the result columns and data (or series) as JSON, without query metadata or null fields.`
+
+// parseOutputFormat reads the "format" argument, defaulting to json and
+// accepting only the listed formats.
+func parseOutputFormat(args map[string]any, allowed ...string) (string, error) {
+	raw, _ := args["format"].(string)
+	format := strings.ToLower(strings.TrimSpace(raw))
+	if format == "" {
+		return formatJSON, nil
+	}
+	if format == formatJSON {
+		return format, nil
+	}
+	for _, a := range allowed {
+		if format == a {
+			return format, nil
+		}
+	}
+	return "", fmt.Errorf(`"format" must be one of %q, got %q`, append([]string{formatJSON}, allowed...), raw)
+}
+
+// withOutputFormat applies the requested output format to a query result
+// built from payload. JSON results are returned unchanged.
+func withOutputFormat(res *mcp.CallToolResult, payload []byte, format string) *mcp.CallToolResult {
+	switch format {
+	case formatNDJSON:
+		return withNDJSONBody(res, payload)
+	case formatCSV:
+		return withCSVBody(res, payload)
+	case formatCompact:
+		return withCompactBody(res, payload)
+	default:
+		return res
+	}
+}
+
+// rowsAsNDJSON renders the rows of a QB v5 raw response one per line. It
+// reports false when the response has no walkable rows list, so callers
+// can keep the JSON body rather than return an empty export.
+func rowsAsNDJSON(payload []byte) (string, bool) {
+	if _, known := countQueryRangeRows(payload); !known {
+		return "", false
+	}
+	var b bytes.Buffer
+	for _, row := range queryResultRows(payload) {
+		b.Write(bytes.TrimSpace(row))
+		b.WriteByte('\n')
+	}
+	return b.String(), true
+}
+
+// withNDJSONBody swaps the JSON body (content block 0) of a search result
+// for its NDJSON rows, keeping the notes that follow it. Structured content
+// is dropped because the body is no longer a single JSON value.
+func withNDJSONBody(res *mcp.CallToolResult, payload []byte) *mcp.CallToolResult {
+	if res == nil || res.IsError || len(res.Content) == 0 {
+		return res
+	}
+	body, ok := rowsAsNDJSON(payload)
+	if !ok {
+		res.Content = append(res.Content, mcp.NewTextContent(`note: format "ndjson" was requested but the response rows could not be read; returning the JSON response instead.`))
+		return res
+	}
+	res.Content[0] = mcp.NewTextContent(body)
+	res.StructuredContent = nil
+	return res
+}
+
+// csvTableEnvelope is the subset of a QB v5 scalar or time_series response
+// that tableAsCSV flattens. Numbers are kept as json.Number so cells keep
+// the backend's formatting.
+type csvTableEnvelope struct {
+	Data struct {
+		Data struct {
+			Results []struct {
+				Columns []struct {
+					Name string `json:"name"`
+				} `json:"columns"`
+				Data         [][]any `json:"data"`
+				Aggregations []struct {
+					Index  int `json:"index"`
+					Series []struct {
+						Labels []struct {
+							Key struct {
+								Name string `json:"name"`
+							} `json:"key"`
+							Value any `json:"value"`
+						} `json:"labels"`
+						Values []struct {
+							Timestamp any `json:"timestamp"`
+							Value     any `json:"value"`
+						} `json:"values"`
+					} `json:"series"`
+				} `json:"aggregations"`
+			} `json:"results"`
+		} `json:"data"`
+	} `json:"data"`
+}
+
+// tableAsCSV renders the table (scalar) or series (time_series) results of
+// a QB v5 response as CSV. Multiple results are separated by a blank line.
+// Missing cells are empty. It reports false when there is nothing tabular
+// to render.
+func tableAsCSV(payload []byte) (string, bool) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var env csvTableEnvelope
+	if err := dec.Decode(&env); err != nil {
+		return "", false
+	}
+
+	var blocks []string
+	for _, res := range env.Data.Data.Results {
+		var records [][]string
+		switch {
+		case len(res.Columns) > 0:
+			header := make([]string, len(res.Columns))
+			for i, c := range res.Columns {
+				header[i] = c.Name
+			}
+			records = append(records, header)
+			for _, row := range res.Data {
+				record := make([]string, len(header))
+				for i := range record {
+					if i < len(row) {
+						record[i] = csvCell(row[i])
+					}
+				}
+				records = append(records, record)
+			}
+		case len(res.Aggregations) > 0:
+			keySet := map[string]struct{}{}
+			for _, agg := range res.Aggregations {
+				for _, series := range agg.Series {
+					for _, l := range series.Labels {
+						keySet[l.Key.Name] = struct{}{}
+					}
+				}
+			}
+			keys := make([]string, 0, len(keySet))
+			for k := range keySet {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			records = append(records, append(append([]string{}, keys...), "aggregation", "timestamp", "value"))
+			for _, agg := range res.Aggregations {
+				for _, series := range agg.Series {
+					labels := make(map[string]string, len(series.Labels))
+					for _, l := range series.Labels {
+						labels[l.Key.Name] = csvCell(l.Value)
+					}
+					for _, v := range series.Values {
+						record := make([]string, 0, len(keys)+3)
+						for _, k := range keys {
+							record = append(record, labels[k])
+						}
+						record = append(record, strconv.Itoa(agg.Index), csvCell(v.Timestamp), csvCell(v.Value))
+						records = append(records, record)
+					}
+				}
+			}
+		default:
+			continue
+		}
+
+		var b bytes.Buffer
+		w := csv.NewWriter(&b)
+		if err := w.WriteAll(records); err != nil {
+			return "", false
+		}
+		blocks = append(blocks, b.String())
+	}
+	if len(blocks) == 0 {
+		return "", false
+	}
+	return strings.Join(blocks, "\n"), true
+}
+
+func csvCell(v any) string {
+	switch x := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return x
+	case json.Number:
+		return x.String()
+	case bool:
+		return strconv.FormatBool(x)
+	default:
+		b, err := json.Marshal(x)
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		return string(b)
+	}
+}
+
+// withCSVBody is withNDJSONBody for CSV table output.
+func withCSVBody(res *mcp.CallToolResult, payload []byte) *mcp.CallToolResult {
+	if res == nil || res.IsError || len(res.Content) == 0 {
+		return res
+	}
+	body, ok := tableAsCSV(payload)
+	if !ok {
+		res.Content = append(res.Content, mcp.NewTextContent(`note: format "csv" was requested but the response has no table or series to render; returning the JSON response instead.`))
+		return res
+	}
+	res.Content[0] = mcp.NewTextContent(body)
+	res.StructuredContent = nil
+	return res
+}
+
+// compactDroppedKeys are per-result and per-column keys that echo query
+// configuration or describe the backend execution rather than the data.
+var compactDroppedKeys = []string{"meta", "fieldContext", "fieldDataType", "signal", "queryName", "aggregationIndex", "columnType"}
+
+// compactQueryResult slims a QB v5 response down to its result type,
+// results (rows, columns and data, or series), and any injected nextCursor,
+// dropping execution metadata, echoed query fields, and null values. It
+// reports false when the response has no results list to keep.
+func compactQueryResult(payload []byte) ([]byte, bool) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var env struct {
+		Data struct {
+			Type string `json:"type"`
+			Data struct {
+				Results []any `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+		NextCursor string `json:"nextCursor"`
+	}
+	if err := dec.Decode(&env); err != nil || env.Data.Data.Results == nil {
+		return nil, false
+	}
+
+	results := make([]any, 0, len(env.Data.Data.Results))
+	for _, r := range env.Data.Data.Results {
+		res, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		dropKeys(res)
+		if cols, ok := res["columns"].([]any); ok {
+			for _, c := range cols {
+				if col, ok := c.(map[string]any); ok {
+					dropKeys(col)
+				}
+			}
+		}
+		results = append(results, stripNulls(res))
+	}
+
+	out := map[string]any{"results": results}
+	if env.Data.Type != "" {
+		out["type"] = env.Data.Type
+	}
+	if env.NextCursor != "" {
+		out["nextCursor"] = env.NextCursor
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		return nil, false
+	}
+	return body, true
+}
+
+func dropKeys(m map[string]any) {
+	for _, k := range compactDroppedKeys {
+		delete(m, k)
+	}
+}
+
+// stripNulls removes null object fields at every depth. Nulls inside arrays
+// are kept so positional rows (scalar data) stay aligned with their columns.
+func stripNulls(v any) any {
+	switch x := v.(type) {
+	case map[string]any:
+		for k, val := range x {
+			if val == nil {
+				delete(x, k)
+				continue
+			}
+			x[k] = stripNulls(val)
+		}
+	case []any:
+		for i, val := range x {
+			x[i] = stripNulls(val)
+		}
+	}
+	return v
+}
+
+// withCompactBody is withNDJSONBody for compact JSON output.
+func withCompactBody(res *mcp.CallToolResult, payload []byte) *mcp.CallToolResult {
+	if res == nil || res.IsError || len(res.Content) == 0 {
+		return res
+	}
+	body, ok := compactQueryResult(payload)
+	if !ok {
+		res.Content = append(res.Content, mcp.NewTextContent(`note: format "compact" was requested but the response results could not be read; returning the full JSON response instead.`))
+		return res
+	}
+	res.Content[0] = mcp.NewTextContent(string(body))
+	res.StructuredContent = nil
+	return res
+}
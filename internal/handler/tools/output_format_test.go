@@ -0,0 +1,146 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestParseOutputFormat(t *testing.T) {
+	got, err := parseOutputFormat(map[string]any{}, formatNDJSON)
+	require.NoError(t, err)
+	assert.Equal(t, formatJSON, got)
+
+	got, err = parseOutputFormat(map[string]any{"format": " NDJSON "}, formatNDJSON)
+	require.NoError(t, err)
+	assert.Equal(t, formatNDJSON, got)
+
+	_, err = parseOutputFormat(map[string]any{"format": "xml"}, formatNDJSON)
+	assert.ErrorContains(t, err, `"format" must be one of`)
+}
+
+func TestRowsAsNDJSON(t *testing.T) {
+	payload := []byte(`{"data":{"data":{"results":[{"rows":[{"a":1},
+		{"a":2}]},{"rows":[{"a":3}]}]}}}`)
+	got, ok := rowsAsNDJSON(payload)
+	require.True(t, ok)
+	assert.Equal(t, "{\"a\":1}\n{\"a\":2}\n{\"a\":3}\n", got)
+
+	_, ok = rowsAsNDJSON([]byte(`{"status":"success"}`))
+	assert.False(t, ok)
+}
+
+func TestHandleSearchLogs_NDJSON(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"data":{"results":[{"rows":[
+				{"timestamp":"2024-01-02T03:04:05Z","data":{"id":"r1","body":"one"}},
+				{"timestamp":"2024-01-02T03:04:04Z","data":{"id":"r2","body":"two"}}
+			]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"format": "ndjson", "timeRange": "1h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	assert.Nil(t, result.StructuredContent)
+
+	lines := strings.Split(strings.TrimSuffix(textContent(t, result), "\n"), "\n")
+	require.Len(t, lines, 2)
+	for _, line := range lines {
+		var row map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &row), line)
+	}
+	assert.Contains(t, lines[1], `"id":"r2"`)
+	require.Greater(t, len(result.Content), 1, "completeness note should be kept")
+}
+
+func TestHandleSearchTraces_InvalidFormat(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	result, err := h.handleSearchTraces(testCtx(), makeToolRequest("signoz_search_traces", map[string]any{"format": "csv"}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+}
+
+func TestTableAsCSV_Scalar(t *testing.T) {
+	payload := []byte(`{"data":{"type":"scalar","data":{"results":[{"queryName":"A",
+		"columns":[{"name":"service.name"},{"name":"p99(duration_nano)"},{"name":"count()"}],
+		"data":[["api",1500000000.5,12],["web, eu",null],["db",7,3]]}]}}}`)
+	got, ok := tableAsCSV(payload)
+	require.True(t, ok)
+	assert.Equal(t, "service.name,p99(duration_nano),count()\napi,1500000000.5,12\n\"web, eu\",,\ndb,7,3\n", got)
+}
+
+func TestTableAsCSV_TimeSeries(t *testing.T) {
+	payload := []byte(`{"data":{"type":"time_series","data":{"results":[{"queryName":"A","aggregations":[{"index":0,"series":[
+		{"labels":[{"key":{"name":"service.name"},"value":"api"}],"values":[{"timestamp":1000,"value":1},{"timestamp":2000,"value":2}]},
+		{"labels":[{"key":{"name":"env"},"value":"prod"}],"values":[{"timestamp":1000,"value":3}]}
+	]}]}]}}}`)
+	got, ok := tableAsCSV(payload)
+	require.True(t, ok)
+	assert.Equal(t, "env,service.name,aggregation,timestamp,value\n,api,0,1000,1\n,api,0,2000,2\nprod,,0,1000,3\n", got)
+
+	_, ok = tableAsCSV([]byte(`{"data":{"data":{"results":[{"rows":[]}]}}}`))
+	assert.False(t, ok)
+}
+
+func TestHandleAggregateTraces_CSV(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A",
+				"columns":[{"name":"service.name"},{"name":"p99(duration_nano)"}],
+				"data":[["api",42]]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleAggregateTraces(testCtx(), makeToolRequest("signoz_aggregate_traces", map[string]any{
+		"aggregation": "p99", "aggregateOn": "duration_nano", "groupBy": "service.name", "format": "csv",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Nil(t, result.StructuredContent)
+	assert.Equal(t, "service.name,p99(duration_nano)\napi,42\n", textContent(t, result))
+}
+
+func TestCompactQueryResult(t *testing.T) {
+	payload := []byte(`{"status":"success","nextCursor":"abc","data":{"type":"scalar","meta":{"rowsScanned":10,"bytesScanned":200,"durationMs":3},
+		"data":{"results":[{"queryName":"A","meta":{"x":1},
+			"columns":[{"name":"service.name","queryName":"A","signal":"traces","fieldContext":"resource","fieldDataType":"string","columnType":"group"},
+				{"name":"count()","queryName":"A","aggregationIndex":0,"columnType":"aggregation","meta":null}],
+			"data":[["api",3],["web",null]],"nextCursor":null}]}}}`)
+	got, ok := compactQueryResult(payload)
+	require.True(t, ok)
+	assert.JSONEq(t, `{"type":"scalar","nextCursor":"abc","results":[{
+		"columns":[{"name":"service.name"},{"name":"count()"}],
+		"data":[["api",3],["web",null]]}]}`, string(got))
+
+	_, ok = compactQueryResult([]byte(`{"status":"success"}`))
+	assert.False(t, ok)
+}
+
+func TestHandleSearchTraces_Compact(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"type":"raw","meta":{"rowsScanned":1},"data":{"results":[{"queryName":"A","rows":[
+				{"timestamp":"2024-01-02T03:04:05Z","data":{"trace_id":"t1","status_message":null}}
+			]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleSearchTraces(testCtx(), makeToolRequest("signoz_search_traces", map[string]any{"format": "compact"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.JSONEq(t, `{"type":"raw","results":[{"rows":[{"timestamp":"2024-01-02T03:04:05Z","data":{"trace_id":"t1"}}]}]}`, textContent(t, result))
+	require.Greater(t, len(result.Content), 1, "completeness note should be kept")
+}
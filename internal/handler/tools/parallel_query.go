@@ -0,0 +1,56 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"golang.org/x/sync/errgroup"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+// maxParallelQueries bounds the QueryBuilderV5 calls one tool call keeps in
+// flight, so a composite tool cannot flood the backend.
+const maxParallelQueries = 4
+
+// parallelQueryResult is the outcome of one query sent by runQueriesParallel.
+type parallelQueryResult struct {
+	Data json.RawMessage
+	Err  error
+}
+
+// runQueriesParallel sends each payload to QueryBuilderV5 concurrently, at
+// most maxParallelQueries at a time, and returns the results in input order.
+//
+// With failFast, the first error cancels the queries still running and is
+// returned. Otherwise every query runs to completion and failures are
+// reported per result, so the caller can return partial data. Cancellation
+// of ctx always stops the remaining queries.
+func runQueriesParallel(ctx context.Context, client signozclient.Client, bodies [][]byte, failFast bool) ([]parallelQueryResult, error) {
+	results := make([]parallelQueryResult, len(bodies))
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(maxParallelQueries)
+
+	for i, body := range bodies {
+		g.Go(func() error {
+			if err := gctx.Err(); err != nil {
+				results[i].Err = err
+				return err
+			}
+			data, err := client.QueryBuilderV5(gctx, body)
+			results[i] = parallelQueryResult{Data: data, Err: err}
+			if err != nil && failFast {
+				return err
+			}
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return results, err
+	}
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	return results, nil
+}
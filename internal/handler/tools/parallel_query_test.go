@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestRunQueriesParallel_KeepsOrderAndBoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			return json.RawMessage(body), nil
+		},
+	}
+
+	bodies := make([][]byte, 10)
+	for i := range bodies {
+		bodies[i] = []byte{byte('a' + i)}
+	}
+	results, err := runQueriesParallel(testCtx(), mock, bodies, true)
+	require.NoError(t, err)
+	require.Len(t, results, len(bodies))
+	for i, r := range results {
+		require.NoError(t, r.Err)
+		assert.Equal(t, string(bodies[i]), string(r.Data))
+	}
+	assert.LessOrEqual(t, peak.Load(), int32(maxParallelQueries))
+}
+
+func TestRunQueriesParallel_FailFastCancelsOthers(t *testing.T) {
+	boom := errors.New("boom")
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			if string(body) == "bad" {
+				return nil, boom
+			}
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return json.RawMessage(`{}`), nil
+			}
+		},
+	}
+
+	start := time.Now()
+	results, err := runQueriesParallel(testCtx(), mock, [][]byte{[]byte("slow"), []byte("bad"), []byte("slow")}, true)
+	require.ErrorIs(t, err, boom)
+	assert.Less(t, time.Since(start), 2*time.Second)
+	assert.ErrorIs(t, results[1].Err, boom)
+	assert.ErrorIs(t, results[0].Err, context.Canceled)
+	assert.ErrorIs(t, results[2].Err, context.Canceled)
+}
+
+func TestRunQueriesParallel_PartialResults(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			if string(body) == "bad" {
+				return nil, errors.New("boom")
+			}
+			return json.RawMessage(`{"ok":true}`), nil
+		},
+	}
+
+	results, err := runQueriesParallel(testCtx(), mock, [][]byte{[]byte("a"), []byte("bad"), []byte("c")}, false)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ok":true}`, string(results[0].Data))
+	assert.EqualError(t, results[1].Err, "boom")
+	assert.JSONEq(t, `{"ok":true}`, string(results[2].Data))
+}
+
+func TestRunQueriesParallel_ParentCancellation(t *testing.T) {
+	ctx, cancel := context.WithCancel(testCtx())
+	cancel()
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			t.Error("no query should be sent after the parent context is canceled")
+			return nil, nil
+		},
+	}
+
+	_, err := runQueriesParallel(ctx, mock, [][]byte{[]byte("a"), []byte("b")}, false)
+	assert.ErrorIs(t, err, context.Canceled)
+}
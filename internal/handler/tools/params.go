@@ -7,22 +7,72 @@ import (
 	"strings"
 
 	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
 const aggregateRequestTypeDescription = `Result shape. "scalar" (default) returns one value or a grouped/ranked table over the full time range; use it for totals, percentiles, and top lists. "time_series" returns time-bucketed values, with one series per group when grouped; use it for spikes, trends, changes over time, or questions about when something happened.`
 
-// listResult wraps a paginated list payload (a code-controlled envelope) as a
-// structured tool result, so list tools keep StructuredContent alongside the
-// text block. When the requested per-page limit was clamped to paginate.MaxLimit
-// it appends a trailing advisory note.
-func listResult(payload []byte, limitClamped bool) *mcp.CallToolResult {
-	if !limitClamped {
-		return structuredResult(payload)
+// listClampWarning is attached to a list response whose requested per-page
+// limit was clamped to the server's cap.
+func listClampWarning(limit int) string {
+	return fmt.Sprintf(
+		"limit clamped to %d per page to bound server memory; use \"offset\" to page through more results.",
+		limit)
+}
+
+// newToolResult marshals a types.ToolResponse envelope as a structured tool
+// result. Each warning is also appended as a trailing note block, so clients
+// that only read the text content still see it.
+func newToolResult(resp types.ToolResponse) (*mcp.CallToolResult, error) {
+	payload, err := json.Marshal(resp)
+	if err != nil {
+		return nil, err
+	}
+	notes := make([]string, 0, len(resp.Warnings))
+	for _, w := range resp.Warnings {
+		notes = append(notes, "note: "+w)
+	}
+	return structuredResultWithNotes(payload, notes...), nil
+}
+
+// parseListParams reads limit/offset for a summary list tool, clamping the
+// limit to the configured per-page cap.
+func (h *Handler) parseListParams(args any) (limit, offset int, clamped bool) {
+	return paginate.ParseParamsMax(args, h.listLimitCap())
+}
+
+// listLimitCap is the configured per-page cap, or paginate.MaxLimit when
+// unset.
+func (h *Handler) listLimitCap() int {
+	if h.maxListLimit > 0 {
+		return h.maxListLimit
 	}
-	return structuredResultWithNotes(payload, fmt.Sprintf(
-		"note: limit clamped to %d per page to bound server memory; use \"offset\" to page through more results.",
-		paginate.MaxLimit))
+	return paginate.MaxLimit
+}
+
+// listLimitDefault is the default "limit" of the summary list tools, which
+// never exceeds the cap.
+func (h *Handler) listLimitDefault() string {
+	return strconv.Itoa(min(paginate.DefaultLimit, h.listLimitCap()))
+}
+
+// listLimitDescription is the shared "limit" parameter description of the
+// summary list tools; what names the listed items.
+func (h *Handler) listLimitDescription(what string) string {
+	return fmt.Sprintf("Maximum number of %s to return per page. Default: %s, max: %d (higher values are clamped).",
+		what, h.listLimitDefault(), h.listLimitCap())
+}
+
+// listResponse builds the envelope for one page of a summary list tool.
+func listResponse(page []any, total, offset, limit int, limitClamped bool) types.ToolResponse {
+	meta := paginate.NewMetadata(total, offset, limit)
+	meta.LimitClamped = limitClamped
+	resp := types.ToolResponse{Data: page, Pagination: &meta}
+	if limitClamped {
+		resp.Warnings = []string{listClampWarning(limit)}
+	}
+	return resp
 }
 
 // looseInt parses a limit/offset-style integer that may arrive as a JSON number
@@ -111,6 +161,14 @@ func intOrStringType() mcp.PropertyOption {
 	}
 }
 
+// numberOrStringType is intOrStringType for fractional values such as
+// percentages.
+func numberOrStringType() mcp.PropertyOption {
+	return func(schema map[string]any) {
+		schema["type"] = []string{"number", "string"}
+	}
+}
+
 func boolOrStringType() mcp.PropertyOption {
 	return func(schema map[string]any) {
 		schema["type"] = []string{"boolean", "string"}
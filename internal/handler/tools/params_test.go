@@ -6,6 +6,7 @@ import (
 	"testing"
 
 	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 	"github.com/mark3labs/mcp-go/mcp"
 )
 
@@ -157,30 +158,108 @@ func TestParseParamsClamped(t *testing.T) {
 	}
 }
 
-// TestListResult_ClampNoteSeparateBlock pins that the clamp note is a separate
-// trailing block and the JSON payload is content block 0.
-func TestListResult_ClampNoteSeparateBlock(t *testing.T) {
-	payload := []byte(`{"data":[],"pagination":{}}`)
+// TestNewToolResult_ListEnvelope pins the list envelope: data and pagination
+// are always populated, warnings only when the limit was clamped, and the
+// JSON payload stays content block 0 with each warning as a trailing note.
+func TestNewToolResult_ListEnvelope(t *testing.T) {
+	page := []any{map[string]any{"name": "a"}, map[string]any{"name": "b"}}
 
-	if n := len(listResult(payload, false).Content); n != 1 {
+	res, err := newToolResult(listResponse(page, 5, 0, 2, false))
+	if err != nil {
+		t.Fatalf("newToolResult: %v", err)
+	}
+	if n := len(res.Content); n != 1 {
 		t.Fatalf("not-clamped: want 1 content block, got %d", n)
 	}
+	block0, ok := mcp.AsTextContent(res.Content[0])
+	if !ok {
+		t.Fatalf("block 0 is not text")
+	}
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(block0.Text), &parsed); err != nil {
+		t.Fatalf("block 0 must be valid JSON: %v", err)
+	}
+	if data, _ := parsed["data"].([]any); len(data) != 2 {
+		t.Fatalf("data: want 2 items, got %#v", parsed["data"])
+	}
+	pagination, _ := parsed["pagination"].(map[string]any)
+	if pagination["total"] != float64(5) || pagination["hasMore"] != true || pagination["nextOffset"] != float64(2) {
+		t.Fatalf("pagination: got %#v", pagination)
+	}
+	if _, present := pagination["limitClamped"]; present {
+		t.Fatalf("limitClamped should be omitted when the limit was not clamped")
+	}
+	for _, key := range []string{"warnings", "appliedQuery"} {
+		if _, present := parsed[key]; present {
+			t.Fatalf("%s should be omitted when unset, got %#v", key, parsed[key])
+		}
+	}
+	if res.StructuredContent == nil {
+		t.Fatalf("list envelope must carry StructuredContent")
+	}
 
-	clamped := listResult(payload, true)
+	clamped, err := newToolResult(listResponse(page, 2, 0, paginate.MaxLimit, true))
+	if err != nil {
+		t.Fatalf("newToolResult (clamped): %v", err)
+	}
 	if len(clamped.Content) != 2 {
 		t.Fatalf("clamped: want 2 content blocks, got %d", len(clamped.Content))
 	}
-	// Block 0 must remain parseable JSON.
-	block0, ok := mcp.AsTextContent(clamped.Content[0])
-	if !ok {
-		t.Fatalf("clamped: block 0 is not text")
-	}
-	var parsed map[string]any
+	block0, _ = mcp.AsTextContent(clamped.Content[0])
+	parsed = nil
 	if err := json.Unmarshal([]byte(block0.Text), &parsed); err != nil {
 		t.Fatalf("clamped: block 0 must be valid JSON: %v", err)
 	}
+	if pagination, _ := parsed["pagination"].(map[string]any); pagination["limitClamped"] != true {
+		t.Fatalf("clamped: pagination.limitClamped should be true, got %#v", parsed["pagination"])
+	}
+	if warnings, _ := parsed["warnings"].([]any); len(warnings) != 1 || !strings.Contains(warnings[0].(string), "clamped") {
+		t.Fatalf("clamped: want one clamp warning, got %#v", parsed["warnings"])
+	}
 	block1, ok := mcp.AsTextContent(clamped.Content[1])
-	if !ok || !strings.Contains(block1.Text, "clamped") {
+	if !ok || !strings.HasPrefix(block1.Text, "note: ") || !strings.Contains(block1.Text, "clamped") {
 		t.Fatalf("clamped: block 1 should be the clamp note, got %#v", clamped.Content[1])
 	}
 }
+
+// TestNewToolResult_AppliedQuery pins that a non-list envelope omits
+// pagination and echoes the applied query.
+func TestNewToolResult_AppliedQuery(t *testing.T) {
+	res, err := newToolResult(types.ToolResponse{
+		Data:         []any{},
+		AppliedQuery: map[string]any{"filter": "service.name = 'api'"},
+	})
+	if err != nil {
+		t.Fatalf("newToolResult: %v", err)
+	}
+	block0, _ := mcp.AsTextContent(res.Content[0])
+	var parsed map[string]any
+	if err := json.Unmarshal([]byte(block0.Text), &parsed); err != nil {
+		t.Fatalf("block 0 must be valid JSON: %v", err)
+	}
+	if _, present := parsed["pagination"]; present {
+		t.Fatalf("pagination should be omitted when unset")
+	}
+	applied, _ := parsed["appliedQuery"].(map[string]any)
+	if applied["filter"] != "service.name = 'api'" {
+		t.Fatalf("appliedQuery: got %#v", parsed["appliedQuery"])
+	}
+}
+
+// TestListParams_ConfiguredCap pins that the summary list tools clamp to the
+// configured cap and advertise it in the limit description.
+func TestListParams_ConfiguredCap(t *testing.T) {
+	h := newTestHandler(nil)
+	if got := h.listLimitCap(); got != paginate.MaxLimit {
+		t.Fatalf("unset cap = %d, want paginate.MaxLimit %d", got, paginate.MaxLimit)
+	}
+
+	h.maxListLimit = 25
+	limit, offset, clamped := h.parseListParams(map[string]any{"limit": "100000", "offset": "-1"})
+	if limit != 25 || offset != 0 || !clamped {
+		t.Fatalf("limit=%d offset=%d clamped=%v, want 25 0 true", limit, offset, clamped)
+	}
+	if desc := h.listLimitDescription("views"); !strings.Contains(desc, "Default: 25, max: 25") {
+		t.Fatalf("description should advertise the configured cap, got %q", desc)
+	}
+}
@@ -25,10 +25,12 @@ func (h *Handler) RegisterQueryBuilderV5Handlers(s *server.MCPServer) {
 		mcp.WithDescription(
 			"Use this only when the user needs a SigNoz Query Builder v5 request that the dedicated log, trace, and metric tools cannot express, including multi-query requests, formulas, PromQL, and ClickHouse SQL. "+
 				"Use signoz_search_logs/signoz_search_traces for raw rows, signoz_aggregate_logs/signoz_aggregate_traces for grouped or top-N analysis, and signoz_query_metrics for ordinary metrics queries. "+
-				"Before composing the query, read the matching signoz://logs/query-builder-guide, signoz://traces/query-builder-guide, or signoz://metrics-aggregation-guide; formulas also require the metrics guide, and PromQL requires signoz://promql/instructions. "+
+				"To start from a payload that already validates, call signoz_get_query_examples. Before composing the query, read the matching signoz://logs/query-builder-guide, signoz://traces/query-builder-guide, or signoz://metrics-aggregation-guide; formulas also require the metrics guide, and PromQL requires signoz://promql/instructions. "+
 				"For predictable formulas, explicitly set each input builder_query limit to 10000, the builder_formula result limit to 100, and non-empty spec.order (not dashboard orderBy) on every builder_query and builder_formula; the server normalizes omissions.",
 		),
-		mcp.WithObject("query", mcp.Required(), mcp.Description("Complete SigNoz Query Builder v5 JSON object with schemaVersion, start, end, requestType, compositeQuery, formatOptions, and variables. For predictable bounds, explicitly supply a positive spec.limit and non-empty spec.order (not dashboard orderBy) for every builder_query and builder_formula; the server inserts signal-aware defaults when they are omitted. Missing or zero standalone and formula-result limits normalize to 100; builder queries feeding a formula normalize to 10000 because input limits apply before formula evaluation.")),
+		mcp.WithString("timeoutSeconds", intOrStringType(), mcp.Description(timeoutSecondsParamDescription)),
+		mcp.WithBoolean("explain", boolOrStringType(), mcp.Description("When true, validate and normalize the query and return the exact JSON that would be sent, with the defaults applied, without running it (default: false). Use it to check query structure before executing.")),
+		mcp.WithObject("query", mcp.Required(), mcp.Description("Complete SigNoz Query Builder v5 JSON object with schemaVersion, start, end, requestType, compositeQuery, formatOptions, and variables. When requestType is omitted it is inferred: 'time_series' for PromQL, formulas, metrics, and logs/traces aggregations with stepInterval; 'scalar' for logs/traces aggregations without stepInterval (one row per group); otherwise 'raw'. An explicit requestType is always used as given. For predictable bounds, explicitly supply a positive spec.limit and non-empty spec.order (not dashboard orderBy) for every builder_query and builder_formula; the server inserts signal-aware defaults when they are omitted. Missing or zero standalone and formula-result limits normalize to 100; builder queries feeding a formula normalize to 10000 because input limits apply before formula evaluation.")),
 	)
 
 	h.addTool(s, executeQuery, h.handleExecuteBuilderQuery)
@@ -85,6 +87,11 @@ func (h *Handler) handleExecuteBuilderQuery(ctx context.Context, req mcp.CallToo
 		return validationError("query", "must be a JSON object"), nil
 	}
 
+	explain, _, err := parseBoolArg(args, "explain")
+	if err != nil {
+		return validationError("explain", err.Error()), nil
+	}
+
 	queryJSON, err := json.Marshal(queryObj)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Failed to marshal query object", logpkg.ErrAttr(err))
@@ -104,16 +111,28 @@ func (h *Handler) handleExecuteBuilderQuery(ctx context.Context, req mcp.CallToo
 		return errorWithCode(CodeValidationFailed, "query validation error: "+err.Error()), nil
 	}
 
+	ctx, timeoutNote, err := h.withRequestTimeout(ctx, args)
+	if err != nil {
+		return validationError("timeoutSeconds", err.Error()), nil
+	}
+
 	finalQueryJSON, err := json.Marshal(queryPayload)
 	if err != nil {
 		h.logger.ErrorContext(ctx, "Failed to marshal validated query payload", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal validated query payload: " + err.Error()), nil
 	}
 
+	if explain {
+		return h.explainBuilderQuery(ctx, finalQueryJSON, queryPayload), nil
+	}
+
 	client, err := h.GetClient(ctx)
 	if err != nil {
 		return clientError(err), nil
 	}
+	if errResult := h.checkMetricNames(ctx, client, queryMetricRefs(queryPayload)); errResult != nil {
+		return errResult, nil
+	}
 	data, err := client.QueryBuilderV5(ctx, finalQueryJSON)
 	if err != nil {
 		h.logQueryFailure(ctx, "Failed to execute query builder v5", err)
@@ -121,11 +140,21 @@ func (h *Handler) handleExecuteBuilderQuery(ctx context.Context, req mcp.CallToo
 	}
 
 	h.logger.DebugContext(ctx, "Successfully executed query builder v5")
+	data, _, capNote, errResult := h.capQueryResponse(ctx, "signoz_execute_builder_query", data)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	// Surface non-fatal backend warnings as a note + WARN log, matching the five
 	// sibling QueryBuilderV5 callers (search/aggregate logs & traces, query_metrics).
 	// Returning the body verbatim previously dropped them entirely.
 	var notes []string
+	if timeoutNote != "" {
+		notes = append(notes, timeoutNote)
+	}
+	if queryPayload.RequestTypeInferred {
+		notes = append(notes, "note: "+inferredRequestTypeMessage(queryPayload.RequestType))
+	}
 	if len(queryPayload.AppliedBounds) > 0 {
 		notes = append(notes, queryBoundsDecisionsNote(queryPayload.AppliedBounds, queryPayload.RequestType))
 	}
@@ -135,12 +164,52 @@ func (h *Handler) handleExecuteBuilderQuery(ctx context.Context, req mcp.CallToo
 	if len(warnings) > 0 {
 		notes = append(notes, backendWarningsNote(warnings))
 	}
+	notes = append(notes, capNote)
 	return resultWithNotes(data, notes...), nil
 }
 
+// explainBuilderQueryResponse is the explain=true result: the payload exactly
+// as it would be sent, and the defaults validation inserted into it.
+type explainBuilderQueryResponse struct {
+	Explain  bool            `json:"explain"`
+	Query    json.RawMessage `json:"query"`
+	Warnings []string        `json:"warnings"`
+}
+
+func (h *Handler) explainBuilderQuery(ctx context.Context, finalQueryJSON []byte, payload types.QueryPayload) *mcp.CallToolResult {
+	warnings := []string{}
+	if payload.RequestTypeInferred {
+		warnings = append(warnings, inferredRequestTypeMessage(payload.RequestType))
+	}
+	body, err := json.Marshal(explainBuilderQueryResponse{
+		Explain:  true,
+		Query:    finalQueryJSON,
+		Warnings: append(warnings, queryBoundsDecisions(payload.AppliedBounds, payload.RequestType)...),
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal query explanation", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal query explanation: " + err.Error())
+	}
+	return structuredResult(body)
+}
+
+func inferredRequestTypeMessage(requestType string) string {
+	return fmt.Sprintf("requestType was omitted and inferred as %q; set it explicitly if you need a different result shape.", requestType)
+}
+
 func queryBoundsDecisionsNote(applied []types.AppliedQueryBounds, requestType string) string {
 	var b strings.Builder
 	b.WriteString("[Decisions applied]\n")
+	for _, decision := range queryBoundsDecisions(applied, requestType) {
+		b.WriteString("  " + decision + "\n")
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// queryBoundsDecisions describes each default validation inserted, one line
+// per query, plus the time_series top-group caveat.
+func queryBoundsDecisions(applied []types.AppliedQueryBounds, requestType string) []string {
+	lines := make([]string, 0, len(applied)+1)
 	for _, bounds := range applied {
 		var decisions []string
 		if bounds.LimitDefaulted {
@@ -153,12 +222,12 @@ func queryBoundsDecisionsNote(applied []types.AppliedQueryBounds, requestType st
 		if bounds.OrderDefaulted {
 			decisions = append(decisions, fmt.Sprintf("order=%s (signal-safe default)", formatQueryOrder(bounds.Order)))
 		}
-		b.WriteString(fmt.Sprintf("  %s: %s\n", bounds.QueryName, strings.Join(decisions, ", ")))
+		lines = append(lines, fmt.Sprintf("%s: %s", bounds.QueryName, strings.Join(decisions, ", ")))
 	}
-	if requestType == "time_series" {
-		b.WriteString("  NOTE: time_series limits select top groups using the ordering across the entire time range; a short-lived spike can fall outside the selected groups.\n")
+	if requestType == "time_series" && len(applied) > 0 {
+		lines = append(lines, "NOTE: time_series limits select top groups using the ordering across the entire time range; a short-lived spike can fall outside the selected groups.")
 	}
-	return strings.TrimRight(b.String(), "\n")
+	return lines
 }
 
 func formatQueryOrder(order []types.Order) string {
@@ -0,0 +1,105 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestHandleExecuteBuilderQuery_ExplainSkipsBackend(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			t.Error("explain must not run the query")
+			return nil, nil
+		},
+		ListMetricsFn: func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
+			t.Error("explain must not call the backend")
+			return nil, nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"explain": "true",
+		"query": map[string]any{
+			"start": 1711123200000, "end": 1711130400000, "requestType": "time_series",
+			"compositeQuery": map[string]any{"queries": []any{
+				map[string]any{"type": "builder_query", "spec": map[string]any{"name": "A", "signal": "logs", "aggregations": []any{map[string]any{"expression": "count()"}}}},
+			}},
+		},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	require.Len(t, result.Content, 1)
+
+	var out struct {
+		Explain  bool               `json:"explain"`
+		Query    types.QueryPayload `json:"query"`
+		Warnings []string           `json:"warnings"`
+	}
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.True(t, out.Explain)
+	assert.Equal(t, "v1", out.Query.SchemaVersion)
+	assert.Equal(t, int64(1711123200000), out.Query.Start)
+	spec := out.Query.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, types.DefaultAggregateQueryLimit, spec.Limit)
+	require.Len(t, out.Warnings, 2)
+	assert.Contains(t, out.Warnings[0], `query "A": limit=100`)
+	assert.Contains(t, out.Warnings[1], "time_series limits select top groups")
+}
+
+func TestHandleExecuteBuilderQuery_ExplainStillValidates(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+
+	result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"explain": true,
+		"query": map[string]any{
+			"start": 1711123200000, "end": 1711130400000, "requestType": "bogus",
+			"compositeQuery": map[string]any{"queries": []any{}},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+
+	result, err = h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"explain": "maybe",
+		"query":   map[string]any{},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+}
+
+func TestHandleExecuteBuilderQuery_NotesInferredRequestType(t *testing.T) {
+	var sent types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			require.NoError(t, json.Unmarshal(body, &sent))
+			return json.RawMessage(`{"status":"success","data":{"type":"scalar","data":{"results":[]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"query": map[string]any{
+			"start": 1711123200000, "end": 1711130400000,
+			"compositeQuery": map[string]any{"queries": []any{
+				map[string]any{"type": "builder_query", "spec": map[string]any{
+					"name": "A", "signal": "logs", "limit": 10, "order": []any{map[string]any{"key": map[string]any{"name": "count()"}, "direction": "desc"}},
+					"aggregations": []any{map[string]any{"expression": "count()"}},
+					"groupBy":      []any{map[string]any{"name": "service.name"}},
+				}},
+			}},
+		},
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, "scalar", sent.RequestType)
+	require.Len(t, result.Content, 2)
+	assert.Contains(t, allTextBlocks(result)[1], `requestType was omitted and inferred as "scalar"`)
+}
@@ -0,0 +1,86 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/querybuilder"
+)
+
+func (h *Handler) RegisterQueryExamplesHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering query examples handlers")
+
+	tool := mcp.NewTool("signoz_get_query_examples",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this before writing a query for signoz_execute_builder_query, to start from a payload that already validates. It returns ready-to-run Query Builder v5 payloads for common cases (raw logs by service, error spans, metric rate, p99 latency, formula-based error rate) with start and end filled in for the requested window. Swap the sample values listed in replace for the user's own, then pass the query unchanged otherwise. It does not query SigNoz."),
+		mcp.WithString("name", mcp.Enum(querybuilder.QueryExampleNames()...), mcp.Description("Return only this example. Omit to return all of them.")),
+		mcp.WithString("signal", mcp.Enum("logs", "traces", "metrics"), mcp.Description("Return only examples for this signal. Omit to return all of them.")),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Sets start and end in the returned payloads. Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetQueryExamples)
+}
+
+type queryExample struct {
+	Name        string          `json:"name"`
+	Description string          `json:"description"`
+	Signal      string          `json:"signal"`
+	Replace     []string        `json:"replace"`
+	Query       json.RawMessage `json:"query"`
+}
+
+type queryExamplesResponse struct {
+	Start    int64          `json:"start"`
+	End      int64          `json:"end"`
+	Examples []queryExample `json:"examples"`
+}
+
+func (h *Handler) handleGetQueryExamples(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	name := strings.TrimSpace(stringArg(args, "name"))
+	signal := strings.ToLower(strings.TrimSpace(stringArg(args, "signal")))
+	start, end, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_query_examples", slog.String("name", name), slog.String("signal", signal))
+
+	if name != "" && !slices.Contains(querybuilder.QueryExampleNames(), name) {
+		return validationErrorf("name", "must be one of: %s", strings.Join(querybuilder.QueryExampleNames(), ", ")), nil
+	}
+
+	out := queryExamplesResponse{Start: start, End: end, Examples: []queryExample{}}
+	for _, example := range querybuilder.QueryExamples {
+		if (name != "" && example.Name != name) || (signal != "" && example.Signal != signal) {
+			continue
+		}
+		out.Examples = append(out.Examples, queryExample{
+			Name:        example.Name,
+			Description: example.Description,
+			Signal:      example.Signal,
+			Replace:     example.Replace,
+			Query:       example.Fill(start, end),
+		})
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal query examples", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal query examples: " + err.Error()), nil
+	}
+	return structuredResult(resp), nil
+}
@@ -0,0 +1,58 @@
+package tools
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/pkg/querybuilder"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestHandleGetQueryExamples(t *testing.T) {
+	h := newTestHandler(nil)
+
+	result, err := h.handleGetQueryExamples(testCtx(), makeToolRequest("signoz_get_query_examples", map[string]any{
+		"start": "1767225600000",
+		"end":   "1767229200000",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out queryExamplesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	require.Len(t, out.Examples, len(querybuilder.QueryExamples))
+	for _, example := range out.Examples {
+		var payload types.QueryPayload
+		require.NoError(t, json.Unmarshal(example.Query, &payload), example.Name)
+		assert.Equal(t, int64(1767225600000), payload.Start, example.Name)
+		assert.Equal(t, int64(1767229200000), payload.End, example.Name)
+		assert.NoError(t, payload.Validate(), example.Name)
+	}
+}
+
+func TestHandleGetQueryExamples_Filters(t *testing.T) {
+	h := newTestHandler(nil)
+
+	result, err := h.handleGetQueryExamples(testCtx(), makeToolRequest("signoz_get_query_examples", map[string]any{"signal": "metrics"}))
+	require.NoError(t, err)
+	var out queryExamplesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	require.NotEmpty(t, out.Examples)
+	for _, example := range out.Examples {
+		assert.Equal(t, "metrics", example.Signal)
+	}
+
+	result, err = h.handleGetQueryExamples(testCtx(), makeToolRequest("signoz_get_query_examples", map[string]any{"name": "error_rate_formula"}))
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	require.Len(t, out.Examples, 1)
+	assert.Equal(t, "error_rate_formula", out.Examples[0].Name)
+
+	result, err = h.handleGetQueryExamples(testCtx(), makeToolRequest("signoz_get_query_examples", map[string]any{"name": "nope"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+}
@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+func timeoutTestQuery() map[string]any {
+	return map[string]any{
+		"schemaVersion": "v1",
+		"start":         1711123200000,
+		"end":           1711130400000,
+		"requestType":   "raw",
+		"compositeQuery": map[string]any{
+			"queries": []any{
+				map[string]any{
+					"type": "builder_query",
+					"spec": map[string]any{
+						"name":   "A",
+						"signal": "logs",
+						"limit":  10,
+						"order":  []any{map[string]any{"key": map[string]any{"name": "timestamp"}, "direction": "desc"}},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestHandleExecuteBuilderQuery_TimeoutSecondsOverride(t *testing.T) {
+	cases := []struct {
+		name      string
+		maxCfg    time.Duration
+		arg       any
+		want      time.Duration
+		wantSet   bool
+		wantClamp bool
+	}{
+		{name: "absent keeps client default", arg: nil, wantSet: false},
+		{name: "shorter than default", arg: 5, want: 5 * time.Second, wantSet: true},
+		{name: "string form", arg: "45", want: 45 * time.Second, wantSet: true},
+		{name: "longer within configured max", maxCfg: 20 * time.Minute, arg: 900, want: 900 * time.Second, wantSet: true},
+		{name: "clamped to configured max", maxCfg: 30 * time.Second, arg: 120, want: 30 * time.Second, wantSet: true, wantClamp: true},
+		{name: "clamped to default max when unconfigured", arg: 3600, want: client.DefaultQueryTimeout, wantSet: true, wantClamp: true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var got time.Duration
+			var gotSet bool
+			mock := &client.MockClient{
+				QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+					got, gotSet = util.GetRequestTimeout(ctx)
+					return json.RawMessage(`{"status":"success","data":{}}`), nil
+				},
+			}
+			h := newTestHandler(mock)
+			h.maxQueryTimeout = tc.maxCfg
+			args := map[string]any{"query": timeoutTestQuery()}
+			if tc.arg != nil {
+				args["timeoutSeconds"] = tc.arg
+			}
+
+			result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("unexpected error result: %v", allTextBlocks(result))
+			}
+			if gotSet != tc.wantSet || got != tc.want {
+				t.Fatalf("request timeout = (%s, %v), want (%s, %v)", got, gotSet, tc.want, tc.wantSet)
+			}
+			if clamped := strings.Contains(strings.Join(allTextBlocks(result), "\n"), "clamped"); clamped != tc.wantClamp {
+				t.Fatalf("clamp note present = %v, want %v", clamped, tc.wantClamp)
+			}
+		})
+	}
+}
+
+func TestHandleExecuteBuilderQuery_InvalidTimeoutSeconds(t *testing.T) {
+	for _, arg := range []any{0, -3, "soon"} {
+		mock := &client.MockClient{
+			QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+				t.Fatalf("upstream must not be called for timeoutSeconds=%v", arg)
+				return nil, nil
+			},
+		}
+		h := newTestHandler(mock)
+		result, err := h.handleExecuteBuilderQuery(testCtx(), makeToolRequest("signoz_execute_builder_query", map[string]any{
+			"query":          timeoutTestQuery(),
+			"timeoutSeconds": arg,
+		}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code := resultCode(t, result); code != CodeValidationFailed {
+			t.Fatalf("timeoutSeconds=%v: resultCode = %q, want %q", arg, code, CodeValidationFailed)
+		}
+	}
+}
+
+func TestHandleExecuteBuilderQuery_ParentCancelReachesClient(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(5 * time.Second):
+				return json.RawMessage(`{"status":"success"}`), nil
+			}
+		},
+	}
+	h := newTestHandler(mock)
+
+	ctx, cancel := context.WithCancel(testCtx())
+	time.AfterFunc(50*time.Millisecond, cancel)
+	start := time.Now()
+	result, err := h.handleExecuteBuilderQuery(ctx, makeToolRequest("signoz_execute_builder_query", map[string]any{
+		"query":          timeoutTestQuery(),
+		"timeoutSeconds": 300,
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.IsError {
+		t.Fatalf("expected an error result after the caller canceled")
+	}
+	if elapsed := time.Since(start); elapsed > 2*time.Second {
+		t.Fatalf("handler returned %s after cancellation; the client did not see the parent context", elapsed)
+	}
+}
@@ -1,27 +1,77 @@
 package tools
 
 import (
+	"log/slog"
+
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// toolGroupRegistration ties one Register*Handlers func to the tool group
+// operators name in ENABLED_TOOL_GROUPS.
+type toolGroupRegistration struct {
+	group    string
+	register func(*Handler, *server.MCPServer)
+}
+
+// toolGroupRegistrations lists every handler group in registration order.
+// A new Register*Handlers func must be added here to reach the server. It is
+// a func rather than a var because signoz_list_tools reads it back.
+func toolGroupRegistrations() []toolGroupRegistration {
+	return []toolGroupRegistration{
+		{"metrics", (*Handler).RegisterMetricsHandlers},
+		{"metrics", (*Handler).RegisterTopMetricsHandlers},
+		{"metrics", (*Handler).RegisterMetricUsageHandlers},
+		{"fields", (*Handler).RegisterFieldsHandlers},
+		{"fields", (*Handler).RegisterFieldCardinalityHandlers},
+		{"alerts", (*Handler).RegisterAlertsHandlers},
+		{"alerts", (*Handler).RegisterAlertPreviewHandlers},
+		{"alerts", (*Handler).RegisterAlertStatsHandlers},
+		{"alerts", (*Handler).RegisterAlertContextHandlers},
+		{"dashboards", (*Handler).RegisterDashboardHandlers},
+		{"services", (*Handler).RegisterServiceHandlers},
+		{"services", (*Handler).RegisterServiceExternalsHandlers},
+		{"services", (*Handler).RegisterServiceErrorsHandlers},
+		{"services", (*Handler).RegisterInvestigateServiceHandlers},
+		{"query", (*Handler).RegisterQueryBuilderV5Handlers},
+		{"query", (*Handler).RegisterCompareHandlers},
+		{"query", (*Handler).RegisterQueryExamplesHandlers},
+		{"logs", (*Handler).RegisterLogsHandlers},
+		{"logs", (*Handler).RegisterExportLogsHandlers},
+		{"logs", (*Handler).RegisterTailLogsHandlers},
+		{"logs", (*Handler).RegisterLogsHistogramHandlers},
+		{"logs", (*Handler).RegisterTopErrorsHandlers},
+		{"views", (*Handler).RegisterViewHandlers},
+		{"docs", (*Handler).RegisterDocsHandlers},
+		{"traces", (*Handler).RegisterTracesHandlers},
+		{"traces", (*Handler).RegisterTraceOperatorHandlers},
+		{"traces", (*Handler).RegisterTraceDependencyHandlers},
+		{"traces", (*Handler).RegisterErrorSampleHandlers},
+		{"traces", (*Handler).RegisterExemplarTraceHandlers},
+		{"traces", (*Handler).RegisterTraceLatencyHistogramHandlers},
+		{"notification_channels", (*Handler).RegisterNotificationChannelHandlers},
+		{"metrics", (*Handler).RegisterMetricCardinalityHandlers},
+		{"server", (*Handler).RegisterServerStatsHandlers},
+		{"server", (*Handler).RegisterDebugLastRequestHandlers},
+		{"server", (*Handler).RegisterHealthCheckHandlers},
+		{"server", (*Handler).RegisterVersionHandlers},
+		{"server", (*Handler).RegisterListToolsHandlers},
+	}
+}
+
 // RegisterAllToolHandlers registers every tool handler group on s. It is the
 // single source of truth for tool registration: production
 // (internal/mcp-server) and the schema/annotation inventory tests all
 // register through it, so a new handler group cannot reach the server
 // without also passing the pinned-inventory tests.
+//
+// Groups left out of ENABLED_TOOL_GROUPS are skipped; tools listed in
+// DISABLED_TOOLS are dropped by addTool.
 func (h *Handler) RegisterAllToolHandlers(s *server.MCPServer) {
-	h.RegisterMetricsHandlers(s)
-	h.RegisterTopMetricsHandlers(s)
-	h.RegisterMetricUsageHandlers(s)
-	h.RegisterFieldsHandlers(s)
-	h.RegisterAlertsHandlers(s)
-	h.RegisterDashboardHandlers(s)
-	h.RegisterServiceHandlers(s)
-	h.RegisterQueryBuilderV5Handlers(s)
-	h.RegisterLogsHandlers(s)
-	h.RegisterViewHandlers(s)
-	h.RegisterDocsHandlers(s)
-	h.RegisterTracesHandlers(s)
-	h.RegisterNotificationChannelHandlers(s)
-	h.RegisterMetricCardinalityHandlers(s)
+	for _, reg := range toolGroupRegistrations() {
+		if !h.toolGroupEnabled(reg.group) {
+			h.logger.Debug("Skipping disabled tool group", slog.String("group", reg.group))
+			continue
+		}
+		reg.register(h, s)
+	}
 }
@@ -0,0 +1,168 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/mark3labs/mcp-go/mcp"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+)
+
+// responseTruncation describes how a query response was shrunk to fit the
+// configured byte cap. Kept and Total count row-like items: raw rows,
+// time-series series, and scalar table rows.
+type responseTruncation struct {
+	Kept  int
+	Total int
+}
+
+// capQueryResponse enforces h.maxResponseBytes on a QB v5 query_range body,
+// measured as serializedSize, the form the client finally receives. An
+// over-cap body is truncated to a valid prefix of its rows/series with a
+// top-level {"truncated": true, "totalApprox": N} marker, and a note says
+// what was dropped. A body that cannot be shrunk enough becomes an error
+// result asking the caller to narrow the query. A zero cap (an unconfigured
+// handler) disables the guard.
+func (h *Handler) capQueryResponse(ctx context.Context, toolName string, payload []byte) ([]byte, *responseTruncation, string, *mcp.CallToolResult) {
+	return h.capQueryResponseWithin(ctx, toolName, "response", payload, h.maxResponseBytes)
+}
+
+// capQueryResponseWithin is capQueryResponse with an explicit byte budget,
+// for tools that embed several query bodies in one result. what names the
+// body in the note and error text.
+func (h *Handler) capQueryResponseWithin(ctx context.Context, toolName, what string, payload []byte, maxBytes int) ([]byte, *responseTruncation, string, *mcp.CallToolResult) {
+	if h.maxResponseBytes <= 0 || h.serializedSize(payload) <= maxBytes {
+		return payload, nil, "", nil
+	}
+	capped, tr, ok := truncateQueryResponse(payload, maxBytes, h.serializedSize)
+	if !ok {
+		h.logger.WarnContext(ctx, "Query response exceeds the response cap and could not be truncated",
+			slog.String("tool", toolName),
+			slog.Int("bytes", len(payload)),
+			slog.Int("max_bytes", maxBytes))
+		return nil, nil, "", validationResult(fmt.Sprintf(
+			"query %s of %d bytes exceeds the %d-byte limit (%s) and could not be truncated to fit; narrow the query (reduce limit, time range, selected fields, or groupBy cardinality).",
+			what, len(payload), maxBytes, config.MaxResponseBytesEnv))
+	}
+	if tr == nil {
+		return capped, nil, "", nil
+	}
+	h.logger.DebugContext(ctx, "Truncated oversized query response",
+		slog.String("tool", toolName),
+		slog.Int("bytes", len(payload)),
+		slog.Int("kept", tr.Kept),
+		slog.Int("total", tr.Total))
+	note := fmt.Sprintf(
+		"note: %s exceeded the %d-byte limit, so only the first %d of %d rows/series are included (truncated=true); narrow the query or page with a smaller limit for the rest.",
+		what, maxBytes, tr.Kept, tr.Total)
+	return capped, tr, note, nil
+}
+
+// serializedSize is the size of a JSON body as the client receives it: the
+// indented size when SIGNOZ_PRETTY_JSON is set (see prettyJSONDecorator),
+// otherwise its length.
+func (h *Handler) serializedSize(body []byte) int {
+	if !h.prettyJSON {
+		return len(body)
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, body, "", "  "); err != nil {
+		return len(body)
+	}
+	return buf.Len()
+}
+
+// truncateQueryResponse keeps the longest prefix of the response's row-like
+// lists, taken in result order, whose re-encoded body measures at most
+// maxBytes by size, including the truncation marker. It reports ok=false when
+// the body has an unexpected shape or does not fit even with every list
+// emptied. A nil truncation with ok=true means the compact re-encoding fit
+// without dropping anything.
+func truncateQueryResponse(payload []byte, maxBytes int, size func([]byte) int) ([]byte, *responseTruncation, bool) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var root map[string]any
+	if err := dec.Decode(&root); err != nil {
+		return nil, nil, false
+	}
+	lists := rowLists(root)
+	if len(lists) == 0 {
+		return nil, nil, false
+	}
+	total := 0
+	for _, l := range lists {
+		total += len(l.items)
+	}
+	encode := func(keep int) []byte {
+		for _, l := range lists {
+			n := min(keep, len(l.items))
+			l.set(l.items[:n])
+			keep -= n
+		}
+		out, err := json.Marshal(root)
+		if err != nil {
+			return nil
+		}
+		return out
+	}
+	fits := func(keep int) bool {
+		out := encode(keep)
+		return out != nil && size(out) <= maxBytes
+	}
+
+	if fits(total) {
+		return encode(total), nil, true
+	}
+	root["truncated"] = true
+	root["totalApprox"] = total
+	if !fits(0) {
+		return nil, nil, false
+	}
+	// Largest keep in [0, total) that fits; fits is monotone in keep.
+	keep := sort.Search(total, func(k int) bool { return !fits(k + 1) })
+	return encode(keep), &responseTruncation{Kept: keep, Total: total}, true
+}
+
+// rowList is one truncatable array inside a decoded response, with a setter
+// that writes a shortened slice back into its parent.
+type rowList struct {
+	items []any
+	set   func([]any)
+}
+
+// rowLists finds data.data.results[] and returns, in order, each result's
+// raw "rows", each aggregation's time-series "series", and scalar "data"
+// table rows.
+func rowLists(root map[string]any) []rowList {
+	outer, _ := root["data"].(map[string]any)
+	inner, _ := outer["data"].(map[string]any)
+	results, _ := inner["results"].([]any)
+
+	var lists []rowList
+	addList := func(parent map[string]any, key string) {
+		if items, ok := parent[key].([]any); ok {
+			lists = append(lists, rowList{items: items, set: func(v []any) { parent[key] = v }})
+		}
+	}
+	for _, r := range results {
+		result, ok := r.(map[string]any)
+		if !ok {
+			continue
+		}
+		addList(result, "rows")
+		if aggs, ok := result["aggregations"].([]any); ok {
+			for _, a := range aggs {
+				if agg, ok := a.(map[string]any); ok {
+					addList(agg, "series")
+				}
+			}
+		}
+		addList(result, "data")
+	}
+	return lists
+}
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+// rawRowsBody builds a query_range body with n raw rows, each carrying a
+// body of the given size.
+func rawRowsBody(n, bodySize int) []byte {
+	rows := make([]string, n)
+	for i := range rows {
+		rows[i] = fmt.Sprintf(`{"timestamp":"2026-01-01T00:00:%02dZ","data":{"body":%q}}`, i%60, strings.Repeat("x", bodySize))
+	}
+	return []byte(`{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":[` + strings.Join(rows, ",") + `]}]}}}`)
+}
+
+func byteLen(b []byte) int { return len(b) }
+
+func TestTruncateQueryResponse_KeepsRowPrefix(t *testing.T) {
+	body := rawRowsBody(50, 200)
+	out, tr, ok := truncateQueryResponse(body, 2000, byteLen)
+	require.True(t, ok)
+	require.NotNil(t, tr)
+	assert.LessOrEqual(t, len(out), 2000)
+	assert.Equal(t, 50, tr.Total)
+	assert.Greater(t, tr.Kept, 0)
+	assert.Less(t, tr.Kept, 50)
+
+	var parsed map[string]any
+	require.NoError(t, json.Unmarshal(out, &parsed))
+	assert.Equal(t, true, parsed["truncated"])
+	assert.Equal(t, float64(50), parsed["totalApprox"])
+	rows, known := countQueryRangeRows(out)
+	require.True(t, known)
+	assert.Equal(t, tr.Kept, rows)
+
+	// One more row must not fit, or the prefix was not maximal.
+	longer, _, _ := truncateQueryResponse(body, len(out)+1, byteLen)
+	assert.LessOrEqual(t, len(longer), len(out)+1)
+}
+
+func TestTruncateQueryResponse_TimeSeriesAcrossResults(t *testing.T) {
+	series := func(n int) string {
+		s := make([]string, n)
+		for i := range s {
+			s[i] = fmt.Sprintf(`{"labels":[{"key":{"name":"service.name"},"value":"svc-%03d"}],"values":[{"timestamp":1,"value":%d}]}`, i, i)
+		}
+		return strings.Join(s, ",")
+	}
+	body := []byte(`{"status":"success","data":{"type":"time_series","data":{"results":[` +
+		`{"queryName":"A","aggregations":[{"index":0,"series":[` + series(20) + `]}]},` +
+		`{"queryName":"B","aggregations":[{"index":0,"series":[` + series(20) + `]}]}]}}}`)
+
+	out, tr, ok := truncateQueryResponse(body, len(body)/2, byteLen)
+	require.True(t, ok)
+	require.NotNil(t, tr)
+	assert.Equal(t, 40, tr.Total)
+	assert.Less(t, tr.Kept, 40)
+	assert.LessOrEqual(t, len(out), len(body)/2)
+	assert.True(t, json.Valid(out))
+}
+
+func TestTruncateQueryResponse_Unshrinkable(t *testing.T) {
+	_, _, ok := truncateQueryResponse(rawRowsBody(5, 10), 20, byteLen)
+	assert.False(t, ok, "a cap smaller than the empty envelope cannot be met")
+
+	_, _, ok = truncateQueryResponse([]byte(`{"status":"success","data":{"blob":"`+strings.Repeat("y", 500)+`"}}`), 100, byteLen)
+	assert.False(t, ok, "a body without row-like lists cannot be truncated")
+}
+
+func TestTruncateQueryResponse_CompactionFits(t *testing.T) {
+	compact := rawRowsBody(3, 10)
+	var v any
+	require.NoError(t, json.Unmarshal(compact, &v))
+	pretty, err := json.MarshalIndent(v, "", "    ")
+	require.NoError(t, err)
+
+	out, tr, ok := truncateQueryResponse(pretty, len(compact)+10, byteLen)
+	require.True(t, ok)
+	assert.Nil(t, tr, "nothing dropped when the re-encoded body fits")
+	assert.NotContains(t, string(out), "truncated")
+}
+
+func TestCapQueryResponse_BudgetsForPrettyJSON(t *testing.T) {
+	body := rawRowsBody(40, 50)
+	h := newTestHandler(nil)
+	h.prettyJSON = true
+	h.maxResponseBytes = len(body) + 50
+	require.Greater(t, h.serializedSize(body), h.maxResponseBytes, "fixture must fit compact but not indented")
+
+	out, tr, note, errResult := h.capQueryResponse(testCtx(), "signoz_search_logs", body)
+	require.Nil(t, errResult)
+	require.NotNil(t, tr, "the indented form is over the cap, so rows must be dropped")
+	assert.Less(t, tr.Kept, 40)
+	assert.NotEmpty(t, note)
+	assert.LessOrEqual(t, h.serializedSize(out), h.maxResponseBytes)
+
+	h.prettyJSON = false
+	out, tr, _, errResult = h.capQueryResponse(testCtx(), "signoz_search_logs", body)
+	require.Nil(t, errResult)
+	assert.Nil(t, tr)
+	assert.Equal(t, body, out)
+}
+
+func TestSearchLogs_TruncatesOversizedResponse(t *testing.T) {
+	body := rawRowsBody(40, 300)
+	h := newTestHandler(&client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, _ []byte) (json.RawMessage, error) {
+			return body, nil
+		},
+	})
+	h.maxResponseBytes = 4000
+
+	result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"limit":  "40",
+		"offset": "100",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	blocks := allTextBlocks(result)
+	require.GreaterOrEqual(t, len(blocks), 3)
+	assert.LessOrEqual(t, len(blocks[0]), 4000)
+	rows, known := countQueryRangeRows([]byte(blocks[0]))
+	require.True(t, known)
+	require.Less(t, rows, 40)
+
+	joined := strings.Join(blocks[1:], "\n")
+	assert.Contains(t, joined, fmt.Sprintf("only the first %d of 40 rows/series", rows))
+	assert.Contains(t, joined, fmt.Sprintf("offset=%d", 100+rows), "next page must start after the kept rows")
+}
+
+func TestSearchLogs_UnshrinkableResponseIsValidationError(t *testing.T) {
+	h := newTestHandler(&client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, _ []byte) (json.RawMessage, error) {
+			return rawRowsBody(5, 100), nil
+		},
+	})
+	h.maxResponseBytes = 30
+
+	result, err := h.handleSearchLogs(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	assert.Contains(t, resultText(t, result), "MCP_MAX_RESPONSE_BYTES")
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"runtime/debug"
 	"strconv"
 	"strings"
 
@@ -59,6 +60,10 @@ var schemaArrayFields = map[string]struct{}{
 }
 
 func (h *Handler) addTool(s *server.MCPServer, tool mcp.Tool, handler server.ToolHandlerFunc) {
+	if h.toolDisabled(tool.Name) {
+		h.logger.Debug("Skipping disabled tool", slog.String("gen_ai.tool.name", tool.Name))
+		return
+	}
 	normalizeToolSchemas(&tool)
 
 	input, inputErr := compileToolSchema(tool.Name, "input", inputSchemaJSON(tool))
@@ -70,10 +75,12 @@ func (h *Handler) addTool(s *server.MCPServer, tool mcp.Tool, handler server.Too
 		h.recordSchemaCompileFailure(context.Background(), tool.Name, "output", outputErr)
 	}
 
+	handler = h.recoveryDecorator(tool.Name, handler)
 	if input != nil || output != nil {
 		handler = h.validationDecorator(tool.Name, input, output, handler)
 	}
 	handler = h.errorCodeDecorator(tool.Name, handler)
+	handler = h.prettyJSONDecorator(handler)
 	h.registerTool(s, tool, handler)
 }
 
@@ -204,6 +211,29 @@ func (h *Handler) validationDecorator(toolName string, input, output *compiledTo
 	}
 }
 
+// recoveryDecorator turns a panic in a tool handler into an internal-error
+// result, logging the stack, so one bad call cannot take down the server. It
+// wraps the handler directly, so the outer decorators see an ordinary error
+// result.
+func (h *Handler) recoveryDecorator(toolName string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				return
+			}
+			if h.logger != nil {
+				h.logger.ErrorContext(ctx, "tool handler panicked",
+					slog.String("gen_ai.tool.name", toolName),
+					slog.String("panic", fmt.Sprint(r)),
+					slog.String("stack", string(debug.Stack())))
+			}
+			result, err = InternalErrorResult(fmt.Sprintf("internal error in %s; the request was not completed. Retry, or report this if it persists.", toolName)), nil
+		}()
+		return next(ctx, req)
+	}
+}
+
 func (h *Handler) errorCodeDecorator(toolName string, next server.ToolHandlerFunc) server.ToolHandlerFunc {
 	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 		result, err := next(ctx, req)
@@ -223,6 +253,47 @@ func (h *Handler) errorCodeDecorator(toolName string, next server.ToolHandlerFun
 	}
 }
 
+// prettyJSONDecorator re-indents JSON text blocks of successful results when
+// SIGNOZ_PRETTY_JSON is set. Non-JSON blocks (notes) and errors pass through;
+// json.Indent keeps number literals verbatim, so large int64 IDs survive. A
+// block whose indented form would exceed MCP_MAX_RESPONSE_BYTES stays compact,
+// so indentation never pushes a capped result past the limit. NDJSON and CSV
+// results are line-oriented and pass through: a one-row NDJSON body is also a
+// valid JSON object, and indenting it would break one record per line.
+func (h *Handler) prettyJSONDecorator(next server.ToolHandlerFunc) server.ToolHandlerFunc {
+	return func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		result, err := next(ctx, req)
+		if err != nil || result == nil || result.IsError || !h.prettyJSON {
+			return result, err
+		}
+		if args, ok := req.Params.Arguments.(map[string]any); ok {
+			if format, _ := parseOutputFormat(args, formatNDJSON, formatCSV); format == formatNDJSON || format == formatCSV {
+				return result, nil
+			}
+		}
+		for i, c := range result.Content {
+			tc, ok := c.(mcp.TextContent)
+			if !ok {
+				continue
+			}
+			trimmed := strings.TrimSpace(tc.Text)
+			if trimmed == "" || (trimmed[0] != '{' && trimmed[0] != '[') {
+				continue
+			}
+			var buf bytes.Buffer
+			if err := json.Indent(&buf, []byte(trimmed), "", "  "); err != nil {
+				continue
+			}
+			if h.maxResponseBytes > 0 && buf.Len() > h.maxResponseBytes {
+				continue
+			}
+			tc.Text = buf.String()
+			result.Content[i] = tc
+		}
+		return result, nil
+	}
+}
+
 // validateArguments validates the exact wire bytes when the SDK preserved
 // them, avoiding the marshal round-trip of the decoded argument tree.
 func validateArguments(schema *jsonschema.Schema, req mcp.CallToolRequest) error {
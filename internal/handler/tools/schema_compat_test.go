@@ -1,11 +1,18 @@
 package tools
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
+	"log/slog"
+	"strconv"
+	"strings"
 	"testing"
 
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
 	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
 )
 
 func TestNormalizeRawSchemaReplacesSchemaTrueWithEmptyObject(t *testing.T) {
@@ -400,3 +407,120 @@ func propTypeUnion(t *testing.T, props map[string]any, name string) []string {
 		return nil
 	}
 }
+
+func TestPrettyJSONDecorator(t *testing.T) {
+	const compact = `{"data":{"id":12345678901234567890,"rows":[1,2]}}`
+	next := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return resultWithNotes([]byte(compact), "note: not JSON"), nil
+	}
+
+	for _, pretty := range []bool{false, true} {
+		h := newTestHandler(nil)
+		h.prettyJSON = pretty
+		result, err := h.prettyJSONDecorator(next)(testCtx(), mcp.CallToolRequest{})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		blocks := allTextBlocks(result)
+		if blocks[1] != "note: not JSON" {
+			t.Fatalf("pretty=%v: note block changed to %q", pretty, blocks[1])
+		}
+		if !pretty {
+			if blocks[0] != compact {
+				t.Fatalf("pretty=false: got %q, want compact passthrough", blocks[0])
+			}
+			continue
+		}
+		want := "{\n  \"data\": {\n    \"id\": 12345678901234567890,\n    \"rows\": [\n      1,\n      2\n    ]\n  }\n}"
+		if blocks[0] != want {
+			t.Fatalf("pretty=true: got\n%s\nwant\n%s", blocks[0], want)
+		}
+	}
+}
+
+func TestPrettyJSONDecorator_KeepsNearCapResultCompact(t *testing.T) {
+	rows := make([]string, 40)
+	for i := range rows {
+		rows[i] = `{"id":` + strconv.Itoa(i) + `,"body":"row"}`
+	}
+	compact := `{"data":{"rows":[` + strings.Join(rows, ",") + `]}}`
+	next := func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		return resultWithNotes([]byte(compact), "note: truncated"), nil
+	}
+
+	h := newTestHandler(nil)
+	h.prettyJSON = true
+	h.maxResponseBytes = len(compact) + 16
+	result, err := h.prettyJSONDecorator(next)(testCtx(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := allTextBlocks(result)[0]; got != compact {
+		t.Fatalf("near-cap result was re-indented to %d bytes past the %d-byte cap", len(got), h.maxResponseBytes)
+	}
+
+	h.maxResponseBytes = 4 * len(compact)
+	result, err = h.prettyJSONDecorator(next)(testCtx(), mcp.CallToolRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	got := allTextBlocks(result)[0]
+	if !strings.Contains(got, "\n  ") || len(got) > h.maxResponseBytes {
+		t.Fatalf("expected an indented result within the %d-byte cap, got %d bytes", h.maxResponseBytes, len(got))
+	}
+}
+
+func TestPrettyJSONDecorator_KeepsOneRowNDJSONOnOneLine(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(rawRowsBody(1, 5)), nil
+		},
+	}
+	h := newTestHandler(mock)
+	h.prettyJSON = true
+	result, err := h.prettyJSONDecorator(h.handleSearchLogs)(testCtx(), makeToolRequest("signoz_search_logs", map[string]any{
+		"format": "ndjson",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("unexpected error result: %v", allTextBlocks(result))
+	}
+	body := allTextBlocks(result)[0]
+	if lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n"); len(lines) != 1 {
+		t.Fatalf("one-row NDJSON body spans %d lines:\n%s", len(lines), body)
+	}
+}
+
+func TestRecoveryDecorator(t *testing.T) {
+	var logs bytes.Buffer
+	h := newTestHandler(nil)
+	h.logger = slog.New(slog.NewTextHandler(&logs, nil))
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	h.addTool(s, mcp.NewTool("panic_tool"), func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var m map[string]int
+		m["boom"]++ // nil map write panics
+		return nil, nil
+	})
+
+	result, err := s.GetTool("panic_tool").Handler(testCtx(), mcp.CallToolRequest{Params: mcp.CallToolParams{Name: "panic_tool"}})
+	if err != nil {
+		t.Fatalf("panic must become a tool result, got error %v", err)
+	}
+	if result == nil || !result.IsError {
+		t.Fatalf("expected an error result, got %+v", result)
+	}
+	if code := resultCode(t, result); code != CodeInternalError {
+		t.Fatalf("code = %q, want %q", code, CodeInternalError)
+	}
+	if msg := resultText(t, result); !strings.Contains(msg, "internal error in panic_tool") {
+		t.Fatalf("unexpected message %q", msg)
+	}
+	out := logs.String()
+	for _, want := range []string{"level=ERROR", "tool handler panicked", "gen_ai.tool.name=panic_tool", "assignment to entry in nil map", "stack="} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log missing %q:\n%s", want, out)
+		}
+	}
+}
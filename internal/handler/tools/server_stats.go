@@ -0,0 +1,50 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+func (h *Handler) RegisterServerStatsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering server stats handlers")
+
+	tool := mcp.NewTool("signoz_server_stats",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when the user asks why tool calls are slow or failing and wants to know whether SigNoz itself is the bottleneck. It reports, per SigNoz API endpoint the MCP server has called since startup, the request count, error count, average and max latency, and HTTP status-code distribution. It does not query telemetry data. Requires the server to run with "+config.RequestStatsEnv+"=true; otherwise it returns an UNSUPPORTED error."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+	)
+
+	h.addTool(s, tool, h.handleServerStats)
+}
+
+type serverStatsResponse struct {
+	signozclient.RequestStatsSnapshot
+	UptimeSeconds int64 `json:"uptimeSeconds"`
+}
+
+func (h *Handler) handleServerStats(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.logger.DebugContext(ctx, "Tool called: signoz_server_stats")
+
+	if h.requestStats == nil {
+		return errorWithCode(CodeUnsupported, "request stats are disabled on this server; set "+config.RequestStatsEnv+"=true to enable them"), nil
+	}
+
+	snapshot := h.requestStats.Snapshot()
+	out, err := json.Marshal(serverStatsResponse{
+		RequestStatsSnapshot: snapshot,
+		UptimeSeconds:        int64(time.Since(snapshot.Since).Seconds()),
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal server stats", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal server stats: " + err.Error()), nil
+	}
+	return structuredResult(out), nil
+}
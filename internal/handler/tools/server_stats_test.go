@@ -0,0 +1,45 @@
+package tools
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestHandleServerStats_DisabledIsUnsupported(t *testing.T) {
+	h := newTestHandler(nil)
+
+	result, err := h.handleServerStats(testCtx(), makeToolRequest("signoz_server_stats", nil))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, CodeUnsupported, resultCode(t, result))
+	assert.Contains(t, resultText(t, result), "SIGNOZ_REQUEST_STATS")
+}
+
+func TestHandleServerStats_ReportsEndpoints(t *testing.T) {
+	h := newTestHandler(nil)
+	h.requestStats = signozclient.NewRequestStats()
+	h.requestStats.Record(http.MethodPost, "https://signoz.example.com/api/v5/query_range", 200, 40*time.Millisecond)
+	h.requestStats.Record(http.MethodPost, "https://signoz.example.com/api/v5/query_range", 503, 80*time.Millisecond)
+
+	result, err := h.handleServerStats(testCtx(), makeToolRequest("signoz_server_stats", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got serverStatsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &got))
+	require.Len(t, got.Endpoints, 1)
+	ep := got.Endpoints[0]
+	assert.Equal(t, "POST /api/v5/query_range", ep.Endpoint)
+	assert.EqualValues(t, 2, ep.Count)
+	assert.EqualValues(t, 1, ep.Errors)
+	assert.InDelta(t, 60.0, ep.AvgLatencyMs, 0.001)
+	assert.Equal(t, map[string]int64{"200": 1, "503": 1}, ep.StatusCodes)
+	assert.NotNil(t, result.StructuredContent)
+}
@@ -0,0 +1,129 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func serviceErrorsScalarResponse(groupColumn, rows string) string {
+	return `{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A","columns":[` + groupColumn + `
+		{"name":"count()","queryName":"A","aggregationIndex":0,"columnType":"aggregation"},
+		{"name":"countIf(has_error = true)","queryName":"A","aggregationIndex":1,"columnType":"aggregation"}],
+		"data":[` + rows + `]}]}}}`
+}
+
+const serviceErrorLogsResponse = `{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":[
+	{"timestamp":"2026-01-01T00:00:02Z","data":{"body":"payment declined","severity_text":"ERROR","trace_id":"t1"}},
+	{"timestamp":"2026-01-01T00:00:01Z","data":{"body":{"msg":"panic"},"severity_text":"FATAL"}}
+]}]}}}`
+
+func TestHandleGetServiceErrors(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var payload types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+			assert.Contains(t, spec.Filter.Expression, "service.name = 'checkout'")
+			switch {
+			case spec.Signal == "logs":
+				assert.Contains(t, spec.Filter.Expression, errorSeverityFilter)
+				assert.Equal(t, 5, spec.Limit)
+				return json.RawMessage(serviceErrorLogsResponse), nil
+			case len(spec.GroupBy) == 0:
+				return json.RawMessage(serviceErrorsScalarResponse("", `[1000,50]`)), nil
+			default:
+				assert.Equal(t, "name", spec.GroupBy[0].Name)
+				return json.RawMessage(serviceErrorsScalarResponse(`{"name":"name","columnType":"group"},`,
+					`["POST /pay",100,40],["GET /cart",800,10],["GET /health",100,0]`)), nil
+			}
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceErrors(testCtx(), makeToolRequest("signoz_get_service_errors", map[string]any{
+		"service":  "checkout",
+		"logLimit": "5",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out serviceErrorsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(1000), out.Calls)
+	assert.Equal(t, int64(50), out.Errors)
+	assert.InDelta(t, 0.05, out.ErrorRate, 1e-9)
+	assert.Equal(t, []serviceErrorOperation{
+		{Name: "POST /pay", Calls: 100, Errors: 40, ErrorRate: 0.4},
+		{Name: "GET /cart", Calls: 800, Errors: 10, ErrorRate: 0.0125},
+	}, out.ErrorOperations)
+	assert.Equal(t, []serviceErrorLog{
+		{Timestamp: "2026-01-01T00:00:02Z", Severity: "ERROR", Body: "payment declined", TraceID: "t1"},
+		{Timestamp: "2026-01-01T00:00:01Z", Severity: "FATAL", Body: `{"msg":"panic"}`},
+	}, out.RecentErrorLogs)
+	assert.Len(t, result.Content, 1)
+}
+
+func TestHandleGetServiceErrors_PartialFailure(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var payload types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			if payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec).Signal == "logs" {
+				return nil, errors.New("unexpected status 500: logs unavailable")
+			}
+			return json.RawMessage(serviceErrorsScalarResponse("", `[10,1]`)), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceErrors(testCtx(), makeToolRequest("signoz_get_service_errors", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out serviceErrorsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(10), out.Calls)
+	assert.Empty(t, out.RecentErrorLogs)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "error logs query failed")
+}
+
+func TestHandleGetServiceErrors_LogsAuthFailurePropagates(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			if strings.Contains(string(body), `"signal":"logs"`) {
+				return nil, &client.HTTPStatusError{StatusCode: 403, Body: `{"error":"forbidden"}`}
+			}
+			return json.RawMessage(`{"status":"success","data":{"type":"scalar","data":{"results":[]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceErrors(testCtx(), makeToolRequest("signoz_get_service_errors", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	require.True(t, result.IsError, "a 403 on the logs query must not become a partial result")
+	assert.Equal(t, CodePermissionDenied, resultCode(t, result))
+}
+
+func TestHandleGetServiceErrors_AllQueriesFail(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return nil, errors.New("unexpected status 503: unavailable")
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceErrors(testCtx(), makeToolRequest("signoz_get_service_errors", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+}
@@ -0,0 +1,251 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	defaultExternalsLimit = 20
+	maxExternalsLimit     = 100
+)
+
+// externalCallKind describes one class of outbound call: the spans that
+// represent it and the attribute that names the dependency.
+type externalCallKind struct {
+	name    string
+	filter  string
+	groupBy string
+}
+
+var externalCallKinds = []externalCallKind{
+	{name: "db", filter: "db.system EXISTS", groupBy: "db.system"},
+	{name: "http", filter: "kind_string = 'Client' AND http_url != ''", groupBy: "server.address"},
+	{name: "messaging", filter: "messaging.system EXISTS", groupBy: "messaging.system"},
+}
+
+// externalAggregations are the per-dependency columns, in result order.
+var externalAggregations = []string{"count()", "countIf(has_error = true)", "avg(duration_nano)", "p99(duration_nano)"}
+
+func (h *Handler) RegisterServiceExternalsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering service externals handlers")
+
+	tool := mcp.NewTool("signoz_get_service_externals",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user asks which downstream dependencies a service calls or which one is slowest or failing: databases, outbound HTTP hosts, or messaging systems. It aggregates the service's outbound spans by dependency and returns, per dependency, the call count, error count and rate, and average and p99 latency, slowest p99 first. db groups by db.system, http groups client spans by server.address, and messaging groups by messaging.system; set groupBy with a single type to use another attribute such as net.peer.name or db.name. Use signoz_aggregate_traces for other breakdowns. Defaults to the last 1 hour."),
+		mcp.WithString("service", mcp.Required(), mcp.Description("Service name whose outbound calls to summarize, typically from signoz_list_services.")),
+		mcp.WithString("type", mcp.DefaultString("all"), mcp.Enum("all", "db", "http", "messaging"), mcp.Description("Which outbound calls to include: db, http, messaging, or all (default: all).")),
+		mcp.WithString("groupBy", mcp.Description("Attribute naming the dependency, overriding the type's default (e.g. 'net.peer.name', 'db.name', 'http.url'). Requires a single type.")),
+		mcp.WithString("limit", mcp.DefaultString(fmt.Sprint(defaultExternalsLimit)), intOrStringType(), mcp.Description(fmt.Sprintf("Maximum dependencies to return per type (default: %d, max: %d).", defaultExternalsLimit, maxExternalsLimit))),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetServiceExternals)
+}
+
+type externalDependency struct {
+	Type            string  `json:"type"`
+	GroupBy         string  `json:"groupBy"`
+	Name            string  `json:"name"`
+	Calls           int64   `json:"calls"`
+	Errors          int64   `json:"errors"`
+	ErrorRate       float64 `json:"errorRate"`
+	AvgDurationNano int64   `json:"avgDurationNano"`
+	AvgDuration     string  `json:"avgDuration"`
+	P99DurationNano int64   `json:"p99DurationNano"`
+	P99Duration     string  `json:"p99Duration"`
+}
+
+type serviceExternalsResponse struct {
+	Service      string               `json:"service"`
+	Start        int64                `json:"start"`
+	End          int64                `json:"end"`
+	Dependencies []externalDependency `json:"dependencies"`
+}
+
+func (h *Handler) handleGetServiceExternals(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	service, errResult := requireStringArg(args, "service")
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	kinds := externalCallKinds
+	if t := strings.TrimSpace(stringArg(args, "type")); t != "" && t != "all" {
+		i := slices.IndexFunc(externalCallKinds, func(k externalCallKind) bool { return k.name == t })
+		if i < 0 {
+			return validationError("type", "must be one of: all, db, http, messaging"), nil
+		}
+		kinds = []externalCallKind{externalCallKinds[i]}
+	}
+	if groupBy := strings.TrimSpace(stringArg(args, "groupBy")); groupBy != "" {
+		if len(kinds) != 1 {
+			return validationError("groupBy", `requires a single "type" (db, http, or messaging)`), nil
+		}
+		kinds = []externalCallKind{{name: kinds[0].name, filter: kinds[0].filter, groupBy: groupBy}}
+	}
+
+	limit, err := intArg(args, "limit", defaultExternalsLimit)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if limit < 1 {
+		limit = defaultExternalsLimit
+	}
+	limit = min(limit, maxExternalsLimit)
+
+	start, end, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	bodies := make([][]byte, len(kinds))
+	for i, kind := range kinds {
+		body, err := json.Marshal(buildExternalsQueryPayload(service, kind, start, end, limit))
+		if err != nil {
+			h.logger.ErrorContext(ctx, "Failed to marshal externals query payload", logpkg.ErrAttr(err))
+			return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+		}
+		bodies[i] = body
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_service_externals",
+		slog.String("service", service), slog.Int("types", len(kinds)), slog.Int64("start", start), slog.Int64("end", end))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	results, err := runQueriesParallel(ctx, client, bodies, len(kinds) == 1)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to query service externals", err)
+		return upstreamQueryError(err, "traces"), nil
+	}
+
+	for _, res := range results {
+		if isUpstreamAuthError(res.Err) {
+			return upstreamError(res.Err), nil
+		}
+	}
+
+	out := serviceExternalsResponse{Service: service, Start: start, End: end, Dependencies: []externalDependency{}}
+	var notes []string
+	failed := 0
+	for i, res := range results {
+		if res.Err != nil {
+			failed++
+			h.logQueryFailure(ctx, "Failed to query service externals", res.Err, slog.String("type", kinds[i].name))
+			notes = append(notes, fmt.Sprintf("note: the %s query failed and is omitted: %s", kinds[i].name, res.Err.Error()))
+			continue
+		}
+		out.Dependencies = append(out.Dependencies, parseExternalDependencies(res.Data, kinds[i])...)
+	}
+	if failed == len(results) {
+		return upstreamQueryError(results[0].Err, "traces"), nil
+	}
+	slices.SortStableFunc(out.Dependencies, func(a, b externalDependency) int {
+		return cmp.Compare(b.P99DurationNano, a.P99DurationNano)
+	})
+	if len(out.Dependencies) == 0 && len(notes) == 0 {
+		notes = append(notes, fmt.Sprintf("note: no outbound calls from %q matched in this window. Check the service name with signoz_list_services, or find the attribute naming the dependency with signoz_get_field_keys and pass it as groupBy.", service))
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal service externals", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal service externals: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// buildExternalsQueryPayload builds one scalar traces query computing
+// externalAggregations per dependency of kind, slowest p99 first.
+func buildExternalsQueryPayload(service string, kind externalCallKind, start, end int64, limit int) *types.QueryPayload {
+	aggregations := make([]any, len(externalAggregations))
+	for i, expr := range externalAggregations {
+		aggregations[i] = types.QueryAggregation{Expression: expr}
+	}
+	filter := fmt.Sprintf("service.name = %s AND %s AND %s EXISTS", quoteFilterString(service), kind.filter, kind.groupBy)
+	return &types.QueryPayload{
+		SchemaVersion: "v1",
+		Start:         start,
+		End:           end,
+		RequestType:   "scalar",
+		CompositeQuery: types.CompositeQuery{
+			Queries: []types.Query{{
+				Type: "builder_query",
+				Spec: types.QuerySpec{
+					Name:         "A",
+					Signal:       "traces",
+					Filter:       &types.Filter{Expression: filter},
+					Limit:        limit,
+					Order:        []types.Order{{Key: types.Key{Name: "p99(duration_nano)"}, Direction: "desc"}},
+					GroupBy:      []types.SelectField{aggregateGroupByField("traces", kind.groupBy)},
+					Aggregations: aggregations,
+				},
+			}},
+		},
+		Variables: map[string]any{},
+	}
+}
+
+// parseExternalDependencies reads the scalar rows of an externals query.
+// Rows without a dependency name or call count are skipped.
+func parseExternalDependencies(data json.RawMessage, kind externalCallKind) []externalDependency {
+	var env qbResultEnvelope
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil
+	}
+	var out []externalDependency
+	for _, res := range env.Data.Data.Results {
+		for _, row := range res.Data {
+			dep := externalDependency{Type: kind.name, GroupBy: kind.groupBy}
+			var values [4]float64
+			hasCalls := false
+			for i, col := range res.Columns {
+				if i >= len(row) {
+					break
+				}
+				switch col.ColumnType {
+				case "group":
+					dep.Name = fmt.Sprint(row[i])
+				case "aggregation":
+					if f, ok := finiteFloat(row[i]); ok && col.AggregationIndex >= 0 && col.AggregationIndex < len(values) {
+						values[col.AggregationIndex] = f
+						hasCalls = hasCalls || col.AggregationIndex == 0
+					}
+				}
+			}
+			if dep.Name == "" || !hasCalls {
+				continue
+			}
+			dep.Calls, dep.Errors = int64(values[0]), int64(values[1])
+			if dep.Calls > 0 {
+				dep.ErrorRate = float64(dep.Errors) / float64(dep.Calls)
+			}
+			dep.AvgDurationNano, dep.P99DurationNano = int64(values[2]), int64(values[3])
+			dep.AvgDuration = time.Duration(dep.AvgDurationNano).String()
+			dep.P99Duration = time.Duration(dep.P99DurationNano).String()
+			out = append(out, dep)
+		}
+	}
+	return out
+}
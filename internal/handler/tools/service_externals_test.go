@@ -0,0 +1,130 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func externalsScalarResponse(group string, rows string) string {
+	return `{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A","columns":[
+		{"name":"` + group + `","columnType":"group"},
+		{"name":"count()","queryName":"A","aggregationIndex":0,"columnType":"aggregation"},
+		{"name":"countIf(has_error = true)","queryName":"A","aggregationIndex":1,"columnType":"aggregation"},
+		{"name":"avg(duration_nano)","queryName":"A","aggregationIndex":2,"columnType":"aggregation"},
+		{"name":"p99(duration_nano)","queryName":"A","aggregationIndex":3,"columnType":"aggregation"}],
+		"data":[` + rows + `]}]}}}`
+}
+
+func TestHandleGetServiceExternals_All(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var payload types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+			assert.Contains(t, spec.Filter.Expression, "service.name = 'checkout'")
+			assert.Len(t, spec.Aggregations, 4)
+			switch spec.GroupBy[0].Name {
+			case "db.system":
+				return json.RawMessage(externalsScalarResponse("db.system", `["postgresql",200,2,4000000,30000000],["redis",900,0,500000,2000000]`)), nil
+			case "server.address":
+				assert.Contains(t, spec.Filter.Expression, "kind_string = 'Client'")
+				return json.RawMessage(externalsScalarResponse("server.address", `["payments.internal",50,5,90000000,450000000]`)), nil
+			default:
+				return nil, errors.New(`unexpected status 400: key "messaging.system" not found`)
+			}
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceExternals(testCtx(), makeToolRequest("signoz_get_service_externals", map[string]any{
+		"service": "checkout",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var out serviceExternalsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	require.Len(t, out.Dependencies, 3)
+	slowest := out.Dependencies[0]
+	assert.Equal(t, "http", slowest.Type)
+	assert.Equal(t, "payments.internal", slowest.Name)
+	assert.Equal(t, int64(50), slowest.Calls)
+	assert.InDelta(t, 0.1, slowest.ErrorRate, 1e-9)
+	assert.Equal(t, "450ms", slowest.P99Duration)
+	assert.Equal(t, "postgresql", out.Dependencies[1].Name)
+	assert.Equal(t, "redis", out.Dependencies[2].Name)
+
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "messaging query failed")
+}
+
+func TestHandleGetServiceExternals_AuthFailurePropagates(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			if strings.Contains(string(body), `"name":"db.system"`) {
+				return nil, &client.HTTPStatusError{StatusCode: 403, Body: `{"error":"forbidden"}`}
+			}
+			return json.RawMessage(externalsScalarResponse("server.address", ``)), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceExternals(testCtx(), makeToolRequest("signoz_get_service_externals", map[string]any{
+		"service": "checkout",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError)
+	assert.Equal(t, CodePermissionDenied, resultCode(t, result))
+}
+
+func TestHandleGetServiceExternals_AllQueriesFail(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return nil, &client.HTTPStatusError{StatusCode: 503, Body: `{"error":"unavailable"}`}
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceExternals(testCtx(), makeToolRequest("signoz_get_service_externals", map[string]any{
+		"service": "checkout",
+	}))
+	require.NoError(t, err)
+	require.True(t, result.IsError, "a result with every query failed must not be reported as success")
+}
+
+func TestHandleGetServiceExternals_GroupByOverride(t *testing.T) {
+	calls := 0
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			calls++
+			assert.True(t, strings.Contains(string(body), `"name":"net.peer.name"`), string(body))
+			return json.RawMessage(externalsScalarResponse("net.peer.name", ``)), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceExternals(testCtx(), makeToolRequest("signoz_get_service_externals", map[string]any{
+		"service": "checkout", "type": "http", "groupBy": "net.peer.name",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, 1, calls)
+	assert.Contains(t, allTextBlocks(result)[1], "no outbound calls")
+
+	result, err = h.handleGetServiceExternals(testCtx(), makeToolRequest("signoz_get_service_externals", map[string]any{
+		"service": "checkout", "groupBy": "net.peer.name",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	assert.Equal(t, 1, calls)
+}
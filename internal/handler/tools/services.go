@@ -1,9 +1,12 @@
 package tools
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"log/slog"
+	"slices"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -11,6 +14,7 @@ import (
 	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
 	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
 	"github.com/SigNoz/signoz-mcp-server/pkg/timeutil"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 	"github.com/SigNoz/signoz-mcp-server/pkg/util"
 )
 
@@ -20,12 +24,15 @@ func (h *Handler) RegisterServiceHandlers(s *server.MCPServer) {
 	listTool := mcp.NewTool("signoz_list_services",
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user wants APM services with trace activity and their call or latency summaries in a time window. It returns paginated traced-service records; absence means no trace activity in that window, not that a matching service.name never appears in logs. For log values use signoz_get_field_values with signal=\"logs\" and name=\"service.name\"; for one service's operations use signoz_get_service_top_operations. Follow pagination.nextOffset until hasMore=false before concluding a traced service is absent."),
+		mcp.WithDescription("Use this when the user wants APM services with trace activity and their call or latency summaries in a time window. It returns paginated traced-service records; absence means no trace activity in that window, not that a matching service.name never appears in logs. For log values use signoz_get_field_values with signal=\"logs\" and name=\"service.name\"; for one service's operations use signoz_get_service_top_operations. Set sortBy, order, and minErrorRate to rank or filter before paging, e.g. services over 5% errors sorted by p99. Follow pagination.nextOffset until hasMore=false before concluding a traced service is absent."),
 		mcp.WithString("timeRange", mcp.DefaultString("6h"), mcp.Description(timeRangeDesc("Defaults to last 6 hours if not provided."))),
 		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional, defaults to 6 hours ago).")),
 		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional, defaults to now).")),
-		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum services per page. Default: 50; max: 1000 (higher values are clamped).")),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("services"))),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of services to skip. Default: 0; use pagination.nextOffset for the next page.")),
+		mcp.WithString("sortBy", mcp.DefaultString("name"), mcp.Enum(serviceSortFields...), mcp.Description("Sort services before paging by this field: name (default), p99, avgDuration, numCalls, callRate, numErrors, errorRate, or fourXXRate.")),
+		mcp.WithString("order", mcp.Enum("asc", "desc"), mcp.Description("Sort direction: 'asc' or 'desc'. Defaults to 'asc' for name and 'desc' for the numeric fields, so the slowest or most failing services come first.")),
+		mcp.WithString("minErrorRate", numberOrStringType(), mcp.Description("Keep only services whose errorRate is at least this percentage, e.g. 5 for 5%. Applied before paging, so pagination.total counts matching services.")),
 	)
 
 	h.addTool(s, listTool, h.handleListServices)
@@ -33,19 +40,23 @@ func (h *Handler) RegisterServiceHandlers(s *server.MCPServer) {
 	getOpsTool := mcp.NewTool("signoz_get_service_top_operations",
 		withReadOnlyToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
-		mcp.WithDescription("Use this when the user wants the built-in operation table for one traced service in a time window. It ranks operation names by p99 latency and returns p50, p95, p99, call count, and error count. Use signoz_list_services to discover active traced service names. For custom aggregation, grouping, time series, cross-service comparison, or arbitrary trace filters, use signoz_aggregate_traces instead. The optional tags parameter is a JSON-encoded TagQueryParam array."),
+		mcp.WithDescription("Use this when the user wants the built-in operation table for one traced service in a time window. It ranks operation names by p99 latency by default and returns p50, p95, p99, call count, and error count; set orderBy to rank by p95, p50, calls, errors, or errorRate instead. Use signoz_list_services to discover active traced service names. For custom aggregation, grouping, time series, cross-service comparison, or arbitrary trace filters, use signoz_aggregate_traces instead. The optional tags parameter is a JSON-encoded TagQueryParam array."),
 		mcp.WithString("service", mcp.Required(), mcp.Description("Exact traced service name, typically from signoz_list_services.")),
 		mcp.WithString("timeRange", mcp.DefaultString("6h"), mcp.Description(timeRangeDesc("Defaults to last 6 hours if not provided."))),
 		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional, defaults to 6 hours ago).")),
 		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional, defaults to now).")),
 		mcp.WithString("tags", mcp.Description("JSON-encoded TagQueryParam array; omit for no tag filter. Example: [{\"key\":\"http.method\",\"tagType\":\"SpanAttribute\",\"operator\":\"In\",\"stringValues\":[\"GET\"]}]. Pass the array as a string, not as a JSON array value.")),
+		mcp.WithString("orderBy", mcp.DefaultString("p99"), mcp.Enum(topOperationsOrders...), mcp.Description("Ranking, highest first: p99 (default, the upstream order), p95, p50, calls, errors, or errorRate (errors divided by calls; adds an errorRate field to each operation).")),
 	)
 
 	h.addTool(s, getOpsTool, h.handleGetServiceTopOperations)
 }
 
 func (h *Handler) handleListServices(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-	args := req.GetArguments()
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
 
 	// Reject a present-but-malformed start/end loudly; otherwise
 	// GetTimestampsWithDefaults silently falls back to the default window.
@@ -55,9 +66,36 @@ func (h *Handler) handleListServices(ctx context.Context, req mcp.CallToolReques
 	}
 
 	start, end := timeutil.GetTimestampsWithDefaults(args, timeutil.UnitNanos)
-	limit, offset, limitClamped := paginate.ParseParamsClamped(req.Params.Arguments)
+	limit, offset, limitClamped := h.parseListParams(req.Params.Arguments)
+
+	sortBy := strings.TrimSpace(stringArg(args, "sortBy"))
+	if sortBy == "" {
+		sortBy = "name"
+	}
+	if !slices.Contains(serviceSortFields, sortBy) {
+		return validationError("sortBy", "must be one of: "+strings.Join(serviceSortFields, ", ")), nil
+	}
+	order := strings.TrimSpace(stringArg(args, "order"))
+	switch order {
+	case "":
+		order = "desc"
+		if sortBy == "name" {
+			order = "asc"
+		}
+	case "asc", "desc":
+	default:
+		return validationError("order", `must be "asc" or "desc"`), nil
+	}
+	var minErrorRate float64
+	if v, ok := args["minErrorRate"]; ok && v != nil && v != "" {
+		f, ok := finiteFloat(v)
+		if !ok || f < 0 {
+			return validationError("minErrorRate", "must be a non-negative percentage, e.g. 5 for 5%"), nil
+		}
+		minErrorRate = f
+	}
 
-	h.logger.DebugContext(ctx, "Tool called: signoz_list_services", slog.String("start", start), slog.String("end", end), slog.Int("limit", limit), slog.Int("offset", offset))
+	h.logger.DebugContext(ctx, "Tool called: signoz_list_services", slog.String("start", start), slog.String("end", end), slog.Int("limit", limit), slog.Int("offset", offset), slog.String("sortBy", sortBy))
 	client, err := h.GetClient(ctx)
 	if err != nil {
 		return clientError(err), nil
@@ -68,35 +106,40 @@ func (h *Handler) handleListServices(ctx context.Context, req mcp.CallToolReques
 		return upstreamError(err), nil
 	}
 
-	var services []any
+	var services []types.ServiceSummary
 	if err := json.Unmarshal(result, &services); err != nil {
 		h.logger.ErrorContext(ctx, "Failed to parse services response", logpkg.ErrAttr(err))
 		return upstreamResponseError("failed to parse response: " + err.Error()), nil
 	}
 
-	if base, hasURL := util.GetSigNozURL(ctx); hasURL {
-		for _, item := range services {
-			m, ok := item.(map[string]any)
-			if !ok {
-				continue
-			}
-			name, _ := m["serviceName"].(string)
-			if webURL, ok := util.ResourceWebURL(base, "service", name); ok {
-				m["webUrl"] = webURL
+	if minErrorRate > 0 {
+		services = slices.DeleteFunc(services, func(svc types.ServiceSummary) bool {
+			return svc.ErrorRate < minErrorRate
+		})
+	}
+	sortServices(services, sortBy, order)
+
+	base, hasURL := util.GetSigNozURL(ctx)
+	items := make([]any, len(services))
+	for i := range services {
+		if hasURL {
+			if webURL, ok := util.ResourceWebURL(base, "service", services[i].ServiceName); ok {
+				services[i].WebURL = webURL
 			}
 		}
+		items[i] = services[i]
 	}
 
-	total := len(services)
-	pagedServices := paginate.Array(services, offset, limit)
+	total := len(items)
+	pagedServices := paginate.Array(items, offset, limit)
 
-	resultJSON, err := paginate.Wrap(pagedServices, total, offset, limit)
+	toolResult, err := newToolResult(listResponse(pagedServices, total, offset, limit, limitClamped))
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to wrap services with pagination", logpkg.ErrAttr(err))
+		h.logger.ErrorContext(ctx, "Failed to marshal services response", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
 	}
 
-	return listResult(resultJSON, limitClamped), nil
+	return toolResult, nil
 }
 
 func (h *Handler) handleGetServiceTopOperations(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -120,6 +163,14 @@ func (h *Handler) handleGetServiceTopOperations(ctx context.Context, req mcp.Cal
 
 	start, end := timeutil.GetTimestampsWithDefaults(args, timeutil.UnitNanos)
 
+	orderBy := stringArg(args, "orderBy")
+	if orderBy == "" {
+		orderBy = "p99"
+	}
+	if !slices.Contains(topOperationsOrders, orderBy) {
+		return validationError("orderBy", "must be one of: p99, p95, p50, calls, errors, errorRate"), nil
+	}
+
 	// tags is passed through to the SigNoz API verbatim. The backend's
 	// /api/v1/service/top_operations expects a structured []TagQueryParam array,
 	// so the caller supplies that raw JSON; an absent/non-string value defaults
@@ -134,7 +185,8 @@ func (h *Handler) handleGetServiceTopOperations(ctx context.Context, req mcp.Cal
 	h.logger.DebugContext(ctx, "Tool called: signoz_get_service_top_operations",
 		slog.String("start", start),
 		slog.String("end", end),
-		slog.String("service", service))
+		slog.String("service", service),
+		slog.String("orderBy", orderBy))
 
 	client, err := h.GetClient(ctx)
 	if err != nil {
@@ -145,5 +197,89 @@ func (h *Handler) handleGetServiceTopOperations(ctx context.Context, req mcp.Cal
 		h.logUpstreamFailure(ctx, "Failed to get service top operations", err, slog.String("start", start), slog.String("end", end), slog.String("service", service))
 		return upstreamError(err), nil
 	}
-	return mcp.NewToolResultText(string(result)), nil
+	if orderBy == "p99" {
+		return mcp.NewToolResultText(string(result)), nil
+	}
+
+	var operations []map[string]any
+	if err := json.Unmarshal(result, &operations); err != nil {
+		h.logger.ErrorContext(ctx, "Failed to parse top operations response", logpkg.ErrAttr(err))
+		return upstreamResponseError("failed to parse response: " + err.Error()), nil
+	}
+	rankTopOperations(operations, orderBy)
+
+	sorted, err := json.Marshal(operations)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal top operations", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
+	}
+	return mcp.NewToolResultText(string(sorted)), nil
+}
+
+// serviceSortFields are the accepted sortBy values. name sorts on
+// serviceName; the rest are the ServiceSummary JSON fields they sort on.
+var serviceSortFields = []string{"name", "p99", "avgDuration", "numCalls", "callRate", "numErrors", "errorRate", "fourXXRate"}
+
+// sortServices orders services by the sortBy field in order ("asc" or
+// "desc"), keeping the upstream order among ties.
+func sortServices(services []types.ServiceSummary, sortBy, order string) {
+	key := func(svc types.ServiceSummary) float64 {
+		switch sortBy {
+		case "p99":
+			return svc.P99
+		case "avgDuration":
+			return svc.AvgDuration
+		case "numCalls":
+			return float64(svc.NumCalls)
+		case "callRate":
+			return svc.CallRate
+		case "numErrors":
+			return float64(svc.NumErrors)
+		case "errorRate":
+			return svc.ErrorRate
+		case "fourXXRate":
+			return svc.FourXXRate
+		}
+		return 0
+	}
+	slices.SortStableFunc(services, func(a, b types.ServiceSummary) int {
+		c := cmp.Compare(key(a), key(b))
+		if sortBy == "name" {
+			c = cmp.Compare(a.ServiceName, b.ServiceName)
+		}
+		if order == "desc" {
+			return -c
+		}
+		return c
+	})
+}
+
+// topOperationsOrders are the accepted orderBy values; p99 keeps the
+// upstream order.
+var topOperationsOrders = []string{"p99", "p95", "p50", "calls", "errors", "errorRate"}
+
+// rankTopOperations sorts top_operations rows by orderBy, highest first,
+// keeping the upstream order among ties. For errorRate it first sets each
+// row's errorRate to errorCount/numCalls.
+func rankTopOperations(operations []map[string]any, orderBy string) {
+	field := map[string]string{"calls": "numCalls", "errors": "errorCount"}[orderBy]
+	if field == "" {
+		field = orderBy
+	}
+	if orderBy == "errorRate" {
+		for _, op := range operations {
+			calls, _ := finiteFloat(op["numCalls"])
+			errs, _ := finiteFloat(op["errorCount"])
+			rate := 0.0
+			if calls > 0 {
+				rate = errs / calls
+			}
+			op["errorRate"] = rate
+		}
+	}
+	slices.SortStableFunc(operations, func(a, b map[string]any) int {
+		x, _ := finiteFloat(a[field])
+		y, _ := finiteFloat(b[field])
+		return cmp.Compare(y, x)
+	})
 }
@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 )
 
 func TestHandleListServices_AddsWebURL(t *testing.T) {
@@ -50,6 +51,102 @@ func TestHandleListServices_OmitsWebURLWhenNoBaseURL(t *testing.T) {
 	}
 }
 
+func TestHandleListServices_FilterAndSortBeforePaging(t *testing.T) {
+	mock := &client.MockClient{
+		ListServicesFn: func(ctx context.Context, start, end string) (json.RawMessage, error) {
+			return json.RawMessage(`[
+				{"serviceName":"cart","p99":3000000,"numCalls":100,"numErrors":10,"errorRate":10},
+				{"serviceName":"auth","p99":1000000,"numCalls":100,"numErrors":1,"errorRate":1},
+				{"serviceName":"pay","p99":9000000,"numCalls":50,"numErrors":5,"errorRate":10},
+				{"serviceName":"search","p99":5000000,"numCalls":20,"numErrors":2,"errorRate":10}
+			]`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_list_services", map[string]any{
+		"minErrorRate": 5.0,
+		"sortBy":       "p99",
+		"limit":        "2",
+	})
+
+	result, err := h.handleListServices(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %s", textContent(t, result))
+	}
+	var out struct {
+		Data       []types.ServiceSummary `json:"data"`
+		Pagination struct {
+			Total int `json:"total"`
+		} `json:"pagination"`
+	}
+	if err := json.Unmarshal([]byte(textContent(t, result)), &out); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if out.Pagination.Total != 3 {
+		t.Fatalf("total = %d, want 3 services at or above 5%% errors", out.Pagination.Total)
+	}
+	if len(out.Data) != 2 || out.Data[0].ServiceName != "pay" || out.Data[1].ServiceName != "search" {
+		t.Fatalf("expected pay then search by p99 desc, got: %+v", out.Data)
+	}
+}
+
+func TestHandleListServices_DefaultSortsByNameAscending(t *testing.T) {
+	mock := &client.MockClient{
+		ListServicesFn: func(ctx context.Context, start, end string) (json.RawMessage, error) {
+			return json.RawMessage(`[{"serviceName":"search","p99":1},{"serviceName":"auth","p99":3},{"serviceName":"cart","p99":2}]`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	for _, tc := range []struct {
+		args map[string]any
+		want string
+	}{
+		{map[string]any{}, "auth,cart,search"},
+		{map[string]any{"sortBy": "name", "order": "desc"}, "search,cart,auth"},
+		{map[string]any{"sortBy": "p99", "order": "asc"}, "search,cart,auth"},
+	} {
+		result, err := h.handleListServices(testCtx(), makeToolRequest("signoz_list_services", tc.args))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var out struct {
+			Data []types.ServiceSummary `json:"data"`
+		}
+		if err := json.Unmarshal([]byte(textContent(t, result)), &out); err != nil {
+			t.Fatalf("unmarshal: %v", err)
+		}
+		var names []string
+		for _, svc := range out.Data {
+			names = append(names, svc.ServiceName)
+		}
+		if got := strings.Join(names, ","); got != tc.want {
+			t.Fatalf("%v: order = %s, want %s", tc.args, got, tc.want)
+		}
+	}
+}
+
+func TestHandleListServices_InvalidSortArgs(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	for _, args := range []map[string]any{
+		{"sortBy": "latency"},
+		{"sortBy": "p99", "order": "up"},
+		{"minErrorRate": "high"},
+		{"minErrorRate": -1.0},
+	} {
+		result, err := h.handleListServices(testCtx(), makeToolRequest("signoz_list_services", args))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code := resultCode(t, result); code != CodeValidationFailed {
+			t.Fatalf("%v: expected %s, got %s", args, CodeValidationFailed, code)
+		}
+	}
+}
+
 func TestHandleListServices_ExplicitStartEndOverrideTimeRange(t *testing.T) {
 	var capturedStart string
 	var capturedEnd string
@@ -129,3 +226,82 @@ func TestHandleGetServiceTopOperations_NanosecondBackwardCompat(t *testing.T) {
 		t.Fatalf("ns values must round-trip to the top-operations client unchanged: start=%s end=%s", capturedStart, capturedEnd)
 	}
 }
+
+func TestHandleGetServiceTopOperations_OrderByErrorRate(t *testing.T) {
+	mock := &client.MockClient{
+		GetServiceTopOperationsFn: func(ctx context.Context, start, end, service string, tags json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(`[
+				{"name":"GET /slow","p99":9000000,"numCalls":100,"errorCount":1},
+				{"name":"POST /pay","p99":2000000,"numCalls":10,"errorCount":5},
+				{"name":"GET /idle","p99":1000000,"numCalls":0,"errorCount":0}
+			]`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_get_service_top_operations", map[string]any{
+		"service": "frontend",
+		"orderBy": "errorRate",
+	})
+
+	result, err := h.handleGetServiceTopOperations(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %s", textContent(t, result))
+	}
+	var ops []map[string]any
+	if err := json.Unmarshal([]byte(textContent(t, result)), &ops); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	var names []string
+	for _, op := range ops {
+		names = append(names, op["name"].(string))
+	}
+	if strings.Join(names, ",") != "POST /pay,GET /slow,GET /idle" {
+		t.Fatalf("unexpected order: %v", names)
+	}
+	if ops[0]["errorRate"] != 0.5 {
+		t.Fatalf("expected errorRate 0.5, got %v", ops[0]["errorRate"])
+	}
+}
+
+func TestHandleGetServiceTopOperations_DefaultKeepsUpstreamOrder(t *testing.T) {
+	const upstream = `[{"name":"b","p99":1,"numCalls":9},{"name":"a","p99":2,"numCalls":1}]`
+	mock := &client.MockClient{
+		GetServiceTopOperationsFn: func(ctx context.Context, start, end, service string, tags json.RawMessage) (json.RawMessage, error) {
+			return json.RawMessage(upstream), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceTopOperations(testCtx(), makeToolRequest("signoz_get_service_top_operations", map[string]any{"service": "frontend"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := textContent(t, result); got != upstream {
+		t.Fatalf("default order must pass the upstream body through, got: %s", got)
+	}
+
+	result, err = h.handleGetServiceTopOperations(testCtx(), makeToolRequest("signoz_get_service_top_operations", map[string]any{"service": "frontend", "orderBy": "calls"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := textContent(t, result); !strings.HasPrefix(got, `[{"name":"b"`) {
+		t.Fatalf("expected calls ordering to put b first, got: %s", got)
+	}
+}
+
+func TestHandleGetServiceTopOperations_InvalidOrderBy(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	result, err := h.handleGetServiceTopOperations(testCtx(), makeToolRequest("signoz_get_service_top_operations", map[string]any{
+		"service": "frontend",
+		"orderBy": "p42",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Fatalf("expected %s, got %s", CodeValidationFailed, code)
+	}
+}
@@ -276,36 +276,40 @@ func TestParseStepInterval_RejectsSuffixedStrings(t *testing.T) {
 	}
 }
 
-// Comment-1 regression: the list_metrics completeness note must NOT instruct
-// callers to paginate by offset (the tool has no offset param).
-func TestHandleListMetrics_NoteDoesNotClaimOffset(t *testing.T) {
-	// 5 rows at limit 5 -> hasMore=true branch, the one that previously said
-	// "fetch the next page with offset=".
+// list_metrics pages by offset client-side: the backend is asked for
+// offset+limit rows and the first offset are dropped, and the note points at
+// the next offset.
+func TestHandleListMetrics_OffsetPages(t *testing.T) {
+	var gotLimit int
 	mock := &client.MockClient{
 		ListMetricsFn: func(ctx context.Context, start, end int64, limit int, searchText, source string) (json.RawMessage, error) {
-			return json.RawMessage(`{"status":"success","data":{"metrics":[{},{},{},{},{}]}}`), nil
+			gotLimit = limit
+			return json.RawMessage(`{"status":"success","data":{"metrics":[{"metricName":"a"},{"metricName":"b"},{"metricName":"c"},{"metricName":"d"},{"metricName":"e"}]}}`), nil
 		},
 	}
 	h := newTestHandler(mock)
-	res, err := h.handleListMetrics(testCtx(), makeToolRequest("signoz_list_metrics", map[string]any{"limit": "5"}))
+	res, err := h.handleListMetrics(testCtx(), makeToolRequest("signoz_list_metrics", map[string]any{"limit": "2", "offset": "3"}))
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-	note := res.Content[1].(mcp.TextContent).Text
-	// Must NOT instruct the caller to paginate by offset (no offset param). The
-	// clarifying phrase "no offset paging" is fine; an "offset=" page-fetch
-	// instruction or "next page" directive is not.
-	if strings.Contains(note, "offset=") {
-		t.Fatalf("list_metrics note must not give an offset= page-fetch instruction; got %q", note)
+	if gotLimit != 5 {
+		t.Fatalf("backend limit = %d, want offset+limit = 5", gotLimit)
+	}
+	body := res.Content[0].(mcp.TextContent).Text
+	if !strings.Contains(body, `"status":"success"`) || !strings.Contains(body, `"metrics":[{"metricName":"d"},{"metricName":"e"}]`) {
+		t.Fatalf("page body = %s, want rows d and e in the original envelope", body)
 	}
-	if strings.Contains(strings.ToLower(note), "next page") {
-		t.Fatalf("list_metrics note must not say 'next page' (no offset paging); got %q", note)
+	note := res.Content[1].(mcp.TextContent).Text
+	if !strings.Contains(note, "hasMore=true") || !strings.Contains(note, "offset=5") {
+		t.Fatalf("expected hasMore=true with offset=5 in note, got %q", note)
 	}
-	if !strings.Contains(note, "hasMore=true") {
-		t.Fatalf("expected hasMore=true in note, got %q", note)
+
+	res, err = h.handleListMetrics(testCtx(), makeToolRequest("signoz_list_metrics", map[string]any{"limit": "4000", "offset": "2000"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
 	}
-	if !strings.Contains(strings.ToLower(note), "narrow") {
-		t.Fatalf("expected a narrow-the-result-set hint, got %q", note)
+	if !res.IsError {
+		t.Fatal("offset+limit above the window cap must be rejected")
 	}
 }
 
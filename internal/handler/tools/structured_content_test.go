@@ -14,7 +14,7 @@ import (
 )
 
 // Two-tier structuredContent rule:
-//   - Code-controlled tools (paginate.Wrap list/summary, single-resource get_*,
+//   - Code-controlled tools (types.ToolResponse list/summary, single-resource get_*,
 //     and synthesized-JSON mutations) carry structuredContent.
 //   - Raw QB passthrough tools (search/aggregate/query_metrics) do NOT, because
 //     their upstream JSON shape is variable and an outputSchema would be brittle.
@@ -0,0 +1,93 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	defaultTailLogsLimit = 20
+	maxTailLogsLimit     = 100
+	// tailLogsWindow is the fixed lookback; signoz_search_logs covers
+	// anything older.
+	tailLogsWindow = "15m"
+)
+
+func (h *Handler) RegisterTailLogsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering tail logs handlers")
+
+	tool := mcp.NewTool("signoz_tail_logs",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription(fmt.Sprintf("Use this for a quick look at the latest logs of one service, e.g. \"show me the last 20 logs for checkout\". It returns the newest log rows from the last 15 minutes, newest first, with nothing else to configure. Use signoz_search_logs to filter by severity, text, or fields, to look further back, or to page through more than %d rows.", maxTailLogsLimit)),
+		mcp.WithString("service", mcp.Required(), mcp.Description("Service name whose logs to show (matches service.name).")),
+		mcp.WithString("limit", mcp.DefaultString(fmt.Sprint(defaultTailLogsLimit)), intOrStringType(), mcp.Description(fmt.Sprintf("Number of logs to return (default: %d, max: %d).", defaultTailLogsLimit, maxTailLogsLimit))),
+	)
+
+	h.addTool(s, tool, h.handleTailLogs)
+}
+
+func (h *Handler) handleTailLogs(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	service, errResult := requireStringArg(args, "service")
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit, err := intArg(args, "limit", defaultTailLogsLimit)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	limit = min(limit, maxTailLogsLimit)
+
+	start, end, err := resolveTimestamps(map[string]any{}, tailLogsWindow)
+	if err != nil {
+		return InternalErrorResult("failed to resolve time window: " + err.Error()), nil
+	}
+	filterExpr := buildLogFilterExpr("", service, "", "")
+	queryJSON, err := json.Marshal(types.BuildLogsQueryPayload(start, end, filterExpr, limit, 0))
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal tail logs query payload", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_tail_logs", slog.String("service", service), slog.Int("limit", limit))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	result, err := client.QueryBuilderV5(ctx, queryJSON)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to tail logs", err)
+		return upstreamQueryError(err, "logs"), nil
+	}
+	result, _, capNote, errResult := h.capQueryResponse(ctx, "signoz_tail_logs", result)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	var notes []string
+	if capNote != "" {
+		notes = append(notes, capNote)
+	}
+	rows, known := countQueryRangeRows(result)
+	switch {
+	case known && rows == 0:
+		notes = append(notes, fmt.Sprintf("note: no logs from %q in the last 15 minutes. Check the service name, or use signoz_search_logs with a longer timeRange.", service))
+	case known && rows >= limit:
+		notes = append(notes, fmt.Sprintf("note: showing the newest %d logs; use signoz_search_logs for older logs or narrower filters.", rows))
+	}
+	notes = append(notes, backendWarningNotes(ctx, h.logger, "signoz_tail_logs", result)...)
+	return withOutputFormat(resultWithNotes(result, notes...), result, formatCompact), nil
+}
@@ -0,0 +1,84 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const tailLogsResponse = `{"status":"success","data":{"type":"raw","meta":{"rowsScanned":2},"data":{"results":[{"queryName":"A","nextCursor":"","rows":[
+	{"timestamp":"2026-01-01T00:00:02Z","data":{"body":"second","severity_text":"INFO"}},
+	{"timestamp":"2026-01-01T00:00:01Z","data":{"body":"first","severity_text":"INFO"}}
+]}]}}}`
+
+func TestHandleTailLogs(t *testing.T) {
+	var payload types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			require.NoError(t, json.Unmarshal(body, &payload))
+			return json.RawMessage(tailLogsResponse), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleTailLogs(testCtx(), makeToolRequest("signoz_tail_logs", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, "logs", spec.Signal)
+	assert.Equal(t, "service.name = 'checkout'", spec.Filter.Expression)
+	assert.Equal(t, defaultTailLogsLimit, spec.Limit)
+	assert.Equal(t, "desc", spec.Order[0].Direction)
+	assert.Equal(t, int64(15*60*1000), payload.End-payload.Start)
+
+	body := textContent(t, result)
+	assert.Contains(t, body, `"second"`)
+	assert.NotContains(t, body, "rowsScanned")
+	assert.Len(t, result.Content, 1, "a short page carries no note")
+}
+
+func TestHandleTailLogs_LimitClampedAndNotes(t *testing.T) {
+	var limit int
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var payload types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &payload))
+			limit = payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec).Limit
+			return json.RawMessage(tailLogsResponse), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	_, err := h.handleTailLogs(testCtx(), makeToolRequest("signoz_tail_logs", map[string]any{"service": "checkout", "limit": "500"}))
+	require.NoError(t, err)
+	assert.Equal(t, maxTailLogsLimit, limit)
+
+	result, err := h.handleTailLogs(testCtx(), makeToolRequest("signoz_tail_logs", map[string]any{"service": "checkout", "limit": "2"}))
+	require.NoError(t, err)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "showing the newest 2 logs")
+}
+
+func TestHandleTailLogs_Empty(t *testing.T) {
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":[]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleTailLogs(testCtx(), makeToolRequest("signoz_tail_logs", map[string]any{"service": "checkout"}))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], `no logs from "checkout"`)
+}
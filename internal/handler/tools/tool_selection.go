@@ -0,0 +1,68 @@
+package tools
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/config"
+)
+
+// ToolGroups returns the tool group names accepted by ENABLED_TOOL_GROUPS,
+// in the order they first appear in toolGroupRegistrations. The README lists
+// them in the same order.
+func ToolGroups() []string {
+	var groups []string
+	for _, reg := range toolGroupRegistrations() {
+		if !slices.Contains(groups, reg.group) {
+			groups = append(groups, reg.group)
+		}
+	}
+	return groups
+}
+
+// ToolNames returns the name of every built-in tool, sorted, as
+// RegisterAllToolHandlers would expose them with no selection configured.
+func ToolNames() []string {
+	names := slices.Collect(maps.Keys(toolGroupIndex()))
+	slices.Sort(names)
+	return names
+}
+
+// ValidateToolSelection rejects ENABLED_TOOL_GROUPS and DISABLED_TOOLS
+// entries that name no known group or tool, so a typo fails startup instead
+// of silently exposing (or hiding) the wrong tools.
+func ValidateToolSelection(enabledGroups, disabledTools []string) error {
+	if unknown := unknownNames(enabledGroups, ToolGroups()); len(unknown) > 0 {
+		return fmt.Errorf("%s: unknown tool group(s) %s; valid groups: %s", config.EnabledToolGroupsEnv, strings.Join(unknown, ", "), strings.Join(ToolGroups(), ", "))
+	}
+	if len(disabledTools) == 0 {
+		return nil
+	}
+	if unknown := unknownNames(disabledTools, ToolNames()); len(unknown) > 0 {
+		return fmt.Errorf("%s: unknown tool(s) %s; use full tool names such as signoz_delete_dashboard", config.DisabledToolsEnv, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+func unknownNames(names, known []string) []string {
+	var unknown []string
+	for _, name := range names {
+		if !slices.Contains(known, name) {
+			unknown = append(unknown, fmt.Sprintf("%q", name))
+		}
+	}
+	return unknown
+}
+
+// toolGroupEnabled reports whether group is registered; every group is when
+// no ENABLED_TOOL_GROUPS are configured.
+func (h *Handler) toolGroupEnabled(group string) bool {
+	return len(h.enabledToolGroups) == 0 || slices.Contains(h.enabledToolGroups, group)
+}
+
+// toolDisabled reports whether name is listed in DISABLED_TOOLS.
+func (h *Handler) toolDisabled(name string) bool {
+	return slices.Contains(h.disabledTools, name)
+}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"testing"
+
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterAllToolHandlers_ToolSelection(t *testing.T) {
+	h := newTestHandler(nil)
+	h.enabledToolGroups = []string{"logs", "traces"}
+	h.disabledTools = []string{"signoz_export_logs"}
+	s := server.NewMCPServer("test", "0.0.0", server.WithToolCapabilities(false))
+	h.RegisterAllToolHandlers(s)
+
+	registered := s.ListTools()
+	assert.Contains(t, registered, "signoz_search_logs")
+	assert.Contains(t, registered, "signoz_search_traces")
+	assert.NotContains(t, registered, "signoz_export_logs")
+	assert.NotContains(t, registered, "signoz_list_dashboards")
+	assert.NotContains(t, registered, "signoz_delete_dashboard")
+}
+
+func TestToolNames_MatchesFullRegistration(t *testing.T) {
+	registered := registeredTestTools(t)
+	names := ToolNames()
+	require.Len(t, names, len(registered))
+	for _, name := range names {
+		assert.Contains(t, registered, name)
+	}
+}
+
+func TestToolGroups(t *testing.T) {
+	// README.md's ENABLED_TOOL_GROUPS row lists the same names in this order.
+	assert.Equal(t, []string{
+		"metrics", "fields", "alerts", "dashboards", "services", "query",
+		"logs", "views", "docs", "traces", "notification_channels", "server",
+	}, ToolGroups())
+}
+
+func TestValidateToolSelection(t *testing.T) {
+	require.NoError(t, ValidateToolSelection(nil, nil))
+	require.NoError(t, ValidateToolSelection([]string{"logs", "server"}, []string{"signoz_delete_dashboard"}))
+
+	err := ValidateToolSelection([]string{"logs", "dashbaords"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"dashbaords"`)
+	assert.Contains(t, err.Error(), "valid groups:")
+
+	err = ValidateToolSelection(nil, []string{"delete_dashboard"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"delete_dashboard"`)
+}
@@ -0,0 +1,231 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+)
+
+const (
+	defaultTopErrorGroups = 10
+	maxTopErrorGroups     = 50
+	defaultTopErrorSample = 1000
+	// maxErrorPatternLen bounds the normalized message used as a group key
+	// and returned as the pattern; maxErrorExampleLen bounds the example.
+	maxErrorPatternLen = 300
+	maxErrorExampleLen = 1000
+)
+
+// errorSeverityFilter selects the log records signoz_get_top_errors groups.
+const errorSeverityFilter = "severity_text IN ('ERROR', 'FATAL')"
+
+// Patterns applied in order by normalizeErrorMessage. UUIDs go before hex
+// so their digit groups are not split, and hex before numbers so a hash
+// collapses to one placeholder.
+var (
+	uuidPattern       = regexp.MustCompile(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`)
+	hexLiteralPattern = regexp.MustCompile(`(?i)\b0x[0-9a-f]+\b`)
+	// hexWordPattern only counts as an ID when it mixes digits and letters;
+	// see normalizeErrorMessage.
+	hexWordPattern = regexp.MustCompile(`(?i)\b[0-9a-f]{8,}\b`)
+	numberPattern  = regexp.MustCompile(`\d+(\.\d+)?`)
+	spacePattern   = regexp.MustCompile(`\s+`)
+)
+
+func (h *Handler) RegisterTopErrorsHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering top errors handlers")
+
+	tool := mcp.NewTool("signoz_get_top_errors",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription(fmt.Sprintf("Use this when the user asks what the most common errors are right now. It reads the newest ERROR and FATAL logs (up to sampleSize, at most %d), normalizes each body by replacing UUIDs, hex IDs, and numbers with placeholders, and returns the largest message groups with their count and an example body. Use signoz_search_logs to read the logs of one group and signoz_get_logs_histogram to see when errors spiked. Defaults to the last 1 hour.", maxExportLogsRows)),
+		mcp.WithString("service", mcp.Description("Optional service name to filter by (adds service.name = '<value>').")),
+		mcp.WithString("filter", mcp.Description(logsFilterParamDescription+" Combined with the ERROR/FATAL severity filter using AND.")),
+		mcp.WithString("limit", mcp.DefaultString(fmt.Sprint(defaultTopErrorGroups)), intOrStringType(), mcp.Description(fmt.Sprintf("Number of message groups to return (default: %d, max: %d).", defaultTopErrorGroups, maxTopErrorGroups))),
+		mcp.WithString("sampleSize", mcp.DefaultString(fmt.Sprint(defaultTopErrorSample)), intOrStringType(), mcp.Description(fmt.Sprintf("Maximum number of error logs to read, newest first (default: %d, max: %d; higher values are clamped).", defaultTopErrorSample, maxExportLogsRows))),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetTopErrors)
+}
+
+type errorGroup struct {
+	Pattern  string          `json:"pattern"`
+	Count    int             `json:"count"`
+	Example  string          `json:"example"`
+	LastSeen json.RawMessage `json:"lastSeen,omitempty"`
+}
+
+type topErrorsResponse struct {
+	Start       int64        `json:"start"`
+	End         int64        `json:"end"`
+	SampledRows int          `json:"sampledRows"`
+	Complete    bool         `json:"complete"`
+	TotalGroups int          `json:"totalGroups"`
+	Groups      []errorGroup `json:"groups"`
+}
+
+func (h *Handler) handleGetTopErrors(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, ok := req.Params.Arguments.(map[string]any)
+	if !ok {
+		return notAJSONObjectError(), nil
+	}
+
+	filter, err := readFilterExpr(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	service, _ := args["service"].(string)
+	filterExpr := buildLogFilterExpr(filter, service, "", "")
+	if filterExpr != "" {
+		filterExpr = "(" + filterExpr + ") AND " + errorSeverityFilter
+	} else {
+		filterExpr = errorSeverityFilter
+	}
+
+	limit, err := intArg(args, "limit", defaultTopErrorGroups)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if limit < 1 {
+		limit = defaultTopErrorGroups
+	}
+	limit = min(limit, maxTopErrorGroups)
+	sampleSize, err := intArg(args, "sampleSize", defaultTopErrorSample)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if sampleSize < 1 {
+		sampleSize = defaultTopErrorSample
+	}
+	sampleClamped := sampleSize > maxExportLogsRows
+	sampleSize = min(sampleSize, maxExportLogsRows)
+
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_top_errors",
+		slog.String("filter", filterExpr), slog.Int("sample_size", sampleSize))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	export, errResult := h.exportLogPages(ctx, client, startTime, endTime, filterExpr, nil, sampleSize)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	groups := groupErrorRows(export.Rows)
+	out := topErrorsResponse{
+		Start:       startTime,
+		End:         endTime,
+		SampledRows: export.RowCount,
+		Complete:    export.Complete,
+		TotalGroups: len(groups),
+		Groups:      groups[:min(limit, len(groups))],
+	}
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal top errors", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal top errors: " + err.Error()), nil
+	}
+
+	var notes []string
+	if sampleClamped {
+		notes = append(notes, fmt.Sprintf("note: sampleSize was clamped to %d.", maxExportLogsRows))
+	}
+	if !out.Complete {
+		notes = append(notes, fmt.Sprintf("note: counts cover the newest %d error logs only; more match in this window. Narrow the time range or raise sampleSize for fuller counts.", out.SampledRows))
+	}
+	return structuredResultWithNotes(body, notes...), nil
+}
+
+// groupErrorRows groups raw log rows by normalized body, largest group
+// first. Rows arrive newest first, so each group's example and lastSeen
+// come from its newest row. Ties keep first-seen order.
+func groupErrorRows(rows []json.RawMessage) []errorGroup {
+	index := make(map[string]int)
+	groups := []errorGroup{}
+	for _, raw := range rows {
+		var row struct {
+			Timestamp json.RawMessage `json:"timestamp"`
+			Data      struct {
+				Body any `json:"body"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(raw, &row); err != nil {
+			continue
+		}
+		body := logBodyText(row.Data.Body)
+		pattern := normalizeErrorMessage(body)
+		if i, ok := index[pattern]; ok {
+			groups[i].Count++
+			continue
+		}
+		index[pattern] = len(groups)
+		groups = append(groups, errorGroup{Pattern: pattern, Count: 1, Example: truncateText(body, maxErrorExampleLen), LastSeen: row.Timestamp})
+	}
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Count > groups[j].Count })
+	return groups
+}
+
+// logBodyText renders a log body as text; a JSON body is re-encoded.
+func logBodyText(body any) string {
+	switch b := body.(type) {
+	case nil:
+		return ""
+	case string:
+		return b
+	default:
+		encoded, err := json.Marshal(b)
+		if err != nil {
+			return fmt.Sprint(b)
+		}
+		return string(encoded)
+	}
+}
+
+// normalizeErrorMessage replaces the variable parts of a log message (UUIDs,
+// hex IDs, numbers) with placeholders so messages that differ only in those
+// parts group together.
+func normalizeErrorMessage(msg string) string {
+	msg = uuidPattern.ReplaceAllString(msg, "<uuid>")
+	msg = hexLiteralPattern.ReplaceAllString(msg, "<hex>")
+	msg = hexWordPattern.ReplaceAllStringFunc(msg, func(word string) string {
+		if strings.IndexFunc(word, unicode.IsDigit) >= 0 && strings.IndexFunc(word, unicode.IsLetter) >= 0 {
+			return "<hex>"
+		}
+		return word
+	})
+	msg = numberPattern.ReplaceAllString(msg, "<num>")
+	msg = strings.TrimSpace(spacePattern.ReplaceAllString(msg, " "))
+	return truncateText(msg, maxErrorPatternLen)
+}
+
+// truncateText cuts s to at most n bytes on a rune boundary, marking the cut
+// with an ellipsis.
+func truncateText(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n] + "…"
+}
@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+func TestNormalizeErrorMessage(t *testing.T) {
+	cases := map[string]string{
+		"order 12345 failed after 3.5s":                                    "order <num> failed after <num>s",
+		"user 3f2504e0-4f89-11d3-9a0c-0305e82c3301 not found":              "user <uuid> not found",
+		"bad pointer 0xDEADBEEF":                                           "bad pointer <hex>",
+		"commit 9fceb02d0ae598e95dc970b74767f19372d61af8 missing":          "commit <hex> missing",
+		"connection refused   to\tdb":                                      "connection refused to db",
+		"deadline exceeded in handler":                                     "deadline exceeded in handler",
+		"request 20240101 took 1200ms":                                     "request <num> took <num>ms",
+		"  trailing space 7 ":                                              "trailing space <num>",
+		"http2: stream 41 closed":                                          "http<num>: stream <num> closed",
+		"accessed facade":                                                  "accessed facade",
+		"token cafebabe1234 rejected":                                      "token <hex> rejected",
+		"timeout for key=abc, value=deadbeef":                              "timeout for key=abc, value=deadbeef",
+		"retry 2 of 5 failed: dial tcp 10.0.0.12:5432: connection refused": "retry <num> of <num> failed: dial tcp <num>.<num>:<num>: connection refused",
+	}
+	for in, want := range cases {
+		assert.Equal(t, want, normalizeErrorMessage(in), in)
+	}
+	assert.LessOrEqual(t, len(normalizeErrorMessage(strings.Repeat("é", 400))), maxErrorPatternLen+len("…"))
+}
+
+func TestHandleGetTopErrors(t *testing.T) {
+	bodies := []string{
+		"payment 101 declined",
+		"db timeout after 30s",
+		"payment 202 declined",
+		"payment 303 declined",
+		"db timeout after 31s",
+		"cache miss",
+	}
+	var filter string
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &p))
+			filter = p.CompositeQuery.Queries[0].Spec.(types.QuerySpec).Filter.Expression
+			rows := make([]string, len(bodies))
+			for i, b := range bodies {
+				rows[i] = fmt.Sprintf(`{"timestamp":"2024-01-01T00:00:%02dZ","data":{"id":"id%d","body":%q}}`, 59-i, i, b)
+			}
+			return json.RawMessage(`{"status":"success","data":{"data":{"results":[{"rows":[` + strings.Join(rows, ",") + `]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetTopErrors(testCtx(), makeToolRequest("signoz_get_top_errors", map[string]any{
+		"service": "checkout", "limit": "2",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, "(service.name = 'checkout') AND severity_text IN ('ERROR', 'FATAL')", filter)
+
+	var out topErrorsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.True(t, out.Complete)
+	assert.Equal(t, 6, out.SampledRows)
+	assert.Equal(t, 3, out.TotalGroups)
+	require.Len(t, out.Groups, 2)
+	assert.Equal(t, "payment <num> declined", out.Groups[0].Pattern)
+	assert.Equal(t, 3, out.Groups[0].Count)
+	assert.Equal(t, "payment 101 declined", out.Groups[0].Example, "example comes from the newest row")
+	assert.JSONEq(t, `"2024-01-01T00:00:59Z"`, string(out.Groups[0].LastSeen))
+	assert.Equal(t, "db timeout after <num>s", out.Groups[1].Pattern)
+	assert.Equal(t, 2, out.Groups[1].Count)
+}
+
+func TestHandleGetTopErrors_IncompleteSampleAddsNote(t *testing.T) {
+	var filters []string
+	h := newTestHandler(pagedLogsMock(t, 50, &filters))
+
+	result, err := h.handleGetTopErrors(testCtx(), makeToolRequest("signoz_get_top_errors", map[string]any{
+		"sampleSize": "20",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, errorSeverityFilter, filters[0])
+
+	var out topErrorsResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.False(t, out.Complete)
+	assert.Equal(t, 20, out.SampledRows)
+	require.Len(t, out.Groups, 1)
+	assert.Equal(t, "line <num>", out.Groups[0].Pattern)
+	assert.Contains(t, strings.Join(allTextBlocks(result), "\n"), "counts cover the newest 20 error logs only")
+}
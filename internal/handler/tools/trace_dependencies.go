@@ -0,0 +1,233 @@
+package tools
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+	"github.com/SigNoz/signoz-mcp-server/pkg/util"
+)
+
+func (h *Handler) RegisterTraceDependencyHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering trace dependency handlers")
+
+	tool := mcp.NewTool("signoz_get_service_dependencies_for_trace",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user has a known trace ID and wants to see which services that one request touched and how they called each other. It fetches the trace's spans and returns each service with its span and error counts, plus one edge per caller→callee service pair with the number of calls, how many of those calls errored, and their total duration. Calls within one service are not edges. Use signoz_get_trace_details for the individual spans, and signoz_search_traces to discover trace IDs. Supply a time window containing the trace; the default last 6 hours can miss an older trace."),
+		mcp.WithString("traceId", mcp.Required(), mcp.Description("Known trace ID. Discover it with signoz_search_traces when the user has not supplied one.")),
+		mcp.WithString("timeRange", mcp.DefaultString("6h"), mcp.Description(timeRangeDesc("Defaults to last 6 hours if not provided."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional, defaults to 6 hours ago).")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional, defaults to now).")),
+		mcp.WithString("limit", mcp.DefaultString(strconv.Itoa(types.DefaultTraceDetailsLimit)), intOrStringType(), mcp.Description(traceDetailsLimitDescription)),
+	)
+
+	h.addTool(s, tool, h.handleGetServiceDependenciesForTrace)
+}
+
+type traceServiceSummary struct {
+	Service string `json:"service"`
+	Spans   int    `json:"spans"`
+	Errors  int    `json:"errors"`
+}
+
+type traceServiceEdge struct {
+	Caller            string `json:"caller"`
+	Callee            string `json:"callee"`
+	Calls             int    `json:"calls"`
+	Errors            int    `json:"errors"`
+	TotalDurationNano int64  `json:"totalDurationNano"`
+	TotalDuration     string `json:"totalDuration"`
+}
+
+type traceDependenciesResponse struct {
+	TraceID     string                `json:"traceId"`
+	Spans       int                   `json:"spans"`
+	RootService string                `json:"rootService,omitempty"`
+	Services    []traceServiceSummary `json:"services"`
+	Edges       []traceServiceEdge    `json:"edges"`
+	WebURL      string                `json:"webUrl,omitempty"`
+}
+
+// traceSpan is the part of a span row the dependency summary needs.
+type traceSpan struct {
+	spanID       string
+	parentSpanID string
+	service      string
+	hasError     bool
+	durationNano int64
+}
+
+func (h *Handler) handleGetServiceDependenciesForTrace(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+	traceID, errResult := requireStringArg(args, "traceId")
+	if errResult != nil {
+		return errResult, nil
+	}
+	start, end, err := resolveTimestamps(args, "6h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	limit, limitClamped, err := traceDetailsLimitArg(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_service_dependencies_for_trace",
+		slog.String("traceId", traceID), slog.Int64("start", start), slog.Int64("end", end), slog.Int("limit", limit))
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	data, err := client.GetTraceDetails(ctx, traceID, true, start, end, limit)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to get trace details", err, slog.String("traceId", traceID))
+		return upstreamError(err), nil
+	}
+
+	spans := parseTraceSpans(data)
+	if len(spans) == 0 {
+		return errorWithCode(CodeNotFound, fmt.Sprintf("no spans found for trace %q between %d and %d. Widen the time window with start/end, or confirm the ID with signoz_search_traces.", traceID, start, end)), nil
+	}
+	out, orphans := summarizeTraceDependencies(spans)
+	out.TraceID = traceID
+	base, _ := util.GetSigNozURL(ctx)
+	out.WebURL, _ = util.ResourceWebURL(base, "trace", traceID)
+
+	var notes []string
+	if limitClamped {
+		notes = append(notes, traceDetailsLimitClampedNote)
+	}
+	if len(spans) >= limit {
+		notes = append(notes, fmt.Sprintf("note: the trace returned %d spans, the fetch limit, so some calls may be missing from the summary; raise limit to fetch more.", len(spans)))
+	}
+	if orphans > 0 {
+		notes = append(notes, fmt.Sprintf("note: %d span(s) reference a parent span that is not in the window; their caller is unknown. Widen the time window if the trace started earlier.", orphans))
+	}
+
+	resp, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal trace dependencies", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal trace dependencies: " + err.Error()), nil
+	}
+	return structuredResultWithNotes(resp, notes...), nil
+}
+
+// parseTraceSpans walks data.data.results[].rows[] of a raw traces response.
+// It fails open to no spans on an unexpected shape.
+func parseTraceSpans(data json.RawMessage) []traceSpan {
+	var env struct {
+		Data struct {
+			Data struct {
+				Results []struct {
+					Rows []struct {
+						Data map[string]any `json:"data"`
+					} `json:"rows"`
+				} `json:"results"`
+			} `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.Unmarshal(data, &env); err != nil {
+		return nil
+	}
+	var out []traceSpan
+	for _, res := range env.Data.Data.Results {
+		for _, row := range res.Rows {
+			d, _ := finiteFloat(row.Data["duration_nano"])
+			hasError, _ := row.Data["has_error"].(bool)
+			out = append(out, traceSpan{
+				spanID:       stringValue(row.Data["span_id"]),
+				parentSpanID: stringValue(row.Data["parent_span_id"]),
+				service:      stringValue(row.Data["service.name"]),
+				hasError:     hasError,
+				durationNano: int64(d),
+			})
+		}
+	}
+	return out
+}
+
+// summarizeTraceDependencies counts spans and errors per service and folds
+// every cross-service parent→child span pair into a caller→callee edge. It
+// also returns how many non-root spans have a parent outside spans.
+func summarizeTraceDependencies(spans []traceSpan) (traceDependenciesResponse, int) {
+	byID := make(map[string]traceSpan, len(spans))
+	for _, s := range spans {
+		if s.spanID != "" {
+			byID[s.spanID] = s
+		}
+	}
+
+	services := map[string]*traceServiceSummary{}
+	edges := map[[2]string]*traceServiceEdge{}
+	var out traceDependenciesResponse
+	orphans := 0
+	for _, s := range spans {
+		svc, ok := services[s.service]
+		if !ok {
+			svc = &traceServiceSummary{Service: s.service}
+			services[s.service] = svc
+		}
+		svc.Spans++
+		if s.hasError {
+			svc.Errors++
+		}
+
+		if s.parentSpanID == "" {
+			if out.RootService == "" {
+				out.RootService = s.service
+			}
+			continue
+		}
+		parent, ok := byID[s.parentSpanID]
+		if !ok {
+			orphans++
+			continue
+		}
+		if parent.service == s.service {
+			continue
+		}
+		key := [2]string{parent.service, s.service}
+		edge, ok := edges[key]
+		if !ok {
+			edge = &traceServiceEdge{Caller: parent.service, Callee: s.service}
+			edges[key] = edge
+		}
+		edge.Calls++
+		if s.hasError {
+			edge.Errors++
+		}
+		edge.TotalDurationNano += s.durationNano
+	}
+
+	out.Spans = len(spans)
+	out.Services = make([]traceServiceSummary, 0, len(services))
+	for _, svc := range services {
+		out.Services = append(out.Services, *svc)
+	}
+	slices.SortFunc(out.Services, func(a, b traceServiceSummary) int {
+		return cmp.Or(cmp.Compare(b.Spans, a.Spans), cmp.Compare(a.Service, b.Service))
+	})
+	out.Edges = make([]traceServiceEdge, 0, len(edges))
+	for _, edge := range edges {
+		edge.TotalDuration = time.Duration(edge.TotalDurationNano).String()
+		out.Edges = append(out.Edges, *edge)
+	}
+	slices.SortFunc(out.Edges, func(a, b traceServiceEdge) int {
+		return cmp.Or(cmp.Compare(b.Calls, a.Calls), cmp.Compare(a.Caller, b.Caller), cmp.Compare(a.Callee, b.Callee))
+	})
+	return out, orphans
+}
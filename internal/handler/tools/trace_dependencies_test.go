@@ -0,0 +1,95 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+const traceDependencySpans = `{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":[
+	{"timestamp":"2026-01-01T00:00:00Z","data":{"span_id":"s1","parent_span_id":"","service.name":"frontend","duration_nano":9000000,"has_error":false}},
+	{"timestamp":"2026-01-01T00:00:00Z","data":{"span_id":"s2","parent_span_id":"s1","service.name":"frontend","duration_nano":8000000,"has_error":false}},
+	{"timestamp":"2026-01-01T00:00:00Z","data":{"span_id":"s3","parent_span_id":"s2","service.name":"checkout","duration_nano":5000000,"has_error":true}},
+	{"timestamp":"2026-01-01T00:00:00Z","data":{"span_id":"s4","parent_span_id":"s2","service.name":"checkout","duration_nano":1000000,"has_error":false}},
+	{"timestamp":"2026-01-01T00:00:00Z","data":{"span_id":"s5","parent_span_id":"s3","service.name":"payment","duration_nano":3000000,"has_error":true}},
+	{"timestamp":"2026-01-01T00:00:00Z","data":{"span_id":"s6","parent_span_id":"gone","service.name":"payment","duration_nano":1000,"has_error":false}}
+]}]}}}`
+
+func TestHandleGetServiceDependenciesForTrace(t *testing.T) {
+	var gotTraceID string
+	mock := &client.MockClient{
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
+			gotTraceID = traceID
+			return json.RawMessage(traceDependencySpans), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceDependenciesForTrace(ctxWithURL(), makeToolRequest("signoz_get_service_dependencies_for_trace", map[string]any{
+		"traceId": "abc123",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, "abc123", gotTraceID)
+
+	var out traceDependenciesResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, 6, out.Spans)
+	assert.Equal(t, "frontend", out.RootService)
+	assert.Equal(t, "https://signoz.example.com/trace/abc123", out.WebURL)
+	assert.Equal(t, []traceServiceSummary{
+		{Service: "checkout", Spans: 2, Errors: 1},
+		{Service: "frontend", Spans: 2},
+		{Service: "payment", Spans: 2, Errors: 1},
+	}, out.Services)
+	assert.Equal(t, []traceServiceEdge{
+		{Caller: "frontend", Callee: "checkout", Calls: 2, Errors: 1, TotalDurationNano: 6000000, TotalDuration: "6ms"},
+		{Caller: "checkout", Callee: "payment", Calls: 1, Errors: 1, TotalDurationNano: 3000000, TotalDuration: "3ms"},
+	}, out.Edges)
+
+	blocks := allTextBlocks(result)
+	require.Len(t, blocks, 2)
+	assert.Contains(t, blocks[1], "1 span(s) reference a parent span that is not in the window")
+}
+
+func TestHandleGetServiceDependenciesForTrace_NoSpans(t *testing.T) {
+	mock := &client.MockClient{
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
+			return json.RawMessage(`{"status":"success","data":{"type":"raw","data":{"results":[{"queryName":"A","rows":null}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceDependenciesForTrace(testCtx(), makeToolRequest("signoz_get_service_dependencies_for_trace", map[string]any{
+		"traceId": "missing",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeNotFound, resultCode(t, result))
+	assert.Contains(t, textContent(t, result), "Widen the time window")
+}
+
+func TestHandleGetServiceDependenciesForTrace_LimitReachedNote(t *testing.T) {
+	var gotLimit int
+	mock := &client.MockClient{
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
+			gotLimit = limit
+			return json.RawMessage(traceDependencySpans), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetServiceDependenciesForTrace(testCtx(), makeToolRequest("signoz_get_service_dependencies_for_trace", map[string]any{
+		"traceId": "abc123",
+		"limit":   "6",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, 6, gotLimit)
+	assert.Contains(t, strings.Join(allTextBlocks(result), "\n"), "returned 6 spans, the fetch limit")
+}
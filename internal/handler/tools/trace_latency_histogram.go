@@ -0,0 +1,220 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+const (
+	defaultLatencyBuckets = 20
+	maxLatencyBuckets     = 50
+)
+
+// latencySummaryAggregations is the first query of the histogram; the
+// response is read back by aggregation index in this order.
+var latencySummaryAggregations = []string{
+	"min(duration_nano)", "max(duration_nano)", "count()",
+	"p50(duration_nano)", "p90(duration_nano)", "p99(duration_nano)",
+}
+
+func (h *Handler) RegisterTraceLatencyHistogramHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering trace latency histogram handlers")
+
+	tool := mcp.NewTool("signoz_get_trace_latency_histogram",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when the user wants the latency distribution of a service or operation—to spot a bimodal shape, a long tail, or where most requests land—rather than a single percentile or a list of traces. Returns span counts per duration bucket on a log scale between the fastest and slowest span, plus p50/p90/p99. Use signoz_get_exemplar_traces for example slow traces and signoz_aggregate_traces for latency over time. Defaults to the last 1 hour."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithString("service", mcp.Required(), mcp.Description("Service name whose span latency to bucket.")),
+		mcp.WithString("operation", mcp.Description("Optional span name to restrict to (adds name = '<value>').")),
+		mcp.WithString("filter", mcp.Description(tracesFilterParamDescription+" Combined with service and operation using AND.")),
+		mcp.WithString("buckets", mcp.DefaultString(fmt.Sprint(defaultLatencyBuckets)), intOrStringType(), mcp.Description(fmt.Sprintf("Number of duration buckets (default: %d, max: %d).", defaultLatencyBuckets, maxLatencyBuckets))),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+	)
+
+	h.addTool(s, tool, h.handleGetTraceLatencyHistogram)
+}
+
+// latencyBucket counts spans with lowerNano <= duration_nano < upperNano;
+// the last bucket also includes upperNano.
+type latencyBucket struct {
+	LowerNano int64  `json:"lowerNano"`
+	UpperNano int64  `json:"upperNano"`
+	Lower     string `json:"lower"`
+	Upper     string `json:"upper"`
+	Count     int64  `json:"count"`
+}
+
+type traceLatencyHistogramResponse struct {
+	Service     string           `json:"service"`
+	Operation   string           `json:"operation,omitempty"`
+	Start       int64            `json:"start"`
+	End         int64            `json:"end"`
+	TotalSpans  int64            `json:"totalSpans"`
+	MinNano     int64            `json:"minNano"`
+	MaxNano     int64            `json:"maxNano"`
+	Percentiles map[string]int64 `json:"percentiles,omitempty"`
+	Buckets     []latencyBucket  `json:"buckets"`
+}
+
+func (h *Handler) handleGetTraceLatencyHistogram(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	service, errResult := requireStringArg(args, "service")
+	if errResult != nil {
+		return errResult, nil
+	}
+	operation := strings.TrimSpace(stringArg(args, "operation"))
+	filter, err := readFilterExpr(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	buckets, err := intArg(args, "buckets", defaultLatencyBuckets)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+	if buckets < 1 {
+		buckets = defaultLatencyBuckets
+	}
+	buckets = min(buckets, maxLatencyBuckets)
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	filterExpr := serviceFilterExpr([]string{service})
+	if operation != "" {
+		filterExpr += " AND name = " + quoteFilterString(operation)
+	}
+	if filter != "" {
+		filterExpr = "(" + filter + ") AND " + filterExpr
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_trace_latency_histogram",
+		slog.String("service", service), slog.String("operation", operation), slog.Int("buckets", buckets))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+
+	out := traceLatencyHistogramResponse{
+		Service:   service,
+		Operation: operation,
+		Start:     startTime,
+		End:       endTime,
+		Buckets:   []latencyBucket{},
+	}
+
+	summary, errResult := h.queryScalarAggregations(ctx, client, startTime, endTime, filterExpr, latencySummaryAggregations)
+	if errResult != nil {
+		return errResult, nil
+	}
+	out.TotalSpans = int64(summary[2])
+	if out.TotalSpans == 0 {
+		return h.latencyHistogramResult(ctx, out)
+	}
+	out.MinNano, out.MaxNano = int64(summary[0]), int64(summary[1])
+	out.Percentiles = map[string]int64{}
+	for i, name := range []string{"p50", "p90", "p99"} {
+		if v, ok := summary[3+i]; ok {
+			out.Percentiles[name] = int64(v)
+		}
+	}
+
+	bounds := latencyBucketBounds(out.MinNano, out.MaxNano, buckets)
+	exprs := make([]string, len(bounds)-1)
+	for i := range exprs {
+		upperOp := "<"
+		if i == len(exprs)-1 {
+			upperOp = "<="
+		}
+		exprs[i] = fmt.Sprintf("countIf(duration_nano >= %d AND duration_nano %s %d)", bounds[i], upperOp, bounds[i+1])
+	}
+	counts, errResult := h.queryScalarAggregations(ctx, client, startTime, endTime, filterExpr, exprs)
+	if errResult != nil {
+		return errResult, nil
+	}
+	for i := range exprs {
+		out.Buckets = append(out.Buckets, latencyBucket{
+			LowerNano: bounds[i],
+			UpperNano: bounds[i+1],
+			Lower:     time.Duration(bounds[i]).String(),
+			Upper:     time.Duration(bounds[i+1]).String(),
+			Count:     int64(counts[i]),
+		})
+	}
+	return h.latencyHistogramResult(ctx, out)
+}
+
+func (h *Handler) latencyHistogramResult(ctx context.Context, out traceLatencyHistogramResponse) (*mcp.CallToolResult, error) {
+	body, err := json.Marshal(out)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal trace latency histogram", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal trace latency histogram: " + err.Error()), nil
+	}
+	return structuredResult(body), nil
+}
+
+// queryScalarAggregations runs one scalar traces query with the given
+// aggregation expressions and returns their values by index. Aggregations
+// the backend returned no value for are absent from the map.
+func (h *Handler) queryScalarAggregations(ctx context.Context, client signozclient.Client, start, end int64, filterExpr string, exprs []string) (map[int]float64, *mcp.CallToolResult) {
+	payload := types.BuildAggregateQueryPayload("traces", start, end, exprs[0], filterExpr, nil, exprs[0], "desc", 1, "scalar", nil)
+	spec := payload.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	for _, expr := range exprs[1:] {
+		spec.Aggregations = append(spec.Aggregations, types.QueryAggregation{Expression: expr})
+	}
+	payload.CompositeQuery.Queries[0].Spec = spec
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, InternalErrorResult("failed to marshal query payload: " + err.Error())
+	}
+	data, err := client.QueryBuilderV5(ctx, body)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to query trace latency histogram", err)
+		return nil, upstreamQueryError(err, "traces")
+	}
+	values := make(map[int]float64, len(exprs))
+	for _, s := range summarizeWindow(data) {
+		values[s.aggregation] = s.value
+	}
+	return values, nil
+}
+
+// latencyBucketBounds splits [minNano, maxNano] into n buckets of equal
+// width on a log scale, since latency is heavy-tailed. Bounds that round to
+// the same nanosecond are merged, so fewer buckets come back for narrow
+// ranges.
+func latencyBucketBounds(minNano, maxNano int64, n int) []int64 {
+	lo := max(minNano, 1)
+	if maxNano <= lo {
+		return []int64{minNano, max(maxNano, minNano)}
+	}
+	ratio := math.Log(float64(maxNano) / float64(lo))
+	bounds := []int64{minNano}
+	for i := 1; i < n; i++ {
+		b := int64(math.Round(float64(lo) * math.Exp(ratio*float64(i)/float64(n))))
+		if b > bounds[len(bounds)-1] && b < maxNano {
+			bounds = append(bounds, b)
+		}
+	}
+	return append(bounds, maxNano)
+}
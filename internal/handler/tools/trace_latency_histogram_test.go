@@ -0,0 +1,102 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+// scalarAggResponse renders one scalar result row holding values, one
+// aggregation column per value.
+func scalarAggResponse(values ...float64) json.RawMessage {
+	cols := make([]string, len(values))
+	cells := make([]string, len(values))
+	for i, v := range values {
+		cols[i] = fmt.Sprintf(`{"name":"__result_%d","queryName":"A","aggregationIndex":%d,"columnType":"aggregation"}`, i, i)
+		cells[i] = fmt.Sprint(v)
+	}
+	return json.RawMessage(`{"status":"success","data":{"type":"scalar","data":{"results":[{"queryName":"A","columns":[` +
+		strings.Join(cols, ",") + `],"data":[[` + strings.Join(cells, ",") + `]]}]}}}`)
+}
+
+func TestLatencyBucketBounds(t *testing.T) {
+	bounds := latencyBucketBounds(1_000, 1_000_000, 3)
+	assert.Equal(t, []int64{1_000, 10_000, 100_000, 1_000_000}, bounds)
+
+	assert.Equal(t, []int64{5, 5}, latencyBucketBounds(5, 5, 10), "a single duration gets one bucket")
+	assert.Equal(t, []int64{10, 11, 12}, latencyBucketBounds(10, 12, 20), "bounds rounding to the same nanosecond merge")
+	assert.Equal(t, int64(0), latencyBucketBounds(0, 1000, 4)[0])
+}
+
+func TestHandleGetTraceLatencyHistogram(t *testing.T) {
+	var payloads []types.QueryPayload
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			var p types.QueryPayload
+			require.NoError(t, json.Unmarshal(body, &p))
+			payloads = append(payloads, p)
+			if len(payloads) == 1 {
+				return scalarAggResponse(1_000, 1_000_000, 60, 2_000, 500_000, 900_000), nil
+			}
+			return scalarAggResponse(30, 0, 30), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetTraceLatencyHistogram(testCtx(), makeToolRequest("signoz_get_trace_latency_histogram", map[string]any{
+		"service": "api", "operation": "GET /users", "buckets": "3",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	require.Len(t, payloads, 2)
+
+	spec := payloads[0].CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	assert.Equal(t, "traces", spec.Signal)
+	assert.Equal(t, "service.name = 'api' AND name = 'GET /users'", spec.Filter.Expression)
+	assert.Len(t, spec.Aggregations, len(latencySummaryAggregations))
+
+	spec = payloads[1].CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	require.Len(t, spec.Aggregations, 3)
+	agg, _ := json.Marshal(spec.Aggregations[2])
+	assert.JSONEq(t, `{"expression":"countIf(duration_nano >= 100000 AND duration_nano <= 1000000)"}`, string(agg))
+
+	var out traceLatencyHistogramResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Equal(t, int64(60), out.TotalSpans)
+	assert.Equal(t, map[string]int64{"p50": 2_000, "p90": 500_000, "p99": 900_000}, out.Percentiles)
+	require.Len(t, out.Buckets, 3)
+	assert.Equal(t, latencyBucket{LowerNano: 1_000, UpperNano: 10_000, Lower: "1µs", Upper: "10µs", Count: 30}, out.Buckets[0])
+	assert.Equal(t, int64(0), out.Buckets[1].Count)
+	assert.Equal(t, int64(30), out.Buckets[2].Count)
+}
+
+func TestHandleGetTraceLatencyHistogram_NoSpans(t *testing.T) {
+	calls := 0
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			calls++
+			return json.RawMessage(`{"status":"success","data":{"type":"scalar","data":{"results":[]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleGetTraceLatencyHistogram(testCtx(), makeToolRequest("signoz_get_trace_latency_histogram", map[string]any{
+		"service": "api",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+	assert.Equal(t, 1, calls, "no bucket query when the window is empty")
+
+	var out traceLatencyHistogramResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &out))
+	assert.Zero(t, out.TotalSpans)
+	assert.Empty(t, out.Buckets)
+}
@@ -0,0 +1,252 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
+)
+
+// maxTraceOperatorQueries bounds spanFilters; each filter is one more span
+// scan joined by trace, so large expressions get expensive quickly.
+const maxTraceOperatorQueries = 6
+
+func (h *Handler) RegisterTraceOperatorHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering trace operator handlers")
+
+	tool := mcp.NewTool("signoz_search_traces_advanced",
+		withReadOnlyToolAnnotations(),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+		mcp.WithDescription("Use this when the user asks about relationships between spans in the same trace—e.g. traces where frontend directly calls a failing customer service, or where a checkout span has a slow database descendant. Define each span set in spanFilters (named A, B, C, ... in order) and relate them in expression with trace operators. It returns the matching spans of one named set, paginated. For spans matching a single filter use signoz_search_traces; for statistics use signoz_aggregate_traces. Read signoz://traces/query-builder-guide before using unfamiliar workspace fields. Defaults to the last 1 hour."),
+		mcp.WithArray("spanFilters",
+			mcp.Required(),
+			mcp.WithStringItems(),
+			mcp.Description(fmt.Sprintf("Span filter expressions in SigNoz search syntax, named A, B, C, ... in order (at most %d). Example: [\"service.name = 'frontend'\", \"service.name = 'customer' AND has_error = true\"].", maxTraceOperatorQueries)),
+		),
+		mcp.WithString("expression",
+			mcp.Required(),
+			mcp.Description("Trace operator expression over the spanFilters names. Operators: A => B (B is a direct child of A), A -> B (B is any descendant of A), A && B (both occur in the trace), A || B (either occurs), NOT A (A does not occur); group with parentheses. Every name must be used. Example: \"A => B\"."),
+		),
+		mcp.WithString("returnSpansFrom", mcp.Description("Name of the span set whose spans are returned (e.g. 'B'). Defaults to the first name in expression.")),
+		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
+		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
+		mcp.WithString("limit", mcp.DefaultString(strconv.Itoa(types.DefaultRawQueryLimit)), intOrStringType(), mcp.Description("Maximum number of span rows to return (default: 100, max: 10000; higher values are clamped — paginate with offset).")),
+		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of span rows to skip for pagination (default: 0).")),
+	)
+
+	h.addTool(s, tool, h.handleSearchTracesAdvanced)
+}
+
+// TraceOperatorRequest holds the parsed parameters for a trace operator search.
+type TraceOperatorRequest struct {
+	SpanFilters     []string
+	Expression      string
+	ReturnSpansFrom string
+	Limit           int
+	LimitClamped    bool
+	Offset          int
+	StartTime       int64
+	EndTime         int64
+}
+
+func parseTraceOperatorArgs(args map[string]any) (*TraceOperatorRequest, error) {
+	rawFilters, ok := args["spanFilters"].([]any)
+	if !ok || len(rawFilters) == 0 {
+		return nil, fmt.Errorf(`"spanFilters" must be a non-empty array of filter expressions`)
+	}
+	if len(rawFilters) > maxTraceOperatorQueries {
+		return nil, fmt.Errorf(`"spanFilters" has %d entries; at most %d are allowed`, len(rawFilters), maxTraceOperatorQueries)
+	}
+	filters := make([]string, len(rawFilters))
+	for i, v := range rawFilters {
+		f, ok := v.(string)
+		if !ok || strings.TrimSpace(f) == "" {
+			return nil, fmt.Errorf(`"spanFilters" entry %d (%c) must be a non-empty filter expression`, i, 'A'+i)
+		}
+		filters[i] = strings.TrimSpace(f)
+	}
+
+	expression, _ := args["expression"].(string)
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return nil, fmt.Errorf(`"expression" is required, e.g. "A => B"`)
+	}
+	referenced, err := checkTraceOperatorExpression(expression, len(filters))
+	if err != nil {
+		return nil, fmt.Errorf(`invalid "expression": %w`, err)
+	}
+	if len(referenced) < len(filters) {
+		used := strings.Join(referenced, "")
+		for i := range filters {
+			if name := string(rune('A' + i)); !strings.Contains(used, name) {
+				return nil, fmt.Errorf(`"spanFilters" entry %d (%s) is not used in "expression"`, i, name)
+			}
+		}
+	}
+
+	returnSpansFrom, _ := args["returnSpansFrom"].(string)
+	returnSpansFrom = strings.ToUpper(strings.TrimSpace(returnSpansFrom))
+	if returnSpansFrom == "" {
+		returnSpansFrom = referenced[0]
+	} else if len(returnSpansFrom) != 1 || returnSpansFrom[0] < 'A' || int(returnSpansFrom[0]-'A') >= len(filters) {
+		return nil, fmt.Errorf(`"returnSpansFrom" must name one of the spanFilters (A-%c), got %q`, 'A'+len(filters)-1, returnSpansFrom)
+	}
+
+	limit, err := intArg(args, "limit", types.DefaultRawQueryLimit)
+	if err != nil {
+		return nil, err
+	}
+	limit, clamped := clampLimit(limit)
+	offset, err := intArg(args, "offset", 0)
+	if err != nil {
+		return nil, err
+	}
+	startTime, endTime, err := resolveTimestamps(args, "1h")
+	if err != nil {
+		return nil, err
+	}
+
+	return &TraceOperatorRequest{
+		SpanFilters:     filters,
+		Expression:      expression,
+		ReturnSpansFrom: returnSpansFrom,
+		Limit:           limit,
+		LimitClamped:    clamped,
+		Offset:          offset,
+		StartTime:       startTime,
+		EndTime:         endTime,
+	}, nil
+}
+
+// checkTraceOperatorExpression checks that expr is a well-formed trace
+// operator expression over the names A.. of n span filters, so a typo is a
+// precise validation error rather than an opaque upstream parse failure. It
+// returns the names in order of first use.
+func checkTraceOperatorExpression(expr string, n int) ([]string, error) {
+	var referenced []string
+	seen := map[string]bool{}
+	depth := 0
+	expectOperand := true
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+			continue
+		case c == '(':
+			if !expectOperand {
+				return nil, fmt.Errorf("missing operator before '(' at position %d", i)
+			}
+			depth++
+			i++
+			continue
+		case c == ')':
+			if expectOperand || depth == 0 {
+				return nil, fmt.Errorf("unexpected ')' at position %d", i)
+			}
+			depth--
+			i++
+			continue
+		}
+
+		if i+1 < len(expr) {
+			switch expr[i : i+2] {
+			case "=>", "->", "&&", "||":
+				if expectOperand {
+					return nil, fmt.Errorf("operator %q at position %d has no left operand", expr[i:i+2], i)
+				}
+				expectOperand = true
+				i += 2
+				continue
+			}
+		}
+
+		j := i
+		for j < len(expr) && (expr[j] >= 'A' && expr[j] <= 'Z' || expr[j] >= 'a' && expr[j] <= 'z') {
+			j++
+		}
+		word := expr[i:j]
+		switch {
+		case word == "":
+			return nil, fmt.Errorf("unexpected %q at position %d; use span filter names, parentheses, and =>, ->, &&, ||, NOT", string(c), i)
+		case strings.EqualFold(word, "NOT"):
+			if !expectOperand {
+				return nil, fmt.Errorf("NOT at position %d must start an operand", i)
+			}
+		case len(word) == 1 && word[0] >= 'A' && int(word[0]-'A') < n:
+			if !expectOperand {
+				return nil, fmt.Errorf("missing operator before %s at position %d", word, i)
+			}
+			if !seen[word] {
+				seen[word] = true
+				referenced = append(referenced, word)
+			}
+			expectOperand = false
+		default:
+			return nil, fmt.Errorf("%q at position %d is not a span filter name (A-%c)", word, i, 'A'+n-1)
+		}
+		i = j
+	}
+	if expectOperand {
+		return nil, fmt.Errorf("expression ends without an operand")
+	}
+	if depth != 0 {
+		return nil, fmt.Errorf("unbalanced parentheses")
+	}
+	return referenced, nil
+}
+
+func (h *Handler) handleSearchTracesAdvanced(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	args, errResult := requireArgsMap(req.Params.Arguments)
+	if errResult != nil {
+		return errResult, nil
+	}
+
+	reqData, err := parseTraceOperatorArgs(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
+	queryPayload := types.BuildTraceOperatorQueryPayload(reqData.StartTime, reqData.EndTime,
+		reqData.SpanFilters, reqData.Expression, reqData.ReturnSpansFrom, reqData.Limit, reqData.Offset)
+
+	queryJSON, err := json.Marshal(queryPayload)
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal trace operator query payload", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal query payload: " + err.Error()), nil
+	}
+
+	h.logger.DebugContext(ctx, "Tool called: signoz_search_traces_advanced",
+		slog.String("expression", reqData.Expression),
+		slog.Int("span_filters", len(reqData.SpanFilters)))
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	result, err := client.QueryBuilderV5(ctx, queryJSON)
+	if err != nil {
+		h.logQueryFailure(ctx, "Failed to search traces with trace operator", err)
+		return upstreamQueryError(err, "traces"), nil
+	}
+
+	result = h.enrichSearchTracesWebURL(ctx, result)
+	result, truncation, capNote, errResult := h.capQueryResponse(ctx, "signoz_search_traces_advanced", result)
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit := reqData.Limit
+	if truncation != nil {
+		limit = truncation.Kept
+	}
+	return rawSearchResult(ctx, h.logger, "signoz_search_traces_advanced", result, limit, reqData.Offset, reqData.LimitClamped, capNote), nil
+}
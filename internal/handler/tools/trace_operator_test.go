@@ -0,0 +1,137 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/SigNoz/signoz-mcp-server/internal/client"
+)
+
+func TestCheckTraceOperatorExpression(t *testing.T) {
+	valid := []struct {
+		expr string
+		want []string
+	}{
+		{"A => B", []string{"A", "B"}},
+		{"B -> A", []string{"B", "A"}},
+		{"(A -> B) && NOT C", []string{"A", "B", "C"}},
+		{"A || (B && not C)", []string{"A", "B", "C"}},
+		{"A", []string{"A"}},
+	}
+	for _, tc := range valid {
+		got, err := checkTraceOperatorExpression(tc.expr, 3)
+		require.NoError(t, err, tc.expr)
+		assert.Equal(t, tc.want, got, tc.expr)
+	}
+
+	for _, expr := range []string{
+		"A B",
+		"A =>",
+		"=> A",
+		"D",
+		"(A => B",
+		"A => B)",
+		"A = B",
+		"A NOT B",
+		"()",
+	} {
+		_, err := checkTraceOperatorExpression(expr, 3)
+		assert.Error(t, err, expr)
+	}
+}
+
+func TestParseTraceOperatorArgs(t *testing.T) {
+	req, err := parseTraceOperatorArgs(map[string]any{
+		"spanFilters": []any{"service.name = 'frontend'", "has_error = true"},
+		"expression":  "A => B",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "A", req.ReturnSpansFrom)
+	assert.Equal(t, 100, req.Limit)
+
+	_, err = parseTraceOperatorArgs(map[string]any{
+		"spanFilters": []any{"service.name = 'frontend'", "has_error = true", "x = 1"},
+		"expression":  "A => B",
+	})
+	assert.ErrorContains(t, err, "(C) is not used")
+
+	_, err = parseTraceOperatorArgs(map[string]any{
+		"spanFilters":     []any{"service.name = 'frontend'", "has_error = true"},
+		"expression":      "A => B",
+		"returnSpansFrom": "C",
+	})
+	assert.ErrorContains(t, err, `"returnSpansFrom" must name one of the spanFilters`)
+
+	_, err = parseTraceOperatorArgs(map[string]any{
+		"spanFilters": []any{"a", "b", "c", "d", "e", "f", "g"},
+		"expression":  "A",
+	})
+	assert.ErrorContains(t, err, "at most 6")
+}
+
+func TestHandleSearchTracesAdvanced_BuildsTraceOperatorQuery(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success","data":{"data":{"results":[{"rows":[]}]}}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleSearchTracesAdvanced(testCtx(), makeToolRequest("signoz_search_traces_advanced", map[string]any{
+		"spanFilters":     []any{"service.name = 'frontend'", "service.name = 'customer' AND has_error = true"},
+		"expression":      "A => B",
+		"returnSpansFrom": "b",
+		"timeRange":       "1h",
+	}))
+	require.NoError(t, err)
+	require.False(t, result.IsError, textContent(t, result))
+
+	var payload struct {
+		RequestType    string `json:"requestType"`
+		CompositeQuery struct {
+			Queries []struct {
+				Type string         `json:"type"`
+				Spec map[string]any `json:"spec"`
+			} `json:"queries"`
+		} `json:"compositeQuery"`
+	}
+	require.NoError(t, json.Unmarshal(captured, &payload))
+	assert.Equal(t, "raw", payload.RequestType)
+	queries := payload.CompositeQuery.Queries
+	require.Len(t, queries, 3)
+	for i, name := range []string{"A", "B"} {
+		assert.Equal(t, "builder_query", queries[i].Type)
+		assert.Equal(t, name, queries[i].Spec["name"])
+		assert.Equal(t, true, queries[i].Spec["disabled"])
+	}
+	op := queries[2]
+	assert.Equal(t, "builder_trace_operator", op.Type)
+	assert.Equal(t, "T1", op.Spec["name"])
+	assert.Equal(t, "A => B", op.Spec["expression"])
+	assert.Equal(t, "B", op.Spec["returnSpansFrom"])
+}
+
+func TestHandleSearchTracesAdvanced_InvalidExpressionNotSent(t *testing.T) {
+	called := false
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			called = true
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+
+	result, err := h.handleSearchTracesAdvanced(testCtx(), makeToolRequest("signoz_search_traces_advanced", map[string]any{
+		"spanFilters": []any{"service.name = 'frontend'", "has_error = true"},
+		"expression":  "A => => B",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, CodeValidationFailed, resultCode(t, result))
+	assert.False(t, called, "backend was called with an invalid expression")
+}
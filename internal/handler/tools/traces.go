@@ -16,6 +16,10 @@ import (
 	"github.com/SigNoz/signoz-mcp-server/pkg/util"
 )
 
+// traceDetailsLimitDescription documents the span limit shared by the tools
+// that fetch one whole trace.
+var traceDetailsLimitDescription = fmt.Sprintf("Maximum number of spans to fetch from the trace (default: %d, max: %d; higher values are clamped). Raise it for very large traces; lower it to keep the response small.", types.DefaultTraceDetailsLimit, MaxRawResultLimit)
+
 const tracesFilterParamDescription = "Filter expression using SigNoz search syntax (see signoz://traces/query-builder-guide). Combine conditions with AND, OR, and parentheses for precedence. Unknown keys hard-error; keys present in multiple contexts default to resource context. Disambiguate with attribute.<key>, resource.<key>, or span.<key>. Discover valid keys with signoz_get_field_keys, then confirm values with signoz_get_field_values, before filtering. Examples: \"service.name = 'payment-svc' AND has_error = true\", \"http_method = 'POST' AND (has_error = true OR duration_nano > 1000000000)\"."
 
 func (h *Handler) RegisterTracesHandlers(s *server.MCPServer) {
@@ -33,8 +37,8 @@ func (h *Handler) RegisterTracesHandlers(s *server.MCPServer) {
 		mcp.WithString("service", mcp.Description("Shortcut filter for service name. Equivalent to adding service.name = '<value>' to filter.")),
 		mcp.WithString("operation", mcp.Description("Shortcut filter for span/operation name. Equivalent to adding name = '<value>' to filter.")),
 		mcp.WithBoolean("error", boolOrStringType(), mcp.Description("Shortcut filter for error spans (true or false). Equivalent to adding has_error = true/false to filter.")),
-		mcp.WithString("minDuration", mcp.Description("Minimum span duration in nanoseconds. Example: '500000000' for 500ms.")),
-		mcp.WithString("maxDuration", mcp.Description("Maximum span duration in nanoseconds. Example: '2000000000' for 2s.")),
+		mcp.WithString("minDuration", mcp.Description("Minimum span duration, with a unit (e.g. '500ms', '1.5s', '250us') or as bare nanoseconds.")),
+		mcp.WithString("maxDuration", mcp.Description("Maximum span duration, with a unit (e.g. '2s') or as bare nanoseconds.")),
 		mcp.WithString("orderBy", mcp.Description("How to order results. Format: '<expression> <direction>', e.g. 'count() desc' or 'avg(duration_nano) asc'. Defaults to the aggregation expression descending.")),
 		mcp.WithString("limit", mcp.DefaultString(strconv.Itoa(types.DefaultAggregateQueryLimit)), intOrStringType(), mcp.Description("Maximum number of groups to return (default: 100, max: 10000; higher values are clamped). For time_series queries, groups are ranked across the entire time range, so a short-lived spike can fall outside the selected top groups.")),
 		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
@@ -42,6 +46,7 @@ func (h *Handler) RegisterTracesHandlers(s *server.MCPServer) {
 		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
 		mcp.WithString("requestType", mcp.DefaultString("scalar"), mcp.Enum("scalar", "time_series"), mcp.Description(aggregateRequestTypeDescription)),
 		mcp.WithString("stepInterval", intOrStringType(), mcp.Description(stepIntervalDesc)),
+		mcp.WithString("format", mcp.DefaultString(formatJSON), mcp.Enum(formatJSON, formatCSV, formatCompact), mcp.Description(csvFormatDesc)),
 	)
 
 	h.addTool(s, aggregateTracesTool, h.handleAggregateTraces)
@@ -52,15 +57,19 @@ func (h *Handler) RegisterTracesHandlers(s *server.MCPServer) {
 		mcp.WithDescription("Use this when the user wants individual raw span rows matching service, operation, error, duration, or field filters, or needs to discover trace IDs. It returns paginated spans, not aggregate trends/groups or a full trace hierarchy; use signoz_aggregate_traces for statistics and signoz_get_trace_details for one known trace ID. Read signoz://traces/query-builder-guide before using unfamiliar workspace fields. Defaults to the last 1 hour."),
 		mcp.WithString("filter", mcp.Description(tracesFilterParamDescription+" Combined with shortcut params using AND.")),
 		mcp.WithString("service", mcp.Description("Optional service name to filter by.")),
+		withConditionsParam(),
 		mcp.WithString("operation", mcp.Description("Operation/span name to filter by.")),
 		mcp.WithBoolean("error", boolOrStringType(), mcp.Description("Filter by error status (true or false).")),
-		mcp.WithString("minDuration", mcp.Description("Minimum span duration in nanoseconds. Example: '500000000' for 500ms.")),
-		mcp.WithString("maxDuration", mcp.Description("Maximum span duration in nanoseconds. Example: '2000000000' for 2s.")),
+		mcp.WithString("minDuration", mcp.Description("Minimum span duration, with a unit (e.g. '500ms', '1.5s', '250us') or as bare nanoseconds.")),
+		mcp.WithString("maxDuration", mcp.Description("Maximum span duration, with a unit (e.g. '2s') or as bare nanoseconds.")),
 		mcp.WithString("timeRange", mcp.DefaultString("1h"), mcp.Description(timeRangeDesc("Defaults to '1h'."))),
 		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
 		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional). When both start and end are provided, they override timeRange.")),
 		mcp.WithString("limit", mcp.DefaultString(strconv.Itoa(types.DefaultRawQueryLimit)), intOrStringType(), mcp.Description("Maximum number of span rows to return (default: 100, max: 10000; higher values are clamped — paginate with offset).")),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of span rows to skip for pagination (default: 0).")),
+		mcp.WithString("orderBy", mcp.Description("How to order span rows. Format: '<field> <direction>', e.g. 'duration_nano desc' for the slowest spans first. Defaults to 'timestamp desc'.")),
+		mcp.WithString("selectFields", mcp.Description("Comma-separated span fields to return instead of the default set, e.g. 'name,duration_nano,http.route'. trace_id is always included. Use it to keep responses small.")),
+		mcp.WithString("format", mcp.DefaultString(formatJSON), mcp.Enum(formatJSON, formatNDJSON, formatCompact), mcp.Description(ndjsonFormatDesc)),
 	)
 
 	h.addTool(s, searchTracesTool, h.handleSearchTraces)
@@ -74,6 +83,7 @@ func (h *Handler) RegisterTracesHandlers(s *server.MCPServer) {
 		mcp.WithString("start", intOrStringType(), mcp.Description("Start time in unix milliseconds (optional, defaults to 6 hours ago).")),
 		mcp.WithString("end", intOrStringType(), mcp.Description("End time in unix milliseconds (optional, defaults to now).")),
 		mcp.WithBoolean("includeSpans", boolOrStringType(), mcp.Description("Include detailed span information (default: true).")),
+		mcp.WithString("limit", mcp.DefaultString(strconv.Itoa(types.DefaultTraceDetailsLimit)), intOrStringType(), mcp.Description(traceDetailsLimitDescription)),
 	)
 
 	h.addTool(s, getTraceDetailsTool, h.handleGetTraceDetails)
@@ -119,8 +129,13 @@ func (h *Handler) handleAggregateTraces(ctx context.Context, req mcp.CallToolReq
 		h.logQueryFailure(ctx, "Failed to aggregate traces", err)
 		return upstreamQueryError(err, "traces"), nil
 	}
+	result, _, capNote, errResult := h.capQueryResponse(ctx, "signoz_aggregate_traces", result)
+	if errResult != nil {
+		return errResult, nil
+	}
 
-	return aggregateResult(ctx, h.logger, "signoz_aggregate_traces", result, reqData.LimitClamped), nil
+	res := aggregateResult(ctx, h.logger, "signoz_aggregate_traces", result, reqData.LimitClamped, capNote)
+	return withOutputFormat(res, result, reqData.Format), nil
 }
 
 func (h *Handler) handleSearchTraces(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -134,7 +149,11 @@ func (h *Handler) handleSearchTraces(ctx context.Context, req mcp.CallToolReques
 		return errorWithCode(CodeValidationFailed, err.Error()), nil
 	}
 
-	queryPayload := types.BuildTracesQueryPayload(reqData.StartTime, reqData.EndTime, reqData.FilterExpression, reqData.Limit, reqData.Offset)
+	opts := types.RawQueryOptions{SelectFields: reqData.SelectFields}
+	if reqData.OrderField != "" {
+		opts.Order = []types.Order{{Key: types.Key{Name: reqData.OrderField}, Direction: reqData.OrderDir}}
+	}
+	queryPayload := types.BuildTracesQueryPayloadWithOptions(reqData.StartTime, reqData.EndTime, reqData.FilterExpression, reqData.Limit, reqData.Offset, opts)
 
 	queryJSON, err := json.Marshal(queryPayload)
 	if err != nil {
@@ -143,7 +162,8 @@ func (h *Handler) handleSearchTraces(ctx context.Context, req mcp.CallToolReques
 	}
 
 	h.logger.DebugContext(ctx, "Tool called: signoz_search_traces",
-		slog.String("filter", reqData.FilterExpression))
+		slog.String("filter", reqData.FilterExpression),
+		slog.String("order_by", reqData.OrderField))
 
 	client, err := h.GetClient(ctx)
 	if err != nil {
@@ -156,7 +176,16 @@ func (h *Handler) handleSearchTraces(ctx context.Context, req mcp.CallToolReques
 	}
 
 	result = h.enrichSearchTracesWebURL(ctx, result)
-	return rawSearchResult(ctx, h.logger, "signoz_search_traces", result, reqData.Limit, reqData.Offset, reqData.LimitClamped), nil
+	result, truncation, capNote, errResult := h.capQueryResponse(ctx, "signoz_search_traces", result)
+	if errResult != nil {
+		return errResult, nil
+	}
+	limit := reqData.Limit
+	if truncation != nil {
+		limit = truncation.Kept
+	}
+	res := rawSearchResult(ctx, h.logger, "signoz_search_traces", result, limit, reqData.Offset, reqData.LimitClamped, capNote)
+	return withOutputFormat(res, result, reqData.Format), nil
 }
 
 func (h *Handler) handleGetTraceDetails(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -186,6 +215,11 @@ func (h *Handler) handleGetTraceDetails(ctx context.Context, req mcp.CallToolReq
 		includeSpans = v
 	}
 
+	limit, limitClamped, err := traceDetailsLimitArg(args)
+	if err != nil {
+		return errorWithCode(CodeValidationFailed, err.Error()), nil
+	}
+
 	var startTime, endTime int64
 	if err := json.Unmarshal([]byte(start), &startTime); err != nil {
 		return validationErrorf("start", `invalid timestamp format: %s. Use "timeRange" instead (e.g., "1h", "24h")`, start), nil
@@ -194,20 +228,38 @@ func (h *Handler) handleGetTraceDetails(ctx context.Context, req mcp.CallToolReq
 		return validationErrorf("end", `invalid timestamp format: %s. Use "timeRange" instead (e.g., "1h", "24h")`, end), nil
 	}
 
-	h.logger.DebugContext(ctx, "Tool called: signoz_get_trace_details", slog.String("traceId", traceID), slog.Bool("includeSpans", includeSpans), slog.String("start", start), slog.String("end", end))
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_trace_details", slog.String("traceId", traceID), slog.Bool("includeSpans", includeSpans), slog.String("start", start), slog.String("end", end), slog.Int("limit", limit))
 	client, err := h.GetClient(ctx)
 	if err != nil {
 		return clientError(err), nil
 	}
-	result, err := client.GetTraceDetails(ctx, traceID, includeSpans, startTime, endTime)
+	result, err := client.GetTraceDetails(ctx, traceID, includeSpans, startTime, endTime, limit)
 	if err != nil {
 		h.logUpstreamFailure(ctx, "Failed to get trace details", err, slog.String("traceId", traceID))
 		return upstreamError(err), nil
 	}
 	result = enrichTraceWebURL(ctx, result, traceID)
+	if limitClamped {
+		return structuredResultWithNotes(result, traceDetailsLimitClampedNote), nil
+	}
 	return structuredResult(result), nil
 }
 
+// traceDetailsLimitClampedNote tells the caller a requested span limit was
+// lowered to MaxRawResultLimit.
+var traceDetailsLimitClampedNote = fmt.Sprintf("note: span limit lowered to the maximum of %d to bound server memory.", MaxRawResultLimit)
+
+// traceDetailsLimitArg parses the optional span limit of the single-trace
+// tools, clamped to MaxRawResultLimit.
+func traceDetailsLimitArg(args map[string]any) (limit int, clamped bool, err error) {
+	limit, err = intArg(args, "limit", types.DefaultTraceDetailsLimit)
+	if err != nil {
+		return 0, false, err
+	}
+	limit, clamped = clampLimit(limit)
+	return limit, clamped, nil
+}
+
 // enrichTraceWebURL injects a webUrl deep link into a single-trace passthrough
 // body. Delegates to util.InjectWebURL, which preserves large int64 fields
 // (e.g. duration_nano) and fails open on unparseable input.
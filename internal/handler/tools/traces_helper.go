@@ -2,8 +2,10 @@ package tools
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/SigNoz/signoz-mcp-server/pkg/timeutil"
 	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 )
 
@@ -13,8 +15,13 @@ type SearchTracesRequest struct {
 	Limit            int
 	LimitClamped     bool
 	Offset           int
-	StartTime        int64
-	EndTime          int64
+	OrderField       string
+	OrderDir         string
+	// SelectFields, when set, replaces the default span columns.
+	SelectFields []types.SelectField
+	Format       string
+	StartTime    int64
+	EndTime      int64
 }
 
 func parseSearchTracesArgs(args map[string]any) (*SearchTracesRequest, error) {
@@ -28,10 +35,23 @@ func parseSearchTracesArgs(args map[string]any) (*SearchTracesRequest, error) {
 	if err != nil {
 		return nil, err
 	}
-	minDuration, _ := args["minDuration"].(string)
-	maxDuration, _ := args["maxDuration"].(string)
+	minDuration, maxDuration, err := durationRangeArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	filterExpr := buildTraceFilterExpr(filter, service, operation, errorFilter, errorPresent, minDuration, maxDuration)
 
+	conditions, err := conditionsFilter(args)
+	if err != nil {
+		return nil, err
+	}
+	if conditions != "" {
+		if filterExpr != "" {
+			filterExpr += " AND "
+		}
+		filterExpr += conditions
+	}
+
 	limit, err := intArg(args, "limit", types.DefaultRawQueryLimit)
 	if err != nil {
 		return nil, err
@@ -43,6 +63,22 @@ func parseSearchTracesArgs(args map[string]any) (*SearchTracesRequest, error) {
 		return nil, err
 	}
 
+	orderBy, _ := args["orderBy"].(string)
+	orderField, orderDir := splitOrderBy(orderBy)
+	if orderField != "" && !isTraceOrderField(orderField) {
+		return nil, fmt.Errorf(`"orderBy" must be a span field and direction, e.g. "duration_nano desc" or "timestamp asc"; got %q`, strings.TrimSpace(orderBy))
+	}
+
+	selectFields, err := traceSelectFieldsArg(args)
+	if err != nil {
+		return nil, err
+	}
+
+	format, err := parseOutputFormat(args, formatNDJSON, formatCompact)
+	if err != nil {
+		return nil, err
+	}
+
 	startTime, endTime, err := resolveTimestamps(args, "1h")
 	if err != nil {
 		return nil, err
@@ -53,11 +89,50 @@ func parseSearchTracesArgs(args map[string]any) (*SearchTracesRequest, error) {
 		Limit:            limit,
 		LimitClamped:     clamped,
 		Offset:           offset,
+		OrderField:       orderField,
+		OrderDir:         orderDir,
+		SelectFields:     selectFields,
+		Format:           format,
 		StartTime:        startTime,
 		EndTime:          endTime,
 	}, nil
 }
 
+// traceSelectFieldsArg parses the comma-separated selectFields argument into
+// span columns, adding trace_id first when missing since webUrl enrichment
+// and follow-up calls need it. It returns nil when the argument is absent.
+func traceSelectFieldsArg(args map[string]any) ([]types.SelectField, error) {
+	var fields []types.SelectField
+	seen := map[string]bool{}
+	for _, name := range strings.Split(stringValue(args["selectFields"]), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		if !isTraceOrderField(name) {
+			return nil, fmt.Errorf(`invalid "selectFields" field %q: use span field names such as "name" or "http.route", separated by commas`, name)
+		}
+		seen[name] = true
+		fields = append(fields, aggregateGroupByField("traces", name))
+	}
+	if len(fields) > 0 && !seen["trace_id"] {
+		fields = append([]types.SelectField{aggregateGroupByField("traces", "trace_id")}, fields...)
+	}
+	return fields, nil
+}
+
+// isTraceOrderField reports whether s looks like a bare field name (e.g.
+// duration_nano, attribute.http.route). Raw span rows cannot be ordered by an
+// aggregation, so anything else is rejected before it reaches the backend.
+func isTraceOrderField(s string) bool {
+	for _, r := range s {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.') {
+			return false
+		}
+	}
+	return s != ""
+}
+
 // parseAggregateTracesArgs validates and parses arguments for the aggregate_traces tool.
 func parseAggregateTracesArgs(args map[string]any) (*AggregateRequest, error) {
 	service, _ := args["service"].(string)
@@ -66,8 +141,10 @@ func parseAggregateTracesArgs(args map[string]any) (*AggregateRequest, error) {
 	if err != nil {
 		return nil, err
 	}
-	minDuration, _ := args["minDuration"].(string)
-	maxDuration, _ := args["maxDuration"].(string)
+	minDuration, maxDuration, err := durationRangeArgs(args)
+	if err != nil {
+		return nil, err
+	}
 	filter, err := readFilterExpr(args)
 	if err != nil {
 		return nil, err
@@ -77,6 +154,25 @@ func parseAggregateTracesArgs(args map[string]any) (*AggregateRequest, error) {
 	return parseAggregateArgs(args, "traces", filterExpr)
 }
 
+// durationRangeArgs reads minDuration/maxDuration as nanosecond strings
+// ready for a duration_nano comparison. Values may carry a unit ("500ms",
+// "1.5s"); bare numbers stay nanoseconds. Absent values return "".
+func durationRangeArgs(args map[string]any) (minDuration, maxDuration string, err error) {
+	bounds := [2]string{}
+	for i, key := range []string{"minDuration", "maxDuration"} {
+		raw, _ := args[key].(string)
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+		nanos, err := timeutil.ParseDurationNanos(raw)
+		if err != nil {
+			return "", "", fmt.Errorf(`invalid "%s": %w`, key, err)
+		}
+		bounds[i] = strconv.FormatInt(nanos, 10)
+	}
+	return bounds[0], bounds[1], nil
+}
+
 // buildTraceFilterExpr combines free-form filter with trace-specific shortcut
 // filters. The error shortcut is applied only when errorPresent is true; an
 // invalid value is rejected upstream by parseBoolArg rather than silently
@@ -85,6 +85,269 @@ func TestHandleSearchTraces_ErrorAndDurationFilters(t *testing.T) {
 	}
 }
 
+func TestHandleSearchTraces_DurationUnits(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_traces", map[string]any{
+		"minDuration": "250us",
+		"maxDuration": "1s",
+		"timeRange":   "1h",
+	})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	want := "duration_nano >= 250000 AND duration_nano <= 1000000000"
+	if got := payloadFilterExpression(t, captured); got != want {
+		t.Fatalf("payload filter = %q, want %q", got, want)
+	}
+
+	req = makeToolRequest("signoz_aggregate_traces", map[string]any{
+		"aggregation": "count",
+		"minDuration": "500ms",
+		"timeRange":   "1h",
+	})
+	if _, err := h.handleAggregateTraces(testCtx(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, want := payloadFilterExpression(t, captured), "duration_nano >= 500000000"; got != want {
+		t.Fatalf("aggregate payload filter = %q, want %q", got, want)
+	}
+}
+
+func TestHandleSearchTraces_InvalidDuration(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	req := makeToolRequest("signoz_search_traces", map[string]any{"minDuration": "500 OR 1=1"})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Fatalf("code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
+func TestHandleSearchTraces_RegexpFilter(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_traces", map[string]any{
+		"service": "frontend",
+		"conditions": []any{
+			map[string]any{"field": "http.route", "op": "REGEXP", "value": `^/api/v[12]/\d+`},
+		},
+	})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	// The filter grammar unescapes the literal back to the RE2 pattern.
+	want := `service.name = 'frontend' AND http.route REGEXP '^/api/v[12]/\\d+'`
+	if got := payloadFilterExpression(t, captured); got != want {
+		t.Fatalf("payload filter = %q, want %q", got, want)
+	}
+}
+
+func TestHandleSearchTraces_InvalidRegexp(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	for _, cond := range []map[string]any{
+		{"field": "name", "op": "REGEXP"},
+		{"field": "name", "op": "REGEXP", "value": 5},
+		{"field": "name) OR (1", "op": "REGEXP", "value": "x"},
+		{"field": "name", "op": "NOT REGEXP", "value": "(?=lookahead)"},
+	} {
+		args := map[string]any{"conditions": []any{cond}}
+		result, err := h.handleSearchTraces(testCtx(), makeToolRequest("signoz_search_traces", args))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code := resultCode(t, result); code != CodeValidationFailed {
+			t.Fatalf("%v: code = %q, want %q", args, code, CodeValidationFailed)
+		}
+	}
+	result, _ := h.handleSearchTraces(testCtx(), makeToolRequest("signoz_search_traces", map[string]any{
+		"conditions": []any{map[string]any{"field": "name", "op": "REGEXP", "value": "(a"}},
+	}))
+	if !strings.Contains(textContent(t, result), "RE2 syntax") {
+		t.Fatalf("expected RE2 hint, got: %s", textContent(t, result))
+	}
+}
+
+func TestHandleSearchTraces_FieldExistence(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_traces", map[string]any{
+		"filter": "db.system != 'redis'",
+		"conditions": []any{
+			map[string]any{"field": "db.system", "op": "EXISTS"},
+			map[string]any{"field": "db.name", "op": "EXISTS"},
+			map[string]any{"field": "messaging.system", "op": "NOT EXISTS"},
+		},
+	})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	want := "db.system != 'redis' AND db.system EXISTS AND db.name EXISTS AND messaging.system NOT EXISTS"
+	if got := payloadFilterExpression(t, captured); got != want {
+		t.Fatalf("payload filter = %q, want %q", got, want)
+	}
+
+	result, err = h.handleSearchTraces(testCtx(), makeToolRequest("signoz_search_traces", map[string]any{
+		"conditions": []any{map[string]any{"field": "db.system OR 1=1", "op": "EXISTS"}},
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Fatalf("code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
+func TestHandleSearchTraces_OrderBy(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success"}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_traces", map[string]any{
+		"orderBy":   "duration_nano DESC",
+		"offset":    "20",
+		"timeRange": "1h",
+	})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	var parsed types.QueryPayload
+	if err := json.Unmarshal(captured, &parsed); err != nil {
+		t.Fatalf("failed to parse captured query: %v", err)
+	}
+	spec := parsed.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	if len(spec.Order) != 1 || spec.Order[0].Key.Name != "duration_nano" || spec.Order[0].Direction != "desc" {
+		t.Fatalf("order = %#v, want duration_nano desc", spec.Order)
+	}
+	if spec.Offset != 20 {
+		t.Fatalf("offset = %d, want 20", spec.Offset)
+	}
+
+	req = makeToolRequest("signoz_search_traces", map[string]any{"orderBy": "timestamp asc"})
+	if _, err := h.handleSearchTraces(testCtx(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := json.Unmarshal(captured, &parsed); err != nil {
+		t.Fatalf("failed to parse captured query: %v", err)
+	}
+	spec = parsed.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	if spec.Order[0].Key.Name != "timestamp" || spec.Order[0].Direction != "asc" {
+		t.Fatalf("order = %#v, want timestamp asc", spec.Order)
+	}
+}
+
+func TestHandleSearchTraces_InvalidOrderBy(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	req := makeToolRequest("signoz_search_traces", map[string]any{"orderBy": "p99(duration_nano) desc"})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Fatalf("code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
+func TestHandleSearchTraces_SelectFields(t *testing.T) {
+	var captured []byte
+	mock := &client.MockClient{
+		QueryBuilderV5Fn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			captured = body
+			return json.RawMessage(`{"status":"success","result":[]}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_search_traces", map[string]any{
+		"selectFields": "name, duration_nano,http.route,name",
+		"orderBy":      "duration_nano desc",
+	})
+
+	result, err := h.handleSearchTraces(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler returned error result: %v", result.Content)
+	}
+	var parsed types.QueryPayload
+	if err := json.Unmarshal(captured, &parsed); err != nil {
+		t.Fatalf("failed to parse captured query: %v", err)
+	}
+	spec := parsed.CompositeQuery.Queries[0].Spec.(types.QuerySpec)
+	var names []string
+	for _, f := range spec.SelectFields {
+		names = append(names, f.Name)
+	}
+	if got := strings.Join(names, ","); got != "trace_id,name,duration_nano,http.route" {
+		t.Errorf("selectFields = %s, want trace_id,name,duration_nano,http.route", got)
+	}
+	if spec.SelectFields[2].FieldContext != "span" || spec.SelectFields[2].FieldDataType != "number" {
+		t.Errorf("duration_nano field = %#v, want span number metadata", spec.SelectFields[2])
+	}
+	if len(spec.Order) != 1 || spec.Order[0].Key.Name != "duration_nano" || spec.Order[0].Direction != "desc" {
+		t.Errorf("order = %#v, want duration_nano desc", spec.Order)
+	}
+}
+
+func TestHandleSearchTraces_InvalidSelectFields(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+	result, err := h.handleSearchTraces(testCtx(), makeToolRequest("signoz_search_traces", map[string]any{
+		"selectFields": "name,count()",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Errorf("code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
 func TestHandleSearchTraces_OperationFilter(t *testing.T) {
 	called := false
 	mock := &client.MockClient{
@@ -349,7 +612,7 @@ func TestHandleGetTraceDetails(t *testing.T) {
 	var capturedTraceID string
 	var capturedIncludeSpans bool
 	mock := &client.MockClient{
-		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 			capturedTraceID = traceID
 			capturedIncludeSpans = includeSpans
 			return json.RawMessage(`{"traceId":"abc123","spans":[]}`), nil
@@ -381,7 +644,7 @@ func TestHandleGetTraceDetails_ExplicitStartEndOverrideTimeRange(t *testing.T) {
 	var capturedStart int64
 	var capturedEnd int64
 	mock := &client.MockClient{
-		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 			capturedStart = startTime
 			capturedEnd = endTime
 			return json.RawMessage(`{"traceId":"abc123","spans":[]}`), nil
@@ -411,6 +674,65 @@ func TestHandleGetTraceDetails_ExplicitStartEndOverrideTimeRange(t *testing.T) {
 	}
 }
 
+func TestHandleGetTraceDetails_Limit(t *testing.T) {
+	tests := []struct {
+		name      string
+		limit     any
+		wantLimit int
+		wantNote  bool
+	}{
+		{name: "default", wantLimit: types.DefaultTraceDetailsLimit},
+		{name: "lower", limit: "50", wantLimit: 50},
+		{name: "raised", limit: 5000, wantLimit: 5000},
+		{name: "clamped", limit: "50000", wantLimit: MaxRawResultLimit, wantNote: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotLimit int
+			mock := &client.MockClient{
+				GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
+					gotLimit = limit
+					return json.RawMessage(`{"traceId":"abc123","spans":[]}`), nil
+				},
+			}
+			h := newTestHandler(mock)
+			args := map[string]any{"traceId": "abc123"}
+			if tt.limit != nil {
+				args["limit"] = tt.limit
+			}
+
+			result, err := h.handleGetTraceDetails(testCtx(), makeToolRequest("signoz_get_trace_details", args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.IsError {
+				t.Fatalf("handler returned error result: %v", result.Content)
+			}
+			if gotLimit != tt.wantLimit {
+				t.Errorf("limit = %d, want %d", gotLimit, tt.wantLimit)
+			}
+			if gotNote := strings.Contains(strings.Join(allTextBlocks(result), "\n"), "span limit lowered"); gotNote != tt.wantNote {
+				t.Errorf("clamp note present = %v, want %v", gotNote, tt.wantNote)
+			}
+		})
+	}
+}
+
+func TestHandleGetTraceDetails_InvalidLimit(t *testing.T) {
+	h := newTestHandler(&client.MockClient{})
+
+	result, err := h.handleGetTraceDetails(testCtx(), makeToolRequest("signoz_get_trace_details", map[string]any{
+		"traceId": "abc123",
+		"limit":   "lots",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if code := resultCode(t, result); code != CodeValidationFailed {
+		t.Errorf("code = %q, want %q", code, CodeValidationFailed)
+	}
+}
+
 func TestHandleGetTraceDetails_EmptyTraceId(t *testing.T) {
 	mock := &client.MockClient{}
 	h := newTestHandler(mock)
@@ -429,7 +751,7 @@ func TestHandleGetTraceDetails_EmptyTraceId(t *testing.T) {
 
 func TestHandleGetTraceDetails_WrappedBodyGetsWebURL(t *testing.T) {
 	mock := &client.MockClient{
-		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 			return json.RawMessage(`{"data":{"spans":[]}}`), nil
 		},
 	}
@@ -461,7 +783,7 @@ func TestHandleGetTraceDetails_WrappedBodyGetsWebURL(t *testing.T) {
 
 func TestHandleGetTraceDetails_BareBodyGetsWebURL(t *testing.T) {
 	mock := &client.MockClient{
-		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 			return json.RawMessage(`{"spans":[]}`), nil
 		},
 	}
@@ -480,7 +802,7 @@ func TestHandleGetTraceDetails_BareBodyGetsWebURL(t *testing.T) {
 
 func TestHandleGetTraceDetails_OmitsWebURLWhenNoBaseURL(t *testing.T) {
 	mock := &client.MockClient{
-		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64) (json.RawMessage, error) {
+		GetTraceDetailsFn: func(ctx context.Context, traceID string, includeSpans bool, startTime, endTime int64, limit int) (json.RawMessage, error) {
 			return json.RawMessage(`{"data":{"spans":[]}}`), nil
 		},
 	}
@@ -0,0 +1,55 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+
+	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/SigNoz/signoz-mcp-server/pkg/version"
+)
+
+func (h *Handler) RegisterVersionHandlers(s *server.MCPServer) {
+	h.logger.Debug("Registering version handlers")
+
+	tool := mcp.NewTool("signoz_get_version",
+		withReadOnlyToolAnnotations(),
+		mcp.WithDescription("Use this when the user asks which SigNoz version or edition they run, when a feature or API may depend on the backend version, or when recording environment details for a bug report. Returns the SigNoz backend version, its edition (community or enterprise), and this MCP server's version. It does not query telemetry data."),
+		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
+	)
+
+	h.addTool(s, tool, h.handleGetVersion)
+}
+
+type getVersionResponse struct {
+	Version          string `json:"version"`
+	Edition          string `json:"edition"`
+	MCPServerVersion string `json:"mcpServerVersion"`
+}
+
+func (h *Handler) handleGetVersion(ctx context.Context, _ mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	h.logger.DebugContext(ctx, "Tool called: signoz_get_version")
+
+	client, err := h.GetClient(ctx)
+	if err != nil {
+		return clientError(err), nil
+	}
+	info, err := client.GetVersion(ctx)
+	if err != nil {
+		h.logUpstreamFailure(ctx, "Failed to get SigNoz version", err)
+		return upstreamError(err), nil
+	}
+
+	out, err := json.Marshal(getVersionResponse{
+		Version:          info.Version,
+		Edition:          info.Edition,
+		MCPServerVersion: version.Version,
+	})
+	if err != nil {
+		h.logger.ErrorContext(ctx, "Failed to marshal version", logpkg.ErrAttr(err))
+		return InternalErrorResult("failed to marshal version: " + err.Error()), nil
+	}
+	return structuredResult(out), nil
+}
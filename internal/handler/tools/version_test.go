@@ -0,0 +1,29 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	signozclient "github.com/SigNoz/signoz-mcp-server/internal/client"
+	"github.com/SigNoz/signoz-mcp-server/pkg/version"
+)
+
+func TestHandleGetVersion(t *testing.T) {
+	h := newTestHandler(&signozclient.MockClient{
+		GetVersionFn: func(ctx context.Context) (*signozclient.VersionInfo, error) {
+			return &signozclient.VersionInfo{Version: "v0.80.0", Edition: "enterprise"}, nil
+		},
+	})
+
+	result, err := h.handleGetVersion(testCtx(), makeToolRequest("signoz_get_version", nil))
+	require.NoError(t, err)
+	require.False(t, result.IsError)
+
+	var got getVersionResponse
+	require.NoError(t, json.Unmarshal([]byte(textContent(t, result)), &got))
+	assert.Equal(t, getVersionResponse{Version: "v0.80.0", Edition: "enterprise", MCPServerVersion: version.Version}, got)
+}
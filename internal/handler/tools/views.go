@@ -5,12 +5,14 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 
 	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
 	"github.com/SigNoz/signoz-mcp-server/pkg/paginate"
+	"github.com/SigNoz/signoz-mcp-server/pkg/types"
 	"github.com/SigNoz/signoz-mcp-server/pkg/views"
 )
 
@@ -47,7 +49,7 @@ func (h *Handler) RegisterViewHandlers(s *server.MCPServer) {
 		mcp.WithString("sourcePage", mcp.Required(), mcp.Enum("traces", "logs", "metrics", "meter"), mcp.Description(`Explorer whose views to list: "traces", "logs", "metrics", or "meter". Use "meter" for Cost Meter, not "metrics".`)),
 		mcp.WithString("name", mcp.Description("Partial, server-side match on the saved-view name. Omit to include every name.")),
 		mcp.WithString("category", mcp.Description("Partial, server-side match on the saved-view category. Omit to include every category.")),
-		mcp.WithString("limit", mcp.DefaultString("50"), intOrStringType(), mcp.Description("Maximum number of views to return per page. Default: 50, max: 1000 (higher values are clamped).")),
+		mcp.WithString("limit", mcp.DefaultString(h.listLimitDefault()), intOrStringType(), mcp.Description(h.listLimitDescription("views"))),
 		mcp.WithString("offset", mcp.DefaultString("0"), intOrStringType(), mcp.Description("Number of results to skip before returning results. Use 'pagination.nextOffset' from the previous page. Default: 0.")),
 	)
 	h.addTool(s, listTool, h.handleListViews)
@@ -66,11 +68,16 @@ func (h *Handler) RegisterViewHandlers(s *server.MCPServer) {
 		withCreateToolAnnotations(),
 		mcp.WithString("searchContext", mcp.Description("Copy the user's entire original request verbatim, including any preflight or confirmation context; do not summarize, shorten, or omit clauses.")),
 		mcp.WithDescription(
-			"Use this when the user wants to save one reusable Explorer query for Logs, Traces, Metrics, or Cost Meter; use signoz_create_dashboard for a multi-widget dashboard. Before composing any payload, you must read both signoz://view/instructions and signoz://view/examples. Cost Meter views use sourcePage=\"meter\" while each builder spec uses signal=\"metrics\" and source=\"meter\". Do not send server-populated IDs or timestamps.",
+			"Use this when the user wants to save one reusable Explorer query for Logs, Traces, Metrics, or Cost Meter; use signoz_create_dashboard for a multi-widget dashboard. Before composing any payload, you must read both signoz://view/instructions and signoz://view/examples. Cost Meter views use sourcePage=\"meter\" while each builder spec uses signal=\"metrics\" and source=\"meter\". Do not send server-populated IDs or timestamps. "+
+				"To save a logs or traces filter you built (for example with signoz_search_logs or signoz_search_traces) as a list view, pass filter and optional columns instead of compositeQuery; the server builds the payload.",
 		),
 		mcp.WithString("name", mcp.Required(), mcp.Description("Display name of the view.")),
 		mcp.WithString("sourcePage", mcp.Required(), mcp.Enum("traces", "logs", "metrics", "meter"), mcp.Description(`Which Explorer this view belongs to. One of: "traces", "logs", "metrics", "meter". Use "meter" for Cost Meter views (queried as metrics with source "meter").`)),
-		mcp.WithObject("compositeQuery", mcp.Required(), mcp.AdditionalProperties(true), mcp.Description("The Query Builder payload as an object (not a string). Must contain queryType plus matching sub-query. See signoz://view/instructions and signoz://view/examples.")),
+		// Not mcp.Required(): the filter/columns list-view shorthand builds it.
+		// The handler requires one or the other.
+		mcp.WithObject("compositeQuery", mcp.AdditionalProperties(true), mcp.Description("The Query Builder payload as an object (not a string). Must contain queryType plus matching sub-query. See signoz://view/instructions and signoz://view/examples. Required unless filter or columns is given; when present, filter and columns are ignored.")),
+		mcp.WithString("filter", mcp.Description("List-view shorthand for sourcePage logs or traces, instead of compositeQuery: a filter expression in SigNoz search syntax, exactly as used with signoz_search_logs or signoz_search_traces. Empty saves an unfiltered list.")),
+		mcp.WithArray("columns", mcp.WithStringItems(), mcp.Description("List-view shorthand: optional field names shown as list columns, e.g. [\"service.name\", \"body\"]. Stored in extraData, so do not pass both.")),
 		mcp.WithString("category", mcp.Description("Optional free-form grouping label.")),
 		mcp.WithArray("tags", mcp.WithStringItems(), mcp.Description("Optional free-form tags.")),
 		mcp.WithString("extraData", mcp.Description("Optional UI-controlled options as a JSON-encoded string (safe to leave empty).")),
@@ -341,7 +348,7 @@ func (h *Handler) handleListViews(ctx context.Context, req mcp.CallToolRequest)
 	}
 	name, _ := args["name"].(string)
 	category, _ := args["category"].(string)
-	limit, offset, limitClamped := paginate.ParseParamsClamped(req.Params.Arguments)
+	limit, offset, limitClamped := h.parseListParams(req.Params.Arguments)
 
 	h.logger.DebugContext(ctx, "Tool called: signoz_list_views",
 		slog.String("sourcePage", sourcePage),
@@ -378,12 +385,12 @@ func (h *Handler) handleListViews(ctx context.Context, req mcp.CallToolRequest)
 	}
 	total := len(data)
 	pagedData := paginate.Array(data, offset, limit)
-	resultJSON, err := paginate.Wrap(pagedData, total, offset, limit)
+	toolResult, err := newToolResult(listResponse(pagedData, total, offset, limit, limitClamped))
 	if err != nil {
-		h.logger.ErrorContext(ctx, "Failed to wrap views with pagination", logpkg.ErrAttr(err))
+		h.logger.ErrorContext(ctx, "Failed to marshal views response", logpkg.ErrAttr(err))
 		return InternalErrorResult("failed to marshal response: " + err.Error()), nil
 	}
-	return listResult(resultJSON, limitClamped), nil
+	return toolResult, nil
 }
 
 func (h *Handler) handleGetView(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -425,9 +432,15 @@ func (h *Handler) handleCreateView(ctx context.Context, req mcp.CallToolRequest)
 	if err := validateSourcePage(sourcePage); err != nil {
 		return errorWithCode(CodeValidationFailed, err.Error()), nil
 	}
+	if errResult := validateViewTags(args); errResult != nil {
+		return errResult, nil
+	}
+	if errResult := applyListViewShorthand(args, sourcePage); errResult != nil {
+		return errResult, nil
+	}
 	cq, present := args["compositeQuery"]
 	if !present {
-		return errorWithCode(CodeValidationFailed, `Parameter validation failed: "compositeQuery" is required. Read signoz://view/instructions and signoz://view/examples for the schema.`), nil
+		return errorWithCode(CodeValidationFailed, `Parameter validation failed: "compositeQuery" is required unless "filter" or "columns" is given for a logs or traces list view. Read signoz://view/instructions and signoz://view/examples for the schema.`), nil
 	}
 	if err := validateBuilderSignal(cq, sourcePage); err != nil {
 		return errorWithCode(CodeValidationFailed, err.Error()), nil
@@ -452,6 +465,104 @@ func (h *Handler) handleCreateView(ctx context.Context, req mcp.CallToolRequest)
 	return mcp.NewToolResultText(string(data)), nil
 }
 
+// validateViewTags checks that tags, when present, is an array of strings;
+// it is otherwise forwarded to SigNoz as-is.
+func validateViewTags(args map[string]any) *mcp.CallToolResult {
+	raw, present := args["tags"]
+	if !present || raw == nil {
+		return nil
+	}
+	items, ok := raw.([]any)
+	if !ok {
+		return validationError("tags", "must be an array of strings")
+	}
+	for i, item := range items {
+		if _, ok := item.(string); !ok {
+			return validationErrorf("tags", "entry %d must be a string, got %T", i, item)
+		}
+	}
+	return nil
+}
+
+// applyListViewShorthand replaces the filter/columns arguments of
+// signoz_create_view with the list-view compositeQuery and extraData the
+// Explorer persists (see signoz://view/examples), so a logs or traces filter
+// can be saved without composing a Query Builder payload. It is a no-op when
+// neither argument is set or when compositeQuery is given, which wins.
+func applyListViewShorthand(args map[string]any, sourcePage string) *mcp.CallToolResult {
+	if _, present := args["compositeQuery"]; present {
+		return nil
+	}
+	rawFilter, hasFilter := args["filter"]
+	rawColumns, hasColumns := args["columns"]
+	hasFilter = hasFilter && rawFilter != nil
+	hasColumns = hasColumns && rawColumns != nil
+	delete(args, "filter")
+	delete(args, "columns")
+	if !hasFilter && !hasColumns {
+		return nil
+	}
+	if sourcePage != "logs" && sourcePage != "traces" {
+		return validationErrorf("filter", `and "columns" build list views for sourcePage "logs" or "traces" only (got %q); pass compositeQuery for other Explorers`, sourcePage)
+	}
+
+	filter, ok := rawFilter.(string)
+	if hasFilter && !ok {
+		return validationError("filter", "must be a filter expression string")
+	}
+	var columns []string
+	if hasColumns {
+		items, ok := rawColumns.([]any)
+		if !ok {
+			return validationError("columns", "must be an array of field names")
+		}
+		for i, item := range items {
+			c, ok := item.(string)
+			if !ok || strings.TrimSpace(c) == "" {
+				return validationErrorf("columns", "entry %d must be a non-empty field name", i)
+			}
+			columns = append(columns, strings.TrimSpace(c))
+		}
+	}
+	if extra, _ := args["extraData"].(string); len(columns) > 0 && strings.TrimSpace(extra) != "" {
+		return validationError("columns", `cannot be combined with "extraData", where columns are stored`)
+	}
+
+	order := []any{map[string]any{"key": map[string]any{"name": "timestamp"}, "direction": "desc"}}
+	if sourcePage == "logs" {
+		order = append(order, map[string]any{"key": map[string]any{"name": "id"}, "direction": "desc"})
+	}
+	args["compositeQuery"] = map[string]any{
+		"queryType": "builder",
+		"panelType": "list",
+		"queries": []any{map[string]any{
+			"type": "builder_query",
+			"spec": map[string]any{
+				"name":         "A",
+				"signal":       sourcePage,
+				"source":       "",
+				"stepInterval": 0,
+				"limit":        types.DefaultRawQueryLimit,
+				"order":        order,
+				"filter":       map[string]any{"expression": filter},
+				"having":       map[string]any{"expression": ""},
+			},
+		}},
+	}
+	if len(columns) > 0 {
+		selectColumns := make([]map[string]string, len(columns))
+		for i, c := range columns {
+			selectColumns[i] = map[string]string{"name": c, "signal": sourcePage}
+		}
+		extra, err := json.Marshal(map[string]any{"selectColumns": selectColumns})
+		if err != nil {
+			return InternalErrorResult("failed to build extraData: " + err.Error())
+		}
+		args["extraData"] = string(extra)
+	}
+	return nil
+}
+
 func (h *Handler) handleUpdateView(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 	args, ok := req.Params.Arguments.(map[string]any)
 	if !ok || len(args) == 0 {
@@ -1007,3 +1007,79 @@ func TestHandleUpdateView_ProceedsWhenGetViewFails(t *testing.T) {
 		t.Fatalf("UpdateView should have been called")
 	}
 }
+
+func TestHandleCreateView_ListViewShorthand(t *testing.T) {
+	var gotBody []byte
+	mock := &client.MockClient{
+		CreateViewFn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+			gotBody = body
+			return json.RawMessage(`{"status":"success","data":{"id":"new-id"}}`), nil
+		},
+	}
+	h := newTestHandler(mock)
+	req := makeToolRequest("signoz_create_view", map[string]any{
+		"name":       "payment errors",
+		"sourcePage": "logs",
+		"filter":     "service.name = 'payment' AND severity_text = 'ERROR'",
+		"columns":    []any{"service.name", "body"},
+		"tags":       []any{"team:payments"},
+	})
+	result, err := h.handleCreateView(testCtx(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("handler error: %v", result.Content)
+	}
+
+	var view map[string]any
+	if err := json.Unmarshal(gotBody, &view); err != nil {
+		t.Fatalf("body is not JSON: %v", err)
+	}
+	if view["name"] != "payment errors" || view["sourcePage"] != "logs" {
+		t.Fatalf("name/sourcePage not set: %s", gotBody)
+	}
+	if _, leaked := view["filter"]; leaked {
+		t.Fatalf("shorthand args must not reach the body: %s", gotBody)
+	}
+	if err := validateBuilderSignal(view["compositeQuery"], "logs"); err != nil {
+		t.Fatalf("generated compositeQuery fails view validation: %v", err)
+	}
+	if !strings.Contains(string(gotBody), `"expression":"service.name = 'payment' AND severity_text = 'ERROR'"`) {
+		t.Errorf("filter expression missing: %s", gotBody)
+	}
+	extra, _ := view["extraData"].(string)
+	if extra != `{"selectColumns":[{"name":"service.name","signal":"logs"},{"name":"body","signal":"logs"}]}` {
+		t.Errorf("extraData = %q", extra)
+	}
+}
+
+func TestHandleCreateView_ListViewShorthandValidation(t *testing.T) {
+	tests := []struct {
+		name string
+		args map[string]any
+	}{
+		{"metrics page", map[string]any{"name": "x", "sourcePage": "metrics", "filter": "a = 1"}},
+		{"columns with extraData", map[string]any{"name": "x", "sourcePage": "logs", "columns": []any{"body"}, "extraData": `{"selectColumns":[]}`}},
+		{"non-string column", map[string]any{"name": "x", "sourcePage": "traces", "columns": []any{1.0}}},
+		{"non-string tag", map[string]any{"name": "x", "sourcePage": "logs", "filter": "a = 1", "tags": []any{"ok", 7.0}}},
+		{"tags not an array", map[string]any{"name": "x", "sourcePage": "logs", "filter": "a = 1", "tags": "team:payments"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newTestHandler(&client.MockClient{
+				CreateViewFn: func(ctx context.Context, body []byte) (json.RawMessage, error) {
+					t.Fatalf("CreateView must not be called")
+					return nil, nil
+				},
+			})
+			result, err := h.handleCreateView(testCtx(), makeToolRequest("signoz_create_view", tt.args))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code := resultCode(t, result); code != CodeValidationFailed {
+				t.Fatalf("code = %q, want %q", code, CodeValidationFailed)
+			}
+		})
+	}
+}
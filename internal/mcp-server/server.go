@@ -26,6 +26,7 @@ import (
 	"github.com/SigNoz/signoz-mcp-server/pkg/toolerrors"
 	"github.com/SigNoz/signoz-mcp-server/pkg/util"
 	"github.com/SigNoz/signoz-mcp-server/pkg/version"
+	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
@@ -356,12 +357,17 @@ func (m *MCPServer) newSDKServer() *server.MCPServer {
 	)
 }
 
-// Shutdown closes the HTTP listener if one is active. It is the caller's
-// responsibility to also cancel the context passed to Run — Shutdown alone
-// does not stop Run from starting a listener if it has not yet reached the
-// publication point. In normal use (main.go), signal.NotifyContext cancels
-// the run ctx and Shutdown is called right after, so both signals converge.
+// Shutdown closes the HTTP listener if one is active, waiting until ctx is
+// done for in-flight tool calls to finish, then closes idle upstream SigNoz
+// connections. It is the caller's responsibility to also cancel the context
+// passed to Run — Shutdown alone does not stop Run from starting a listener
+// if it has not yet reached the publication point. In normal use (main.go),
+// signal.NotifyContext cancels the run ctx and Shutdown is called right
+// after, so both signals converge.
 func (m *MCPServer) Shutdown(ctx context.Context) error {
+	if m.handler != nil {
+		defer m.handler.CloseIdleConnections()
+	}
 	if m.config.TransportMode != "http" {
 		return nil
 	}
@@ -728,8 +734,25 @@ func (m *MCPServer) buildHooks() *server.Hooks {
 	return hooks
 }
 
+// toolCallRequestIDMetaKey is the _meta field a client can set on tools/call
+// to correlate our logs with its own request.
+const toolCallRequestIDMetaKey = "requestId"
+
+// toolCallRequestID returns the caller-supplied _meta.requestId, or a fresh
+// UUID when none is set, so every log line of one tool call shares an ID.
+func toolCallRequestID(req mcp.CallToolRequest) string {
+	if req.Params.Meta != nil {
+		if id, ok := req.Params.Meta.AdditionalFields[toolCallRequestIDMetaKey].(string); ok {
+			if id = util.NormalizeCallerCorrelationValue(id); id != "" {
+				return id
+			}
+		}
+	}
+	return uuid.NewString()
+}
+
 // loggingMiddleware returns a tool handler middleware that logs tool call
-// start/finish with duration, tool name, and search context. It decorates the
+// start/finish with duration, tool name, search context, and request ID. It decorates the
 // request span created by mcp-go with MCP and GenAI semantic attributes.
 func (m *MCPServer) loggingMiddleware() server.ToolHandlerMiddleware {
 	return func(next server.ToolHandlerFunc) server.ToolHandlerFunc {
@@ -745,6 +768,7 @@ func (m *MCPServer) loggingMiddleware() server.ToolHandlerMiddleware {
 			}
 
 			ctx = util.SetToolName(ctx, req.Params.Name)
+			ctx = util.SetRequestID(ctx, toolCallRequestID(req))
 
 			// mcp-go owns the request span lifetime. Decorate that MCP server span
 			// with the low-cardinality tool target and GenAI compatibility attrs.
@@ -998,8 +1022,9 @@ func (m *MCPServer) runStdio(ctx context.Context, s *server.MCPServer) error {
 	// so that GetClient works uniformly across both transports.
 	stdio := server.NewStdioServer(s)
 	stdio.SetContextFunc(func(ctx context.Context) context.Context {
-		ctx = util.SetAPIKey(ctx, m.config.APIKey)
-		ctx = util.SetAuthHeader(ctx, "SIGNOZ-API-KEY")
+		credential, authHeader := m.config.EnvCredential()
+		ctx = util.SetAPIKey(ctx, credential)
+		ctx = util.SetAuthHeader(ctx, authHeader)
 		ctx = util.SetSigNozURL(ctx, m.config.URL)
 		// Stdio has no HTTP headers; seed the default so client_source is
 		// always populated.
@@ -1232,10 +1257,11 @@ func (m *MCPServer) authMiddleware(next http.Handler) http.Handler {
 
 		} else if m.config.APIKey != "" {
 			// Fallback to config API key
-			apiKey = m.config.APIKey
+			var envAuthHeader string
+			apiKey, envAuthHeader = m.config.EnvCredential()
 			authMode = authModeConfigAPIKey
 			ctx = util.SetAPIKey(ctx, apiKey)
-			ctx = util.SetAuthHeader(ctx, "SIGNOZ-API-KEY")
+			ctx = util.SetAuthHeader(ctx, envAuthHeader)
 			m.logger.DebugContext(ctx, "Using API key from environment config")
 		} else {
 			m.logAuthFailure(ctx, r, http.StatusUnauthorized, authFailureMissingCredential, authMode, "No API key found in headers or environment")
@@ -17,6 +17,7 @@ import (
 	"time"
 
 	logpkg "github.com/SigNoz/signoz-mcp-server/pkg/log"
+	"github.com/google/uuid"
 	"github.com/mark3labs/mcp-go/mcp"
 	mcpgoserver "github.com/mark3labs/mcp-go/server"
 	"go.opentelemetry.io/otel"
@@ -441,6 +442,47 @@ func TestAuthMiddlewareHonorsAuthorizationIngress(t *testing.T) {
 	}
 }
 
+// TestAuthMiddlewareConfigAPIKeyFollowsAuthMode pins that the env-configured
+// key fallback is sent in the header AUTH_MODE selects.
+func TestAuthMiddlewareConfigAPIKeyFollowsAuthMode(t *testing.T) {
+	cases := []struct {
+		authMode       string
+		wantAPIKey     string
+		wantAuthHeader string
+	}{
+		{authMode: "", wantAPIKey: "env-token", wantAuthHeader: "SIGNOZ-API-KEY"},
+		{authMode: config.AuthModeAPIKey, wantAPIKey: "env-token", wantAuthHeader: "SIGNOZ-API-KEY"},
+		{authMode: config.AuthModeBearer, wantAPIKey: "Bearer env-token", wantAuthHeader: "Authorization"},
+	}
+
+	for _, tc := range cases {
+		t.Run("mode="+tc.authMode, func(t *testing.T) {
+			cfg := &config.Config{URL: "https://1.1.1.1", APIKey: "env-token", AuthMode: tc.authMode}
+			server := &MCPServer{logger: logpkg.New("error"), config: cfg, analytics: noopanalytics.New()}
+			req := httptest.NewRequest(http.MethodPost, "/mcp", nil)
+
+			rr := httptest.NewRecorder()
+			server.authMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				apiKey, _ := util.GetAPIKey(r.Context())
+				authHeader, _ := util.GetAuthHeader(r.Context())
+				w.Header().Set("X-API-Key", apiKey)
+				w.Header().Set("X-Auth-Header", authHeader)
+				w.WriteHeader(http.StatusOK)
+			})).ServeHTTP(rr, req)
+
+			if rr.Code != http.StatusOK {
+				t.Fatalf("status = %d, want %d", rr.Code, http.StatusOK)
+			}
+			if got := rr.Header().Get("X-API-Key"); got != tc.wantAPIKey {
+				t.Fatalf("api key = %q, want %q", got, tc.wantAPIKey)
+			}
+			if got := rr.Header().Get("X-Auth-Header"); got != tc.wantAuthHeader {
+				t.Fatalf("auth header = %q, want %q", got, tc.wantAuthHeader)
+			}
+		})
+	}
+}
+
 // TestAuthMiddlewareHonorsAuthorizationWithConfigURL pins the two non-customURL
 // Authorization branches: a bearer token that is not a server-issued OAuth
 // token is honored as Authorization when a SigNoz URL comes from config,
@@ -2143,6 +2185,58 @@ func TestLoggingMiddlewareAddsToolNameToLifecycleAndDownstreamLogs(t *testing.T)
 	}
 }
 
+func TestLoggingMiddlewareAddsRequestIDToToolCallLogs(t *testing.T) {
+	tests := []struct {
+		name string
+		meta *mcp.Meta
+		want string
+	}{
+		{name: "generated"},
+		{
+			name: "from request meta",
+			meta: &mcp.Meta{AdditionalFields: map[string]any{"requestId": "  client-req-42 "}},
+			want: "client-req-42",
+		},
+		{
+			name: "blank meta value is replaced",
+			meta: &mcp.Meta{AdditionalFields: map[string]any{"requestId": "   "}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf lockedBuffer
+			logger := newBufferedLogger(&buf, slog.LevelDebug)
+			mcpServer := NewMCPServer(logger, nil, &config.Config{}, noopanalytics.New(), nil)
+
+			var downstreamID string
+			_, err := mcpServer.loggingMiddleware()(func(ctx context.Context, req mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				downstreamID, _ = util.GetRequestID(ctx)
+				logger.DebugContext(ctx, "downstream tool log")
+				return &mcp.CallToolResult{}, nil
+			})(context.Background(), mcp.CallToolRequest{
+				Params: mcp.CallToolParams{Name: "signoz_query", Meta: tt.meta},
+			})
+			if err != nil {
+				t.Fatalf("middleware error = %v", err)
+			}
+
+			if tt.want != "" && downstreamID != tt.want {
+				t.Fatalf("request ID = %q, want %q", downstreamID, tt.want)
+			}
+			if tt.want == "" && uuid.Validate(downstreamID) != nil {
+				t.Fatalf("request ID = %q, want a generated UUID", downstreamID)
+			}
+			for _, msg := range []string{"tool call started", "downstream tool log", "tool call finished"} {
+				rec, _ := logRecordByMessage(t, &buf, msg)
+				if rec["mcp.request_id"] != downstreamID {
+					t.Fatalf("%s mcp.request_id = %v, want %q", msg, rec["mcp.request_id"], downstreamID)
+				}
+			}
+		})
+	}
+}
+
 func TestLoggingMiddleware_ErrorResultLogsWarn(t *testing.T) {
 	var buf bytes.Buffer
 	logger := newBufferedLogger(&buf, slog.LevelDebug)
@@ -3065,3 +3159,53 @@ func TestMaxBytesMiddlewareDisabledWhenZero(t *testing.T) {
 		t.Fatalf("zero cap should not limit body, got err: %v", readErr)
 	}
 }
+
+func TestShutdownWaitsForInFlightHTTPRequest(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	httpSrv := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(entered)
+		<-release
+		w.WriteHeader(http.StatusOK)
+	}))
+	httpSrv.Start()
+	t.Cleanup(httpSrv.Close)
+
+	logger := slog.New(slog.DiscardHandler)
+	cfg := &config.Config{TransportMode: "http", ClientCacheSize: 1}
+	mcpServer := NewMCPServer(logger, tools.NewHandler(logger, cfg), cfg, noopanalytics.New(), nil)
+	mcpServer.httpServer.Store(httpSrv.Config)
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := http.Get(httpSrv.URL)
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-entered
+
+	shutdownDone := make(chan error, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		shutdownDone <- mcpServer.Shutdown(ctx)
+	}()
+
+	select {
+	case err := <-shutdownDone:
+		t.Fatalf("Shutdown returned before the in-flight request finished: %v", err)
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	if err := <-shutdownDone; err != nil {
+		t.Fatalf("Shutdown error = %v", err)
+	}
+	if got := <-status; got != http.StatusOK {
+		t.Fatalf("in-flight request status = %d, want 200", got)
+	}
+}
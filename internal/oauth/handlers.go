@@ -44,6 +44,9 @@ type Handler struct {
 	authorizeTemplate *template.Template
 	emitEvent         AnalyticsEmitter
 	meters            *otelpkg.Meters
+	// configTransport carries the SIGNOZ_URL TLS settings; nil keeps the
+	// default transport.
+	configTransport *http.Transport
 }
 
 type registerClientRequest struct {
@@ -98,6 +101,10 @@ type tokenResponse struct {
 }
 
 func NewHandler(logger *slog.Logger, cfg *config.Config, emitEvent AnalyticsEmitter, meters *otelpkg.Meters) *Handler {
+	var configTransport *http.Transport
+	if tlsConfig := cfg.TLSConfig(); tlsConfig != nil {
+		configTransport = client.NewTLSTransport(tlsConfig)
+	}
 	return &Handler{
 		logger:            logger,
 		config:            cfg,
@@ -105,6 +112,7 @@ func NewHandler(logger *slog.Logger, cfg *config.Config, emitEvent AnalyticsEmit
 		authorizeTemplate: authorizePageTemplate,
 		emitEvent:         emitEvent,
 		meters:            meters,
+		configTransport:   configTransport,
 	}
 }
 
@@ -350,11 +358,14 @@ func (h *Handler) validateSigNozCredentials(ctx context.Context, signozURL, apiK
 	// configured SIGNOZ_URL to prevent leaking proxy-auth credentials to
 	// attacker-controlled hosts.
 	var headers map[string]string
+	var transport *http.Transport
 	configNormalized, _ := util.NormalizeSigNozURL(h.config.URL)
 	if strings.EqualFold(signozURL, configNormalized) {
 		headers = h.config.CustomHeaders
+		transport = h.configTransport
 	}
 	signozClient := client.NewClient(h.logger, signozURL, apiKey, "SIGNOZ-API-KEY", headers)
+	signozClient.SetTransport(transport)
 	return signozClient.ValidateCredentials(ctx)
 }
 
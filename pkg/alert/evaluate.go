@@ -0,0 +1,131 @@
+package alert
+
+import (
+	"fmt"
+	"math"
+)
+
+// compareOpAliases maps every accepted operator spelling (see
+// validCompareOps) to its canonical literal.
+var compareOpAliases = map[string]string{
+	"1": "above", "above": "above", ">": "above",
+	"2": "below", "below": "below", "<": "below",
+	"3": "equal", "equal": "equal", "eq": "equal", "=": "equal",
+	"4": "not_equal", "not_equal": "not_equal", "not_eq": "not_equal", "!=": "not_equal",
+	"5": "above_or_equal", "above_or_equal": "above_or_equal", "above_or_eq": "above_or_equal", ">=": "above_or_equal",
+	"6": "below_or_equal", "below_or_equal": "below_or_equal", "below_or_eq": "below_or_equal", "<=": "below_or_equal",
+	"7": "outside_bounds", "outside_bounds": "outside_bounds",
+}
+
+// matchTypeAliases maps every accepted match type spelling (see
+// validMatchTypes) to its canonical literal.
+var matchTypeAliases = map[string]string{
+	"1": "at_least_once", "at_least_once": "at_least_once",
+	"2": "all_the_times", "all_the_times": "all_the_times",
+	"3": "on_average", "on_average": "on_average", "avg": "on_average",
+	"4": "in_total", "in_total": "in_total", "sum": "in_total",
+	"5": "last", "last": "last",
+}
+
+// Evaluation is the outcome of checking one series against one threshold.
+type Evaluation struct {
+	// Value is the number compared with the target: the average, total, or
+	// last point for those match types, and the point closest to (or
+	// furthest past) the target for at_least_once and all_the_times.
+	Value float64
+	Fires bool
+}
+
+// EvaluateThreshold applies a threshold's operator and match type to the
+// points of one series the way the SigNoz rule evaluator does: at_least_once
+// fires when any point matches, all_the_times when every point does, and
+// on_average, in_total, and last compare the mean, sum, or final point.
+// outside_bounds compares the absolute value. points must not be empty.
+func EvaluateThreshold(points []float64, op, matchType string, target float64) (Evaluation, error) {
+	canonicalOp, ok := compareOpAliases[op]
+	if !ok {
+		return Evaluation{}, fmt.Errorf("unsupported op %q", op)
+	}
+	canonicalMatch, ok := matchTypeAliases[matchType]
+	if !ok {
+		return Evaluation{}, fmt.Errorf("unsupported matchType %q", matchType)
+	}
+	if len(points) == 0 {
+		return Evaluation{}, fmt.Errorf("no data points")
+	}
+	matches := func(v float64) bool { return compareValue(canonicalOp, v, target) }
+
+	switch canonicalMatch {
+	case "on_average", "in_total":
+		var sum float64
+		for _, p := range points {
+			sum += p
+		}
+		if canonicalMatch == "on_average" {
+			sum /= float64(len(points))
+		}
+		return Evaluation{Value: sum, Fires: matches(sum)}, nil
+	case "last":
+		last := points[len(points)-1]
+		return Evaluation{Value: last, Fires: matches(last)}, nil
+	case "at_least_once":
+		for _, p := range points {
+			if matches(p) {
+				return Evaluation{Value: p, Fires: true}, nil
+			}
+		}
+		return Evaluation{Value: extremePoint(canonicalOp, points, true)}, nil
+	default: // all_the_times
+		for _, p := range points {
+			if !matches(p) {
+				return Evaluation{Value: p}, nil
+			}
+		}
+		return Evaluation{Value: extremePoint(canonicalOp, points, false), Fires: true}, nil
+	}
+}
+
+func compareValue(op string, v, target float64) bool {
+	switch op {
+	case "above":
+		return v > target
+	case "below":
+		return v < target
+	case "equal":
+		return v == target
+	case "not_equal":
+		return v != target
+	case "above_or_equal":
+		return v >= target
+	case "below_or_equal":
+		return v <= target
+	default: // outside_bounds
+		return math.Abs(v) >= target
+	}
+}
+
+// extremePoint returns the point nearest to firing (nearest=true, used when
+// no point fired) or the one with the least margin (nearest=false, used when
+// every point fired); both are the max for "above"-style operators and the
+// min for "below"-style ones. Equality operators report the last point.
+func extremePoint(op string, points []float64, nearest bool) float64 {
+	pick := points[len(points)-1]
+	var better func(a, b float64) bool
+	switch op {
+	case "above", "above_or_equal":
+		better = func(a, b float64) bool { return a > b }
+	case "below", "below_or_equal":
+		better = func(a, b float64) bool { return a < b }
+	case "outside_bounds":
+		better = func(a, b float64) bool { return math.Abs(a) > math.Abs(b) }
+	default:
+		return pick
+	}
+	pick = points[0]
+	for _, p := range points[1:] {
+		if better(p, pick) == nearest {
+			pick = p
+		}
+	}
+	return pick
+}
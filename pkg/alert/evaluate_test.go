@@ -0,0 +1,49 @@
+package alert
+
+import "testing"
+
+func TestEvaluateThreshold(t *testing.T) {
+	points := []float64{40, 90, 70, 60}
+	tests := []struct {
+		name      string
+		points    []float64
+		op        string
+		matchType string
+		target    float64
+		want      Evaluation
+	}{
+		{"at_least_once fires on first match", points, "above", "at_least_once", 65, Evaluation{Value: 90, Fires: true}},
+		{"at_least_once reports nearest miss", points, ">", "1", 95, Evaluation{Value: 90}},
+		{"at_least_once below reports min", points, "below", "at_least_once", 10, Evaluation{Value: 40}},
+		{"all_the_times fires with least margin", points, "above", "all_the_times", 30, Evaluation{Value: 40, Fires: true}},
+		{"all_the_times reports first miss", points, "above", "all_the_times", 50, Evaluation{Value: 40}},
+		{"on_average", points, "above_or_eq", "avg", 65, Evaluation{Value: 65, Fires: true}},
+		{"in_total", points, "below", "sum", 200, Evaluation{Value: 260}},
+		{"last", points, "equal", "last", 60, Evaluation{Value: 60, Fires: true}},
+		{"outside_bounds uses absolute value", []float64{-5, 2}, "outside_bounds", "at_least_once", 4, Evaluation{Value: -5, Fires: true}},
+		{"outside_bounds nearest miss", []float64{-3, 2}, "7", "at_least_once", 4, Evaluation{Value: -3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateThreshold(tt.points, tt.op, tt.matchType, tt.target)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateThreshold_Errors(t *testing.T) {
+	if _, err := EvaluateThreshold([]float64{1}, "bigger", "last", 0); err == nil {
+		t.Error("expected error for unknown op")
+	}
+	if _, err := EvaluateThreshold([]float64{1}, "above", "sometimes", 0); err == nil {
+		t.Error("expected error for unknown matchType")
+	}
+	if _, err := EvaluateThreshold(nil, "above", "last", 0); err == nil {
+		t.Error("expected error for no points")
+	}
+}
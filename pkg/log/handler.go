@@ -51,6 +51,9 @@ func (h *ContextHandler) Handle(ctx context.Context, r slog.Record) error {
 			slog.String("gen_ai.operation.name", "execute_tool"),
 		)
 	}
+	if requestID, ok := util.GetRequestID(ctx); ok && requestID != "" {
+		r.AddAttrs(slog.String("mcp.request_id", requestID))
+	}
 	if clientSource, ok := util.GetClientSource(ctx); ok && clientSource != "" {
 		r.AddAttrs(slog.String("mcp.client_source", clientSource))
 	}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/SigNoz/signoz-mcp-server/pkg/util"
 	"go.opentelemetry.io/otel/codes"
@@ -68,6 +69,21 @@ func TestContextHandler_InjectsAssistantCorrelation(t *testing.T) {
 	}
 }
 
+func TestContextHandler_InjectsRequestID(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newTestLogger(&buf)
+
+	logger.InfoContext(util.SetRequestID(context.Background(), "req-123"), "ping")
+
+	var rec map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &rec); err != nil {
+		t.Fatalf("parse log record: %v", err)
+	}
+	if got := rec["mcp.request_id"]; got != "req-123" {
+		t.Fatalf("mcp.request_id = %v, want req-123", got)
+	}
+}
+
 func TestContextHandler_OmitsAssistantCorrelationWhenAbsent(t *testing.T) {
 	var buf bytes.Buffer
 	logger := newTestLogger(&buf)
@@ -205,3 +221,18 @@ func TestTruncBody(t *testing.T) {
 		t.Fatalf("TruncBody(big) len = %d, want <= 4096", len(got))
 	}
 }
+
+func TestTruncBody_DoesNotSplitMultibyteRune(t *testing.T) {
+	cutoff := truncBodyLimit - len(truncBodySuffix)
+	// Place a 3-byte rune so the cutoff lands on its second byte.
+	body := append(bytes.Repeat([]byte("a"), cutoff-1), []byte("€€€")...)
+	body = append(body, bytes.Repeat([]byte("b"), truncBodyLimit)...)
+
+	got := TruncBody(body)
+	if !utf8.ValidString(got) {
+		t.Fatalf("TruncBody split a multibyte rune: %q", got[len(got)-20:])
+	}
+	if want := strings.Repeat("a", cutoff-1) + truncBodySuffix; got != want {
+		t.Fatalf("TruncBody kept %d bytes before the suffix, want %d", len(got)-len(truncBodySuffix), cutoff-1)
+	}
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"log/slog"
 	"os"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/SigNoz/signoz-mcp-server/pkg/version"
 )
@@ -16,8 +18,26 @@ const (
 	truncBodySuffix = "...(truncated)"
 )
 
+// Log output formats accepted by NewWithFormat.
+const (
+	FormatJSON    = "json"
+	FormatConsole = "console"
+)
+
 // New creates a JSON slog logger that matches the Zeus field naming convention.
 func New(level string) *slog.Logger {
+	return NewWithFormat(level, FormatJSON)
+}
+
+// NewWithFormat is New with a choice of output format: FormatJSON for log
+// pipelines, or FormatConsole for key=value lines that are easier to read in
+// a terminal. Unknown formats fall back to JSON.
+func NewWithFormat(level, format string) *slog.Logger {
+	// Use stderr so stdio transport can keep stdout reserved for MCP frames.
+	return newLogger(os.Stderr, level, format)
+}
+
+func newLogger(w io.Writer, level, format string) *slog.Logger {
 	var slogLevel slog.Level
 	switch strings.ToLower(level) {
 	case "debug":
@@ -30,8 +50,7 @@ func New(level string) *slog.Logger {
 		slogLevel = slog.LevelInfo
 	}
 
-	// Use stderr so stdio transport can keep stdout reserved for MCP frames.
-	baseHandler := slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+	opts := &slog.HandlerOptions{
 		Level:     slogLevel,
 		AddSource: true,
 		ReplaceAttr: func(_ []string, a slog.Attr) slog.Attr {
@@ -43,7 +62,11 @@ func New(level string) *slog.Logger {
 			}
 			return a
 		},
-	})
+	}
+	var baseHandler slog.Handler = slog.NewJSONHandler(w, opts)
+	if strings.EqualFold(format, FormatConsole) {
+		baseHandler = slog.NewTextHandler(w, opts)
+	}
 
 	return slog.New(NewContextHandler(baseHandler)).With(
 		slog.String("service.version", version.Version),
@@ -68,6 +91,9 @@ func LevelForError(err error) slog.Level {
 	return slog.LevelError
 }
 
+// TruncBody returns b as a string capped at truncBodyLimit bytes. The cut is
+// moved back to a rune boundary so a multibyte character is never split into
+// invalid UTF-8 in the log record.
 func TruncBody(b []byte) string {
 	if len(b) <= truncBodyLimit {
 		return string(b)
@@ -77,6 +103,9 @@ func TruncBody(b []byte) string {
 	if cutoff < 0 {
 		cutoff = 0
 	}
+	for cutoff > 0 && !utf8.RuneStart(b[cutoff]) {
+		cutoff--
+	}
 
 	return string(b[:cutoff]) + truncBodySuffix
 }
@@ -74,3 +74,33 @@ func TestNew_WritesToStderr(t *testing.T) {
 		t.Fatalf("service.version = %v, want %q", got, version.Version)
 	}
 }
+
+func TestNewWithFormat_Console(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf, "info", FormatConsole)
+	logger.InfoContext(context.Background(), "console line", slog.String("transport_mode", "stdio"))
+
+	out := buf.String()
+	if json.Valid(buf.Bytes()) {
+		t.Fatalf("expected key=value output, got JSON %q", out)
+	}
+	for _, want := range []string{`msg="console line"`, "transport_mode=stdio", "service.version=", "timestamp="} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("expected %q in console output, got %q", want, out)
+		}
+	}
+}
+
+func TestNewWithFormat_UnknownFallsBackToJSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newLogger(&buf, "info", "yaml")
+	logger.InfoContext(context.Background(), "json line")
+
+	var record map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &record); err != nil {
+		t.Fatalf("expected a JSON record, got %q: %v", buf.String(), err)
+	}
+	if record["msg"] != "json line" {
+		t.Fatalf("msg = %v, want %q", record["msg"], "json line")
+	}
+}
@@ -58,7 +58,7 @@ Valid reduceTo values: sum, count, avg, min, max, last, median
 1. **rate/increase on a gauge** → Invalid. Rate measures change over time, but gauges represent instantaneous values. Use avg, latest, or sum instead.
 2. **timeAggregation on histogram** → Ignored. Histogram time aggregation is handled automatically by the backend. Only set spaceAggregation (p50-p99).
 3. **sum/avg/min/max spaceAggregation on histogram** → Invalid. Histograms must use percentile aggregations (p50, p75, p90, p95, p99).
-4. **Providing only metricType for a counter** → This bypasses metadata auto-fetch and can leave temporality or monotonicity unspecified. Either omit metricType so all metadata is fetched, or pass the matching values from signoz_list_metrics.
+4. **Providing only metricType for a counter** → Temporality is still auto-fetched, but monotonicity is not and defaults to false. Either omit metricType so all metadata is fetched, or pass the matching values from signoz_list_metrics.
 
 ---
 
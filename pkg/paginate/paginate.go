@@ -8,12 +8,14 @@ import (
 const (
 	DefaultLimit  = 50
 	DefaultOffset = 0
-	// MaxLimit bounds the per-page size for the summary list tools (services,
-	// dashboards, alerts, alert rules, views, notification channels). These
-	// responses are built fully in memory before paging, so an unbounded limit
-	// is a memory vector on the shared multi-tenant pod. Callers needing more
-	// rows paginate via offset.
-	MaxLimit = 1000
+	// MaxLimit is the default per-page cap for the summary list tools
+	// (services, dashboards, alerts, alert rules, views, notification
+	// channels). These responses are built fully in memory before paging, so
+	// an unbounded limit is a memory vector on the shared multi-tenant pod and
+	// a large page floods the caller's context. Callers needing more rows
+	// paginate via offset. Servers may configure a different cap; see
+	// ParseParamsMax.
+	MaxLimit = 200
 )
 
 // Metadata contains paged info for any listed responses
@@ -24,6 +26,9 @@ type Metadata struct {
 	Limit      int  `json:"limit"`
 	HasMore    bool `json:"hasMore"`
 	NextOffset int  `json:"nextOffset"`
+	// LimitClamped is set when the requested limit exceeded the server's
+	// per-page cap and Limit was lowered to it.
+	LimitClamped bool `json:"limitClamped,omitempty"`
 }
 
 // Response wraps a list data with paged metadata
@@ -33,7 +38,10 @@ type Response struct {
 }
 
 // ParseParams extracts limit and offset from request arguments, clamping the
-// limit to MaxLimit. Accepts limit/offset as a number or a string.
+// limit to MaxLimit. Accepts limit/offset as a number or a string. A limit of
+// 0 or less does not mean "all": it falls back to DefaultLimit, as does an
+// unparseable value. A negative or unparseable offset falls back to
+// DefaultOffset.
 func ParseParams(args any) (int, int) {
 	limit, offset, _ := ParseParamsClamped(args)
 	return limit, offset
@@ -42,7 +50,17 @@ func ParseParams(args any) (int, int) {
 // ParseParamsClamped is ParseParams that also reports whether the requested
 // limit was clamped to MaxLimit, so handlers can surface a note.
 func ParseParamsClamped(args any) (limit, offset int, clamped bool) {
-	limit = DefaultLimit
+	return ParseParamsMax(args, MaxLimit)
+}
+
+// ParseParamsMax is ParseParamsClamped with a caller-chosen cap. A
+// non-positive maxLimit falls back to MaxLimit, and the default limit never
+// exceeds the cap.
+func ParseParamsMax(args any, maxLimit int) (limit, offset int, clamped bool) {
+	if maxLimit <= 0 {
+		maxLimit = MaxLimit
+	}
+	limit = min(DefaultLimit, maxLimit)
 	offset = DefaultOffset
 
 	m, ok := args.(map[string]any)
@@ -51,8 +69,8 @@ func ParseParamsClamped(args any) (limit, offset int, clamped bool) {
 	}
 
 	if v, present, ok := parseLooseInt(m["limit"]); ok && present && v > 0 {
-		if v > MaxLimit {
-			limit = MaxLimit
+		if v > int64(maxLimit) {
+			limit = maxLimit
 			clamped = true
 		} else {
 			limit = int(v)
@@ -97,8 +115,10 @@ func parseLooseInt(v any) (value int64, present bool, ok bool) {
 	}
 }
 
-// Array returns the paged subset for list data.
+// Array returns the paged subset for list data. A negative offset is treated
+// as 0; a non-positive limit yields an empty page.
 func Array(arr []any, offset, limit int) []any {
+	offset = max(offset, 0)
 	if limit <= 0 || offset >= len(arr) {
 		return []any{}
 	}
@@ -110,23 +130,31 @@ func Array(arr []any, offset, limit int) []any {
 	return arr[offset:end]
 }
 
-// Wrap wraps paginated data and metadata into json.
-func Wrap(data []any, total, offset, limit int) ([]byte, error) {
+// NewMetadata computes the pagination metadata for one page of a list of
+// total items, matching the page Array returns for the same arguments.
+// NextOffset is -1 and HasMore false when no further page exists, including
+// when offset is past the end. A non-positive limit never reports more pages,
+// since following its NextOffset would return the same empty page forever.
+func NewMetadata(total, offset, limit int) Metadata {
+	offset = max(offset, 0)
 	nextOffset := offset + limit
-	if nextOffset >= total {
+	if limit <= 0 || nextOffset >= total {
 		nextOffset = -1
 	}
 
-	hasMore := nextOffset != -1
+	return Metadata{
+		Total:      total,
+		Offset:     offset,
+		Limit:      limit,
+		HasMore:    nextOffset != -1,
+		NextOffset: nextOffset,
+	}
+}
 
+// Wrap wraps paginated data and metadata into json.
+func Wrap(data []any, total, offset, limit int) ([]byte, error) {
 	return json.Marshal(Response{
-		Data: data,
-		Pagination: Metadata{
-			Total:      total,
-			Offset:     offset,
-			Limit:      limit,
-			HasMore:    hasMore,
-			NextOffset: nextOffset,
-		},
+		Data:       data,
+		Pagination: NewMetadata(total, offset, limit),
 	})
 }
@@ -0,0 +1,146 @@
+package paginate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func items(n int) []any {
+	out := make([]any, n)
+	for i := range out {
+		out[i] = i
+	}
+	return out
+}
+
+func TestArray(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		offset int
+		limit  int
+		want   []any
+	}{
+		{"first page", 10, 0, 3, []any{0, 1, 2}},
+		{"middle page", 10, 3, 3, []any{3, 4, 5}},
+		{"exact last page", 9, 6, 3, []any{6, 7, 8}},
+		{"limit larger than remaining", 10, 8, 5, []any{8, 9}},
+		{"offset at total", 10, 10, 3, []any{}},
+		{"offset beyond total", 10, 25, 3, []any{}},
+		{"limit zero", 10, 0, 0, []any{}},
+		{"negative limit", 10, 0, -1, []any{}},
+		{"negative offset treated as zero", 10, -4, 2, []any{0, 1}},
+		{"empty input", 0, 0, 5, []any{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Array(items(tt.n), tt.offset, tt.limit))
+		})
+	}
+}
+
+func TestNewMetadata(t *testing.T) {
+	tests := []struct {
+		name   string
+		total  int
+		offset int
+		limit  int
+		want   Metadata
+	}{
+		{"more pages", 10, 0, 3, Metadata{Total: 10, Offset: 0, Limit: 3, HasMore: true, NextOffset: 3}},
+		{"one item left", 10, 6, 3, Metadata{Total: 10, Offset: 6, Limit: 3, HasMore: true, NextOffset: 9}},
+		{"exact boundary", 9, 6, 3, Metadata{Total: 9, Offset: 6, Limit: 3, HasMore: false, NextOffset: -1}},
+		{"limit larger than remaining", 10, 8, 5, Metadata{Total: 10, Offset: 8, Limit: 5, HasMore: false, NextOffset: -1}},
+		{"offset beyond total", 10, 25, 3, Metadata{Total: 10, Offset: 25, Limit: 3, HasMore: false, NextOffset: -1}},
+		{"empty list", 0, 0, 50, Metadata{Total: 0, Offset: 0, Limit: 50, HasMore: false, NextOffset: -1}},
+		{"limit zero never loops", 10, 2, 0, Metadata{Total: 10, Offset: 2, Limit: 0, HasMore: false, NextOffset: -1}},
+		{"negative offset treated as zero", 10, -4, 3, Metadata{Total: 10, Offset: 0, Limit: 3, HasMore: true, NextOffset: 3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, NewMetadata(tt.total, tt.offset, tt.limit))
+		})
+	}
+}
+
+// TestPagingVisitsEveryItemOnce follows nextOffset until hasMore is false
+// and checks that the pages cover the list exactly once.
+func TestPagingVisitsEveryItemOnce(t *testing.T) {
+	for _, total := range []int{0, 1, 6, 7, 9} {
+		for _, limit := range []int{1, 3, 10} {
+			all := items(total)
+			var seen []any
+			offset := 0
+			for pages := 0; ; pages++ {
+				require.Less(t, pages, total+2, "total=%d limit=%d: paging did not terminate", total, limit)
+				seen = append(seen, Array(all, offset, limit)...)
+				meta := NewMetadata(total, offset, limit)
+				require.Equal(t, meta.HasMore, meta.NextOffset != -1)
+				if !meta.HasMore {
+					break
+				}
+				offset = meta.NextOffset
+			}
+			assert.Equal(t, all, append([]any{}, seen...), "total=%d limit=%d", total, limit)
+		}
+	}
+}
+
+func TestParseParamsMax(t *testing.T) {
+	limit, offset, clamped := ParseParamsMax(map[string]any{"limit": "100000", "offset": "-3"}, 75)
+	assert.Equal(t, 75, limit)
+	assert.Equal(t, 0, offset)
+	assert.True(t, clamped)
+
+	limit, _, clamped = ParseParamsMax(map[string]any{"limit": "75"}, 75)
+	assert.Equal(t, 75, limit)
+	assert.False(t, clamped, "a limit equal to the cap is not clamped")
+
+	limit, _, clamped = ParseParamsMax(map[string]any{}, 10)
+	assert.Equal(t, 10, limit, "the default limit never exceeds the cap")
+	assert.False(t, clamped)
+
+	limit, _, clamped = ParseParamsMax(map[string]any{"limit": "5000"}, 0)
+	assert.Equal(t, MaxLimit, limit, "a non-positive cap falls back to MaxLimit")
+	assert.True(t, clamped)
+}
+
+func TestWrap(t *testing.T) {
+	out, err := Wrap([]any{"a", "b"}, 5, 0, 2)
+	require.NoError(t, err)
+
+	var resp Response
+	require.NoError(t, json.Unmarshal(out, &resp))
+	assert.Equal(t, []any{"a", "b"}, resp.Data)
+	assert.Equal(t, NewMetadata(5, 0, 2), resp.Pagination)
+	assert.JSONEq(t, `{"data":["a","b"],"pagination":{"total":5,"offset":0,"limit":2,"hasMore":true,"nextOffset":2}}`, string(out))
+}
+
+func TestParseParams(t *testing.T) {
+	tests := []struct {
+		name       string
+		args       any
+		wantLimit  int
+		wantOffset int
+	}{
+		{"defaults", map[string]any{}, DefaultLimit, DefaultOffset},
+		{"not a map", nil, DefaultLimit, DefaultOffset},
+		{"numbers", map[string]any{"limit": float64(20), "offset": float64(40)}, 20, 40},
+		{"strings", map[string]any{"limit": "20", "offset": "40"}, 20, 40},
+		{"limit zero uses default", map[string]any{"limit": "0"}, DefaultLimit, DefaultOffset},
+		{"negative limit uses default", map[string]any{"limit": float64(-5)}, DefaultLimit, DefaultOffset},
+		{"negative offset uses default", map[string]any{"offset": "-10"}, DefaultLimit, DefaultOffset},
+		{"unparseable values use defaults", map[string]any{"limit": "many", "offset": true}, DefaultLimit, DefaultOffset},
+		{"limit clamped", map[string]any{"limit": "5000"}, MaxLimit, DefaultOffset},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			limit, offset := ParseParams(tt.args)
+			assert.Equal(t, tt.wantLimit, limit)
+			assert.Equal(t, tt.wantOffset, offset)
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package querybuilder
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Timestamp placeholders in QueryExample templates; Fill replaces them with
+// unix milliseconds.
+const (
+	StartPlaceholder = "$START_MS"
+	EndPlaceholder   = "$END_MS"
+)
+
+// QueryExample is a ready-to-run Query Builder v5 payload for a common
+// question. Template is valid JSON once Fill has replaced the timestamps.
+type QueryExample struct {
+	Name        string
+	Description string
+	Signal      string
+	// Replace lists the sample values in Template to swap for the user's own.
+	Replace  []string
+	Template string
+}
+
+// Fill returns the example's payload with start and end set.
+func (e QueryExample) Fill(start, end int64) json.RawMessage {
+	r := strings.NewReplacer(StartPlaceholder, strconv.FormatInt(start, 10), EndPlaceholder, strconv.FormatInt(end, 10))
+	return json.RawMessage(r.Replace(e.Template))
+}
+
+// QueryExamples are the canonical payloads served by signoz_get_query_examples.
+// Each one satisfies types.QueryPayload.Validate without auto-healed bounds.
+var QueryExamples = []QueryExample{
+	{
+		Name:        "logs_by_service",
+		Description: "Newest raw log lines for one service, with stable timestamp and id ordering for pagination.",
+		Signal:      "logs",
+		Replace:     []string{"service.name = 'checkout'"},
+		Template: `{
+  "schemaVersion": "v1",
+  "start": $START_MS,
+  "end": $END_MS,
+  "requestType": "raw",
+  "compositeQuery": {
+    "queries": [
+      {
+        "type": "builder_query",
+        "spec": {
+          "name": "A",
+          "signal": "logs",
+          "disabled": false,
+          "limit": 100,
+          "offset": 0,
+          "order": [
+            {"key": {"name": "timestamp"}, "direction": "desc"},
+            {"key": {"name": "id"}, "direction": "desc"}
+          ],
+          "having": {"expression": ""},
+          "filter": {"expression": "service.name = 'checkout'"}
+        }
+      }
+    ]
+  },
+  "formatOptions": {"formatTableResultForUI": false, "fillGaps": false},
+  "variables": {}
+}`,
+	},
+	{
+		Name:        "trace_errors",
+		Description: "Newest error spans for one service with the fields needed to open each trace.",
+		Signal:      "traces",
+		Replace:     []string{"service.name = 'checkout'"},
+		Template: `{
+  "schemaVersion": "v1",
+  "start": $START_MS,
+  "end": $END_MS,
+  "requestType": "raw",
+  "compositeQuery": {
+    "queries": [
+      {
+        "type": "builder_query",
+        "spec": {
+          "name": "A",
+          "signal": "traces",
+          "disabled": false,
+          "limit": 100,
+          "offset": 0,
+          "order": [{"key": {"name": "timestamp"}, "direction": "desc"}],
+          "having": {"expression": ""},
+          "filter": {"expression": "service.name = 'checkout' AND has_error = true"},
+          "selectFields": [
+            {"name": "service.name", "fieldDataType": "string", "signal": "traces", "fieldContext": "resource"},
+            {"name": "name", "fieldDataType": "string", "signal": "traces", "fieldContext": "span"},
+            {"name": "duration_nano", "fieldDataType": "number", "signal": "traces", "fieldContext": "span"},
+            {"name": "status_message", "fieldDataType": "string", "signal": "traces", "fieldContext": "span"},
+            {"name": "trace_id", "fieldDataType": "string", "signal": "traces", "fieldContext": "span"},
+            {"name": "span_id", "fieldDataType": "string", "signal": "traces", "fieldContext": "span"}
+          ]
+        }
+      }
+    ]
+  },
+  "formatOptions": {"formatTableResultForUI": false, "fillGaps": false},
+  "variables": {}
+}`,
+	},
+	{
+		Name:        "metric_rate",
+		Description: "Per-second rate of a cumulative counter metric over time, summed per service.",
+		Signal:      "metrics",
+		Replace:     []string{"http_requests_total", "service_name"},
+		Template: `{
+  "schemaVersion": "v1",
+  "start": $START_MS,
+  "end": $END_MS,
+  "requestType": "time_series",
+  "compositeQuery": {
+    "queries": [
+      {
+        "type": "builder_query",
+        "spec": {
+          "name": "A",
+          "signal": "metrics",
+          "stepInterval": 60,
+          "limit": 100,
+          "order": [{"key": {"name": "__result"}, "direction": "desc"}],
+          "aggregations": [{
+            "metricName": "http_requests_total",
+            "temporality": "cumulative",
+            "timeAggregation": "rate",
+            "spaceAggregation": "sum"
+          }],
+          "groupBy": [{"name": "service_name", "fieldContext": "attribute", "signal": "metrics"}]
+        }
+      }
+    ]
+  },
+  "formatOptions": {"formatTableResultForUI": false, "fillGaps": false},
+  "variables": {}
+}`,
+	},
+	{
+		Name:        "latency_p99",
+		Description: "p99 span latency over time for one service, from trace durations in nanoseconds.",
+		Signal:      "traces",
+		Replace:     []string{"service.name = 'checkout'"},
+		Template: `{
+  "schemaVersion": "v1",
+  "start": $START_MS,
+  "end": $END_MS,
+  "requestType": "time_series",
+  "compositeQuery": {
+    "queries": [
+      {
+        "type": "builder_query",
+        "spec": {
+          "name": "A",
+          "signal": "traces",
+          "disabled": false,
+          "stepInterval": 60,
+          "limit": 100,
+          "offset": 0,
+          "order": [{"key": {"name": "p99(duration_nano)"}, "direction": "desc"}],
+          "having": {"expression": ""},
+          "filter": {"expression": "service.name = 'checkout'"},
+          "aggregations": [{"expression": "p99(duration_nano)"}]
+        }
+      }
+    ]
+  },
+  "formatOptions": {"formatTableResultForUI": false, "fillGaps": false},
+  "variables": {}
+}`,
+	},
+	{
+		Name:        "error_rate_formula",
+		Description: "Span error rate in percent over time: error spans (A) divided by all spans (B), combined by a formula.",
+		Signal:      "traces",
+		Replace:     []string{"service.name = 'checkout'"},
+		Template: `{
+  "schemaVersion": "v1",
+  "start": $START_MS,
+  "end": $END_MS,
+  "requestType": "time_series",
+  "compositeQuery": {
+    "queries": [
+      {
+        "type": "builder_query",
+        "spec": {
+          "name": "A",
+          "signal": "traces",
+          "disabled": false,
+          "stepInterval": 60,
+          "limit": 10000,
+          "offset": 0,
+          "order": [{"key": {"name": "count()"}, "direction": "desc"}],
+          "having": {"expression": ""},
+          "filter": {"expression": "service.name = 'checkout' AND has_error = true"},
+          "aggregations": [{"expression": "count()"}]
+        }
+      },
+      {
+        "type": "builder_query",
+        "spec": {
+          "name": "B",
+          "signal": "traces",
+          "disabled": false,
+          "stepInterval": 60,
+          "limit": 10000,
+          "offset": 0,
+          "order": [{"key": {"name": "count()"}, "direction": "desc"}],
+          "having": {"expression": ""},
+          "filter": {"expression": "service.name = 'checkout'"},
+          "aggregations": [{"expression": "count()"}]
+        }
+      },
+      {
+        "type": "builder_formula",
+        "spec": {
+          "name": "F1",
+          "expression": "A * 100 / B",
+          "legend": "error_rate_%",
+          "limit": 100,
+          "order": [{"key": {"name": "__result"}, "direction": "desc"}]
+        }
+      }
+    ]
+  },
+  "formatOptions": {"formatTableResultForUI": false, "fillGaps": false},
+  "variables": {}
+}`,
+	},
+}
+
+// QueryExampleNames returns the QueryExamples names in order.
+func QueryExampleNames() []string {
+	names := make([]string, len(QueryExamples))
+	for i, example := range QueryExamples {
+		names[i] = example.Name
+	}
+	return names
+}
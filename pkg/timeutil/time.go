@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -54,6 +55,27 @@ func ParseTimeRange(timeRange string) (time.Duration, error) {
 	return 0, fmt.Errorf("invalid time range format: use formats like '2h', '30m', '2d', '7d'")
 }
 
+// ParseDurationNanos parses a span duration such as "500ms", "1.5s" or
+// "250us" into nanoseconds. A bare integer is taken as nanoseconds, matching
+// the duration_nano field. Negative durations are rejected.
+func ParseDurationNanos(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		if n < 0 {
+			return 0, fmt.Errorf("duration %q must not be negative", s)
+		}
+		return n, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q: use nanoseconds or a value with a unit like '500ms', '1.5s', '250us'", s)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration %q must not be negative", s)
+	}
+	return d.Nanoseconds(), nil
+}
+
 // normalizeEpochToUnit takes a raw positive epoch integer, auto-detects whether
 // it is expressed in seconds / millis / micros / nanos by magnitude, and
 // converts it DIRECTLY to the requested canonical unit ("ms" or "ns").
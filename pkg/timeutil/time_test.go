@@ -318,3 +318,30 @@ func TestValidateExplicitTimestamps(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDurationNanos(t *testing.T) {
+	cases := map[string]int64{
+		"500ms":     500_000_000,
+		"1s":        1_000_000_000,
+		"1.5s":      1_500_000_000,
+		"250us":     250_000,
+		"250µs":     250_000,
+		"500000000": 500_000_000,
+		" 2s ":      2_000_000_000,
+	}
+	for in, want := range cases {
+		got, err := ParseDurationNanos(in)
+		if err != nil {
+			t.Fatalf("ParseDurationNanos(%q): %v", in, err)
+		}
+		if got != want {
+			t.Fatalf("ParseDurationNanos(%q) = %d, want %d", in, got, want)
+		}
+	}
+
+	for _, in := range []string{"", "fast", "-1", "-5ms", "500 ms"} {
+		if _, err := ParseDurationNanos(in); err == nil {
+			t.Fatalf("ParseDurationNanos(%q) = nil error, want error", in)
+		}
+	}
+}
@@ -48,6 +48,22 @@ var knownCodes = map[string]struct{}{
 	CodeIndexNotReady:      {},
 }
 
+// retryableCodes are transient conditions where repeating the identical
+// call later can succeed. Everything else needs a changed request or user
+// action first.
+var retryableCodes = map[string]struct{}{
+	CodeRateLimited:   {},
+	CodeTimeout:       {},
+	CodeIndexNotReady: {},
+}
+
+// Retryable reports whether a result with this code is worth retrying
+// unchanged.
+func Retryable(code string) bool {
+	_, ok := retryableCodes[code]
+	return ok
+}
+
 // Code extracts a known structured code from an MCP tool error result.
 func Code(result *mcp.CallToolResult) string {
 	if result == nil || !result.IsError {
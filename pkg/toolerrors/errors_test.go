@@ -35,3 +35,16 @@ func TestCode(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryable(t *testing.T) {
+	for _, code := range []string{CodeRateLimited, CodeTimeout, CodeIndexNotReady} {
+		if !Retryable(code) {
+			t.Errorf("Retryable(%q) = false, want true", code)
+		}
+	}
+	for _, code := range []string{CodeValidationFailed, CodeUnauthorized, CodeUpstreamError, CodeInternalError, ""} {
+		if Retryable(code) {
+			t.Errorf("Retryable(%q) = true, want false", code)
+		}
+	}
+}
@@ -51,13 +51,11 @@ type Alert struct {
 	StartsAt  string `json:"startsAt"`
 	EndsAt    string `json:"endsAt"`
 	State     string `json:"state"`
-	WebURL    string `json:"webUrl,omitempty"`
-}
-
-type APIAlertLabels struct {
-	Alertname string `json:"alertname"`
-	RuleID    string `json:"ruleId"`
-	Severity  string `json:"severity"`
+	// Labels holds the alert's remaining labels (e.g. service.name, env);
+	// alertname, ruleId, and severity are promoted to the fields above.
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	WebURL      string            `json:"webUrl,omitempty"`
 }
 
 type APIAlertStatus struct {
@@ -65,10 +63,42 @@ type APIAlertStatus struct {
 }
 
 type APIAlert struct {
-	Labels   APIAlertLabels `json:"labels"`
-	Status   APIAlertStatus `json:"status"`
-	StartsAt string         `json:"startsAt"`
-	EndsAt   string         `json:"endsAt"`
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	Status      APIAlertStatus    `json:"status"`
+	StartsAt    string            `json:"startsAt"`
+	EndsAt      string            `json:"endsAt"`
+}
+
+// Alert flattens an Alertmanager alert into the tool's Alert shape. webURL is
+// the alert's deep link, or "" when none is available.
+func (a APIAlert) Alert(webURL string) Alert {
+	var labels map[string]string
+	for k, v := range a.Labels {
+		switch k {
+		case "alertname", "ruleId", "severity":
+			continue
+		}
+		if labels == nil {
+			labels = make(map[string]string, len(a.Labels))
+		}
+		labels[k] = v
+	}
+	var annotations map[string]string
+	if len(a.Annotations) > 0 {
+		annotations = a.Annotations
+	}
+	return Alert{
+		Alertname:   a.Labels["alertname"],
+		RuleID:      a.Labels["ruleId"],
+		Severity:    a.Labels["severity"],
+		StartsAt:    a.StartsAt,
+		EndsAt:      a.EndsAt,
+		State:       a.Status.State,
+		Labels:      labels,
+		Annotations: annotations,
+		WebURL:      webURL,
+	}
 }
 
 type APIAlertsResponse struct {
@@ -90,6 +120,9 @@ type AlertRuleSummary struct {
 	CreatedAt   string            `json:"createdAt,omitempty"`
 	UpdatedAt   string            `json:"updatedAt,omitempty"`
 	WebURL      string            `json:"webUrl,omitempty"`
+	// LastFiredAt is the unix-millisecond time of the rule's newest firing
+	// within the requested window; it is set only when a window is given.
+	LastFiredAt int64 `json:"lastFiredAt,omitempty"`
 }
 
 // APIAlertRule mirrors the compact fields used from GET /api/v2/rules.
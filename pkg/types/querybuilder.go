@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"slices"
 	"strconv"
 	"strings"
 )
@@ -13,6 +14,7 @@ const (
 	DefaultAggregateQueryLimit    = 100
 	MaxQueryLimit                 = 10000
 	DefaultFormulaInputQueryLimit = MaxQueryLimit
+	DefaultTraceDetailsLimit      = 1000
 )
 
 // QueryPayload is struct used as payload the Query Builder v5 JSON schema
@@ -26,6 +28,9 @@ type QueryPayload struct {
 	Variables      map[string]any       `json:"variables"`
 	NoCache        bool                 `json:"noCache,omitempty"`
 	AppliedBounds  []AppliedQueryBounds `json:"-"`
+	// RequestTypeInferred reports that Validate chose RequestType because the
+	// caller left it empty.
+	RequestTypeInferred bool `json:"-"`
 }
 
 // AppliedQueryBounds records defaults injected during validation so raw Query
@@ -288,18 +293,28 @@ type QueryAggregation struct {
 // if there is an error LLM checks the error and fix.
 func (q *QueryPayload) Validate() error {
 	q.AppliedBounds = nil
+	q.RequestTypeInferred = false
 	if q.SchemaVersion == "" {
 		q.SchemaVersion = "v1"
 	}
 
-	if q.Start == 0 || q.End == 0 {
-		return fmt.Errorf("missing start or end timestamp")
+	if q.Start == 0 {
+		return fmt.Errorf("start is required: provide the range start as unix milliseconds")
+	}
+	if q.End == 0 {
+		return fmt.Errorf("end is required: provide the range end as unix milliseconds")
+	}
+	if q.Start >= q.End {
+		return fmt.Errorf("start (%d) must be before end (%d); both are unix milliseconds", q.Start, q.End)
 	}
 	if len(q.CompositeQuery.Queries) == 0 {
 		return fmt.Errorf("missing or empty compositeQuery.queries")
 	}
 	if q.RequestType == "" {
 		q.RequestType = inferDefaultRequestType(q.CompositeQuery.Queries)
+		q.RequestTypeInferred = true
+	} else if !slices.Contains(validRequestTypes, q.RequestType) {
+		return fmt.Errorf("requestType %q is not supported; use one of %s", q.RequestType, strings.Join(validRequestTypes, ", "))
 	}
 
 	for i, query := range q.CompositeQuery.Queries {
@@ -357,6 +372,9 @@ func (q *QueryPayload) Validate() error {
 		}
 		signal := spec.Signal
 		queryName := queryDisplayName(spec.Name, i)
+		if strings.TrimSpace(spec.Name) == "" {
+			return fmt.Errorf(`%s: compositeQuery.queries[%d].spec.name is required for builder_query; use a unique name such as "A"`, queryName, i)
+		}
 
 		switch signal {
 		case "metrics":
@@ -374,7 +392,14 @@ func (q *QueryPayload) Validate() error {
 			// Traces support both raw queries and time series aggregations.
 			// Don't force requestType=raw, since that breaks aggregation queries.
 			switch q.RequestType {
-			case "raw", "trace":
+			case "raw":
+				spec.StepInterval = nil
+				// A disabled query returns no rows of its own; it is, for
+				// example, a span-filter input to a builder_trace_operator.
+				if !spec.Disabled && len(spec.SelectFields) == 0 {
+					return fmt.Errorf(`%s: compositeQuery.queries[%d].spec.selectFields is required for raw traces queries; list the span fields to return, e.g. [{"name": "name", "fieldContext": "span"}]. See signoz://traces/query-builder-guide`, queryName, i)
+				}
+			case "trace":
 				spec.StepInterval = nil
 			case "scalar":
 				spec.StepInterval = nil
@@ -409,7 +434,7 @@ func (q *QueryPayload) Validate() error {
 			}
 
 		default:
-			return fmt.Errorf("%s: unknown signal type '%s'", queryName, signal)
+			return fmt.Errorf(`%s: compositeQuery.queries[%d].spec.signal received %q; use "traces", "logs", or "metrics"`, queryName, i, signal)
 		}
 
 		q.CompositeQuery.Queries[i].Spec = spec
@@ -418,18 +443,37 @@ func (q *QueryPayload) Validate() error {
 	return q.ApplyBuilderBounds()
 }
 
+// validRequestTypes are the Query Builder v5 request types.
+var validRequestTypes = []string{"raw", "raw_stream", "time_series", "scalar", "trace", "distribution"}
+
+// inferDefaultRequestType picks a requestType for a payload that omits one:
+// PromQL, formulas, and metrics need "time_series"; a logs or traces query
+// with aggregations gets "time_series" when it sets stepInterval and
+// "scalar" (one row per group) otherwise; anything else lists rows with "raw".
 func inferDefaultRequestType(queries []Query) string {
+	inferred := "raw"
 	for _, query := range queries {
 		switch query.Type {
 		case "promql", "builder_formula":
 			return "time_series"
 		case "builder_query":
-			if spec, ok := query.Spec.(QuerySpec); ok && spec.Signal == "metrics" {
+			spec, ok := query.Spec.(QuerySpec)
+			if !ok {
+				continue
+			}
+			if spec.Signal == "metrics" {
+				return "time_series"
+			}
+			if len(spec.Aggregations) == 0 {
+				continue
+			}
+			if spec.StepInterval != nil {
 				return "time_series"
 			}
+			inferred = "scalar"
 		}
 	}
-	return "raw"
+	return inferred
 }
 
 // ApplyBuilderBounds normalizes and validates limit/order fields without
@@ -724,8 +768,33 @@ func guideForSignal(signal string) string {
 	}
 }
 
+// RawQueryOptions adjusts the raw logs and traces queries built by
+// BuildLogsQueryPayloadWithOptions and BuildTracesQueryPayloadWithOptions.
+// Zero values keep each builder's defaults.
+type RawQueryOptions struct {
+	// Order replaces the default newest-first row order.
+	Order []Order
+	// SelectFields replaces the default columns: every column for logs, and
+	// the span and resource fields of traceSelectFields for traces.
+	SelectFields []SelectField
+}
+
 // BuildLogsQueryPayload creates a QueryPayload for logs queries
 func BuildLogsQueryPayload(startTime, endTime int64, filterExpression string, limit int, offset int) *QueryPayload {
+	return BuildLogsQueryPayloadWithOptions(startTime, endTime, filterExpression, limit, offset, RawQueryOptions{})
+}
+
+// BuildLogsQueryPayloadWithOptions is BuildLogsQueryPayload with the row
+// order and columns taken from opts. The default order, timestamp then id
+// descending, keeps offset pagination stable.
+func BuildLogsQueryPayloadWithOptions(startTime, endTime int64, filterExpression string, limit int, offset int, opts RawQueryOptions) *QueryPayload {
+	order := opts.Order
+	if len(order) == 0 {
+		order = []Order{
+			{Key: Key{Name: "timestamp"}, Direction: "desc"},
+			{Key: Key{Name: "id"}, Direction: "desc"},
+		}
+	}
 	return &QueryPayload{
 		SchemaVersion: "v1",
 		Start:         startTime,
@@ -736,17 +805,15 @@ func BuildLogsQueryPayload(startTime, endTime int64, filterExpression string, li
 				{
 					Type: "builder_query",
 					Spec: QuerySpec{
-						Name:     "A",
-						Signal:   "logs",
-						Disabled: false,
-						Filter:   &Filter{Expression: filterExpression},
-						Limit:    limit,
-						Offset:   offset,
-						Order: []Order{
-							{Key: Key{Name: "timestamp"}, Direction: "desc"},
-							{Key: Key{Name: "id"}, Direction: "desc"},
-						},
-						Having: Having{Expression: ""},
+						Name:         "A",
+						Signal:       "logs",
+						Disabled:     false,
+						Filter:       &Filter{Expression: filterExpression},
+						Limit:        limit,
+						Offset:       offset,
+						Order:        order,
+						Having:       Having{Expression: ""},
+						SelectFields: opts.SelectFields,
 					},
 				},
 			},
@@ -923,7 +990,32 @@ func BuildMetricsQueryPayloadJSON(startTime, endTime, stepInterval int64, querie
 	return json.Marshal(payload)
 }
 
-func BuildTracesQueryPayload(startTime, endTime int64, filterExpression string, limit int, offset int) *QueryPayload {
+// BuildTracesQueryPayload builds a raw span query. orderField and orderDir
+// default to "timestamp" and "desc" when empty.
+func BuildTracesQueryPayload(startTime, endTime int64, filterExpression string, limit int, offset int, orderField, orderDir string) *QueryPayload {
+	if orderField == "" {
+		orderField = "timestamp"
+	}
+	if orderDir == "" {
+		orderDir = "desc"
+	}
+	return BuildTracesQueryPayloadWithOptions(startTime, endTime, filterExpression, limit, offset, RawQueryOptions{
+		Order: []Order{{Key: Key{Name: orderField}, Direction: orderDir}},
+	})
+}
+
+// BuildTracesQueryPayloadWithOptions is BuildTracesQueryPayload with the row
+// order and columns taken from opts. Order defaults to timestamp descending
+// and SelectFields to traceSelectFields.
+func BuildTracesQueryPayloadWithOptions(startTime, endTime int64, filterExpression string, limit int, offset int, opts RawQueryOptions) *QueryPayload {
+	order := opts.Order
+	if len(order) == 0 {
+		order = []Order{{Key: Key{Name: "timestamp"}, Direction: "desc"}}
+	}
+	selectFields := opts.SelectFields
+	if len(selectFields) == 0 {
+		selectFields = traceSelectFields()
+	}
 	return &QueryPayload{
 		SchemaVersion: "v1",
 		Start:         startTime,
@@ -934,70 +1026,15 @@ func BuildTracesQueryPayload(startTime, endTime int64, filterExpression string,
 				{
 					Type: "builder_query",
 					Spec: QuerySpec{
-						Name:     "A",
-						Signal:   "traces",
-						Disabled: false,
-						Filter:   &Filter{Expression: filterExpression},
-						Limit:    limit,
-						Offset:   offset,
-						Order: []Order{
-							{Key: Key{Name: "timestamp"}, Direction: "desc"},
-						},
-						Having: Having{Expression: ""},
-						SelectFields: []SelectField{
-							// Top-level span fields
-							{Name: "trace_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "span_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "parent_span_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "name", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "duration_nano", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
-							{Name: "timestamp", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
-							{Name: "has_error", FieldDataType: "bool", Signal: "traces", FieldContext: "span"},
-							{Name: "status_code", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
-							{Name: "status_code_string", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "http_method", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "http_url", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "kind_string", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "kind", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
-							{Name: "response_status_code", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							{Name: "status_message", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
-							// Resource attributes
-							{Name: "service.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "cloud.account.id", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "cloud.platform", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "cloud.provider", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "cloud.region", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "deployment.environment", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "host.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.cluster.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.namespace.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.node.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.pod.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.pod.start_time", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.pod.uid", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "k8s.statefulset.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "service.version", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "signoz.deployment.tier", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "signoz.workload", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-							{Name: "signoz.workspace.key.id", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
-
-							// Span attributes
-							{Name: "client.address", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "http.request.method", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "http.response.body.size", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "http.response.status_code", FieldDataType: "number", Signal: "traces", FieldContext: "tag"},
-							{Name: "http.route", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "rpc.method", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "network.peer.address", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "network.peer.port", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "network.protocol.version", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "server.address", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "url.path", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "url.scheme", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "db.operation", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "db.statement", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-							{Name: "db.system", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
-						},
+						Name:         "A",
+						Signal:       "traces",
+						Disabled:     false,
+						Filter:       &Filter{Expression: filterExpression},
+						Limit:        limit,
+						Offset:       offset,
+						Order:        order,
+						Having:       Having{Expression: ""},
+						SelectFields: selectFields,
 					},
 				},
 			},
@@ -1009,3 +1046,126 @@ func BuildTracesQueryPayload(startTime, endTime int64, filterExpression string,
 		Variables: map[string]any{},
 	}
 }
+
+// TraceOperatorSpec is the spec of a builder_trace_operator query. Expression
+// relates the named trace builder queries (e.g. "A => B"), and the result is
+// the spans of ReturnSpansFrom in traces that satisfy it.
+type TraceOperatorSpec struct {
+	Name            string        `json:"name"`
+	Disabled        bool          `json:"disabled"`
+	Expression      string        `json:"expression"`
+	ReturnSpansFrom string        `json:"returnSpansFrom,omitempty"`
+	Limit           int           `json:"limit"`
+	Offset          int           `json:"offset"`
+	Order           []Order       `json:"order"`
+	SelectFields    []SelectField `json:"selectFields"`
+}
+
+// TraceOperatorQueryName is the name of the trace operator query built by
+// BuildTraceOperatorQueryPayload; its rows are the only ones returned.
+const TraceOperatorQueryName = "T1"
+
+// BuildTraceOperatorQueryPayload builds a raw trace operator query. Each span
+// filter becomes a disabled traces builder query named A, B, C, ... in order,
+// which expression references; only the operator's spans are returned.
+func BuildTraceOperatorQueryPayload(startTime, endTime int64, spanFilters []string, expression, returnSpansFrom string, limit int, offset int) *QueryPayload {
+	queries := make([]Query, 0, len(spanFilters)+1)
+	for i, filter := range spanFilters {
+		queries = append(queries, Query{
+			Type: "builder_query",
+			Spec: QuerySpec{
+				Name:         string(rune('A' + i)),
+				Signal:       "traces",
+				Disabled:     true,
+				Filter:       &Filter{Expression: filter},
+				Order:        []Order{{Key: Key{Name: "timestamp"}, Direction: "desc"}},
+				Having:       Having{Expression: ""},
+				SelectFields: []SelectField{},
+			},
+		})
+	}
+	queries = append(queries, Query{
+		Type: "builder_trace_operator",
+		Spec: TraceOperatorSpec{
+			Name:            TraceOperatorQueryName,
+			Expression:      expression,
+			ReturnSpansFrom: returnSpansFrom,
+			Limit:           limit,
+			Offset:          offset,
+			Order:           []Order{{Key: Key{Name: "timestamp"}, Direction: "desc"}},
+			SelectFields:    traceSelectFields(),
+		},
+	})
+
+	return &QueryPayload{
+		SchemaVersion:  "v1",
+		Start:          startTime,
+		End:            endTime,
+		RequestType:    "raw",
+		CompositeQuery: CompositeQuery{Queries: queries},
+		FormatOptions: FormatOptions{
+			FormatTableResultForUI: false,
+			FillGaps:               false,
+		},
+		Variables: map[string]any{},
+	}
+}
+
+// traceSelectFields is the column set raw trace searches return: the span
+// fields plus the resource and span attributes most workspaces populate.
+func traceSelectFields() []SelectField {
+	return []SelectField{
+		// Top-level span fields
+		{Name: "trace_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "span_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "parent_span_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "name", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "duration_nano", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
+		{Name: "timestamp", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
+		{Name: "has_error", FieldDataType: "bool", Signal: "traces", FieldContext: "span"},
+		{Name: "status_code", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
+		{Name: "status_code_string", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "http_method", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "http_url", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "kind_string", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "kind", FieldDataType: "number", Signal: "traces", FieldContext: "span"},
+		{Name: "response_status_code", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		{Name: "status_message", FieldDataType: "string", Signal: "traces", FieldContext: "span"},
+		// Resource attributes
+		{Name: "service.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "cloud.account.id", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "cloud.platform", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "cloud.provider", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "cloud.region", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "deployment.environment", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "host.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.cluster.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.namespace.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.node.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.pod.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.pod.start_time", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.pod.uid", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "k8s.statefulset.name", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "service.version", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "signoz.deployment.tier", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "signoz.workload", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+		{Name: "signoz.workspace.key.id", FieldDataType: "string", Signal: "traces", FieldContext: "resource"},
+
+		// Span attributes
+		{Name: "client.address", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "http.request.method", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "http.response.body.size", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "http.response.status_code", FieldDataType: "number", Signal: "traces", FieldContext: "tag"},
+		{Name: "http.route", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "rpc.method", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "network.peer.address", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "network.peer.port", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "network.protocol.version", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "server.address", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "url.path", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "url.scheme", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "db.operation", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "db.statement", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+		{Name: "db.system", FieldDataType: "string", Signal: "traces", FieldContext: "tag"},
+	}
+}
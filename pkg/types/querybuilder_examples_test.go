@@ -2,6 +2,7 @@ package types
 
 import (
 	"encoding/json"
+	"fmt"
 	"regexp"
 	"strings"
 	"testing"
@@ -33,28 +34,53 @@ func TestQueryBuilderGuideExamplesUseExecutableBoundsContract(t *testing.T) {
 			examples := tc.pattern.FindAllStringSubmatch(tc.guide, -1)
 			require.Len(t, examples, tc.wantCount, "guide JSON example count changed; update this executable contract test")
 			for index, match := range examples {
-				var payload QueryPayload
-				require.NoErrorf(t, json.Unmarshal([]byte(match[1]), &payload), "example %d is invalid JSON", index+1)
-				require.NoErrorf(t, payload.Validate(), "example %d does not satisfy QueryPayload.Validate", index+1)
-				require.Emptyf(t, payload.AppliedBounds, "example %d omitted explicit limit/order and was auto-healed by validation", index+1)
-				formulaInputs := formulaInputQueryNames(payload.CompositeQuery.Queries)
-				for queryIndex, query := range payload.CompositeQuery.Queries {
-					switch spec := query.Spec.(type) {
-					case QuerySpec:
-						wantLimit := defaultLimitForRequestType(payload.RequestType)
-						if formulaInputs[strings.ToLower(strings.TrimSpace(spec.Name))] {
-							wantLimit = DefaultFormulaInputQueryLimit
-						}
-						require.Equalf(t, wantLimit, spec.Limit, "example %d query %d should teach the request-type default limit", index+1, queryIndex+1)
-						wantOrder, err := defaultOrderForQuery(spec, payload.RequestType, queryIndex)
-						require.NoErrorf(t, err, "example %d query %d default order", index+1, queryIndex+1)
-						require.Equalf(t, wantOrder, spec.Order, "example %d query %d should teach the signal-specific default order", index+1, queryIndex+1)
-					case FormulaSpec:
-						require.Equalf(t, DefaultAggregateQueryLimit, spec.Limit, "example %d query %d formula default limit", index+1, queryIndex+1)
-						require.Equalf(t, resultDescendingOrder(), spec.Order, "example %d query %d formula default order", index+1, queryIndex+1)
-					}
-				}
+				requireExecutableExample(t, fmt.Sprintf("example %d", index+1), []byte(match[1]))
 			}
 		})
 	}
 }
+
+func TestQueryExamplesUseExecutableBoundsContract(t *testing.T) {
+	require.NotEmpty(t, querybuilder.QueryExamples)
+	for _, example := range querybuilder.QueryExamples {
+		t.Run(example.Name, func(t *testing.T) {
+			raw := example.Fill(1756386047000, 1756387847000)
+			requireExecutableExample(t, example.Name, raw)
+			var payload QueryPayload
+			require.NoError(t, json.Unmarshal(raw, &payload))
+			require.Equal(t, int64(1756386047000), payload.Start)
+			require.Equal(t, int64(1756387847000), payload.End)
+			for _, value := range example.Replace {
+				require.Contains(t, example.Template, value, "replace hint must appear in the template")
+			}
+		})
+	}
+}
+
+// requireExecutableExample checks that a documented payload validates as is,
+// spelling out the request-type default limits and signal-specific orders
+// instead of relying on the server to fill them in.
+func requireExecutableExample(t *testing.T, label string, raw []byte) {
+	t.Helper()
+	var payload QueryPayload
+	require.NoErrorf(t, json.Unmarshal(raw, &payload), "%s is invalid JSON", label)
+	require.NoErrorf(t, payload.Validate(), "%s does not satisfy QueryPayload.Validate", label)
+	require.Emptyf(t, payload.AppliedBounds, "%s omitted explicit limit/order and was auto-healed by validation", label)
+	formulaInputs := formulaInputQueryNames(payload.CompositeQuery.Queries)
+	for queryIndex, query := range payload.CompositeQuery.Queries {
+		switch spec := query.Spec.(type) {
+		case QuerySpec:
+			wantLimit := defaultLimitForRequestType(payload.RequestType)
+			if formulaInputs[strings.ToLower(strings.TrimSpace(spec.Name))] {
+				wantLimit = DefaultFormulaInputQueryLimit
+			}
+			require.Equalf(t, wantLimit, spec.Limit, "%s query %d should teach the request-type default limit", label, queryIndex+1)
+			wantOrder, err := defaultOrderForQuery(spec, payload.RequestType, queryIndex)
+			require.NoErrorf(t, err, "%s query %d default order", label, queryIndex+1)
+			require.Equalf(t, wantOrder, spec.Order, "%s query %d should teach the signal-specific default order", label, queryIndex+1)
+		case FormulaSpec:
+			require.Equalf(t, DefaultAggregateQueryLimit, spec.Limit, "%s query %d formula default limit", label, queryIndex+1)
+			require.Equalf(t, resultDescendingOrder(), spec.Order, "%s query %d formula default order", label, queryIndex+1)
+		}
+	}
+}
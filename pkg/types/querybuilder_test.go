@@ -341,6 +341,42 @@ func TestQueryPayloadValidate_PromQLDefaultsRequestType(t *testing.T) {
 	require.Equal(t, "time_series", q.RequestType)
 }
 
+func TestQueryPayloadValidate_InfersRequestType(t *testing.T) {
+	count := []any{map[string]any{"expression": "count()"}}
+	tests := []struct {
+		name    string
+		queries []Query
+		want    string
+	}{
+		{"logs rows", []Query{{Type: "builder_query", Spec: QuerySpec{Name: "A", Signal: "logs"}}}, "raw"},
+		{"logs aggregation without step", []Query{{Type: "builder_query", Spec: QuerySpec{Name: "A", Signal: "logs", Aggregations: count,
+			GroupBy: []SelectField{{Name: "service.name"}}}}}, "scalar"},
+		{"traces aggregation with step", []Query{{Type: "builder_query", Spec: QuerySpec{Name: "A", Signal: "traces", Aggregations: count,
+			StepInterval: int64ptr(60)}}}, "time_series"},
+		{"metrics", []Query{{Type: "builder_query", Spec: QuerySpec{Name: "A", Signal: "metrics",
+			Aggregations: []any{map[string]any{"metricName": "cpu"}}}}}, "time_series"},
+		{"formula over scalar inputs", []Query{
+			{Type: "builder_query", Spec: QuerySpec{Name: "A", Signal: "logs", Aggregations: count}},
+			{Type: "builder_formula", Spec: FormulaSpec{Name: "F1", Expression: "A * 2"}},
+		}, "time_series"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			q := &QueryPayload{Start: 1, End: 2, CompositeQuery: CompositeQuery{Queries: tc.queries}}
+			require.NoError(t, q.Validate())
+			require.Equal(t, tc.want, q.RequestType)
+			require.True(t, q.RequestTypeInferred)
+		})
+	}
+
+	explicit := &QueryPayload{Start: 1, End: 2, RequestType: "time_series", CompositeQuery: CompositeQuery{Queries: []Query{
+		{Type: "builder_query", Spec: QuerySpec{Name: "A", Signal: "logs", Aggregations: count}},
+	}}}
+	require.NoError(t, explicit.Validate())
+	require.Equal(t, "time_series", explicit.RequestType, "an explicit requestType is kept")
+	require.False(t, explicit.RequestTypeInferred)
+}
+
 func TestQueryPayloadRoundTrip_MixedBuilderAndPromQL(t *testing.T) {
 	input := `{
 		"schemaVersion":"v1",
@@ -503,7 +539,7 @@ func TestQueryPayloadValidate_DefaultsBoundsByRequestType(t *testing.T) {
 		{
 			name:        "raw traces",
 			requestType: "raw",
-			spec:        QuerySpec{Name: "A", Signal: "traces"},
+			spec:        QuerySpec{Name: "A", Signal: "traces", SelectFields: traceSelectFields()},
 			wantLimit:   DefaultRawQueryLimit,
 			wantOrder:   []Order{{Key: Key{Name: "timestamp"}, Direction: "desc"}},
 		},
@@ -790,6 +826,70 @@ func TestQuerySpecUnmarshal_NormalizesIntegerStringsAndRejectsInvalidShapes(t *t
 	}
 }
 
+func TestQueryPayloadValidate_FieldSpecificErrors(t *testing.T) {
+	logsQuery := func(spec QuerySpec) []Query { return []Query{{Type: "builder_query", Spec: spec}} }
+	tests := []struct {
+		name    string
+		payload QueryPayload
+		want    string
+	}{
+		{
+			name:    "missing start",
+			payload: QueryPayload{End: 2, CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: "A", Signal: "logs"})}},
+			want:    "start is required",
+		},
+		{
+			name:    "missing end",
+			payload: QueryPayload{Start: 1, CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: "A", Signal: "logs"})}},
+			want:    "end is required",
+		},
+		{
+			name:    "start not before end",
+			payload: QueryPayload{Start: 5, End: 5, CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: "A", Signal: "logs"})}},
+			want:    "start (5) must be before end (5)",
+		},
+		{
+			name:    "unknown request type",
+			payload: QueryPayload{Start: 1, End: 2, RequestType: "table", CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: "A", Signal: "logs"})}},
+			want:    `requestType "table" is not supported; use one of raw, raw_stream, time_series, scalar, trace, distribution`,
+		},
+		{
+			name:    "missing builder query name",
+			payload: QueryPayload{Start: 1, End: 2, RequestType: "raw", CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: " ", Signal: "logs"})}},
+			want:    "compositeQuery.queries[0].spec.name is required for builder_query",
+		},
+		{
+			name:    "unknown signal",
+			payload: QueryPayload{Start: 1, End: 2, RequestType: "raw", CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: "A", Signal: "spans"})}},
+			want:    `compositeQuery.queries[0].spec.signal received "spans"; use "traces", "logs", or "metrics"`,
+		},
+		{
+			name:    "raw traces without selectFields",
+			payload: QueryPayload{Start: 1, End: 2, RequestType: "raw", CompositeQuery: CompositeQuery{Queries: logsQuery(QuerySpec{Name: "A", Signal: "traces"})}},
+			want:    "compositeQuery.queries[0].spec.selectFields is required for raw traces queries",
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.payload.Validate()
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tc.want)
+		})
+	}
+}
+
+func TestBuildTraceOperatorQueryPayload_PassesValidate(t *testing.T) {
+	payload := BuildTraceOperatorQueryPayload(1, 2, []string{"service.name = 'frontend'", "service.name = 'cart'"}, "A => B", "B", 10, 0)
+	require.NoError(t, payload.Validate())
+
+	// The same payload sent by a user through signoz_execute_builder_query.
+	raw, err := json.Marshal(payload)
+	require.NoError(t, err)
+	var decoded QueryPayload
+	require.NoError(t, json.Unmarshal(raw, &decoded))
+	require.NoError(t, decoded.Validate())
+}
+
 func TestQueryPayloadValidate_NegativeLimitHasRecoveryGuidance(t *testing.T) {
 	payload := QueryPayload{
 		Start:       1,
@@ -816,6 +916,26 @@ func TestBuildLogsQueryPayload_UsesStablePaginationOrder(t *testing.T) {
 	}, spec.Order)
 }
 
+func TestBuildLogsQueryPayloadWithOptions(t *testing.T) {
+	order := []Order{{Key: Key{Name: "timestamp"}, Direction: "asc"}}
+	fields := []SelectField{{Name: "body", Signal: "logs", FieldContext: "log"}}
+	payload := BuildLogsQueryPayloadWithOptions(1, 2, "", DefaultRawQueryLimit, 0, RawQueryOptions{Order: order, SelectFields: fields})
+	spec := payload.CompositeQuery.Queries[0].Spec.(QuerySpec)
+	require.Equal(t, order, spec.Order)
+	require.Equal(t, fields, spec.SelectFields)
+	require.NoError(t, payload.Validate())
+}
+
+func TestBuildTracesQueryPayloadWithOptions(t *testing.T) {
+	spec := BuildTracesQueryPayloadWithOptions(1, 2, "", 10, 0, RawQueryOptions{}).CompositeQuery.Queries[0].Spec.(QuerySpec)
+	require.Equal(t, []Order{{Key: Key{Name: "timestamp"}, Direction: "desc"}}, spec.Order)
+	require.Equal(t, traceSelectFields(), spec.SelectFields)
+
+	fields := []SelectField{{Name: "trace_id", FieldDataType: "string", Signal: "traces", FieldContext: "span"}}
+	spec = BuildTracesQueryPayloadWithOptions(1, 2, "", 10, 0, RawQueryOptions{SelectFields: fields}).CompositeQuery.Queries[0].Spec.(QuerySpec)
+	require.Equal(t, fields, spec.SelectFields)
+}
+
 // jsonString JSON-encodes s and returns the result as a Go string (including
 // the surrounding double quotes).
 func jsonString(s string) string {
@@ -859,7 +979,7 @@ func TestQueryPayloadValidate_LogsTimeSeriesRequiresAggregations(t *testing.T) {
 // the traces payload hardcoded Offset:0 and ignored the caller's offset, making
 // signoz_search_traces pagination a silent no-op.
 func TestBuildTracesQueryPayload_PropagatesOffset(t *testing.T) {
-	payload := BuildTracesQueryPayload(1000, 2000, "service.name = 'x'", 50, 25)
+	payload := BuildTracesQueryPayload(1000, 2000, "service.name = 'x'", 50, 25, "", "")
 	spec, ok := payload.CompositeQuery.Queries[0].Spec.(QuerySpec)
 	require.True(t, ok, "expected QuerySpec, got %T", payload.CompositeQuery.Queries[0].Spec)
 	require.Equal(t, 50, spec.Limit)
@@ -867,7 +987,7 @@ func TestBuildTracesQueryPayload_PropagatesOffset(t *testing.T) {
 }
 
 func TestBuildTracesQueryPayload_UsesCanonicalTraceFields(t *testing.T) {
-	payload := BuildTracesQueryPayload(1000, 2000, "service.name = 'x'", 50, 0)
+	payload := BuildTracesQueryPayload(1000, 2000, "service.name = 'x'", 50, 0, "", "")
 	spec, ok := payload.CompositeQuery.Queries[0].Spec.(QuerySpec)
 	require.True(t, ok, "expected QuerySpec, got %T", payload.CompositeQuery.Queries[0].Spec)
 
@@ -0,0 +1,13 @@
+package types
+
+import "github.com/SigNoz/signoz-mcp-server/pkg/paginate"
+
+// ToolResponse is the shared JSON envelope for code-controlled tool output.
+// Data is always present; the remaining fields are omitted when unset so a
+// plain list response keeps the {"data", "pagination"} shape.
+type ToolResponse struct {
+	Data         any                `json:"data"`
+	Pagination   *paginate.Metadata `json:"pagination,omitempty"`
+	Warnings     []string           `json:"warnings,omitempty"`
+	AppliedQuery any                `json:"appliedQuery,omitempty"`
+}
@@ -0,0 +1,17 @@
+package types
+
+// ServiceSummary is one row of the /api/v1/services response. P99 and
+// AvgDuration are in nanoseconds; ErrorRate and FourXXRate are percentages of
+// NumCalls, and CallRate is calls per second.
+type ServiceSummary struct {
+	ServiceName string  `json:"serviceName"`
+	P99         float64 `json:"p99"`
+	AvgDuration float64 `json:"avgDuration"`
+	NumCalls    int64   `json:"numCalls"`
+	CallRate    float64 `json:"callRate"`
+	NumErrors   int64   `json:"numErrors"`
+	ErrorRate   float64 `json:"errorRate"`
+	Num4XX      int64   `json:"num4XX"`
+	FourXXRate  float64 `json:"fourXXRate"`
+	WebURL      string  `json:"webUrl,omitempty"`
+}
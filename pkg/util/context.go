@@ -5,6 +5,7 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"strings"
+	"time"
 )
 
 type contextKey string
@@ -18,6 +19,8 @@ const (
 	clientSourceContextKey         contextKey = "client_source"
 	assistantThreadIDContextKey    contextKey = "assistant_thread_id"
 	assistantExecutionIDContextKey contextKey = "assistant_execution_id"
+	requestTimeoutContextKey       contextKey = "request_timeout"
+	requestIDContextKey            contextKey = "request_id"
 )
 
 // ClientSourceUserClient is the default for client_source when the header
@@ -135,6 +138,29 @@ func GetAssistantExecutionID(ctx context.Context) (string, bool) {
 	return id, ok
 }
 
+// SetRequestID stores the correlation ID of the current tool call in the
+// context.
+func SetRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, id)
+}
+
+// GetRequestID retrieves the tool call correlation ID from the context.
+func GetRequestID(ctx context.Context) (string, bool) {
+	id, ok := ctx.Value(requestIDContextKey).(string)
+	return id, ok
+}
+
+// SetRequestTimeout stores a per-call upstream timeout override in the context.
+func SetRequestTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, requestTimeoutContextKey, timeout)
+}
+
+// GetRequestTimeout retrieves the per-call upstream timeout override.
+func GetRequestTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(requestTimeoutContextKey).(time.Duration)
+	return timeout, ok && timeout > 0
+}
+
 // HashTenantKey returns a SHA-256 hash of authHeader, apiKey and signozURL,
 // suitable for use as a cache/map key without exposing the raw API key in
 // memory. The auth-header name is included so two requests carrying the same